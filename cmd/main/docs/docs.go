@@ -15,6 +15,50 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/avatars/{avatarID}": {
+            "get": {
+                "description": "Get a thumbnail of an avatar, the avatar_url of a user. Avatars are public and never change, they may be cached for good",
+                "produces": [
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get Avatar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Avatar ID",
+                        "name": "avatarID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            256,
+                            128,
+                            64
+                        ],
+                        "type": "integer",
+                        "description": "Edge of the thumbnail in pixels, 256 by default",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Avatar thumbnail",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    }
+                }
+            }
+        },
         "/jwt_login": {
             "post": {
                 "description": "Login using JWT authentication",
@@ -49,6 +93,249 @@ const docTemplate = `{
                 }
             }
         },
+        "/posts": {
+            "get": {
+                "description": "Get a page of public posts without authentication",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Get Public Posts",
+                "parameters": [
+                    {
+                        "enum": [
+                            "id",
+                            "created_at",
+                            "title"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Author user ID",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tags the post must all carry, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also match posts carrying a descendant of a tag",
+                        "name": "descendants",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after, RFC 3339 or YYYY-MM-DD",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before, RFC 3339 or YYYY-MM-DD",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Title prefix, case insensitive",
+                        "name": "title_prefix",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/getAllPosts.Response"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/by-slug/{slug}": {
+            "get": {
+                "description": "Get a published post by its slug, the permalink of a post. A previous slug of a post is redirected to its current one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Get Post By Slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getPostBySlug.Response"
+                        }
+                    },
+                    "301": {
+                        "description": "Moved to the current slug"
+                    }
+                }
+            }
+        },
+        "/posts/search": {
+            "get": {
+                "description": "Full-text search over public posts without authentication, best matches first, with highlighted snippets",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Search Public Posts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text, websearch syntax: quoted phrases, OR and -excluded words",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "rank",
+                            "id",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, rank by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Author user ID",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tags the post must all carry, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also match posts carrying a descendant of a tag",
+                        "name": "descendants",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/searchPosts.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{postID}": {
+            "get": {
+                "description": "Get a published public or unlisted post by ID without authentication",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Get Public Post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getPost.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/refresh": {
             "post": {
                 "description": "Refresh JWT token",
@@ -117,9 +404,77 @@ const docTemplate = `{
                 }
             }
         },
+        "/tags/{name}/posts": {
+            "get": {
+                "description": "Get a page of public posts carrying the tag without authentication",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Get Public Tag Posts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag name or alias",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also include posts carrying a descendant tag",
+                        "name": "descendants",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at",
+                            "title"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getTagPosts.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/users": {
             "get": {
-                "description": "Get a list of all users",
+                "description": "Get a page of users",
                 "produces": [
                     "application/json"
                 ],
@@ -127,6 +482,59 @@ const docTemplate = `{
                     "Users"
                 ],
                 "summary": "Get All Users",
+                "parameters": [
+                    {
+                        "enum": [
+                            "id",
+                            "username",
+                            "date_joined"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, id by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Joined on or after, RFC 3339 or YYYY-MM-DD",
+                        "name": "joined_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Joined before, RFC 3339 or YYYY-MM-DD",
+                        "name": "joined_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Username prefix, case insensitive",
+                        "name": "username_prefix",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -201,7 +609,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update user information by ID",
+                "description": "Update user information by ID, only the user themselves or an admin may do so (requires JWT)",
                 "consumes": [
                     "application/json"
                 ],
@@ -240,7 +648,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete user by ID",
+                "description": "Delete user by ID, only the user themselves or an admin may do so (requires JWT)",
                 "produces": [
                     "application/json"
                 ],
@@ -264,93 +672,103 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/logout": {
+        "/users/{userID}/followers": {
             "get": {
-                "description": "Logout and invalidate JWT token",
+                "description": "Get a page of the users following a user, the latest followers first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Users"
+                ],
+                "summary": "Get Followers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
                 ],
-                "summary": "JWT Logout",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getFollowers.Response"
+                        }
                     }
                 }
             }
         },
-        "/v1/posts": {
+        "/users/{userID}/following": {
             "get": {
-                "description": "Get a list of all posts",
+                "description": "Get a page of the users a user follows, the latest followed first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "Users"
                 ],
-                "summary": "Get All Posts",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/getAllPosts.Response"
-                            }
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Create a new post",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Posts"
-                ],
-                "summary": "Create Post",
+                "summary": "Get Following",
                 "parameters": [
                     {
-                        "description": "Create post request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/createPost.Request"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/createPost.Response"
+                            "$ref": "#/definitions/getFollowing.Response"
                         }
                     }
                 }
             }
         },
-        "/v1/posts/{postID}": {
+        "/v1/admin/users/{userID}": {
             "get": {
-                "description": "Get post by ID",
+                "description": "Get user by ID with the role, token version and suspension of the account, admin only",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "Users"
                 ],
-                "summary": "Get Post",
+                "summary": "Get User As Admin",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post ID",
-                        "name": "postID",
+                        "description": "User ID",
+                        "name": "userID",
                         "in": "path",
                         "required": true
                     }
@@ -359,13 +777,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/getPost.Response"
+                            "$ref": "#/definitions/getAdminUser.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/admin/users/{userID}/role": {
             "put": {
-                "description": "Update post information by ID",
+                "description": "Change the role of a user to user, moderator or admin, the tokens and sessions of the user are revoked with it, admin only",
                 "consumes": [
                     "application/json"
                 ],
@@ -373,24 +793,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "Users"
                 ],
-                "summary": "Update Post",
+                "summary": "Set User Role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post ID",
-                        "name": "postID",
+                        "description": "User ID",
+                        "name": "userID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update post request",
+                        "description": "Set role request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/updatePost.Request"
+                            "$ref": "#/definitions/setUserRole.Request"
                         }
                     }
                 ],
@@ -398,80 +818,120 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/updatePost.Response"
+                            "$ref": "#/definitions/setUserRole.Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete post by ID",
+            }
+        },
+        "/v1/attachments/{attachmentID}": {
+            "get": {
+                "description": "Download the content of an attachment, images are shown inline. The checksum is sent as ETag",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Posts"
+                    "Attachments"
                 ],
-                "summary": "Delete Post",
+                "summary": "Get Attachment",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Post ID",
-                        "name": "postID",
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
                     }
                 }
             }
         },
-        "/v2/logout": {
+        "/v1/logout": {
             "get": {
-                "description": "Logs out the user by clearing the session stored in the cookie \"session_id\".",
+                "description": "Logout and invalidate JWT token",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "session_auth"
+                    "Auth"
                 ],
-                "summary": "Logout from session-based authentication",
+                "summary": "JWT Logout",
                 "responses": {
                     "200": {
-                        "description": "Successfully logged out",
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/v1/logout_all": {
+            "post": {
+                "description": "Revoke every access token, refresh token and session of the current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Log out everywhere",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/revoke.Response"
                         }
                     }
                 }
             }
         },
-        "/v2/posts": {
+        "/v1/me": {
             "get": {
-                "description": "Retrieve all posts in the system with session-based authentication (requires \"session_id\" cookie).",
+                "description": "Get the current user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "Me"
                 ],
-                "summary": "Get all posts",
+                "summary": "Get Me",
                 "responses": {
                     "200": {
-                        "description": "List of posts",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/getAllPosts.Response"
-                            }
+                            "$ref": "#/definitions/getMe.Response"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Create a post in the system with session-based authentication (requires \"session_id\" cookie).",
+            "delete": {
+                "description": "Delete the current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Delete Me",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/deleteMe.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update the current user's username or description",
                 "consumes": [
                     "application/json"
                 ],
@@ -479,172 +939,5697 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "Me"
                 ],
-                "summary": "Create a new post",
+                "summary": "Update Me",
                 "parameters": [
                     {
-                        "description": "Post details",
-                        "name": "post",
+                        "description": "Update current user request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/createPost.Request"
+                            "$ref": "#/definitions/updateMe.Request"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Post created successfully",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/createPost.Response"
+                            "$ref": "#/definitions/updateMe.Response"
                         }
                     }
                 }
             }
         },
-        "/v2/posts/{postID}": {
-            "get": {
-                "description": "Retrieve a specific post by its ID with session-based authentication (requires \"session_id\" cookie).",
+        "/v1/me/avatar": {
+            "put": {
+                "description": "Replace the current user's avatar with a PNG, JPEG or WebP picture, cropped to a square and resized to thumbnails without its metadata",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "Me"
                 ],
-                "summary": "Get post by ID",
+                "summary": "Set My Avatar",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID of the post",
-                        "name": "postID",
-                        "in": "path",
+                        "type": "file",
+                        "description": "Picture",
+                        "name": "avatar",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Post details",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/getPost.Response"
+                            "$ref": "#/definitions/setAvatar.Response"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Update a specific post by its ID with session-based authentication (requires \"session_id\" cookie).",
-                "consumes": [
+            "delete": {
+                "description": "Remove the current user's avatar",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Delete My Avatar",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/deleteAvatar.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/blocks": {
+            "get": {
+                "description": "Get a page of the users the current user blocked, the latest blocked first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "Me"
                 ],
-                "summary": "Update a post by ID",
+                "summary": "Get Blocks",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID of the post",
-                        "name": "postID",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "Updated post details",
-                        "name": "post",
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getBlocks.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Block a user, who can then no longer see the posts of the current user, comment on them, react to them or follow them. The follows between the two are removed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Block User",
+                "parameters": [
+                    {
+                        "description": "Block user request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/updatePost.Request"
+                            "$ref": "#/definitions/blockUser.Request"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Post updated successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/updatePost.Response"
+                            "$ref": "#/definitions/blockUser.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/me/blocks/{userID}": {
             "delete": {
-                "description": "Delete a specific post by its ID with session-based authentication (requires \"session_id\" cookie).",
+                "description": "Lift a block, the follows it removed are not restored",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "Me"
                 ],
-                "summary": "Delete a post by ID",
+                "summary": "Unblock User",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID of the post",
-                        "name": "postID",
+                        "description": "User ID",
+                        "name": "userID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Post deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/unblockUser.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "createPost.Request": {
-            "type": "object",
-            "required": [
-                "title"
-            ],
-            "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+        },
+        "/v1/me/feed": {
+            "get": {
+                "description": "Get the posts of the users and tags the current user follows, the latest published first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Get Feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getFeed.Response"
+                        }
                     }
-                },
-                "title": {
-                    "type": "string"
                 }
             }
         },
-        "createPost.Response": {
-            "type": "object",
+        "/v1/me/followed-tags": {
+            "get": {
+                "description": "Get the tags the current user follows, sorted by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Get Followed Tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getFollowedTags.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/followed-tags/{name}": {
+            "put": {
+                "description": "Follow a tag by its name or an alias, following twice is a no-op",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Follow Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag name or alias",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/followTag.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop following a tag",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Unfollow Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag name or alias",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/unfollowTag.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/following/{userID}": {
+            "put": {
+                "description": "Follow a user, following twice is a no-op",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Follow User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/followUser.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop following a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Unfollow User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/unfollowUser.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/mutes": {
+            "get": {
+                "description": "Get a page of the users the current user muted, the latest muted first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Get Mutes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getMutes.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Mute a user, whose posts and comments are then left out of the lists and the feed of the current user. The muted user is not notified",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Mute User",
+                "parameters": [
+                    {
+                        "description": "Mute user request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/muteUser.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/muteUser.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/mutes/{userID}": {
+            "delete": {
+                "description": "Lift a mute",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Unmute User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/unmuteUser.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/password": {
+            "post": {
+                "description": "Change the current user's password, revoke all previous tokens and issue a new pair",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Change Password",
+                "parameters": [
+                    {
+                        "description": "Change password request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/jwtChangePassword.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jwtChangePassword.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/posts": {
+            "get": {
+                "description": "Get the current user's posts, drafts included",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Get My Posts",
+                "parameters": [
+                    {
+                        "enum": [
+                            "id",
+                            "created_at",
+                            "title"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "scheduled",
+                            "published",
+                            "archived"
+                        ],
+                        "type": "string",
+                        "description": "Only list posts in this state",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getMyPosts.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/moderation/reports": {
+            "get": {
+                "description": "Get a page of the moderation queue, the oldest reports first by default, moderators and admins only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Get Reports",
+                "parameters": [
+                    {
+                        "enum": [
+                            "open",
+                            "resolved"
+                        ],
+                        "type": "string",
+                        "description": "Only list reports in this state",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list reports with this reason",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only list reports of this post",
+                        "name": "post_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only list reports of posts by this author",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order, asc by default",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getReports.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/moderation/reports/{reportID}/actions": {
+            "post": {
+                "description": "Act on a report and resolve every open report of the post: dismiss, hide the post, delete the post or suspend its author. The action is recorded with the moderator and the note, moderators and admins only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Resolve Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Report ID",
+                        "name": "reportID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resolve report request, action is one of dismiss, hide, delete, suspend",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/resolveReport.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/resolveReport.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts": {
+            "get": {
+                "description": "Get a page of the posts the current user may read: published public ones, followers-only ones of followed authors and their own. Unlisted posts are only listed to their author",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Get All Posts",
+                "parameters": [
+                    {
+                        "enum": [
+                            "id",
+                            "created_at",
+                            "title"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Author user ID",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tags the post must all carry, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also match posts carrying a descendant of a tag",
+                        "name": "descendants",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after, RFC 3339 or YYYY-MM-DD",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before, RFC 3339 or YYYY-MM-DD",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Title prefix, case insensitive",
+                        "name": "title_prefix",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/getAllPosts.Response"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new post, published right away unless status is draft. Posts rejected by the content filters are answered with the violations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Create Post",
+                "parameters": [
+                    {
+                        "description": "Create post request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/createPost.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/createPost.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/by-slug/{slug}": {
+            "get": {
+                "description": "Get post by its slug, the permalink of a post. Unpublished and private posts are only shown to their author and admins, followers-only ones to the followers of the author. A previous slug of a post is redirected to its current one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Get Post By Slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getPostBySlug.Response"
+                        }
+                    },
+                    "301": {
+                        "description": "Moved to the current slug"
+                    }
+                }
+            }
+        },
+        "/v1/posts/search": {
+            "get": {
+                "description": "Full-text search over post titles and contents, best matches first, with highlighted snippets",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Search Posts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text, websearch syntax: quoted phrases, OR and -excluded words",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "rank",
+                            "id",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, rank by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Author user ID",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tags the post must all carry, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also match posts carrying a descendant of a tag",
+                        "name": "descendants",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/searchPosts.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}": {
+            "get": {
+                "description": "Get post by ID, unpublished and private posts are only shown to their author and admins, followers-only ones to the followers of the author",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Get Post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getPost.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update post information by ID, only the author or an admin may do so. Updates rejected by the content filters are answered with the violations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Update Post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update post request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/updatePost.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/updatePost.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete post by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Delete Post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}/attachments": {
+            "get": {
+                "description": "Get the attachments of a post, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Get Attachments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getAttachments.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Attach a file to a post of the current user. The content type is sniffed from the content and must be one of the allowed types",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Upload Attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to attach",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/uploadAttachment.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}/attachments/{attachmentID}": {
+            "delete": {
+                "description": "Remove an attachment from a post of the current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Delete Attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/deleteAttachment.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}/comments": {
+            "get": {
+                "description": "Get a page of the comments of a post, as a tree or a flat list. Posts the user may not read are not found",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Get Comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "tree",
+                            "flat"
+                        ],
+                        "type": "string",
+                        "description": "tree nests replies, flat lists every comment with its parent_id, tree by default",
+                        "name": "view",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Levels of a tree including its roots, 3 by default and at most 10",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment whose replies are the roots of the tree, top level comments by default",
+                        "name": "parent",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field of the roots, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of roots, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order, asc by default",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getComments.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Comment a post, or reply to a comment with parent_id. Comments rejected by the content filters are answered with the violations, posts the user may not read are not found",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Create Comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create comment request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/createComment.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/createComment.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}/comments/{commentID}": {
+            "delete": {
+                "description": "Delete a comment, its author or an admin can. A comment with replies stays as a placeholder",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Delete Comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "commentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/deleteComment.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Edit a comment, only its author can. Edits rejected by the content filters are answered with the violations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Update Comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "commentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update comment request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/updateComment.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/updateComment.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}/publish": {
+            "post": {
+                "description": "Publish a post of the current user right away, or schedule it with a future publish_at",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Publish Post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Publish post request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/publishPost.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/publishPost.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}/reactions": {
+            "get": {
+                "description": "Get a page of who reacted to a post, newest first by default. Posts the user may not read are not found",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reactions"
+                ],
+                "summary": "Get Reactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list this reaction type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order, desc by default",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getReactions.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}/reactions/{type}": {
+            "put": {
+                "description": "React to a post, reacting twice with the same type is a no-op. Posts the user may not read are not found",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reactions"
+                ],
+                "summary": "Add Reaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reaction type, one of REACTIONS_TYPES",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/addReaction.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Take back a reaction to a post",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reactions"
+                ],
+                "summary": "Remove Reaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reaction type, one of REACTIONS_TYPES",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/removeReaction.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}/report": {
+            "post": {
+                "description": "Report a post to the moderators, reporting it again while the first report is open returns the first report",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Report Post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Report post request, reason is one of spam, harassment, hate, violence, sexual, misinformation, other",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reportPost.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/reportPost.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}/revisions": {
+            "get": {
+                "description": "Get a page of the revisions of a post, newest first by default",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Get Revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order, desc by default",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getRevisions.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}/revisions/{revision}": {
+            "get": {
+                "description": "Get a revision of a post",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Get Revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getRevision.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}/revisions/{revision}/diff": {
+            "get": {
+                "description": "Get a unified diff of the title, tags and content of a post between two revisions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Diff Revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare with, the previous one by default, 0 compares with an empty post",
+                        "name": "from",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/diffRevisions.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}/revisions/{revision}/restore": {
+            "post": {
+                "description": "Bring a post of the current user back to one of its revisions, recorded as a new revision. The revision runs through the content filters first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Restore Revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/restoreRevision.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/posts/{postID}/unpublish": {
+            "post": {
+                "description": "Take a post of the current user back to a draft, or archive it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Unpublish Post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Unpublish post request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/unpublishPost.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/unpublishPost.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tags": {
+            "get": {
+                "description": "Get tags with their post counts, most used first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Get All Tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag prefix for autocomplete",
+                        "name": "prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tags, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getAllTags.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tags/{name}/posts": {
+            "get": {
+                "description": "Get a page of posts carrying the tag",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Get Tag Posts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag name or alias",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also include posts carrying a descendant tag",
+                        "name": "descendants",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at",
+                            "title"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getTagPosts.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tags/{tagID}": {
+            "get": {
+                "description": "Get a tag with its parent, aliases and children",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Get Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/getTag.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a tag, admin only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Delete Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/deleteTag.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Rename a tag, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Rename Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rename tag request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/renameTag.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/renameTag.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tags/{tagID}/aliases": {
+            "post": {
+                "description": "Make an alias resolve to the tag, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Add Tag Alias",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add tag alias request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/addTagAlias.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/addTagAlias.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tags/{tagID}/aliases/{alias}": {
+            "delete": {
+                "description": "Remove an alias of the tag, admin only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Delete Tag Alias",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alias",
+                        "name": "alias",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/deleteTagAlias.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tags/{tagID}/merge": {
+            "post": {
+                "description": "Move every post of the tag to the target tag and delete the tag, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Merge Tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge tags request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mergeTags.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mergeTags.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tags/{tagID}/parent": {
+            "put": {
+                "description": "Move a tag under another tag, parent_id 0 makes it a root tag, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Set Tag Parent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Set tag parent request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/setTagParent.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/setTagParent.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/admin/users/{userID}": {
+            "get": {
+                "description": "Retrieve a user by ID with the role, token version and suspension of the account, with session-based authentication (requires \"session_id\" cookie), admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get a user as an administrator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the user",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User details",
+                        "schema": {
+                            "$ref": "#/definitions/getAdminUser.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/admin/users/{userID}/role": {
+            "put": {
+                "description": "Change the role of a user to user, moderator or admin with session-based authentication (requires \"session_id\" cookie), admin only. The tokens and sessions of the user are revoked with it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Set the role of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the user",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/setUserRole.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User with the new role",
+                        "schema": {
+                            "$ref": "#/definitions/setUserRole.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/attachments/{attachmentID}": {
+            "get": {
+                "description": "Retrieve the content of an attachment with session-based authentication (requires \"session_id\" cookie). Images are shown inline, the checksum is sent as ETag.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Download an attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID of the attachment",
+                        "name": "attachmentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    }
+                }
+            }
+        },
+        "/v2/logout": {
+            "get": {
+                "description": "Logs out the user by clearing the session stored in the cookie \"session_id\".",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session_auth"
+                ],
+                "summary": "Logout from session-based authentication",
+                "responses": {
+                    "200": {
+                        "description": "Successfully logged out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/logout_all": {
+            "post": {
+                "description": "Revoke every session, access token and refresh token of the current user (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session_auth"
+                ],
+                "summary": "Log out everywhere",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/revoke.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/me": {
+            "get": {
+                "description": "Retrieve the current user with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Get the current user",
+                "responses": {
+                    "200": {
+                        "description": "Current user",
+                        "schema": {
+                            "$ref": "#/definitions/getMe.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete the current user with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Delete the current user",
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/deleteMe.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update the current user's username or description with session-based authentication (requires \"session_id\" cookie).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Update the current user",
+                "parameters": [
+                    {
+                        "description": "Updated user details",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/updateMe.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/updateMe.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/me/avatar": {
+            "put": {
+                "description": "Replace the avatar with a PNG, JPEG or WebP picture with session-based authentication (requires \"session_id\" cookie). The picture is cropped to a square and resized to thumbnails without its metadata.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Set the current user's avatar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Picture",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Avatar set successfully",
+                        "schema": {
+                            "$ref": "#/definitions/setAvatar.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the avatar with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Delete the current user's avatar",
+                "responses": {
+                    "200": {
+                        "description": "Avatar deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/deleteAvatar.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/me/blocks": {
+            "get": {
+                "description": "Retrieve a page of the users the current user blocked, the latest blocked first, with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Get the current user's blocks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of users",
+                        "schema": {
+                            "$ref": "#/definitions/getBlocks.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Block a user, who can then no longer see the posts of the current user, comment on them, react to them or follow them, with session-based authentication (requires \"session_id\" cookie).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Block a user",
+                "parameters": [
+                    {
+                        "description": "User to block",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blockUser.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Blocked user",
+                        "schema": {
+                            "$ref": "#/definitions/blockUser.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/me/blocks/{userID}": {
+            "delete": {
+                "description": "Lift a block with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Unblock a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unblocked user",
+                        "schema": {
+                            "$ref": "#/definitions/unblockUser.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/me/feed": {
+            "get": {
+                "description": "Retrieve the posts of the users and tags the current user follows, the latest published first, with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Get the current user's feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of posts",
+                        "schema": {
+                            "$ref": "#/definitions/getFeed.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/me/followed-tags": {
+            "get": {
+                "description": "Retrieve the tags the current user follows, sorted by name, with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Get the current user's followed tags",
+                "responses": {
+                    "200": {
+                        "description": "List of tags",
+                        "schema": {
+                            "$ref": "#/definitions/getFollowedTags.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/me/followed-tags/{name}": {
+            "put": {
+                "description": "Follow a tag by its name or an alias, following twice is a no-op, with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Follow a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag name or alias",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Followed tag",
+                        "schema": {
+                            "$ref": "#/definitions/followTag.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop following a tag with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Unfollow a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag name or alias",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unfollowed tag",
+                        "schema": {
+                            "$ref": "#/definitions/unfollowTag.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/me/following/{userID}": {
+            "put": {
+                "description": "Follow a user, following twice is a no-op, with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Follow a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Followed user",
+                        "schema": {
+                            "$ref": "#/definitions/followUser.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop following a user with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Unfollow a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unfollowed user",
+                        "schema": {
+                            "$ref": "#/definitions/unfollowUser.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/me/mutes": {
+            "get": {
+                "description": "Retrieve a page of the users the current user muted, the latest muted first, with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Get the current user's mutes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of users",
+                        "schema": {
+                            "$ref": "#/definitions/getMutes.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Mute a user, whose posts and comments are then left out of the lists and the feed of the current user, with session-based authentication (requires \"session_id\" cookie).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Mute a user",
+                "parameters": [
+                    {
+                        "description": "User to mute",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/muteUser.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Muted user",
+                        "schema": {
+                            "$ref": "#/definitions/muteUser.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/me/mutes/{userID}": {
+            "delete": {
+                "description": "Lift a mute with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Unmute a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unmuted user",
+                        "schema": {
+                            "$ref": "#/definitions/unmuteUser.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/me/password": {
+            "post": {
+                "description": "Change the password, revoke all previous sessions and issue a new \"session_id\" cookie (requires \"session_id\" cookie).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Change the current user's password",
+                "parameters": [
+                    {
+                        "description": "Change password request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/sessionChangePassword.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/sessionChangePassword.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/me/posts": {
+            "get": {
+                "description": "Retrieve the current user's posts, drafts included, with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Get the current user's posts",
+                "parameters": [
+                    {
+                        "enum": [
+                            "id",
+                            "created_at",
+                            "title"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "scheduled",
+                            "published",
+                            "archived"
+                        ],
+                        "type": "string",
+                        "description": "Only list posts in this state",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of posts",
+                        "schema": {
+                            "$ref": "#/definitions/getMyPosts.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/moderation/reports": {
+            "get": {
+                "description": "Retrieve a page of reports, the oldest first by default, with session-based authentication (requires \"session_id\" cookie), moderators and admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Get the moderation queue",
+                "parameters": [
+                    {
+                        "enum": [
+                            "open",
+                            "resolved"
+                        ],
+                        "type": "string",
+                        "description": "Only list reports in this state",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list reports with this reason",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only list reports of this post",
+                        "name": "post_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only list reports of posts by this author",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order, asc by default",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of reports",
+                        "schema": {
+                            "$ref": "#/definitions/getReports.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/moderation/reports/{reportID}/actions": {
+            "post": {
+                "description": "Dismiss the report, hide or delete the post or suspend its author, recorded with the moderator and the note, with session-based authentication (requires \"session_id\" cookie), moderators and admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Act on a report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the report",
+                        "name": "reportID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Action, one of dismiss, hide, delete, suspend, and note",
+                        "name": "action",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/resolveReport.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report resolved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/resolveReport.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts": {
+            "get": {
+                "description": "Retrieve the posts the current user may read: published public ones, followers-only ones of followed authors and their own, with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Get all posts",
+                "parameters": [
+                    {
+                        "enum": [
+                            "id",
+                            "created_at",
+                            "title"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Author user ID",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tags the post must all carry, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also match posts carrying a descendant of a tag",
+                        "name": "descendants",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after, RFC 3339 or YYYY-MM-DD",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before, RFC 3339 or YYYY-MM-DD",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Title prefix, case insensitive",
+                        "name": "title_prefix",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of posts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/getAllPosts.Response"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a post, published right away unless status is draft, with session-based authentication (requires \"session_id\" cookie). Posts rejected by the content filters are answered with the violations.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Create a new post",
+                "parameters": [
+                    {
+                        "description": "Post details",
+                        "name": "post",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/createPost.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Post created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/createPost.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/by-slug/{slug}": {
+            "get": {
+                "description": "Retrieve a post by its slug, the permalink of a post, unpublished and private posts are only shown to their author and admins, followers-only ones to the followers of the author, with session-based authentication (requires \"session_id\" cookie). A previous slug of a post is redirected to its current one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Get post by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slug of the post",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post details",
+                        "schema": {
+                            "$ref": "#/definitions/getPostBySlug.Response"
+                        }
+                    },
+                    "301": {
+                        "description": "Moved to the current slug"
+                    }
+                }
+            }
+        },
+        "/v2/posts/search": {
+            "get": {
+                "description": "Full-text search over post titles and contents, best matches first, with highlighted snippets, with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Search posts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text, websearch syntax: quoted phrases, OR and -excluded words",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "rank",
+                            "id",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, rank by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Author user ID",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tags the post must all carry, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also match posts carrying a descendant of a tag",
+                        "name": "descendants",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching posts",
+                        "schema": {
+                            "$ref": "#/definitions/searchPosts.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}": {
+            "get": {
+                "description": "Retrieve a specific post by its ID, unpublished and private posts are only shown to their author and admins, followers-only ones to the followers of the author, with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Get post by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post details",
+                        "schema": {
+                            "$ref": "#/definitions/getPost.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a specific post by its ID with session-based authentication (requires \"session_id\" cookie). Only the author or an admin may update a post. Updates rejected by the content filters are answered with the violations.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Update a post by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated post details",
+                        "name": "post",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/updatePost.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/updatePost.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a specific post by its ID with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Delete a post by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}/attachments": {
+            "get": {
+                "description": "Retrieve the attachments of a post, oldest first, with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Get attachments of a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of attachments",
+                        "schema": {
+                            "$ref": "#/definitions/getAttachments.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Upload a file to a post of the current user with session-based authentication (requires \"session_id\" cookie). The content type is sniffed from the content and must be one of the allowed types.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Attach a file to a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to attach",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment uploaded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/uploadAttachment.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}/attachments/{attachmentID}": {
+            "delete": {
+                "description": "Remove an attachment from a post of the current user with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Delete an attachment of a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the attachment",
+                        "name": "attachmentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/deleteAttachment.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}/comments": {
+            "get": {
+                "description": "Retrieve a page of the comments of a post, as a tree or a flat list, with session-based authentication (requires \"session_id\" cookie). Posts the user may not read are not found.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Get comments of a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "tree",
+                            "flat"
+                        ],
+                        "type": "string",
+                        "description": "tree nests replies, flat lists every comment with its parent_id, tree by default",
+                        "name": "view",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Levels of a tree including its roots, 3 by default and at most 10",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment whose replies are the roots of the tree, top level comments by default",
+                        "name": "parent",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field of the roots, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of roots, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order, asc by default",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of comments",
+                        "schema": {
+                            "$ref": "#/definitions/getComments.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Comment a post, or reply to a comment with parent_id, with session-based authentication (requires \"session_id\" cookie). Comments rejected by the content filters are answered with the violations, posts the user may not read are not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Comment a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/createComment.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comment created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/createComment.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}/comments/{commentID}": {
+            "delete": {
+                "description": "Delete a comment with session-based authentication (requires \"session_id\" cookie), its author or an admin can. A comment with replies stays as a placeholder.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Delete a comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the comment",
+                        "name": "commentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comment deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/deleteComment.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Edit a comment with session-based authentication (requires \"session_id\" cookie), only its author can. Edits rejected by the content filters are answered with the violations.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Edit a comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the comment",
+                        "name": "commentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New content",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/updateComment.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comment updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/updateComment.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}/publish": {
+            "post": {
+                "description": "Publish a post of the current user with session-based authentication (requires \"session_id\" cookie), right away or at a future publish_at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Publish a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "When to publish",
+                        "name": "post",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/publishPost.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post published or scheduled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/publishPost.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}/reactions": {
+            "get": {
+                "description": "Retrieve a page of who reacted to a post, newest first by default, with session-based authentication (requires \"session_id\" cookie). Posts the user may not read are not found.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reactions"
+                ],
+                "summary": "Get reactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list this reaction type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order, desc by default",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reactions retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/getReactions.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}/reactions/{type}": {
+            "put": {
+                "description": "React to a post with session-based authentication (requires \"session_id\" cookie). Reacting twice with the same type is a no-op. Posts the user may not read are not found.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reactions"
+                ],
+                "summary": "Add a reaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reaction type, one of REACTIONS_TYPES",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reaction added successfully",
+                        "schema": {
+                            "$ref": "#/definitions/addReaction.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Take back a reaction to a post with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reactions"
+                ],
+                "summary": "Remove a reaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reaction type, one of REACTIONS_TYPES",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reaction removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/removeReaction.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}/report": {
+            "post": {
+                "description": "Report a post to the moderators with session-based authentication (requires \"session_id\" cookie).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Report a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason, one of spam, harassment, hate, violence, sexual, misinformation, other",
+                        "name": "report",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reportPost.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post reported successfully",
+                        "schema": {
+                            "$ref": "#/definitions/reportPost.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}/revisions": {
+            "get": {
+                "description": "Retrieve a page of the revisions of a post, newest first by default, with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Get revisions of a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order, desc by default",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of revisions",
+                        "schema": {
+                            "$ref": "#/definitions/getRevisions.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}/revisions/{revision}": {
+            "get": {
+                "description": "Retrieve a revision of a post with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Get a revision of a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Revision retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/getRevision.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}/revisions/{revision}/diff": {
+            "get": {
+                "description": "Retrieve a unified diff of the title, tags and content of a post between two revisions with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Diff two revisions of a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare with, the previous one by default, 0 compares with an empty post",
+                        "name": "from",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Diff computed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/diffRevisions.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}/revisions/{revision}/restore": {
+            "post": {
+                "description": "Bring a post of the current user back to one of its revisions with session-based authentication (requires \"session_id\" cookie). The restore is recorded as a new revision and runs through the content filters first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Restore a revision of a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Revision restored successfully",
+                        "schema": {
+                            "$ref": "#/definitions/restoreRevision.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/posts/{postID}/unpublish": {
+            "post": {
+                "description": "Take a post of the current user back to a draft, or archive it, with session-based authentication (requires \"session_id\" cookie).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Unpublish a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the post",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Whether to archive",
+                        "name": "post",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/unpublishPost.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post unpublished successfully",
+                        "schema": {
+                            "$ref": "#/definitions/unpublishPost.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/tags": {
+            "get": {
+                "description": "Retrieve tags with their post counts, most used first, with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get all tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag prefix for autocomplete",
+                        "name": "prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tags, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of tags",
+                        "schema": {
+                            "$ref": "#/definitions/getAllTags.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/tags/{name}/posts": {
+            "get": {
+                "description": "Retrieve a page of posts carrying the tag with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get posts by tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag name or alias",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also include posts carrying a descendant tag",
+                        "name": "descendants",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at",
+                            "title"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, created_at by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of posts",
+                        "schema": {
+                            "$ref": "#/definitions/getTagPosts.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/tags/{tagID}": {
+            "get": {
+                "description": "Retrieve a tag with its parent, aliases and children with session-based authentication (requires \"session_id\" cookie).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the tag",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag details",
+                        "schema": {
+                            "$ref": "#/definitions/getTag.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a tag with session-based authentication (requires \"session_id\" cookie), admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the tag",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/deleteTag.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Rename a tag with session-based authentication (requires \"session_id\" cookie), admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Rename a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the tag",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New tag name",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/renameTag.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag renamed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/renameTag.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/tags/{tagID}/aliases": {
+            "post": {
+                "description": "Make an alias resolve to the tag with session-based authentication (requires \"session_id\" cookie), admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Add an alias to a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the tag",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alias",
+                        "name": "alias",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/addTagAlias.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Alias added successfully",
+                        "schema": {
+                            "$ref": "#/definitions/addTagAlias.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/tags/{tagID}/aliases/{alias}": {
+            "delete": {
+                "description": "Remove an alias of the tag with session-based authentication (requires \"session_id\" cookie), admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete an alias of a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the tag",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alias",
+                        "name": "alias",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Alias deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/deleteTagAlias.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/tags/{tagID}/merge": {
+            "post": {
+                "description": "Move every post of the tag to the target tag and delete the tag with session-based authentication (requires \"session_id\" cookie), admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Merge a tag into another",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the tag",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target tag",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mergeTags.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tags merged successfully",
+                        "schema": {
+                            "$ref": "#/definitions/mergeTags.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/tags/{tagID}/parent": {
+            "put": {
+                "description": "Move a tag under another tag, parent_id 0 makes it a root tag, with session-based authentication (requires \"session_id\" cookie), admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Set the parent of a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the tag",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Parent tag",
+                        "name": "parent",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/setTagParent.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag parent set successfully",
+                        "schema": {
+                            "$ref": "#/definitions/setTagParent.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "addReaction.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "post_id": {
+                    "type": "integer"
+                },
+                "reaction": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "addTagAlias.Request": {
+            "type": "object",
+            "required": [
+                "alias"
+            ],
+            "properties": {
+                "alias": {
+                    "type": "string"
+                }
+            }
+        },
+        "addTagAlias.Response": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tag_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "blockUser.Request": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "blockUser.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contentfilter.Violation": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "createComment.Request": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "maxLength": 10000
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "createComment.Response": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "$ref": "#/definitions/views.Comment"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contentfilter.Violation"
+                    }
+                }
+            }
+        },
+        "createPost.Request": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "content_format": {
+                    "type": "string",
+                    "enum": [
+                        "plain",
+                        "markdown"
+                    ]
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "published"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "followers",
+                        "private"
+                    ]
+                }
+            }
+        },
+        "createPost.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "post": {
+                    "$ref": "#/definitions/views.Post"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contentfilter.Violation"
+                    }
+                }
+            }
+        },
+        "createUser.Request": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "createUser.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/views.SelfUser"
+                }
+            }
+        },
+        "deleteAttachment.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "deleteAvatar.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "deleteComment.Response": {
+            "type": "object",
+            "properties": {
+                "comment_id": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "deleteMe.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "deleteTag.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tag_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "deleteTagAlias.Response": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tag_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "diffRevisions.Response": {
+            "type": "object",
+            "properties": {
+                "diff": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "followTag.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tag": {
+                    "$ref": "#/definitions/views.Tag"
+                }
+            }
+        },
+        "followUser.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "getAdminUser.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/views.AdminUser"
+                }
+            }
+        },
+        "getAllPosts.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "posts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Post"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "getAllTags.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Tag"
+                    }
+                }
+            }
+        },
+        "getAllUsers.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "usernames": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.PublicUser"
+                    }
+                }
+            }
+        },
+        "getAttachments.Response": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Attachment"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "getBlocks.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.PublicUser"
+                    }
+                }
+            }
+        },
+        "getComments.Response": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Comment"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "getFeed.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "posts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Post"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "getFollowedTags.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Tag"
+                    }
+                }
+            }
+        },
+        "getFollowers.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.PublicUser"
+                    }
+                }
+            }
+        },
+        "getFollowing.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.PublicUser"
+                    }
+                }
+            }
+        },
+        "getMe.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/views.SelfUser"
+                }
+            }
+        },
+        "getMutes.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.PublicUser"
+                    }
+                }
+            }
+        },
+        "getMyPosts.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "posts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Post"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "getPost.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "post": {
+                    "$ref": "#/definitions/views.Post"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "getPostBySlug.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "post": {
+                    "$ref": "#/definitions/views.Post"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "getReactions.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "reactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Reaction"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "getReports.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "reports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Report"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "getRevision.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "revision": {
+                    "$ref": "#/definitions/views.Revision"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "getRevisions.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Revision"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "getTag.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tag": {
+                    "$ref": "#/definitions/views.TagDetails"
+                }
+            }
+        },
+        "getTagPosts.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "posts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Post"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "getUser.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/views.PublicUser"
+                }
+            }
+        },
+        "jwtChangePassword.Request": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "jwtChangePassword.Response": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "jwtLogin.Request": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "jwtLogin.Response": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "mergeTags.Request": {
+            "type": "object",
+            "required": [
+                "target_id"
+            ],
+            "properties": {
+                "target_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mergeTags.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "muteUser.Request": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "muteUser.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pgtype.Date": {
+            "type": "object",
+            "properties": {
+                "infinityModifier": {
+                    "$ref": "#/definitions/pgtype.InfinityModifier"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "pgtype.InfinityModifier": {
+            "type": "integer",
+            "enum": [
+                1,
+                0,
+                -1
+            ],
+            "x-enum-varnames": [
+                "Infinity",
+                "Finite",
+                "NegativeInfinity"
+            ]
+        },
+        "pgtype.Timestamp": {
+            "type": "object",
+            "properties": {
+                "infinityModifier": {
+                    "$ref": "#/definitions/pgtype.InfinityModifier"
+                },
+                "time": {
+                    "description": "Time zone will be ignored when encoding to PostgreSQL.",
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "publishPost.Request": {
+            "type": "object",
+            "properties": {
+                "publish_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "publishPost.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "post": {
+                    "$ref": "#/definitions/views.Post"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "refresh.Request": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "refresh.Response": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "removeReaction.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "post_id": {
+                    "type": "integer"
+                },
+                "reaction": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "renameTag.Request": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "renameTag.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tag": {
+                    "$ref": "#/definitions/views.Tag"
+                }
+            }
+        },
+        "reportPost.Request": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "details": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "reportPost.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "report": {
+                    "$ref": "#/definitions/views.Report"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "resolveReport.Request": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "resolveReport.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "report": {
+                    "$ref": "#/definitions/views.Report"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "restoreRevision.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "post": {
+                    "$ref": "#/definitions/views.Post"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contentfilter.Violation"
+                    }
+                }
+            }
+        },
+        "revoke.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "searchPosts.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.SearchResult"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "sessionChangePassword.Request": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "sessionChangePassword.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "sessionLogin.Request": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "sessionLogin.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "setAvatar.Response": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "setTagParent.Request": {
+            "type": "object",
+            "properties": {
+                "parent_id": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "setTagParent.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tag_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "setUserRole.Request": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "setUserRole.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/views.AdminUser"
+                }
+            }
+        },
+        "unblockUser.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "unfollowTag.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "unfollowUser.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "unmuteUser.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "unpublishPost.Request": {
+            "type": "object",
+            "properties": {
+                "archive": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "unpublishPost.Response": {
+            "type": "object",
             "properties": {
                 "error": {
                     "type": "string"
                 },
                 "post": {
-                    "$ref": "#/definitions/database.PostDTO"
+                    "$ref": "#/definitions/views.Post"
                 },
                 "status": {
                     "type": "string"
                 }
             }
         },
-        "createUser.Request": {
+        "updateComment.Request": {
             "type": "object",
             "required": [
-                "password",
-                "username"
+                "content"
             ],
             "properties": {
-                "description": {
+                "content": {
+                    "type": "string",
+                    "maxLength": 10000
+                }
+            }
+        },
+        "updateComment.Response": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "$ref": "#/definitions/views.Comment"
+                },
+                "error": {
                     "type": "string"
                 },
-                "password": {
+                "status": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contentfilter.Violation"
+                    }
+                }
+            }
+        },
+        "updateMe.Request": {
+            "type": "object",
+            "properties": {
+                "description": {
                     "type": "string"
                 },
                 "username": {
@@ -652,7 +6637,7 @@ const docTemplate = `{
                 }
             }
         },
-        "createUser.Response": {
+        "updateMe.Response": {
             "type": "object",
             "properties": {
                 "error": {
@@ -662,21 +6647,25 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "user": {
-                    "$ref": "#/definitions/database.UserDTO"
+                    "$ref": "#/definitions/views.SelfUser"
                 }
             }
         },
-        "database.PostDTO": {
+        "updatePost.Request": {
             "type": "object",
             "properties": {
                 "content": {
                     "type": "string"
                 },
-                "created_at": {
-                    "$ref": "#/definitions/pgtype.Timestamp"
+                "content_format": {
+                    "type": "string",
+                    "enum": [
+                        "plain",
+                        "markdown"
+                    ]
                 },
-                "id": {
-                    "type": "integer"
+                "slug": {
+                    "type": "string"
                 },
                 "tags": {
                     "type": "array",
@@ -687,229 +6676,433 @@ const docTemplate = `{
                 "title": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "followers",
+                        "private"
+                    ]
+                }
+            }
+        },
+        "updatePost.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "post": {
+                    "$ref": "#/definitions/views.Post"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contentfilter.Violation"
+                    }
+                }
+            }
+        },
+        "updateUser.Request": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "updateUser.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/views.PublicUser"
+                }
+            }
+        },
+        "uploadAttachment.Response": {
+            "type": "object",
+            "properties": {
+                "attachment": {
+                    "$ref": "#/definitions/views.Attachment"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "database.UserDTO": {
+        "views.AdminUser": {
             "type": "object",
             "properties": {
-                "dateJoined": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "date_joined": {
                     "$ref": "#/definitions/pgtype.Date"
                 },
                 "description": {
                     "type": "string"
                 },
+                "follower_count": {
+                    "type": "integer"
+                },
+                "following_count": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "password": {
+                "role": {
                     "type": "string"
                 },
+                "suspended": {
+                    "type": "boolean"
+                },
+                "token_version": {
+                    "type": "integer"
+                },
                 "username": {
                     "type": "string"
                 }
             }
         },
-        "getAllPosts.Response": {
+        "views.Attachment": {
             "type": "object",
             "properties": {
-                "error": {
+                "checksum": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "post_id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "views.Comment": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "deleted": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "post_id": {
+                    "type": "integer"
+                },
+                "replies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Comment"
+                    }
+                },
+                "reply_count": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "views.Post": {
+            "type": "object",
+            "properties": {
+                "comment_count": {
+                    "type": "integer"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "content_format": {
+                    "type": "string"
+                },
+                "content_html": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "excerpt": {
+                    "type": "string"
+                },
+                "hidden": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "my_reactions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "notice": {
                     "type": "string"
                 },
-                "posts": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/database.PostDTO"
+                "published_at": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "reactions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
                     }
                 },
-                "status": {
-                    "type": "string"
-                }
-            }
-        },
-        "getAllUsers.Response": {
-            "type": "object",
-            "properties": {
-                "error": {
+                "reading_time": {
+                    "type": "integer"
+                },
+                "slug": {
                     "type": "string"
                 },
                 "status": {
                     "type": "string"
                 },
-                "usernames": {
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/database.UserDTO"
+                        "type": "string"
                     }
-                }
-            }
-        },
-        "getPost.Response": {
-            "type": "object",
-            "properties": {
-                "error": {
+                },
+                "title": {
                     "type": "string"
                 },
-                "post": {
-                    "$ref": "#/definitions/database.PostDTO"
+                "user_id": {
+                    "type": "integer"
                 },
-                "status": {
+                "visibility": {
                     "type": "string"
+                },
+                "word_count": {
+                    "type": "integer"
                 }
             }
         },
-        "getUser.Response": {
+        "views.PublicUser": {
             "type": "object",
             "properties": {
-                "error": {
+                "avatar_url": {
                     "type": "string"
                 },
-                "status": {
+                "date_joined": {
+                    "$ref": "#/definitions/pgtype.Date"
+                },
+                "description": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/database.UserDTO"
+                "follower_count": {
+                    "type": "integer"
+                },
+                "following_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "jwtLogin.Request": {
+        "views.Reaction": {
             "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
             "properties": {
-                "password": {
+                "created_at": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "type": {
                     "type": "string"
                 },
+                "user_id": {
+                    "type": "integer"
+                },
                 "username": {
                     "type": "string"
                 }
             }
         },
-        "jwtLogin.Response": {
+        "views.Report": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "action": {
                     "type": "string"
                 },
-                "error": {
+                "author_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "details": {
                     "type": "string"
                 },
-                "refresh_token": {
+                "id": {
+                    "type": "integer"
+                },
+                "moderator_id": {
+                    "type": "integer"
+                },
+                "note": {
                     "type": "string"
                 },
-                "status": {
+                "post_id": {
+                    "type": "integer"
+                },
+                "post_title": {
                     "type": "string"
-                }
-            }
-        },
-        "pgtype.Date": {
-            "type": "object",
-            "properties": {
-                "infinityModifier": {
-                    "$ref": "#/definitions/pgtype.InfinityModifier"
                 },
-                "time": {
+                "reason": {
                     "type": "string"
                 },
-                "valid": {
-                    "type": "boolean"
+                "reporter_id": {
+                    "type": "integer"
+                },
+                "resolved_at": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "pgtype.InfinityModifier": {
-            "type": "integer",
-            "enum": [
-                1,
-                0,
-                -1
-            ],
-            "x-enum-varnames": [
-                "Infinity",
-                "Finite",
-                "NegativeInfinity"
-            ]
-        },
-        "pgtype.Timestamp": {
+        "views.Revision": {
             "type": "object",
             "properties": {
-                "infinityModifier": {
-                    "$ref": "#/definitions/pgtype.InfinityModifier"
+                "content": {
+                    "type": "string"
                 },
-                "time": {
-                    "description": "Time zone will be ignored when encoding to PostgreSQL.",
+                "content_format": {
                     "type": "string"
                 },
-                "valid": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "refresh.Request": {
-            "type": "object",
-            "required": [
-                "refresh_token"
-            ],
-            "properties": {
-                "refresh_token": {
+                "created_at": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "editor_id": {
+                    "type": "integer"
+                },
+                "post_id": {
+                    "type": "integer"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "refresh.Response": {
+        "views.SearchResult": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string"
+                "comment_count": {
+                    "type": "integer"
                 },
-                "error": {
+                "content": {
                     "type": "string"
                 },
-                "refresh_token": {
+                "content_format": {
                     "type": "string"
                 },
-                "status": {
+                "content_html": {
                     "type": "string"
-                }
-            }
-        },
-        "sessionLogin.Request": {
-            "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
-            "properties": {
-                "password": {
+                },
+                "created_at": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "excerpt": {
                     "type": "string"
                 },
-                "username": {
+                "hidden": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "my_reactions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "notice": {
                     "type": "string"
-                }
-            }
-        },
-        "sessionLogin.Response": {
-            "type": "object",
-            "properties": {
-                "error": {
+                },
+                "published_at": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "reactions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "reading_time": {
+                    "type": "integer"
+                },
+                "slug": {
                     "type": "string"
                 },
-                "session_id": {
+                "snippet": {
                     "type": "string"
                 },
                 "status": {
                     "type": "string"
-                }
-            }
-        },
-        "updatePost.Request": {
-            "type": "object",
-            "properties": {
-                "content": {
-                    "type": "string"
                 },
                 "tags": {
                     "type": "array",
@@ -919,30 +7112,43 @@ const docTemplate = `{
                 },
                 "title": {
                     "type": "string"
-                }
-            }
-        },
-        "updatePost.Response": {
-            "type": "object",
-            "properties": {
-                "error": {
+                },
+                "title_highlight": {
                     "type": "string"
                 },
-                "post": {
-                    "$ref": "#/definitions/database.PostDTO"
+                "user_id": {
+                    "type": "integer"
                 },
-                "status": {
+                "visibility": {
                     "type": "string"
+                },
+                "word_count": {
+                    "type": "integer"
                 }
             }
         },
-        "updateUser.Request": {
+        "views.SelfUser": {
             "type": "object",
             "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "date_joined": {
+                    "$ref": "#/definitions/pgtype.Date"
+                },
                 "description": {
                     "type": "string"
                 },
-                "password": {
+                "follower_count": {
+                    "type": "integer"
+                },
+                "following_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
                     "type": "string"
                 },
                 "username": {
@@ -950,17 +7156,43 @@ const docTemplate = `{
                 }
             }
         },
-        "updateUser.Response": {
+        "views.Tag": {
             "type": "object",
             "properties": {
-                "error": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "status": {
+                "post_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "views.TagDetails": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Tag"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/database.UserDTO"
+                "parent_id": {
+                    "type": "integer"
                 }
             }
         }
@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go-rest-api-auth/config"
 	"go-rest-api-auth/internal/api"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -19,25 +21,30 @@ func main() {
 	server := api.NewAPIServer(cfg.HTTPServer.Address, dsn, redisUrl)
 	ctx := context.Background()
 
-	done := make(chan bool)
+	done := make(chan error, 1)
 	go func() {
-		if err := server.Run(cfg, ctx); err != nil {
-			slog.Error("Server error", slog.String("err", err.Error()))
-		}
-		done <- true
+		done <- server.Run(cfg, ctx)
 	}()
 
 	//Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
-	<-quit
+	select {
+	case err := <-done:
+		//Run returns early when the server fails to start
+		slog.Error("Server error", slog.String("err", err.Error()))
+		os.Exit(1)
+	case <-quit:
+	}
 	slog.Info("Shutting down server...")
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 	if err := server.Shutdown(ctx); err != nil {
 		slog.Error("Server forced to shutdown", slog.String("err", err.Error()))
 	}
-	<-done
+	if err := <-done; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("Server error", slog.String("err", err.Error()))
+	}
 	slog.Info("Server exiting")
 
 }
@@ -23,6 +23,14 @@ type Config struct {
 	DATABASE   `env-required:"true"`
 	JWT        `env-required:"true"`
 	REDIS      `env-required:"true"`
+	SESSION    `env-required:"true"`
+	TAGS       `env-required:"true"`
+	REACTIONS  `env-required:"true"`
+	POSTS      `env-required:"true"`
+	STORAGE    `env-required:"true"`
+	AVATARS    `env-required:"true"`
+	FEED       `env-required:"true"`
+	FILTERS    `env-required:"true"`
 }
 
 type HTTPServer struct {
@@ -38,11 +46,72 @@ type JWT struct {
 }
 
 type REDIS struct {
-	Host     string        `env:"REDIS_HOST" env-default:"localhost"`
-	Port     string        `env:"REDIS_PORT" env-default:"6379"`
-	Password string        `env:"REDIS_PASSWORD" env-default:"admin"`
-	DbIndex  int           `env:"REDIS_DB_INDEX" env-default:"0"`
-	TTL      time.Duration `env:"REDIS_TTL" env-default:"360h"`
+	Host     string `env:"REDIS_HOST" env-default:"localhost"`
+	Port     string `env:"REDIS_PORT" env-default:"6379"`
+	Password string `env:"REDIS_PASSWORD" env-default:"admin"`
+	DbIndex  int    `env:"REDIS_DB_INDEX" env-default:"0"`
+}
+
+type SESSION struct {
+	Store            string        `env:"SESSION_STORE" env-default:"redis"`
+	TTL              time.Duration `env:"SESSION_TTL" env-default:"360h"`
+	SweepInterval    time.Duration `env:"SESSION_SWEEP_INTERVAL" env-default:"1m"`
+	SessionSecret    string        `env:"SESSION_SECRET" env-required:"true"`
+	CookieName       string        `env:"SESSION_COOKIE_NAME" env-default:"session_id"`
+	CookieDomain     string        `env:"SESSION_COOKIE_DOMAIN" env-default:""`
+	CookiePath       string        `env:"SESSION_COOKIE_PATH" env-default:"/"`
+	CookieSecure     bool          `env:"SESSION_COOKIE_SECURE" env-default:"true"`
+	CookieSameSite   string        `env:"SESSION_COOKIE_SAME_SITE" env-default:"lax"`
+	CookieHostPrefix bool          `env:"SESSION_COOKIE_HOST_PREFIX" env-default:"false"`
+}
+
+type TAGS struct {
+	MaxTagsPerPost int `env:"TAGS_MAX_PER_POST" env-default:"10"`
+}
+
+type REACTIONS struct {
+	ReactionTypes []string `env:"REACTIONS_TYPES" env-separator:"," env-default:"like,love,laugh,hooray,wow,sad"`
+}
+
+type POSTS struct {
+	PublishInterval   time.Duration `env:"POSTS_PUBLISH_INTERVAL" env-default:"30s"`
+	PublishBatch      int           `env:"POSTS_PUBLISH_BATCH" env-default:"100"`
+	RevisionRetention int           `env:"POSTS_REVISION_RETENTION" env-default:"50"`
+}
+
+type STORAGE struct {
+	Backend         string        `env:"STORAGE_BACKEND" env-default:"local"`
+	LocalDir        string        `env:"STORAGE_LOCAL_DIR" env-default:"./data/blobs"`
+	MaxUploadSize   int64         `env:"STORAGE_MAX_UPLOAD_SIZE" env-default:"10485760"`
+	AllowedTypes    []string      `env:"STORAGE_ALLOWED_TYPES" env-separator:"," env-default:"image/png,image/jpeg,image/gif,image/webp,application/pdf,text/plain"`
+	CleanupInterval time.Duration `env:"STORAGE_CLEANUP_INTERVAL" env-default:"10m"`
+	CleanupBatch    int           `env:"STORAGE_CLEANUP_BATCH" env-default:"100"`
+	S3Endpoint      string        `env:"STORAGE_S3_ENDPOINT" env-default:""`
+	S3Region        string        `env:"STORAGE_S3_REGION" env-default:"us-east-1"`
+	S3Bucket        string        `env:"STORAGE_S3_BUCKET" env-default:""`
+	S3AccessKey     string        `env:"STORAGE_S3_ACCESS_KEY" env-default:""`
+	S3SecretKey     string        `env:"STORAGE_S3_SECRET_KEY" env-default:""`
+	S3PathStyle     bool          `env:"STORAGE_S3_PATH_STYLE" env-default:"true"`
+}
+
+type AVATARS struct {
+	MaxUploadSize int64 `env:"AVATARS_MAX_UPLOAD_SIZE" env-default:"5242880"`
+	MaxDimension  int   `env:"AVATARS_MAX_DIMENSION" env-default:"4096"`
+}
+
+type FEED struct {
+	CacheTTL  time.Duration `env:"FEED_CACHE_TTL" env-default:"0s"`
+	CacheSize int           `env:"FEED_CACHE_SIZE" env-default:"500"`
+}
+
+type FILTERS struct {
+	BlockedWords    []string      `env:"FILTERS_BLOCKED_WORDS" env-separator:"," env-default:""`
+	FlaggedWords    []string      `env:"FILTERS_FLAGGED_WORDS" env-separator:"," env-default:""`
+	MaxLinks        int           `env:"FILTERS_MAX_LINKS" env-default:"10"`
+	DuplicateWindow time.Duration `env:"FILTERS_DUPLICATE_WINDOW" env-default:"24h"`
+	QuotaWindow     time.Duration `env:"FILTERS_QUOTA_WINDOW" env-default:"1h"`
+	PostQuota       int           `env:"FILTERS_POST_QUOTA" env-default:"10"`
+	CommentQuota    int           `env:"FILTERS_COMMENT_QUOTA" env-default:"60"`
 }
 
 func MustLoad() *Config {
@@ -2,30 +2,92 @@ package api
 
 import (
 	"context"
+	"fmt"
 	httpSwagger "github.com/swaggo/http-swagger"
 	_ "go-rest-api-auth/cmd/main/docs"
 	"go-rest-api-auth/config"
+	"go-rest-api-auth/internal/contentfilter"
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/database/auth"
+	"go-rest-api-auth/internal/handlers/attachment/deleteAttachment"
+	"go-rest-api-auth/internal/handlers/attachment/getAttachment"
+	"go-rest-api-auth/internal/handlers/attachment/getAttachments"
+	"go-rest-api-auth/internal/handlers/attachment/uploadAttachment"
+	jwtChangePassword "go-rest-api-auth/internal/handlers/auth/jwt/changePassword"
 	jwtLogin "go-rest-api-auth/internal/handlers/auth/jwt/login"
 	jwtLogout "go-rest-api-auth/internal/handlers/auth/jwt/logout"
 	"go-rest-api-auth/internal/handlers/auth/jwt/refresh"
+	"go-rest-api-auth/internal/handlers/auth/revoke"
+	sessionChangePassword "go-rest-api-auth/internal/handlers/auth/session/changePassword"
 	sessionLogin "go-rest-api-auth/internal/handlers/auth/session/login"
 	sessionLogout "go-rest-api-auth/internal/handlers/auth/session/logout"
+	"go-rest-api-auth/internal/handlers/avatar/getAvatar"
+	"go-rest-api-auth/internal/handlers/block/blockUser"
+	"go-rest-api-auth/internal/handlers/block/getBlocks"
+	"go-rest-api-auth/internal/handlers/block/unblockUser"
+	"go-rest-api-auth/internal/handlers/comment/createComment"
+	"go-rest-api-auth/internal/handlers/comment/deleteComment"
+	"go-rest-api-auth/internal/handlers/comment/getComments"
+	"go-rest-api-auth/internal/handlers/comment/updateComment"
+	"go-rest-api-auth/internal/handlers/follow/followTag"
+	"go-rest-api-auth/internal/handlers/follow/followUser"
+	"go-rest-api-auth/internal/handlers/follow/getFollowedTags"
+	"go-rest-api-auth/internal/handlers/follow/getFollowers"
+	"go-rest-api-auth/internal/handlers/follow/getFollowing"
+	"go-rest-api-auth/internal/handlers/follow/unfollowTag"
+	"go-rest-api-auth/internal/handlers/follow/unfollowUser"
+	"go-rest-api-auth/internal/handlers/me/deleteAvatar"
+	"go-rest-api-auth/internal/handlers/me/deleteMe"
+	"go-rest-api-auth/internal/handlers/me/getFeed"
+	"go-rest-api-auth/internal/handlers/me/getMe"
+	"go-rest-api-auth/internal/handlers/me/getMyPosts"
+	"go-rest-api-auth/internal/handlers/me/setAvatar"
+	"go-rest-api-auth/internal/handlers/me/updateMe"
+	"go-rest-api-auth/internal/handlers/moderation/getReports"
+	"go-rest-api-auth/internal/handlers/moderation/reportPost"
+	"go-rest-api-auth/internal/handlers/moderation/resolveReport"
+	"go-rest-api-auth/internal/handlers/mute/getMutes"
+	"go-rest-api-auth/internal/handlers/mute/muteUser"
+	"go-rest-api-auth/internal/handlers/mute/unmuteUser"
 	"go-rest-api-auth/internal/handlers/post/createPost"
 	"go-rest-api-auth/internal/handlers/post/deletePost"
 	"go-rest-api-auth/internal/handlers/post/getAllPosts"
 	"go-rest-api-auth/internal/handlers/post/getPost"
+	"go-rest-api-auth/internal/handlers/post/getPostBySlug"
+	"go-rest-api-auth/internal/handlers/post/publishPost"
+	"go-rest-api-auth/internal/handlers/post/searchPosts"
+	"go-rest-api-auth/internal/handlers/post/unpublishPost"
 	"go-rest-api-auth/internal/handlers/post/updatePost"
+	"go-rest-api-auth/internal/handlers/reaction/addReaction"
+	"go-rest-api-auth/internal/handlers/reaction/getReactions"
+	"go-rest-api-auth/internal/handlers/reaction/removeReaction"
+	"go-rest-api-auth/internal/handlers/revision/diffRevisions"
+	"go-rest-api-auth/internal/handlers/revision/getRevision"
+	"go-rest-api-auth/internal/handlers/revision/getRevisions"
+	"go-rest-api-auth/internal/handlers/revision/restoreRevision"
+	"go-rest-api-auth/internal/handlers/tag/addTagAlias"
+	"go-rest-api-auth/internal/handlers/tag/deleteTag"
+	"go-rest-api-auth/internal/handlers/tag/deleteTagAlias"
+	"go-rest-api-auth/internal/handlers/tag/getAllTags"
+	"go-rest-api-auth/internal/handlers/tag/getTag"
+	"go-rest-api-auth/internal/handlers/tag/getTagPosts"
+	"go-rest-api-auth/internal/handlers/tag/mergeTags"
+	"go-rest-api-auth/internal/handlers/tag/renameTag"
+	"go-rest-api-auth/internal/handlers/tag/setTagParent"
 	"go-rest-api-auth/internal/handlers/user/createUser"
 	"go-rest-api-auth/internal/handlers/user/deleteUser"
+	"go-rest-api-auth/internal/handlers/user/getAdminUser"
 	"go-rest-api-auth/internal/handlers/user/getAllUsers"
 	"go-rest-api-auth/internal/handlers/user/getUser"
+	"go-rest-api-auth/internal/handlers/user/setUserRole"
 	"go-rest-api-auth/internal/handlers/user/updateUser"
 	"go-rest-api-auth/internal/middleware"
+	"go-rest-api-auth/internal/storage"
+	"go-rest-api-auth/internal/worker"
 	"log/slog"
 	"net/http"
 	"os"
+	"sync"
 )
 
 const (
@@ -34,12 +96,28 @@ const (
 	endProd  = "prod"
 )
 
+const (
+	sessionStoreRedis    = "redis"
+	sessionStorePostgres = "postgres"
+	sessionStoreMemory   = "memory"
+)
+
+const (
+	blobStoreLocal = "local"
+	blobStoreS3    = "s3"
+)
+
 // swagger:model
 type APIServer struct {
 	address  string
 	dbUrl    string
 	redisUrl string
-	server   *http.Server
+	// mu guards the fields Run sets and Shutdown reads from another goroutine
+	mu          sync.Mutex
+	stopped     bool
+	server      *http.Server
+	publisher   *worker.Publisher
+	blobCleaner *worker.BlobCleaner
 }
 
 func NewAPIServer(address string, dbUrl string, redisUrl string) *APIServer {
@@ -71,25 +149,88 @@ func (s *APIServer) Run(cfg *config.Config, ctx context.Context) error {
 		storage.Close()
 	}()
 
-	cache := database.NewRedisClient(ctx, log, s.redisUrl)
-	log.Info("Redis connected")
+	sessionStore, err := s.newSessionStore(cfg, ctx, log, storage)
+	if err != nil {
+		return err
+	}
+	log.Info("Session store ready", slog.String("store", cfg.SESSION.Store))
 	defer func() {
-		err := cache.Cache.Close()
+		err := sessionStore.Close()
 		if err != nil {
-			slog.Error("Failed to close Redis connection")
+			slog.Error("Failed to close session store", slog.String("err", err.Error()))
 		}
-		slog.Info("Redis disconnected")
+		slog.Info("Session store closed")
 	}()
 
+	blobStore, err := newBlobStore(cfg)
+	if err != nil {
+		return err
+	}
+	log.Info("Blob store ready", slog.String("backend", cfg.STORAGE.Backend))
+
+	timeline, err := s.newTimelineCache(cfg, ctx, log)
+	if err != nil {
+		return err
+	}
+	if timeline != nil {
+		log.Info("Timeline cache ready", slog.Duration("ttl", cfg.FEED.CacheTTL))
+		defer func() {
+			err := timeline.Close()
+			if err != nil {
+				slog.Error("Failed to close timeline cache", slog.String("err", err.Error()))
+			}
+			slog.Info("Timeline cache closed")
+		}()
+	}
+
 	TagsService := database.NewTagService(storage)
-	PostService := database.NewPostService(storage, TagsService)
+	PostService := database.NewPostService(storage, TagsService, cfg.MaxTagsPerPost, cfg.POSTS.RevisionRetention)
 	UserService := database.NewUserService(storage)
+	CommentService := database.NewCommentService(storage)
+	ReactionService := database.NewReactionService(storage, cfg.ReactionTypes)
+	RevisionService := database.NewRevisionService(storage)
+	AttachmentService := database.NewAttachmentService(storage, blobStore)
+	AvatarService := database.NewAvatarService(storage, blobStore)
+	FollowService := database.NewFollowService(storage, timeline)
+	BlockService := database.NewBlockService(storage, timeline)
+	ModerationService := database.NewModerationService(storage)
+	ContentFilter := newContentFilter(cfg, database.NewContentHistoryService(storage))
+	UnitOfWork := database.NewUnitOfWork(storage, cfg.MaxTagsPerPost, cfg.POSTS.RevisionRetention)
 	TokenManager := auth.NewJwtManager(cfg, storage)
-	SessionManager := auth.NewSessionManager(cache, cfg.REDIS.TTL)
+	SessionManager := auth.NewSessionManager(sessionStore, cfg.SESSION.TTL, cfg.SessionSecret)
+	SessionCookie := auth.NewSessionCookie(cfg.SESSION)
+
+	if cfg.POSTS.PublishInterval <= 0 {
+		return fmt.Errorf("posts publish interval must be positive: %s", cfg.POSTS.PublishInterval)
+	}
+	if cfg.POSTS.PublishBatch <= 0 {
+		return fmt.Errorf("posts publish batch must be positive: %d", cfg.POSTS.PublishBatch)
+	}
+	if cfg.STORAGE.CleanupInterval <= 0 {
+		return fmt.Errorf("storage cleanup interval must be positive: %s", cfg.STORAGE.CleanupInterval)
+	}
+	if cfg.STORAGE.CleanupBatch <= 0 {
+		return fmt.Errorf("storage cleanup batch must be positive: %d", cfg.STORAGE.CleanupBatch)
+	}
+
+	publisher := worker.NewPublisher(log, PostService, cfg.POSTS.PublishInterval, cfg.POSTS.PublishBatch)
+	defer func() {
+		_ = publisher.Close()
+		slog.Info("Publisher stopped")
+	}()
+
+	blobCleaner := worker.NewBlobCleaner(log, AttachmentService, cfg.STORAGE.CleanupInterval, cfg.STORAGE.CleanupBatch)
+	defer func() {
+		_ = blobCleaner.Close()
+		slog.Info("Blob cleaner stopped")
+	}()
 
 	mainMiddlewareStack := middleware.CreateStack(
 		middleware.RequestLoggerMiddleware(log),
 	)
+	adminOnly := middleware.RequireRoleMiddleware(log, UserService, database.RoleAdmin)
+	moderatorsOnly := middleware.RequireRoleMiddleware(log, UserService, database.RoleModerator, database.RoleAdmin)
+	jwtAuth := middleware.JWTAuthMiddleware(log, TokenManager, UserService)
 
 	//JWT auth
 	//
@@ -111,7 +252,7 @@ func (s *APIServer) Run(cfg *config.Config, ctx context.Context) error {
 	// @Param request body refresh.Request true "JWT refresh request"
 	// @Success 200 {object} refresh.Response
 	// @Router /refresh [post]
-	router.HandleFunc("POST /refresh", refresh.New(log, TokenManager))
+	router.HandleFunc("POST /refresh", refresh.New(log, TokenManager, UserService))
 
 	//Session auth
 	// @Summary Session Login
@@ -122,7 +263,7 @@ func (s *APIServer) Run(cfg *config.Config, ctx context.Context) error {
 	// @Param request body sessionLogin.Request true "Session login request"
 	// @Success 200 {object} sessionLogin.Response
 	// @Router /session_login [post]
-	router.HandleFunc("POST /session_login", sessionLogin.New(log, SessionManager, UserService))
+	router.HandleFunc("POST /session_login", sessionLogin.New(log, SessionManager, UserService, SessionCookie))
 
 	// @Summary Get User
 	// @Description Get user by ID
@@ -134,13 +275,42 @@ func (s *APIServer) Run(cfg *config.Config, ctx context.Context) error {
 	router.HandleFunc("GET /users/{userID}", getUser.New(log, UserService))
 
 	// @Summary Get All Users
-	// @Description Get a list of all users
+	// @Description Get a page of users
 	// @Tags Users
 	// @Produce json
+	// @Param sort query string false "Sort field, id by default" Enums(id, username, date_joined)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order" Enums(asc, desc)
+	// @Param joined_from query string false "Joined on or after, RFC 3339 or YYYY-MM-DD"
+	// @Param joined_to query string false "Joined before, RFC 3339 or YYYY-MM-DD"
+	// @Param username_prefix query string false "Username prefix, case insensitive"
 	// @Success 200 {array} getAllUsers.Response
 	// @Router /users [get]
 	router.HandleFunc("GET /users", getAllUsers.New(log, UserService))
 
+	// @Summary Get Followers
+	// @Description Get a page of the users following a user, the latest followers first
+	// @Tags Users
+	// @Produce json
+	// @Param userID path string true "User ID"
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Success 200 {object} getFollowers.Response
+	// @Router /users/{userID}/followers [get]
+	router.HandleFunc("GET /users/{userID}/followers", getFollowers.New(log, FollowService))
+
+	// @Summary Get Following
+	// @Description Get a page of the users a user follows, the latest followed first
+	// @Tags Users
+	// @Produce json
+	// @Param userID path string true "User ID"
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Success 200 {object} getFollowing.Response
+	// @Router /users/{userID}/following [get]
+	router.HandleFunc("GET /users/{userID}/following", getFollowing.New(log, FollowService))
+
 	// @Summary Create User
 	// @Description Create a new user
 	// @Tags Users
@@ -152,16 +322,16 @@ func (s *APIServer) Run(cfg *config.Config, ctx context.Context) error {
 	router.HandleFunc("POST /users", createUser.New(log, UserService))
 
 	// @Summary Delete User
-	// @Description Delete user by ID
+	// @Description Delete user by ID, only the user themselves or an admin may do so (requires JWT)
 	// @Tags Users
 	// @Produce json
 	// @Param userID path string true "User ID"
 	// @Success 204
 	// @Router /users/{userID} [delete]
-	router.HandleFunc("DELETE /users/{userID}", deleteUser.New(log, UserService))
+	router.Handle("DELETE /users/{userID}", jwtAuth(deleteUser.New(log, UserService)))
 
 	// @Summary Update User
-	// @Description Update user information by ID
+	// @Description Update user information by ID, only the user themselves or an admin may do so (requires JWT)
 	// @Tags Users
 	// @Accept json
 	// @Produce json
@@ -169,12 +339,92 @@ func (s *APIServer) Run(cfg *config.Config, ctx context.Context) error {
 	// @Param request body updateUser.Request true "Update user request"
 	// @Success 200 {object} updateUser.Response
 	// @Router /users/{userID} [put]
-	router.HandleFunc("PUT /users/{userID}", updateUser.New(log, UserService))
+	router.Handle("PUT /users/{userID}", jwtAuth(updateUser.New(log, UserService)))
+
+	// @Summary Get Avatar
+	// @Description Get a thumbnail of an avatar, the avatar_url of a user. Avatars are public and never change, they may be cached for good
+	// @Tags Users
+	// @Produce image/jpeg,image/png
+	// @Param avatarID path string true "Avatar ID"
+	// @Param size query int false "Edge of the thumbnail in pixels, 256 by default" Enums(256, 128, 64)
+	// @Success 200 {file} file "Avatar thumbnail"
+	// @Success 304 "Not modified"
+	// @Router /avatars/{avatarID} [get]
+	router.HandleFunc("GET /avatars/{avatarID}", getAvatar.New(log, AvatarService, blobStore))
+
+	// @Summary Get Post By Slug
+	// @Description Get a published post by its slug, the permalink of a post. A previous slug of a post is redirected to its current one
+	// @Tags Posts
+	// @Produce json
+	// @Param slug path string true "Post slug"
+	// @Success 200 {object} getPostBySlug.Response
+	// @Success 301 "Moved to the current slug"
+	// @Router /posts/by-slug/{slug} [get]
+	router.HandleFunc("GET /posts/by-slug/{slug}", getPostBySlug.New(log, PostService))
+
+	// public posts can be read without logging in, the versioned routes show the rest to their readers
+	//
+	// @Summary Get Public Posts
+	// @Description Get a page of public posts without authentication
+	// @Tags Posts
+	// @Produce json
+	// @Param sort query string false "Sort field, created_at by default" Enums(id, created_at, title)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order" Enums(asc, desc)
+	// @Param author query int false "Author user ID"
+	// @Param tag query []string false "Tags the post must all carry, repeated or comma separated" collectionFormat(multi)
+	// @Param descendants query bool false "Also match posts carrying a descendant of a tag"
+	// @Param created_from query string false "Created at or after, RFC 3339 or YYYY-MM-DD"
+	// @Param created_to query string false "Created before, RFC 3339 or YYYY-MM-DD"
+	// @Param title_prefix query string false "Title prefix, case insensitive"
+	// @Success 200 {array} getAllPosts.Response
+	// @Router /posts [get]
+	router.HandleFunc("GET /posts", getAllPosts.New(log, PostService, ReactionService))
+
+	// @Summary Search Public Posts
+	// @Description Full-text search over public posts without authentication, best matches first, with highlighted snippets
+	// @Tags Posts
+	// @Produce json
+	// @Param q query string true "Search text, websearch syntax: quoted phrases, OR and -excluded words"
+	// @Param sort query string false "Sort field, rank by default" Enums(rank, id, created_at)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order" Enums(asc, desc)
+	// @Param author query int false "Author user ID"
+	// @Param tag query []string false "Tags the post must all carry, repeated or comma separated" collectionFormat(multi)
+	// @Param descendants query bool false "Also match posts carrying a descendant of a tag"
+	// @Success 200 {object} searchPosts.Response
+	// @Router /posts/search [get]
+	router.HandleFunc("GET /posts/search", searchPosts.New(log, PostService, ReactionService))
+
+	// @Summary Get Public Post
+	// @Description Get a published public or unlisted post by ID without authentication
+	// @Tags Posts
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Success 200 {object} getPost.Response
+	// @Router /posts/{postID} [get]
+	router.HandleFunc("GET /posts/{postID}", getPost.New(log, PostService, ReactionService))
+
+	// @Summary Get Public Tag Posts
+	// @Description Get a page of public posts carrying the tag without authentication
+	// @Tags Tags
+	// @Produce json
+	// @Param name path string true "Tag name or alias"
+	// @Param descendants query bool false "Also include posts carrying a descendant tag"
+	// @Param sort query string false "Sort field, created_at by default" Enums(id, created_at, title)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order" Enums(asc, desc)
+	// @Success 200 {object} getTagPosts.Response
+	// @Router /tags/{name}/posts [get]
+	router.HandleFunc("GET /tags/{name}/posts", getTagPosts.New(log, PostService, ReactionService))
 
 	v1 := http.NewServeMux()
 	v1MiddlewareStack := middleware.CreateStack(
 		//middleware.TestAuthMiddleware(log),
-		middleware.JWTAuthMiddleware(log, TokenManager),
+		jwtAuth,
 	)
 
 	// @Summary JWT Logout
@@ -185,35 +435,258 @@ func (s *APIServer) Run(cfg *config.Config, ctx context.Context) error {
 	// @Router /v1/logout [get]
 	v1.HandleFunc("GET /logout", jwtLogout.New(log, TokenManager))
 
+	// @Summary Log out everywhere
+	// @Description Revoke every access token, refresh token and session of the current user
+	// @Tags Auth
+	// @Produce json
+	// @Success 200 {object} revoke.Response
+	// @Router /v1/logout_all [post]
+	v1.HandleFunc("POST /logout_all", revoke.New(log, UserService))
+
+	// @Summary Get Me
+	// @Description Get the current user
+	// @Tags Me
+	// @Produce json
+	// @Success 200 {object} getMe.Response
+	// @Router /v1/me [get]
+	v1.HandleFunc("GET /me", getMe.New(log, UserService))
+
+	// @Summary Update Me
+	// @Description Update the current user's username or description
+	// @Tags Me
+	// @Accept json
+	// @Produce json
+	// @Param request body updateMe.Request true "Update current user request"
+	// @Success 200 {object} updateMe.Response
+	// @Router /v1/me [patch]
+	v1.HandleFunc("PATCH /me", updateMe.New(log, UserService))
+
+	// @Summary Delete Me
+	// @Description Delete the current user
+	// @Tags Me
+	// @Produce json
+	// @Success 200 {object} deleteMe.Response
+	// @Router /v1/me [delete]
+	v1.HandleFunc("DELETE /me", deleteMe.New(log, UserService))
+
+	// @Summary Set My Avatar
+	// @Description Replace the current user's avatar with a PNG, JPEG or WebP picture, cropped to a square and resized to thumbnails without its metadata
+	// @Tags Me
+	// @Accept multipart/form-data
+	// @Produce json
+	// @Param avatar formData file true "Picture"
+	// @Success 200 {object} setAvatar.Response
+	// @Router /v1/me/avatar [put]
+	v1.HandleFunc("PUT /me/avatar", setAvatar.New(log, AvatarService, cfg.AVATARS.MaxUploadSize, cfg.AVATARS.MaxDimension))
+
+	// @Summary Delete My Avatar
+	// @Description Remove the current user's avatar
+	// @Tags Me
+	// @Produce json
+	// @Success 200 {object} deleteAvatar.Response
+	// @Router /v1/me/avatar [delete]
+	v1.HandleFunc("DELETE /me/avatar", deleteAvatar.New(log, AvatarService))
+
+	// @Summary Get My Posts
+	// @Description Get the current user's posts, drafts included
+	// @Tags Me
+	// @Produce json
+	// @Param sort query string false "Sort field, created_at by default" Enums(id, created_at, title)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order" Enums(asc, desc)
+	// @Param status query string false "Only list posts in this state" Enums(draft, scheduled, published, archived)
+	// @Success 200 {object} getMyPosts.Response
+	// @Router /v1/me/posts [get]
+	v1.HandleFunc("GET /me/posts", getMyPosts.New(log, PostService, ReactionService))
+
+	// @Summary Get Feed
+	// @Description Get the posts of the users and tags the current user follows, the latest published first
+	// @Tags Me
+	// @Produce json
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Success 200 {object} getFeed.Response
+	// @Router /v1/me/feed [get]
+	v1.HandleFunc("GET /me/feed", getFeed.New(log, FollowService, ReactionService))
+
+	// @Summary Follow User
+	// @Description Follow a user, following twice is a no-op
+	// @Tags Me
+	// @Produce json
+	// @Param userID path string true "User ID"
+	// @Success 200 {object} followUser.Response
+	// @Router /v1/me/following/{userID} [put]
+	v1.HandleFunc("PUT /me/following/{userID}", followUser.New(log, FollowService))
+
+	// @Summary Unfollow User
+	// @Description Stop following a user
+	// @Tags Me
+	// @Produce json
+	// @Param userID path string true "User ID"
+	// @Success 200 {object} unfollowUser.Response
+	// @Router /v1/me/following/{userID} [delete]
+	v1.HandleFunc("DELETE /me/following/{userID}", unfollowUser.New(log, FollowService))
+
+	// @Summary Get Followed Tags
+	// @Description Get the tags the current user follows, sorted by name
+	// @Tags Me
+	// @Produce json
+	// @Success 200 {object} getFollowedTags.Response
+	// @Router /v1/me/followed-tags [get]
+	v1.HandleFunc("GET /me/followed-tags", getFollowedTags.New(log, FollowService))
+
+	// @Summary Follow Tag
+	// @Description Follow a tag by its name or an alias, following twice is a no-op
+	// @Tags Me
+	// @Produce json
+	// @Param name path string true "Tag name or alias"
+	// @Success 200 {object} followTag.Response
+	// @Router /v1/me/followed-tags/{name} [put]
+	v1.HandleFunc("PUT /me/followed-tags/{name}", followTag.New(log, FollowService))
+
+	// @Summary Unfollow Tag
+	// @Description Stop following a tag
+	// @Tags Me
+	// @Produce json
+	// @Param name path string true "Tag name or alias"
+	// @Success 200 {object} unfollowTag.Response
+	// @Router /v1/me/followed-tags/{name} [delete]
+	v1.HandleFunc("DELETE /me/followed-tags/{name}", unfollowTag.New(log, FollowService))
+
+	// @Summary Get Blocks
+	// @Description Get a page of the users the current user blocked, the latest blocked first
+	// @Tags Me
+	// @Produce json
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Success 200 {object} getBlocks.Response
+	// @Router /v1/me/blocks [get]
+	v1.HandleFunc("GET /me/blocks", getBlocks.New(log, BlockService))
+
+	// @Summary Block User
+	// @Description Block a user, who can then no longer see the posts of the current user, comment on them, react to them or follow them. The follows between the two are removed
+	// @Tags Me
+	// @Accept json
+	// @Produce json
+	// @Param request body blockUser.Request true "Block user request"
+	// @Success 200 {object} blockUser.Response
+	// @Router /v1/me/blocks [post]
+	v1.HandleFunc("POST /me/blocks", blockUser.New(log, BlockService))
+
+	// @Summary Unblock User
+	// @Description Lift a block, the follows it removed are not restored
+	// @Tags Me
+	// @Produce json
+	// @Param userID path string true "User ID"
+	// @Success 200 {object} unblockUser.Response
+	// @Router /v1/me/blocks/{userID} [delete]
+	v1.HandleFunc("DELETE /me/blocks/{userID}", unblockUser.New(log, BlockService))
+
+	// @Summary Get Mutes
+	// @Description Get a page of the users the current user muted, the latest muted first
+	// @Tags Me
+	// @Produce json
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Success 200 {object} getMutes.Response
+	// @Router /v1/me/mutes [get]
+	v1.HandleFunc("GET /me/mutes", getMutes.New(log, BlockService))
+
+	// @Summary Mute User
+	// @Description Mute a user, whose posts and comments are then left out of the lists and the feed of the current user. The muted user is not notified
+	// @Tags Me
+	// @Accept json
+	// @Produce json
+	// @Param request body muteUser.Request true "Mute user request"
+	// @Success 200 {object} muteUser.Response
+	// @Router /v1/me/mutes [post]
+	v1.HandleFunc("POST /me/mutes", muteUser.New(log, BlockService))
+
+	// @Summary Unmute User
+	// @Description Lift a mute
+	// @Tags Me
+	// @Produce json
+	// @Param userID path string true "User ID"
+	// @Success 200 {object} unmuteUser.Response
+	// @Router /v1/me/mutes/{userID} [delete]
+	v1.HandleFunc("DELETE /me/mutes/{userID}", unmuteUser.New(log, BlockService))
+
+	// @Summary Change Password
+	// @Description Change the current user's password, revoke all previous tokens and issue a new pair
+	// @Tags Me
+	// @Accept json
+	// @Produce json
+	// @Param request body jwtChangePassword.Request true "Change password request"
+	// @Success 200 {object} jwtChangePassword.Response
+	// @Router /v1/me/password [post]
+	v1.HandleFunc("POST /me/password", jwtChangePassword.New(log, TokenManager, UserService))
+
 	// @Summary Create Post
-	// @Description Create a new post
+	// @Description Create a new post, published right away unless status is draft. Posts rejected by the content filters are answered with the violations
 	// @Tags Posts
 	// @Accept json
 	// @Produce json
 	// @Param request body createPost.Request true "Create post request"
 	// @Success 201 {object} createPost.Response
 	// @Router /v1/posts [post]
-	v1.HandleFunc("POST /posts", createPost.New(log, PostService))
+	v1.HandleFunc("POST /posts", createPost.New(log, PostService, ContentFilter, ModerationService))
 
 	// @Summary Get All Posts
-	// @Description Get a list of all posts
+	// @Description Get a page of the posts the current user may read: published public ones, followers-only ones of followed authors and their own. Unlisted posts are only listed to their author
 	// @Tags Posts
 	// @Produce json
+	// @Param sort query string false "Sort field, created_at by default" Enums(id, created_at, title)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order" Enums(asc, desc)
+	// @Param author query int false "Author user ID"
+	// @Param tag query []string false "Tags the post must all carry, repeated or comma separated" collectionFormat(multi)
+	// @Param descendants query bool false "Also match posts carrying a descendant of a tag"
+	// @Param created_from query string false "Created at or after, RFC 3339 or YYYY-MM-DD"
+	// @Param created_to query string false "Created before, RFC 3339 or YYYY-MM-DD"
+	// @Param title_prefix query string false "Title prefix, case insensitive"
 	// @Success 200 {array} getAllPosts.Response
 	// @Router /v1/posts [get]
-	v1.HandleFunc("GET /posts", getAllPosts.New(log, PostService))
+	v1.HandleFunc("GET /posts", getAllPosts.New(log, PostService, ReactionService))
+
+	// @Summary Search Posts
+	// @Description Full-text search over post titles and contents, best matches first, with highlighted snippets
+	// @Tags Posts
+	// @Produce json
+	// @Param q query string true "Search text, websearch syntax: quoted phrases, OR and -excluded words"
+	// @Param sort query string false "Sort field, rank by default" Enums(rank, id, created_at)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order" Enums(asc, desc)
+	// @Param author query int false "Author user ID"
+	// @Param tag query []string false "Tags the post must all carry, repeated or comma separated" collectionFormat(multi)
+	// @Param descendants query bool false "Also match posts carrying a descendant of a tag"
+	// @Success 200 {object} searchPosts.Response
+	// @Router /v1/posts/search [get]
+	v1.HandleFunc("GET /posts/search", searchPosts.New(log, PostService, ReactionService))
 
 	// @Summary Get Post
-	// @Description Get post by ID
+	// @Description Get post by ID, unpublished and private posts are only shown to their author and admins, followers-only ones to the followers of the author
 	// @Tags Posts
 	// @Produce json
 	// @Param postID path string true "Post ID"
 	// @Success 200 {object} getPost.Response
 	// @Router /v1/posts/{postID} [get]
-	v1.HandleFunc("GET /posts/{postID}", getPost.New(log, PostService))
+	v1.HandleFunc("GET /posts/{postID}", getPost.New(log, PostService, ReactionService))
+
+	// @Summary Get Post By Slug
+	// @Description Get post by its slug, the permalink of a post. Unpublished and private posts are only shown to their author and admins, followers-only ones to the followers of the author. A previous slug of a post is redirected to its current one
+	// @Tags Posts
+	// @Produce json
+	// @Param slug path string true "Post slug"
+	// @Success 200 {object} getPostBySlug.Response
+	// @Success 301 "Moved to the current slug"
+	// @Router /v1/posts/by-slug/{slug} [get]
+	v1.HandleFunc("GET /posts/by-slug/{slug}", getPostBySlug.New(log, PostService))
 
 	// @Summary Update Post
-	// @Description Update post information by ID
+	// @Description Update post information by ID, only the author or an admin may do so. Updates rejected by the content filters are answered with the violations
 	// @Tags Posts
 	// @Accept json
 	// @Produce json
@@ -221,7 +694,7 @@ func (s *APIServer) Run(cfg *config.Config, ctx context.Context) error {
 	// @Param request body updatePost.Request true "Update post request"
 	// @Success 200 {object} updatePost.Response
 	// @Router /v1/posts/{postID} [put]
-	v1.HandleFunc("PUT /posts/{postID}", updatePost.New(log, PostService))
+	v1.HandleFunc("PUT /posts/{postID}", updatePost.New(log, UnitOfWork, ContentFilter, ModerationService))
 
 	// @Summary Delete Post
 	// @Description Delete post by ID
@@ -232,9 +705,351 @@ func (s *APIServer) Run(cfg *config.Config, ctx context.Context) error {
 	// @Router /v1/posts/{postID} [delete]
 	v1.HandleFunc("DELETE /posts/{postID}", deletePost.New(log, PostService))
 
+	// @Summary Publish Post
+	// @Description Publish a post of the current user right away, or schedule it with a future publish_at
+	// @Tags Posts
+	// @Accept json
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param request body publishPost.Request false "Publish post request"
+	// @Success 200 {object} publishPost.Response
+	// @Router /v1/posts/{postID}/publish [post]
+	v1.HandleFunc("POST /posts/{postID}/publish", publishPost.New(log, PostService))
+
+	// @Summary Unpublish Post
+	// @Description Take a post of the current user back to a draft, or archive it
+	// @Tags Posts
+	// @Accept json
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param request body unpublishPost.Request false "Unpublish post request"
+	// @Success 200 {object} unpublishPost.Response
+	// @Router /v1/posts/{postID}/unpublish [post]
+	v1.HandleFunc("POST /posts/{postID}/unpublish", unpublishPost.New(log, PostService))
+
+	// @Summary Get Revisions
+	// @Description Get a page of the revisions of a post, newest first by default
+	// @Tags Revisions
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order, desc by default" Enums(asc, desc)
+	// @Success 200 {object} getRevisions.Response
+	// @Router /v1/posts/{postID}/revisions [get]
+	v1.HandleFunc("GET /posts/{postID}/revisions", getRevisions.New(log, PostService, RevisionService))
+
+	// @Summary Get Revision
+	// @Description Get a revision of a post
+	// @Tags Revisions
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param revision path int true "Revision number"
+	// @Success 200 {object} getRevision.Response
+	// @Router /v1/posts/{postID}/revisions/{revision} [get]
+	v1.HandleFunc("GET /posts/{postID}/revisions/{revision}", getRevision.New(log, PostService, RevisionService))
+
+	// @Summary Diff Revisions
+	// @Description Get a unified diff of the title, tags and content of a post between two revisions
+	// @Tags Revisions
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param revision path int true "Revision number"
+	// @Param from query int false "Revision to compare with, the previous one by default, 0 compares with an empty post"
+	// @Success 200 {object} diffRevisions.Response
+	// @Router /v1/posts/{postID}/revisions/{revision}/diff [get]
+	v1.HandleFunc("GET /posts/{postID}/revisions/{revision}/diff", diffRevisions.New(log, PostService, RevisionService))
+
+	// @Summary Restore Revision
+	// @Description Bring a post of the current user back to one of its revisions, recorded as a new revision. The revision runs through the content filters first
+	// @Tags Revisions
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param revision path int true "Revision number"
+	// @Success 200 {object} restoreRevision.Response
+	// @Router /v1/posts/{postID}/revisions/{revision}/restore [post]
+	v1.HandleFunc("POST /posts/{postID}/revisions/{revision}/restore", restoreRevision.New(log, PostService, RevisionService, ContentFilter, ModerationService))
+
+	// @Summary Upload Attachment
+	// @Description Attach a file to a post of the current user. The content type is sniffed from the content and must be one of the allowed types
+	// @Tags Attachments
+	// @Accept multipart/form-data
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param file formData file true "File to attach"
+	// @Success 200 {object} uploadAttachment.Response
+	// @Router /v1/posts/{postID}/attachments [post]
+	v1.HandleFunc("POST /posts/{postID}/attachments", uploadAttachment.New(log, PostService, AttachmentService, cfg.STORAGE.MaxUploadSize, cfg.STORAGE.AllowedTypes))
+
+	// @Summary Get Attachments
+	// @Description Get the attachments of a post, oldest first
+	// @Tags Attachments
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Success 200 {object} getAttachments.Response
+	// @Router /v1/posts/{postID}/attachments [get]
+	v1.HandleFunc("GET /posts/{postID}/attachments", getAttachments.New(log, PostService, AttachmentService))
+
+	// @Summary Delete Attachment
+	// @Description Remove an attachment from a post of the current user
+	// @Tags Attachments
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param attachmentID path int true "Attachment ID"
+	// @Success 200 {object} deleteAttachment.Response
+	// @Router /v1/posts/{postID}/attachments/{attachmentID} [delete]
+	v1.HandleFunc("DELETE /posts/{postID}/attachments/{attachmentID}", deleteAttachment.New(log, PostService, AttachmentService))
+
+	// @Summary Get Attachment
+	// @Description Download the content of an attachment, images are shown inline. The checksum is sent as ETag
+	// @Tags Attachments
+	// @Produce octet-stream
+	// @Param attachmentID path int true "Attachment ID"
+	// @Success 200 {file} file
+	// @Success 304 "Not modified"
+	// @Router /v1/attachments/{attachmentID} [get]
+	v1.HandleFunc("GET /attachments/{attachmentID}", getAttachment.New(log, PostService, AttachmentService, blobStore))
+
+	// @Summary Get Comments
+	// @Description Get a page of the comments of a post, as a tree or a flat list. Posts the user may not read are not found
+	// @Tags Comments
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param view query string false "tree nests replies, flat lists every comment with its parent_id, tree by default" Enums(tree, flat)
+	// @Param depth query int false "Levels of a tree including its roots, 3 by default and at most 10"
+	// @Param parent query int false "Comment whose replies are the roots of the tree, top level comments by default"
+	// @Param sort query string false "Sort field of the roots, created_at by default" Enums(id, created_at)
+	// @Param limit query int false "Number of roots, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order, asc by default" Enums(asc, desc)
+	// @Success 200 {object} getComments.Response
+	// @Router /v1/posts/{postID}/comments [get]
+	v1.HandleFunc("GET /posts/{postID}/comments", getComments.New(log, CommentService, PostService))
+
+	// @Summary Create Comment
+	// @Description Comment a post, or reply to a comment with parent_id. Comments rejected by the content filters are answered with the violations, posts the user may not read are not found
+	// @Tags Comments
+	// @Accept json
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param request body createComment.Request true "Create comment request"
+	// @Success 200 {object} createComment.Response
+	// @Router /v1/posts/{postID}/comments [post]
+	v1.HandleFunc("POST /posts/{postID}/comments", createComment.New(log, CommentService, PostService, ContentFilter))
+
+	// @Summary Report Post
+	// @Description Report a post to the moderators, reporting it again while the first report is open returns the first report
+	// @Tags Moderation
+	// @Accept json
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param request body reportPost.Request true "Report post request, reason is one of spam, harassment, hate, violence, sexual, misinformation, other"
+	// @Success 200 {object} reportPost.Response
+	// @Router /v1/posts/{postID}/report [post]
+	v1.HandleFunc("POST /posts/{postID}/report", reportPost.New(log, PostService, ModerationService))
+
+	// @Summary Update Comment
+	// @Description Edit a comment, only its author can. Edits rejected by the content filters are answered with the violations
+	// @Tags Comments
+	// @Accept json
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param commentID path string true "Comment ID"
+	// @Param request body updateComment.Request true "Update comment request"
+	// @Success 200 {object} updateComment.Response
+	// @Router /v1/posts/{postID}/comments/{commentID} [patch]
+	v1.HandleFunc("PATCH /posts/{postID}/comments/{commentID}", updateComment.New(log, CommentService, ContentFilter))
+
+	// @Summary Delete Comment
+	// @Description Delete a comment, its author or an admin can. A comment with replies stays as a placeholder
+	// @Tags Comments
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param commentID path string true "Comment ID"
+	// @Success 200 {object} deleteComment.Response
+	// @Router /v1/posts/{postID}/comments/{commentID} [delete]
+	v1.HandleFunc("DELETE /posts/{postID}/comments/{commentID}", deleteComment.New(log, CommentService, UserService))
+
+	// @Summary Get Reactions
+	// @Description Get a page of who reacted to a post, newest first by default. Posts the user may not read are not found
+	// @Tags Reactions
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param type query string false "Only list this reaction type"
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param sort query string false "Sort field, created_at by default" Enums(id, created_at)
+	// @Param order query string false "Sort order, desc by default" Enums(asc, desc)
+	// @Success 200 {object} getReactions.Response
+	// @Router /v1/posts/{postID}/reactions [get]
+	v1.HandleFunc("GET /posts/{postID}/reactions", getReactions.New(log, ReactionService, PostService))
+
+	// @Summary Add Reaction
+	// @Description React to a post, reacting twice with the same type is a no-op. Posts the user may not read are not found
+	// @Tags Reactions
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param type path string true "Reaction type, one of REACTIONS_TYPES"
+	// @Success 200 {object} addReaction.Response
+	// @Router /v1/posts/{postID}/reactions/{type} [put]
+	v1.HandleFunc("PUT /posts/{postID}/reactions/{type}", addReaction.New(log, ReactionService, PostService))
+
+	// @Summary Remove Reaction
+	// @Description Take back a reaction to a post
+	// @Tags Reactions
+	// @Produce json
+	// @Param postID path string true "Post ID"
+	// @Param type path string true "Reaction type, one of REACTIONS_TYPES"
+	// @Success 200 {object} removeReaction.Response
+	// @Router /v1/posts/{postID}/reactions/{type} [delete]
+	v1.HandleFunc("DELETE /posts/{postID}/reactions/{type}", removeReaction.New(log, ReactionService))
+
+	// @Summary Get All Tags
+	// @Description Get tags with their post counts, most used first
+	// @Tags Tags
+	// @Produce json
+	// @Param prefix query string false "Tag prefix for autocomplete"
+	// @Param limit query int false "Number of tags, 20 by default and at most 100"
+	// @Success 200 {object} getAllTags.Response
+	// @Router /v1/tags [get]
+	v1.HandleFunc("GET /tags", getAllTags.New(log, TagsService))
+
+	// @Summary Get Tag Posts
+	// @Description Get a page of posts carrying the tag
+	// @Tags Tags
+	// @Produce json
+	// @Param name path string true "Tag name or alias"
+	// @Param descendants query bool false "Also include posts carrying a descendant tag"
+	// @Param sort query string false "Sort field, created_at by default" Enums(id, created_at, title)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order" Enums(asc, desc)
+	// @Success 200 {object} getTagPosts.Response
+	// @Router /v1/tags/{name}/posts [get]
+	v1.HandleFunc("GET /tags/{name}/posts", getTagPosts.New(log, PostService, ReactionService))
+
+	// @Summary Get Tag
+	// @Description Get a tag with its parent, aliases and children
+	// @Tags Tags
+	// @Produce json
+	// @Param tagID path string true "Tag ID"
+	// @Success 200 {object} getTag.Response
+	// @Router /v1/tags/{tagID} [get]
+	v1.HandleFunc("GET /tags/{tagID}", getTag.New(log, TagsService))
+
+	// @Summary Rename Tag
+	// @Description Rename a tag, admin only
+	// @Tags Tags
+	// @Accept json
+	// @Produce json
+	// @Param tagID path string true "Tag ID"
+	// @Param request body renameTag.Request true "Rename tag request"
+	// @Success 200 {object} renameTag.Response
+	// @Router /v1/tags/{tagID} [patch]
+	v1.Handle("PATCH /tags/{tagID}", adminOnly(renameTag.New(log, TagsService)))
+
+	// @Summary Merge Tags
+	// @Description Move every post of the tag to the target tag and delete the tag, admin only
+	// @Tags Tags
+	// @Accept json
+	// @Produce json
+	// @Param tagID path string true "Tag ID"
+	// @Param request body mergeTags.Request true "Merge tags request"
+	// @Success 200 {object} mergeTags.Response
+	// @Router /v1/tags/{tagID}/merge [post]
+	v1.Handle("POST /tags/{tagID}/merge", adminOnly(mergeTags.New(log, TagsService)))
+
+	// @Summary Delete Tag
+	// @Description Delete a tag, admin only
+	// @Tags Tags
+	// @Produce json
+	// @Param tagID path string true "Tag ID"
+	// @Success 200 {object} deleteTag.Response
+	// @Router /v1/tags/{tagID} [delete]
+	v1.Handle("DELETE /tags/{tagID}", adminOnly(deleteTag.New(log, TagsService)))
+
+	// @Summary Set Tag Parent
+	// @Description Move a tag under another tag, parent_id 0 makes it a root tag, admin only
+	// @Tags Tags
+	// @Accept json
+	// @Produce json
+	// @Param tagID path string true "Tag ID"
+	// @Param request body setTagParent.Request true "Set tag parent request"
+	// @Success 200 {object} setTagParent.Response
+	// @Router /v1/tags/{tagID}/parent [put]
+	v1.Handle("PUT /tags/{tagID}/parent", adminOnly(setTagParent.New(log, TagsService)))
+
+	// @Summary Add Tag Alias
+	// @Description Make an alias resolve to the tag, admin only
+	// @Tags Tags
+	// @Accept json
+	// @Produce json
+	// @Param tagID path string true "Tag ID"
+	// @Param request body addTagAlias.Request true "Add tag alias request"
+	// @Success 200 {object} addTagAlias.Response
+	// @Router /v1/tags/{tagID}/aliases [post]
+	v1.Handle("POST /tags/{tagID}/aliases", adminOnly(addTagAlias.New(log, TagsService)))
+
+	// @Summary Delete Tag Alias
+	// @Description Remove an alias of the tag, admin only
+	// @Tags Tags
+	// @Produce json
+	// @Param tagID path string true "Tag ID"
+	// @Param alias path string true "Alias"
+	// @Success 200 {object} deleteTagAlias.Response
+	// @Router /v1/tags/{tagID}/aliases/{alias} [delete]
+	v1.Handle("DELETE /tags/{tagID}/aliases/{alias}", adminOnly(deleteTagAlias.New(log, TagsService)))
+
+	// @Summary Get User As Admin
+	// @Description Get user by ID with the role, token version and suspension of the account, admin only
+	// @Tags Users
+	// @Produce json
+	// @Param userID path string true "User ID"
+	// @Success 200 {object} getAdminUser.Response
+	// @Router /v1/admin/users/{userID} [get]
+	v1.Handle("GET /admin/users/{userID}", adminOnly(getAdminUser.New(log, UserService)))
+
+	// @Summary Set User Role
+	// @Description Change the role of a user to user, moderator or admin, the tokens and sessions of the user are revoked with it, admin only
+	// @Tags Users
+	// @Accept json
+	// @Produce json
+	// @Param userID path string true "User ID"
+	// @Param request body setUserRole.Request true "Set role request"
+	// @Success 200 {object} setUserRole.Response
+	// @Router /v1/admin/users/{userID}/role [put]
+	v1.Handle("PUT /admin/users/{userID}/role", adminOnly(setUserRole.New(log, UserService)))
+
+	// @Summary Get Reports
+	// @Description Get a page of the moderation queue, the oldest reports first by default, moderators and admins only
+	// @Tags Moderation
+	// @Produce json
+	// @Param status query string false "Only list reports in this state" Enums(open, resolved)
+	// @Param reason query string false "Only list reports with this reason"
+	// @Param post_id query int false "Only list reports of this post"
+	// @Param author_id query int false "Only list reports of posts by this author"
+	// @Param sort query string false "Sort field, created_at by default" Enums(id, created_at)
+	// @Param order query string false "Sort order, asc by default" Enums(asc, desc)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Success 200 {object} getReports.Response
+	// @Router /v1/moderation/reports [get]
+	v1.Handle("GET /moderation/reports", moderatorsOnly(getReports.New(log, ModerationService)))
+
+	// @Summary Resolve Report
+	// @Description Act on a report and resolve every open report of the post: dismiss, hide the post, delete the post or suspend its author. The action is recorded with the moderator and the note, moderators and admins only
+	// @Tags Moderation
+	// @Accept json
+	// @Produce json
+	// @Param reportID path string true "Report ID"
+	// @Param request body resolveReport.Request true "Resolve report request, action is one of dismiss, hide, delete, suspend"
+	// @Success 200 {object} resolveReport.Response
+	// @Router /v1/moderation/reports/{reportID}/actions [post]
+	v1.Handle("POST /moderation/reports/{reportID}/actions", moderatorsOnly(resolveReport.New(log, ModerationService)))
+
 	v2 := http.NewServeMux()
 	v2MiddlewareStack := middleware.CreateStack(
-		middleware.SessionAuthMiddleware(log, SessionManager),
+		middleware.SessionAuthMiddleware(log, SessionManager, SessionCookie, UserService),
 	)
 
 	// @Summary Logout from session-based authentication
@@ -243,37 +1058,260 @@ func (s *APIServer) Run(cfg *config.Config, ctx context.Context) error {
 	// @Produce json
 	// @Success 200 {string} string "Successfully logged out"
 	// @Router /v2/logout [get]
-	v2.HandleFunc("GET /logout", sessionLogout.New(log, SessionManager))
+	v2.HandleFunc("GET /logout", sessionLogout.New(log, SessionManager, SessionCookie))
+
+	// @Summary Log out everywhere
+	// @Description Revoke every session, access token and refresh token of the current user (requires "session_id" cookie).
+	// @Tags session_auth
+	// @Produce json
+	// @Success 200 {object} revoke.Response
+	// @Router /v2/logout_all [post]
+	v2.HandleFunc("POST /logout_all", revoke.New(log, UserService))
+
+	// @Summary Get the current user
+	// @Description Retrieve the current user with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Success 200 {object} getMe.Response "Current user"
+	// @Router /v2/me [get]
+	v2.HandleFunc("GET /me", getMe.New(log, UserService))
+
+	// @Summary Update the current user
+	// @Description Update the current user's username or description with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Accept json
+	// @Produce json
+	// @Param user body updateMe.Request true "Updated user details"
+	// @Success 200 {object} updateMe.Response "User updated successfully"
+	// @Router /v2/me [patch]
+	v2.HandleFunc("PATCH /me", updateMe.New(log, UserService))
+
+	// @Summary Delete the current user
+	// @Description Delete the current user with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Success 200 {object} deleteMe.Response "User deleted successfully"
+	// @Router /v2/me [delete]
+	v2.HandleFunc("DELETE /me", deleteMe.New(log, UserService))
+
+	// @Summary Set the current user's avatar
+	// @Description Replace the avatar with a PNG, JPEG or WebP picture with session-based authentication (requires "session_id" cookie). The picture is cropped to a square and resized to thumbnails without its metadata.
+	// @Tags me
+	// @Accept multipart/form-data
+	// @Produce json
+	// @Param avatar formData file true "Picture"
+	// @Success 200 {object} setAvatar.Response "Avatar set successfully"
+	// @Router /v2/me/avatar [put]
+	v2.HandleFunc("PUT /me/avatar", setAvatar.New(log, AvatarService, cfg.AVATARS.MaxUploadSize, cfg.AVATARS.MaxDimension))
+
+	// @Summary Delete the current user's avatar
+	// @Description Remove the avatar with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Success 200 {object} deleteAvatar.Response "Avatar deleted successfully"
+	// @Router /v2/me/avatar [delete]
+	v2.HandleFunc("DELETE /me/avatar", deleteAvatar.New(log, AvatarService))
+
+	// @Summary Get the current user's posts
+	// @Description Retrieve the current user's posts, drafts included, with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Param sort query string false "Sort field, created_at by default" Enums(id, created_at, title)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order" Enums(asc, desc)
+	// @Param status query string false "Only list posts in this state" Enums(draft, scheduled, published, archived)
+	// @Success 200 {object} getMyPosts.Response "List of posts"
+	// @Router /v2/me/posts [get]
+	v2.HandleFunc("GET /me/posts", getMyPosts.New(log, PostService, ReactionService))
+
+	// @Summary Get the current user's feed
+	// @Description Retrieve the posts of the users and tags the current user follows, the latest published first, with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Success 200 {object} getFeed.Response "List of posts"
+	// @Router /v2/me/feed [get]
+	v2.HandleFunc("GET /me/feed", getFeed.New(log, FollowService, ReactionService))
+
+	// @Summary Follow a user
+	// @Description Follow a user, following twice is a no-op, with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Param userID path string true "User ID"
+	// @Success 200 {object} followUser.Response "Followed user"
+	// @Router /v2/me/following/{userID} [put]
+	v2.HandleFunc("PUT /me/following/{userID}", followUser.New(log, FollowService))
+
+	// @Summary Unfollow a user
+	// @Description Stop following a user with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Param userID path string true "User ID"
+	// @Success 200 {object} unfollowUser.Response "Unfollowed user"
+	// @Router /v2/me/following/{userID} [delete]
+	v2.HandleFunc("DELETE /me/following/{userID}", unfollowUser.New(log, FollowService))
+
+	// @Summary Get the current user's followed tags
+	// @Description Retrieve the tags the current user follows, sorted by name, with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Success 200 {object} getFollowedTags.Response "List of tags"
+	// @Router /v2/me/followed-tags [get]
+	v2.HandleFunc("GET /me/followed-tags", getFollowedTags.New(log, FollowService))
+
+	// @Summary Follow a tag
+	// @Description Follow a tag by its name or an alias, following twice is a no-op, with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Param name path string true "Tag name or alias"
+	// @Success 200 {object} followTag.Response "Followed tag"
+	// @Router /v2/me/followed-tags/{name} [put]
+	v2.HandleFunc("PUT /me/followed-tags/{name}", followTag.New(log, FollowService))
+
+	// @Summary Unfollow a tag
+	// @Description Stop following a tag with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Param name path string true "Tag name or alias"
+	// @Success 200 {object} unfollowTag.Response "Unfollowed tag"
+	// @Router /v2/me/followed-tags/{name} [delete]
+	v2.HandleFunc("DELETE /me/followed-tags/{name}", unfollowTag.New(log, FollowService))
+
+	// @Summary Get the current user's blocks
+	// @Description Retrieve a page of the users the current user blocked, the latest blocked first, with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Success 200 {object} getBlocks.Response "List of users"
+	// @Router /v2/me/blocks [get]
+	v2.HandleFunc("GET /me/blocks", getBlocks.New(log, BlockService))
+
+	// @Summary Block a user
+	// @Description Block a user, who can then no longer see the posts of the current user, comment on them, react to them or follow them, with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Accept json
+	// @Produce json
+	// @Param request body blockUser.Request true "User to block"
+	// @Success 200 {object} blockUser.Response "Blocked user"
+	// @Router /v2/me/blocks [post]
+	v2.HandleFunc("POST /me/blocks", blockUser.New(log, BlockService))
+
+	// @Summary Unblock a user
+	// @Description Lift a block with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Param userID path string true "User ID"
+	// @Success 200 {object} unblockUser.Response "Unblocked user"
+	// @Router /v2/me/blocks/{userID} [delete]
+	v2.HandleFunc("DELETE /me/blocks/{userID}", unblockUser.New(log, BlockService))
+
+	// @Summary Get the current user's mutes
+	// @Description Retrieve a page of the users the current user muted, the latest muted first, with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Success 200 {object} getMutes.Response "List of users"
+	// @Router /v2/me/mutes [get]
+	v2.HandleFunc("GET /me/mutes", getMutes.New(log, BlockService))
+
+	// @Summary Mute a user
+	// @Description Mute a user, whose posts and comments are then left out of the lists and the feed of the current user, with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Accept json
+	// @Produce json
+	// @Param request body muteUser.Request true "User to mute"
+	// @Success 200 {object} muteUser.Response "Muted user"
+	// @Router /v2/me/mutes [post]
+	v2.HandleFunc("POST /me/mutes", muteUser.New(log, BlockService))
+
+	// @Summary Unmute a user
+	// @Description Lift a mute with session-based authentication (requires "session_id" cookie).
+	// @Tags me
+	// @Produce json
+	// @Param userID path string true "User ID"
+	// @Success 200 {object} unmuteUser.Response "Unmuted user"
+	// @Router /v2/me/mutes/{userID} [delete]
+	v2.HandleFunc("DELETE /me/mutes/{userID}", unmuteUser.New(log, BlockService))
+
+	// @Summary Change the current user's password
+	// @Description Change the password, revoke all previous sessions and issue a new "session_id" cookie (requires "session_id" cookie).
+	// @Tags me
+	// @Accept json
+	// @Produce json
+	// @Param request body sessionChangePassword.Request true "Change password request"
+	// @Success 200 {object} sessionChangePassword.Response "Password changed successfully"
+	// @Router /v2/me/password [post]
+	v2.HandleFunc("POST /me/password", sessionChangePassword.New(log, SessionManager, UserService, SessionCookie))
 
 	// @Summary Create a new post
-	// @Description Create a post in the system with session-based authentication (requires "session_id" cookie).
+	// @Description Create a post, published right away unless status is draft, with session-based authentication (requires "session_id" cookie). Posts rejected by the content filters are answered with the violations.
 	// @Tags posts
 	// @Accept json
 	// @Produce json
 	// @Param post body createPost.Request true "Post details"
 	// @Success 201 {object} createPost.Response "Post created successfully"
 	// @Router /v2/posts [post]
-	v2.HandleFunc("POST /posts", createPost.New(log, PostService))
+	v2.HandleFunc("POST /posts", createPost.New(log, PostService, ContentFilter, ModerationService))
 
 	// @Summary Get all posts
-	// @Description Retrieve all posts in the system with session-based authentication (requires "session_id" cookie).
+	// @Description Retrieve the posts the current user may read: published public ones, followers-only ones of followed authors and their own, with session-based authentication (requires "session_id" cookie).
 	// @Tags posts
 	// @Produce json
+	// @Param sort query string false "Sort field, created_at by default" Enums(id, created_at, title)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order" Enums(asc, desc)
+	// @Param author query int false "Author user ID"
+	// @Param tag query []string false "Tags the post must all carry, repeated or comma separated" collectionFormat(multi)
+	// @Param descendants query bool false "Also match posts carrying a descendant of a tag"
+	// @Param created_from query string false "Created at or after, RFC 3339 or YYYY-MM-DD"
+	// @Param created_to query string false "Created before, RFC 3339 or YYYY-MM-DD"
+	// @Param title_prefix query string false "Title prefix, case insensitive"
 	// @Success 200 {array} getAllPosts.Response "List of posts"
 	// @Router /v2/posts [get]
-	v2.HandleFunc("GET /posts", getAllPosts.New(log, PostService))
+	v2.HandleFunc("GET /posts", getAllPosts.New(log, PostService, ReactionService))
+
+	// @Summary Search posts
+	// @Description Full-text search over post titles and contents, best matches first, with highlighted snippets, with session-based authentication (requires "session_id" cookie).
+	// @Tags posts
+	// @Produce json
+	// @Param q query string true "Search text, websearch syntax: quoted phrases, OR and -excluded words"
+	// @Param sort query string false "Sort field, rank by default" Enums(rank, id, created_at)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order" Enums(asc, desc)
+	// @Param author query int false "Author user ID"
+	// @Param tag query []string false "Tags the post must all carry, repeated or comma separated" collectionFormat(multi)
+	// @Param descendants query bool false "Also match posts carrying a descendant of a tag"
+	// @Success 200 {object} searchPosts.Response "Matching posts"
+	// @Router /v2/posts/search [get]
+	v2.HandleFunc("GET /posts/search", searchPosts.New(log, PostService, ReactionService))
 
 	// @Summary Get post by ID
-	// @Description Retrieve a specific post by its ID with session-based authentication (requires "session_id" cookie).
+	// @Description Retrieve a specific post by its ID, unpublished and private posts are only shown to their author and admins, followers-only ones to the followers of the author, with session-based authentication (requires "session_id" cookie).
 	// @Tags posts
 	// @Produce json
 	// @Param postID path string true "ID of the post"
 	// @Success 200 {object} getPost.Response "Post details"
 	// @Router /v2/posts/{postID} [get]
-	v2.HandleFunc("GET /posts/{postID}", getPost.New(log, PostService))
+	v2.HandleFunc("GET /posts/{postID}", getPost.New(log, PostService, ReactionService))
+
+	// @Summary Get post by slug
+	// @Description Retrieve a post by its slug, the permalink of a post, unpublished and private posts are only shown to their author and admins, followers-only ones to the followers of the author, with session-based authentication (requires "session_id" cookie). A previous slug of a post is redirected to its current one.
+	// @Tags posts
+	// @Produce json
+	// @Param slug path string true "Slug of the post"
+	// @Success 200 {object} getPostBySlug.Response "Post details"
+	// @Success 301 "Moved to the current slug"
+	// @Router /v2/posts/by-slug/{slug} [get]
+	v2.HandleFunc("GET /posts/by-slug/{slug}", getPostBySlug.New(log, PostService))
 
 	// @Summary Update a post by ID
-	// @Description Update a specific post by its ID with session-based authentication (requires "session_id" cookie).
+	// @Description Update a specific post by its ID with session-based authentication (requires "session_id" cookie). Only the author or an admin may update a post. Updates rejected by the content filters are answered with the violations.
 	// @Tags posts
 	// @Accept json
 	// @Produce json
@@ -281,7 +1319,7 @@ func (s *APIServer) Run(cfg *config.Config, ctx context.Context) error {
 	// @Param post body updatePost.Request true "Updated post details"
 	// @Success 200 {object} updatePost.Response "Post updated successfully"
 	// @Router /v2/posts/{postID} [put]
-	v2.HandleFunc("PUT /posts/{postID}", updatePost.New(log, PostService))
+	v2.HandleFunc("PUT /posts/{postID}", updatePost.New(log, UnitOfWork, ContentFilter, ModerationService))
 
 	// @Summary Delete a post by ID
 	// @Description Delete a specific post by its ID with session-based authentication (requires "session_id" cookie).
@@ -292,10 +1330,352 @@ func (s *APIServer) Run(cfg *config.Config, ctx context.Context) error {
 	// @Router /v2/posts/{postID} [delete]
 	v2.HandleFunc("DELETE /posts/{postID}", deletePost.New(log, PostService))
 
+	// @Summary Publish a post
+	// @Description Publish a post of the current user with session-based authentication (requires "session_id" cookie), right away or at a future publish_at.
+	// @Tags posts
+	// @Accept json
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param post body publishPost.Request false "When to publish"
+	// @Success 200 {object} publishPost.Response "Post published or scheduled successfully"
+	// @Router /v2/posts/{postID}/publish [post]
+	v2.HandleFunc("POST /posts/{postID}/publish", publishPost.New(log, PostService))
+
+	// @Summary Unpublish a post
+	// @Description Take a post of the current user back to a draft, or archive it, with session-based authentication (requires "session_id" cookie).
+	// @Tags posts
+	// @Accept json
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param post body unpublishPost.Request false "Whether to archive"
+	// @Success 200 {object} unpublishPost.Response "Post unpublished successfully"
+	// @Router /v2/posts/{postID}/unpublish [post]
+	v2.HandleFunc("POST /posts/{postID}/unpublish", unpublishPost.New(log, PostService))
+
+	// @Summary Get revisions of a post
+	// @Description Retrieve a page of the revisions of a post, newest first by default, with session-based authentication (requires "session_id" cookie).
+	// @Tags revisions
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order, desc by default" Enums(asc, desc)
+	// @Success 200 {object} getRevisions.Response "List of revisions"
+	// @Router /v2/posts/{postID}/revisions [get]
+	v2.HandleFunc("GET /posts/{postID}/revisions", getRevisions.New(log, PostService, RevisionService))
+
+	// @Summary Get a revision of a post
+	// @Description Retrieve a revision of a post with session-based authentication (requires "session_id" cookie).
+	// @Tags revisions
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param revision path int true "Revision number"
+	// @Success 200 {object} getRevision.Response "Revision retrieved successfully"
+	// @Router /v2/posts/{postID}/revisions/{revision} [get]
+	v2.HandleFunc("GET /posts/{postID}/revisions/{revision}", getRevision.New(log, PostService, RevisionService))
+
+	// @Summary Diff two revisions of a post
+	// @Description Retrieve a unified diff of the title, tags and content of a post between two revisions with session-based authentication (requires "session_id" cookie).
+	// @Tags revisions
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param revision path int true "Revision number"
+	// @Param from query int false "Revision to compare with, the previous one by default, 0 compares with an empty post"
+	// @Success 200 {object} diffRevisions.Response "Diff computed successfully"
+	// @Router /v2/posts/{postID}/revisions/{revision}/diff [get]
+	v2.HandleFunc("GET /posts/{postID}/revisions/{revision}/diff", diffRevisions.New(log, PostService, RevisionService))
+
+	// @Summary Restore a revision of a post
+	// @Description Bring a post of the current user back to one of its revisions with session-based authentication (requires "session_id" cookie). The restore is recorded as a new revision and runs through the content filters first.
+	// @Tags revisions
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param revision path int true "Revision number"
+	// @Success 200 {object} restoreRevision.Response "Revision restored successfully"
+	// @Router /v2/posts/{postID}/revisions/{revision}/restore [post]
+	v2.HandleFunc("POST /posts/{postID}/revisions/{revision}/restore", restoreRevision.New(log, PostService, RevisionService, ContentFilter, ModerationService))
+
+	// @Summary Attach a file to a post
+	// @Description Upload a file to a post of the current user with session-based authentication (requires "session_id" cookie). The content type is sniffed from the content and must be one of the allowed types.
+	// @Tags attachments
+	// @Accept multipart/form-data
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param file formData file true "File to attach"
+	// @Success 200 {object} uploadAttachment.Response "Attachment uploaded successfully"
+	// @Router /v2/posts/{postID}/attachments [post]
+	v2.HandleFunc("POST /posts/{postID}/attachments", uploadAttachment.New(log, PostService, AttachmentService, cfg.STORAGE.MaxUploadSize, cfg.STORAGE.AllowedTypes))
+
+	// @Summary Get attachments of a post
+	// @Description Retrieve the attachments of a post, oldest first, with session-based authentication (requires "session_id" cookie).
+	// @Tags attachments
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Success 200 {object} getAttachments.Response "List of attachments"
+	// @Router /v2/posts/{postID}/attachments [get]
+	v2.HandleFunc("GET /posts/{postID}/attachments", getAttachments.New(log, PostService, AttachmentService))
+
+	// @Summary Delete an attachment of a post
+	// @Description Remove an attachment from a post of the current user with session-based authentication (requires "session_id" cookie).
+	// @Tags attachments
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param attachmentID path int true "ID of the attachment"
+	// @Success 200 {object} deleteAttachment.Response "Attachment deleted successfully"
+	// @Router /v2/posts/{postID}/attachments/{attachmentID} [delete]
+	v2.HandleFunc("DELETE /posts/{postID}/attachments/{attachmentID}", deleteAttachment.New(log, PostService, AttachmentService))
+
+	// @Summary Download an attachment
+	// @Description Retrieve the content of an attachment with session-based authentication (requires "session_id" cookie). Images are shown inline, the checksum is sent as ETag.
+	// @Tags attachments
+	// @Produce octet-stream
+	// @Param attachmentID path int true "ID of the attachment"
+	// @Success 200 {file} file "Attachment content"
+	// @Success 304 "Not modified"
+	// @Router /v2/attachments/{attachmentID} [get]
+	v2.HandleFunc("GET /attachments/{attachmentID}", getAttachment.New(log, PostService, AttachmentService, blobStore))
+
+	// @Summary Get comments of a post
+	// @Description Retrieve a page of the comments of a post, as a tree or a flat list, with session-based authentication (requires "session_id" cookie). Posts the user may not read are not found.
+	// @Tags comments
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param view query string false "tree nests replies, flat lists every comment with its parent_id, tree by default" Enums(tree, flat)
+	// @Param depth query int false "Levels of a tree including its roots, 3 by default and at most 10"
+	// @Param parent query int false "Comment whose replies are the roots of the tree, top level comments by default"
+	// @Param sort query string false "Sort field of the roots, created_at by default" Enums(id, created_at)
+	// @Param limit query int false "Number of roots, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order, asc by default" Enums(asc, desc)
+	// @Success 200 {object} getComments.Response "List of comments"
+	// @Router /v2/posts/{postID}/comments [get]
+	v2.HandleFunc("GET /posts/{postID}/comments", getComments.New(log, CommentService, PostService))
+
+	// @Summary Comment a post
+	// @Description Comment a post, or reply to a comment with parent_id, with session-based authentication (requires "session_id" cookie). Comments rejected by the content filters are answered with the violations, posts the user may not read are not found.
+	// @Tags comments
+	// @Accept json
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param comment body createComment.Request true "Comment"
+	// @Success 200 {object} createComment.Response "Comment created successfully"
+	// @Router /v2/posts/{postID}/comments [post]
+	v2.HandleFunc("POST /posts/{postID}/comments", createComment.New(log, CommentService, PostService, ContentFilter))
+
+	// @Summary Report a post
+	// @Description Report a post to the moderators with session-based authentication (requires "session_id" cookie).
+	// @Tags moderation
+	// @Accept json
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param report body reportPost.Request true "Reason, one of spam, harassment, hate, violence, sexual, misinformation, other"
+	// @Success 200 {object} reportPost.Response "Post reported successfully"
+	// @Router /v2/posts/{postID}/report [post]
+	v2.HandleFunc("POST /posts/{postID}/report", reportPost.New(log, PostService, ModerationService))
+
+	// @Summary Edit a comment
+	// @Description Edit a comment with session-based authentication (requires "session_id" cookie), only its author can. Edits rejected by the content filters are answered with the violations.
+	// @Tags comments
+	// @Accept json
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param commentID path string true "ID of the comment"
+	// @Param comment body updateComment.Request true "New content"
+	// @Success 200 {object} updateComment.Response "Comment updated successfully"
+	// @Router /v2/posts/{postID}/comments/{commentID} [patch]
+	v2.HandleFunc("PATCH /posts/{postID}/comments/{commentID}", updateComment.New(log, CommentService, ContentFilter))
+
+	// @Summary Delete a comment
+	// @Description Delete a comment with session-based authentication (requires "session_id" cookie), its author or an admin can. A comment with replies stays as a placeholder.
+	// @Tags comments
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param commentID path string true "ID of the comment"
+	// @Success 200 {object} deleteComment.Response "Comment deleted successfully"
+	// @Router /v2/posts/{postID}/comments/{commentID} [delete]
+	v2.HandleFunc("DELETE /posts/{postID}/comments/{commentID}", deleteComment.New(log, CommentService, UserService))
+
+	// @Summary Get reactions
+	// @Description Retrieve a page of who reacted to a post, newest first by default, with session-based authentication (requires "session_id" cookie). Posts the user may not read are not found.
+	// @Tags reactions
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param type query string false "Only list this reaction type"
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param sort query string false "Sort field, created_at by default" Enums(id, created_at)
+	// @Param order query string false "Sort order, desc by default" Enums(asc, desc)
+	// @Success 200 {object} getReactions.Response "Reactions retrieved successfully"
+	// @Router /v2/posts/{postID}/reactions [get]
+	v2.HandleFunc("GET /posts/{postID}/reactions", getReactions.New(log, ReactionService, PostService))
+
+	// @Summary Add a reaction
+	// @Description React to a post with session-based authentication (requires "session_id" cookie). Reacting twice with the same type is a no-op. Posts the user may not read are not found.
+	// @Tags reactions
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param type path string true "Reaction type, one of REACTIONS_TYPES"
+	// @Success 200 {object} addReaction.Response "Reaction added successfully"
+	// @Router /v2/posts/{postID}/reactions/{type} [put]
+	v2.HandleFunc("PUT /posts/{postID}/reactions/{type}", addReaction.New(log, ReactionService, PostService))
+
+	// @Summary Remove a reaction
+	// @Description Take back a reaction to a post with session-based authentication (requires "session_id" cookie).
+	// @Tags reactions
+	// @Produce json
+	// @Param postID path string true "ID of the post"
+	// @Param type path string true "Reaction type, one of REACTIONS_TYPES"
+	// @Success 200 {object} removeReaction.Response "Reaction removed successfully"
+	// @Router /v2/posts/{postID}/reactions/{type} [delete]
+	v2.HandleFunc("DELETE /posts/{postID}/reactions/{type}", removeReaction.New(log, ReactionService))
+
+	// @Summary Get all tags
+	// @Description Retrieve tags with their post counts, most used first, with session-based authentication (requires "session_id" cookie).
+	// @Tags tags
+	// @Produce json
+	// @Param prefix query string false "Tag prefix for autocomplete"
+	// @Param limit query int false "Number of tags, 20 by default and at most 100"
+	// @Success 200 {object} getAllTags.Response "List of tags"
+	// @Router /v2/tags [get]
+	v2.HandleFunc("GET /tags", getAllTags.New(log, TagsService))
+
+	// @Summary Get posts by tag
+	// @Description Retrieve a page of posts carrying the tag with session-based authentication (requires "session_id" cookie).
+	// @Tags tags
+	// @Produce json
+	// @Param name path string true "Tag name or alias"
+	// @Param descendants query bool false "Also include posts carrying a descendant tag"
+	// @Param sort query string false "Sort field, created_at by default" Enums(id, created_at, title)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Param order query string false "Sort order" Enums(asc, desc)
+	// @Success 200 {object} getTagPosts.Response "List of posts"
+	// @Router /v2/tags/{name}/posts [get]
+	v2.HandleFunc("GET /tags/{name}/posts", getTagPosts.New(log, PostService, ReactionService))
+
+	// @Summary Get a tag
+	// @Description Retrieve a tag with its parent, aliases and children with session-based authentication (requires "session_id" cookie).
+	// @Tags tags
+	// @Produce json
+	// @Param tagID path string true "ID of the tag"
+	// @Success 200 {object} getTag.Response "Tag details"
+	// @Router /v2/tags/{tagID} [get]
+	v2.HandleFunc("GET /tags/{tagID}", getTag.New(log, TagsService))
+
+	// @Summary Rename a tag
+	// @Description Rename a tag with session-based authentication (requires "session_id" cookie), admin only.
+	// @Tags tags
+	// @Accept json
+	// @Produce json
+	// @Param tagID path string true "ID of the tag"
+	// @Param tag body renameTag.Request true "New tag name"
+	// @Success 200 {object} renameTag.Response "Tag renamed successfully"
+	// @Router /v2/tags/{tagID} [patch]
+	v2.Handle("PATCH /tags/{tagID}", adminOnly(renameTag.New(log, TagsService)))
+
+	// @Summary Merge a tag into another
+	// @Description Move every post of the tag to the target tag and delete the tag with session-based authentication (requires "session_id" cookie), admin only.
+	// @Tags tags
+	// @Accept json
+	// @Produce json
+	// @Param tagID path string true "ID of the tag"
+	// @Param merge body mergeTags.Request true "Target tag"
+	// @Success 200 {object} mergeTags.Response "Tags merged successfully"
+	// @Router /v2/tags/{tagID}/merge [post]
+	v2.Handle("POST /tags/{tagID}/merge", adminOnly(mergeTags.New(log, TagsService)))
+
+	// @Summary Delete a tag
+	// @Description Delete a tag with session-based authentication (requires "session_id" cookie), admin only.
+	// @Tags tags
+	// @Produce json
+	// @Param tagID path string true "ID of the tag"
+	// @Success 200 {object} deleteTag.Response "Tag deleted successfully"
+	// @Router /v2/tags/{tagID} [delete]
+	v2.Handle("DELETE /tags/{tagID}", adminOnly(deleteTag.New(log, TagsService)))
+
+	// @Summary Set the parent of a tag
+	// @Description Move a tag under another tag, parent_id 0 makes it a root tag, with session-based authentication (requires "session_id" cookie), admin only.
+	// @Tags tags
+	// @Accept json
+	// @Produce json
+	// @Param tagID path string true "ID of the tag"
+	// @Param parent body setTagParent.Request true "Parent tag"
+	// @Success 200 {object} setTagParent.Response "Tag parent set successfully"
+	// @Router /v2/tags/{tagID}/parent [put]
+	v2.Handle("PUT /tags/{tagID}/parent", adminOnly(setTagParent.New(log, TagsService)))
+
+	// @Summary Add an alias to a tag
+	// @Description Make an alias resolve to the tag with session-based authentication (requires "session_id" cookie), admin only.
+	// @Tags tags
+	// @Accept json
+	// @Produce json
+	// @Param tagID path string true "ID of the tag"
+	// @Param alias body addTagAlias.Request true "Alias"
+	// @Success 200 {object} addTagAlias.Response "Alias added successfully"
+	// @Router /v2/tags/{tagID}/aliases [post]
+	v2.Handle("POST /tags/{tagID}/aliases", adminOnly(addTagAlias.New(log, TagsService)))
+
+	// @Summary Delete an alias of a tag
+	// @Description Remove an alias of the tag with session-based authentication (requires "session_id" cookie), admin only.
+	// @Tags tags
+	// @Produce json
+	// @Param tagID path string true "ID of the tag"
+	// @Param alias path string true "Alias"
+	// @Success 200 {object} deleteTagAlias.Response "Alias deleted successfully"
+	// @Router /v2/tags/{tagID}/aliases/{alias} [delete]
+	v2.Handle("DELETE /tags/{tagID}/aliases/{alias}", adminOnly(deleteTagAlias.New(log, TagsService)))
+
+	// @Summary Get a user as an administrator
+	// @Description Retrieve a user by ID with the role, token version and suspension of the account, with session-based authentication (requires "session_id" cookie), admin only.
+	// @Tags users
+	// @Produce json
+	// @Param userID path string true "ID of the user"
+	// @Success 200 {object} getAdminUser.Response "User details"
+	// @Router /v2/admin/users/{userID} [get]
+	v2.Handle("GET /admin/users/{userID}", adminOnly(getAdminUser.New(log, UserService)))
+
+	// @Summary Set the role of a user
+	// @Description Change the role of a user to user, moderator or admin with session-based authentication (requires "session_id" cookie), admin only. The tokens and sessions of the user are revoked with it.
+	// @Tags users
+	// @Accept json
+	// @Produce json
+	// @Param userID path string true "ID of the user"
+	// @Param role body setUserRole.Request true "New role"
+	// @Success 200 {object} setUserRole.Response "User with the new role"
+	// @Router /v2/admin/users/{userID}/role [put]
+	v2.Handle("PUT /admin/users/{userID}/role", adminOnly(setUserRole.New(log, UserService)))
+
+	// @Summary Get the moderation queue
+	// @Description Retrieve a page of reports, the oldest first by default, with session-based authentication (requires "session_id" cookie), moderators and admins only.
+	// @Tags moderation
+	// @Produce json
+	// @Param status query string false "Only list reports in this state" Enums(open, resolved)
+	// @Param reason query string false "Only list reports with this reason"
+	// @Param post_id query int false "Only list reports of this post"
+	// @Param author_id query int false "Only list reports of posts by this author"
+	// @Param sort query string false "Sort field, created_at by default" Enums(id, created_at)
+	// @Param order query string false "Sort order, asc by default" Enums(asc, desc)
+	// @Param limit query int false "Page size, 20 by default and at most 100"
+	// @Param cursor query string false "next_cursor of the previous page"
+	// @Success 200 {object} getReports.Response "List of reports"
+	// @Router /v2/moderation/reports [get]
+	v2.Handle("GET /moderation/reports", moderatorsOnly(getReports.New(log, ModerationService)))
+
+	// @Summary Act on a report
+	// @Description Dismiss the report, hide or delete the post or suspend its author, recorded with the moderator and the note, with session-based authentication (requires "session_id" cookie), moderators and admins only.
+	// @Tags moderation
+	// @Accept json
+	// @Produce json
+	// @Param reportID path string true "ID of the report"
+	// @Param action body resolveReport.Request true "Action, one of dismiss, hide, delete, suspend, and note"
+	// @Success 200 {object} resolveReport.Response "Report resolved successfully"
+	// @Router /v2/moderation/reports/{reportID}/actions [post]
+	v2.Handle("POST /moderation/reports/{reportID}/actions", moderatorsOnly(resolveReport.New(log, ModerationService)))
+
 	router.Handle("/v1/", http.StripPrefix("/v1", v1MiddlewareStack(v1)))
 	router.Handle("/v2/", http.StripPrefix("/v2", v2MiddlewareStack(v2)))
 
-	s.server = &http.Server{
+	server := &http.Server{
 		Addr:         s.address,
 		Handler:      mainMiddlewareStack(router),
 		ReadTimeout:  cfg.HTTPServer.Timeout,
@@ -303,14 +1683,115 @@ func (s *APIServer) Run(cfg *config.Config, ctx context.Context) error {
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
 	}
 
+	s.mu.Lock()
+	if s.stopped {
+		//Shutdown was called while the server was starting
+		s.mu.Unlock()
+		return http.ErrServerClosed
+	}
+	s.server, s.publisher, s.blobCleaner = server, publisher, blobCleaner
+	s.mu.Unlock()
+
 	log.Info("Server has started: ", slog.String("address", s.address))
 	log.Debug("debug logger enabled")
 
-	return s.server.ListenAndServe()
+	return server.ListenAndServe()
+}
+
+func (s *APIServer) newSessionStore(cfg *config.Config, ctx context.Context, log *slog.Logger, storage *database.DbPool) (auth.SessionStore, error) {
+	switch cfg.SESSION.Store {
+	case sessionStoreRedis:
+		cache, err := database.NewRedisClient(ctx, log, s.redisUrl)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("Redis connected")
+		return auth.NewRedisSessionStore(cache), nil
+	case sessionStorePostgres:
+		if cfg.SESSION.SweepInterval <= 0 {
+			return nil, fmt.Errorf("session sweep interval must be positive: %s", cfg.SESSION.SweepInterval)
+		}
+		return auth.NewPostgresSessionStore(storage, cfg.SESSION.SweepInterval), nil
+	case sessionStoreMemory:
+		if cfg.SESSION.SweepInterval <= 0 {
+			return nil, fmt.Errorf("session sweep interval must be positive: %s", cfg.SESSION.SweepInterval)
+		}
+		return auth.NewMemorySessionStore(cfg.SESSION.SweepInterval), nil
+	default:
+		return nil, fmt.Errorf("unknown session store: %q", cfg.SESSION.Store)
+	}
+}
+
+// newTimelineCache connects the feeds to redis, unless FEED_CACHE_TTL is 0 and feeds are collected on every request.
+func (s *APIServer) newTimelineCache(cfg *config.Config, ctx context.Context, log *slog.Logger) (*database.TimelineCache, error) {
+	if cfg.FEED.CacheTTL <= 0 {
+		return nil, nil
+	}
+
+	cache, err := database.NewRedisClient(ctx, log, s.redisUrl)
+	if err != nil {
+		return nil, err
+	}
+	return database.NewTimelineCache(cache, cfg.FEED.CacheTTL, cfg.FEED.CacheSize), nil
 }
 
+func newBlobStore(cfg *config.Config) (storage.BlobStore, error) {
+	switch cfg.STORAGE.Backend {
+	case blobStoreLocal:
+		return storage.NewLocalStore(cfg.STORAGE.LocalDir)
+	case blobStoreS3:
+		return storage.NewS3Store(storage.S3Config{
+			Endpoint:  cfg.STORAGE.S3Endpoint,
+			Region:    cfg.STORAGE.S3Region,
+			Bucket:    cfg.STORAGE.S3Bucket,
+			AccessKey: cfg.STORAGE.S3AccessKey,
+			SecretKey: cfg.STORAGE.S3SecretKey,
+			PathStyle: cfg.STORAGE.S3PathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown blob store: %q", cfg.STORAGE.Backend)
+	}
+}
+
+// newContentFilter chains the content filters enabled in cfg, the word lists first
+// as they do not need the database.
+func newContentFilter(cfg *config.Config, history contentfilter.History) contentfilter.Pipeline {
+	filters := []contentfilter.Filter{
+		contentfilter.NewWordList("blocked_words", cfg.FILTERS.BlockedWords, contentfilter.Reject),
+		contentfilter.NewWordList("flagged_words", cfg.FILTERS.FlaggedWords, contentfilter.Flag),
+	}
+	if cfg.FILTERS.MaxLinks > 0 {
+		filters = append(filters, contentfilter.NewLinkLimit(cfg.FILTERS.MaxLinks))
+	}
+	if cfg.FILTERS.DuplicateWindow > 0 {
+		filters = append(filters, contentfilter.NewDuplicate(history, cfg.FILTERS.DuplicateWindow))
+	}
+	if cfg.FILTERS.QuotaWindow > 0 {
+		filters = append(filters, contentfilter.NewRateQuota(history, cfg.FILTERS.QuotaWindow, map[string]int{
+			contentfilter.KindPost:    cfg.FILTERS.PostQuota,
+			contentfilter.KindComment: cfg.FILTERS.CommentQuota,
+		}))
+	}
+	return contentfilter.NewChain(filters...)
+}
+
+// Shutdown stops the background workers before the server, so no batch runs against a closing pool.
 func (s *APIServer) Shutdown(ctx context.Context) error {
-	return s.server.Shutdown(ctx)
+	s.mu.Lock()
+	s.stopped = true
+	server, publisher, blobCleaner := s.server, s.publisher, s.blobCleaner
+	s.mu.Unlock()
+
+	if publisher != nil {
+		_ = publisher.Close()
+	}
+	if blobCleaner != nil {
+		_ = blobCleaner.Close()
+	}
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
 }
 
 func setupLogger(env string) *slog.Logger {
@@ -0,0 +1,122 @@
+// Package avatar turns an uploaded picture into the square thumbnails shown for a user.
+package avatar
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+	"image"
+	"image/jpeg"
+	"image/png"
+)
+
+// DefaultSize is the variant served when no size is asked for.
+const DefaultSize = 256
+
+// Sizes are the edges in pixels of the generated thumbnails, largest first.
+var Sizes = []int{256, 128, 64}
+
+const jpegQuality = 85
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrInvalidImage      = errors.New("invalid image")
+)
+
+// formats are the decoders accepted for uploads, as named by image.RegisterFormat.
+var formats = map[string]bool{
+	"png":  true,
+	"jpeg": true,
+	"webp": true,
+}
+
+// Variant is an encoded thumbnail. Checksum is the hex SHA-256 of Content.
+type Variant struct {
+	Size        int
+	Content     []byte
+	ContentType string
+	Checksum    string
+}
+
+// Process decodes a PNG, JPEG or WebP picture of at most maxDimension pixels per side and
+// returns a thumbnail for each of Sizes, cropped to the center square. JPEG orientation is
+// applied and the thumbnails are encoded from pixels only, so no metadata of the upload
+// such as EXIF survives. Opaque pictures are encoded as JPEG and the others as PNG.
+func Process(data []byte, maxDimension int) ([]Variant, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) || (err == nil && !formats[format]) {
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImage, err.Error())
+	}
+	// checked before decoding, so a small file can not claim a huge canvas
+	if config.Width <= 0 || config.Height <= 0 || config.Width > maxDimension || config.Height > maxDimension {
+		return nil, fmt.Errorf("%w: %dx%d pixels, at most %d per side", ErrInvalidImage, config.Width, config.Height, maxDimension)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImage, err.Error())
+	}
+
+	orientation := 1
+	if format == "jpeg" {
+		orientation = jpegOrientation(data)
+	}
+
+	variants := make([]Variant, 0, len(Sizes))
+	for _, size := range Sizes {
+		thumbnail := orient(thumbnail(src, size), orientation)
+		variant, err := encode(thumbnail, isOpaque(src))
+		if err != nil {
+			return nil, err
+		}
+		variant.Size = size
+		variants = append(variants, variant)
+	}
+
+	return variants, nil
+}
+
+// thumbnail scales the center square of src to size pixels per side.
+func thumbnail(src image.Image, size int) *image.RGBA {
+	bounds := src.Bounds()
+	edge := min(bounds.Dx(), bounds.Dy())
+	x := bounds.Min.X + (bounds.Dx()-edge)/2
+	y := bounds.Min.Y + (bounds.Dy()-edge)/2
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, image.Rect(x, y, x+edge, y+edge), draw.Src, nil)
+	return dst
+}
+
+func isOpaque(src image.Image) bool {
+	opaque, ok := src.(interface{ Opaque() bool })
+	return ok && opaque.Opaque()
+}
+
+func encode(img image.Image, opaque bool) (Variant, error) {
+	var buf bytes.Buffer
+	variant := Variant{ContentType: "image/png"}
+
+	var err error
+	if opaque {
+		variant.ContentType = "image/jpeg"
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return Variant{}, err
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	variant.Content = buf.Bytes()
+	variant.Checksum = hex.EncodeToString(sum[:])
+	return variant, nil
+}
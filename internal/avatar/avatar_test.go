@@ -0,0 +1,196 @@
+package avatar
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+var (
+	red   = color.RGBA{R: 255, A: 255}
+	green = color.RGBA{G: 255, A: 255}
+	blue  = color.RGBA{B: 255, A: 255}
+)
+
+// tinyWebP is a lossless 1x1 WebP picture.
+const tinyWebP = "UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA=="
+
+// stripes returns an image painted with one color per vertical stripe, or per horizontal one.
+func stripes(width, height int, horizontal bool, colors ...color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := x * len(colors) / width
+			if horizontal {
+				i = y * len(colors) / height
+			}
+			img.SetRGBA(x, y, colors[i])
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}))
+	return buf.Bytes()
+}
+
+// withOrientation inserts an EXIF segment holding only the orientation tag right after the SOI marker.
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	entry := make([]byte, ifdEntrySize)
+	binary.BigEndian.PutUint16(entry, orientationTag)
+	binary.BigEndian.PutUint16(entry[2:], tiffShort)
+	binary.BigEndian.PutUint32(entry[4:], 1)
+	binary.BigEndian.PutUint16(entry[8:], orientation)
+	tiff = append(append(tiff, entry...), 0, 0, 0, 0)
+
+	segment := append([]byte(exifHeader), tiff...)
+	header := []byte{0xFF, markerExif, 0, 0}
+	binary.BigEndian.PutUint16(header[2:], uint16(len(segment)+2))
+
+	result := append([]byte{}, data[:2]...)
+	result = append(append(result, header...), segment...)
+	return append(result, data[2:]...)
+}
+
+func decode(t *testing.T, variant Variant) image.Image {
+	img, format, err := image.Decode(bytes.NewReader(variant.Content))
+	require.NoError(t, err)
+	assert.Equal(t, "image/"+format, variant.ContentType)
+	return img
+}
+
+func assertColor(t *testing.T, expected color.RGBA, actual color.Color) {
+	r, g, b, _ := actual.RGBA()
+	got := []int{int(r >> 8), int(g >> 8), int(b >> 8)}
+	want := []int{int(expected.R), int(expected.G), int(expected.B)}
+	for i := range want {
+		assert.InDelta(t, want[i], got[i], 40, "expected %v, got %v", expected, actual)
+	}
+}
+
+func TestProcess(t *testing.T) {
+	variants, err := Process(encodeJPEG(t, stripes(300, 100, false, red, green, blue)), 1000)
+	require.NoError(t, err)
+	require.Len(t, variants, len(Sizes))
+
+	for i, variant := range variants {
+		assert.Equal(t, Sizes[i], variant.Size)
+		assert.Equal(t, "image/jpeg", variant.ContentType)
+		assert.Len(t, variant.Checksum, 64)
+
+		img := decode(t, variant)
+		assert.Equal(t, image.Rect(0, 0, variant.Size, variant.Size), img.Bounds())
+		// only the center square, the green stripe, is kept
+		assertColor(t, green, img.At(0, 0))
+		assertColor(t, green, img.At(variant.Size-1, variant.Size-1))
+	}
+}
+
+func TestProcessTransparent(t *testing.T) {
+	img := stripes(64, 64, false, red, green)
+	img.SetRGBA(0, 0, color.RGBA{})
+
+	variants, err := Process(encodePNG(t, img), 1000)
+	require.NoError(t, err)
+	for _, variant := range variants {
+		assert.Equal(t, "image/png", variant.ContentType)
+		decode(t, variant)
+	}
+}
+
+func TestProcessWebP(t *testing.T) {
+	data, err := base64.StdEncoding.DecodeString(tinyWebP)
+	require.NoError(t, err)
+
+	variants, err := Process(data, 1000)
+	require.NoError(t, err)
+	assert.Len(t, variants, len(Sizes))
+}
+
+func TestProcessOrientation(t *testing.T) {
+	upright := encodeJPEG(t, stripes(100, 100, true, red, blue))
+
+	tests := []struct {
+		name        string
+		orientation uint16
+		left, right color.RGBA
+	}{
+		{name: "TurnClockwise", orientation: 6, left: blue, right: red},
+		{name: "TurnCounterclockwise", orientation: 8, left: red, right: blue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := withOrientation(upright, tt.orientation)
+			require.Equal(t, int(tt.orientation), jpegOrientation(data))
+
+			variants, err := Process(data, 1000)
+			require.NoError(t, err)
+
+			for _, variant := range variants {
+				assert.NotContains(t, string(variant.Content), "Exif")
+				img := decode(t, variant)
+				assertColor(t, tt.left, img.At(2, variant.Size/2))
+				assertColor(t, tt.right, img.At(variant.Size-3, variant.Size/2))
+			}
+		})
+	}
+}
+
+func TestProcessInvalid(t *testing.T) {
+	picture := encodePNG(t, stripes(10, 10, false, red))
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{name: "Text", data: []byte("not a picture"), expected: ErrUnsupportedFormat},
+		{name: "GIF", data: []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;"), expected: ErrUnsupportedFormat},
+		{name: "Truncated", data: picture[:len(picture)/2], expected: ErrInvalidImage},
+		{name: "TooLarge", data: encodePNG(t, stripes(16, 10, false, red)), expected: ErrInvalidImage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Process(tt.data, 15)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestJPEGOrientationMalformed(t *testing.T) {
+	upright := encodeJPEG(t, stripes(8, 8, false, red))
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "NoExif", data: upright},
+		{name: "NotJPEG", data: []byte("\x89PNG")},
+		{name: "OutOfRange", data: withOrientation(upright, 9)},
+		{name: "Truncated", data: withOrientation(upright, 6)[:30]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, 1, jpegOrientation(tt.data))
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package avatar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+const (
+	exifHeader         = "Exif\x00\x00"
+	orientationTag     = 0x0112
+	tiffShort          = 3
+	ifdEntrySize       = 12
+	markerStartOfImage = 0xD8
+	markerStartOfScan  = 0xDA
+	markerEndOfImage   = 0xD9
+	markerExif         = 0xE1
+)
+
+// jpegOrientation returns the EXIF orientation of a JPEG, 1 (as stored) when it is missing or malformed.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != markerStartOfImage {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == markerStartOfScan || marker == markerEndOfImage {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == markerExif && bytes.HasPrefix(segment, []byte(exifHeader)) {
+			return tiffOrientation(segment[len(exifHeader):])
+		}
+		i += 2 + length
+	}
+
+	return 1
+}
+
+// tiffOrientation reads the orientation tag of the first IFD of a TIFF structure.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[offset:]))
+	for n := 0; n < entries; n++ {
+		entry := offset + 2 + n*ifdEntrySize
+		if entry+ifdEntrySize > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != orientationTag || order.Uint16(tiff[entry+2:]) != tiffShort {
+			continue
+		}
+		orientation := int(order.Uint16(tiff[entry+8:]))
+		if orientation < 1 || orientation > 8 {
+			return 1
+		}
+		return orientation
+	}
+
+	return 1
+}
+
+// orient applies an EXIF orientation to a square image, so it is displayed upright without its metadata.
+func orient(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	n := img.Bounds().Dx()
+	last := n - 1
+	dst := image.NewRGBA(img.Bounds())
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // flip horizontally
+				sx, sy = last-x, y
+			case 3: // turn half way
+				sx, sy = last-x, last-y
+			case 4: // flip vertically
+				sx, sy = x, last-y
+			case 5: // transpose
+				sx, sy = y, x
+			case 6: // turn clockwise
+				sx, sy = y, last-x
+			case 7: // transverse
+				sx, sy = last-y, last-x
+			case 8: // turn counterclockwise
+				sx, sy = last-y, x
+			}
+			dst.SetRGBA(x, y, img.RGBAAt(sx, sy))
+		}
+	}
+	return dst
+}
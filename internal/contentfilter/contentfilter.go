@@ -0,0 +1,159 @@
+// Package contentfilter runs posts and comments through a chain of filters before they are saved.
+// Every filter allows the content, flags it for review or rejects it with a reason.
+package contentfilter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	KindPost    = "post"
+	KindComment = "comment"
+)
+
+// Verdict is what a filter decides about content, a higher verdict overrides a lower one.
+type Verdict int
+
+const (
+	Allow Verdict = iota
+	Flag
+	Reject
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case Allow:
+		return "allow"
+	case Flag:
+		return "flag"
+	case Reject:
+		return "reject"
+	}
+	return fmt.Sprintf("Verdict(%d)", int(v))
+}
+
+// ErrRejected matches every RejectedError.
+var ErrRejected = errors.New("content rejected")
+
+// Content is a post or a comment about to be saved. ID is 0 for new content and Title is
+// empty for comments.
+type Content struct {
+	Kind   string
+	ID     int
+	UserID int
+	Title  string
+	Body   string
+}
+
+// Text is the title and the body of the content.
+func (c Content) Text() string {
+	if c.Title == "" {
+		return c.Body
+	}
+	return c.Title + "\n" + c.Body
+}
+
+// Result is the verdict of a filter, Reason tells the author why content is flagged or rejected.
+type Result struct {
+	Verdict Verdict
+	Reason  string
+}
+
+// Violation is a filter flagging or rejecting content.
+// swagger:model
+type Violation struct {
+	Filter string `json:"filter"`
+	Reason string `json:"reason"`
+}
+
+// Decision is the verdict of a pipeline, Violations are the filters that reached it.
+type Decision struct {
+	Verdict    Verdict
+	Violations []Violation
+}
+
+// Reason lists the violations of the decision, one "filter: reason" after another.
+func (d Decision) Reason() string {
+	return describe(d.Violations)
+}
+
+// RejectedError is returned by a pipeline rejecting content, it lists every filter that rejected it.
+type RejectedError struct {
+	Violations []Violation
+}
+
+func (e *RejectedError) Error() string {
+	return ErrRejected.Error() + ": " + describe(e.Violations)
+}
+
+func (e *RejectedError) Is(target error) bool {
+	return target == ErrRejected
+}
+
+// Filter checks content for one kind of problem. An error means the filter could not decide,
+// not that the content is bad.
+type Filter interface {
+	Name() string
+	Check(ctx context.Context, content Content) (Result, error)
+}
+
+// Pipeline decides whether content may be saved. It returns a *RejectedError when it may not,
+// flagged content is saved and left for moderators to review.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name Pipeline --output ../../testing/mocks
+type Pipeline interface {
+	Check(ctx context.Context, content Content) (Decision, error)
+}
+
+type Chain struct {
+	filters []Filter
+}
+
+// NewChain returns a pipeline running filters in order. Every filter runs, so a rejection
+// lists all the problems of the content at once.
+func NewChain(filters ...Filter) Pipeline {
+	return &Chain{
+		filters: filters,
+	}
+}
+
+func (chain *Chain) Check(ctx context.Context, content Content) (Decision, error) {
+	var flags, rejections []Violation
+	for _, filter := range chain.filters {
+		if err := ctx.Err(); err != nil {
+			return Decision{}, err
+		}
+
+		result, err := filter.Check(ctx, content)
+		if err != nil {
+			return Decision{}, fmt.Errorf("%s filter: %w", filter.Name(), err)
+		}
+
+		violation := Violation{Filter: filter.Name(), Reason: result.Reason}
+		switch result.Verdict {
+		case Flag:
+			flags = append(flags, violation)
+		case Reject:
+			rejections = append(rejections, violation)
+		}
+	}
+
+	if len(rejections) > 0 {
+		return Decision{Verdict: Reject, Violations: rejections}, &RejectedError{Violations: rejections}
+	}
+	if len(flags) > 0 {
+		return Decision{Verdict: Flag, Violations: flags}, nil
+	}
+	return Decision{Verdict: Allow}, nil
+}
+
+func describe(violations []Violation) string {
+	reasons := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		reasons = append(reasons, violation.Filter+": "+violation.Reason)
+	}
+	return strings.Join(reasons, "; ")
+}
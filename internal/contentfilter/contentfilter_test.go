@@ -0,0 +1,98 @@
+package contentfilter
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type fixedFilter struct {
+	name   string
+	result Result
+	err    error
+	calls  int
+}
+
+func (f *fixedFilter) Name() string {
+	return f.name
+}
+
+func (f *fixedFilter) Check(_ context.Context, _ Content) (Result, error) {
+	f.calls++
+	return f.result, f.err
+}
+
+func TestChain(t *testing.T) {
+	allow := func(name string) *fixedFilter { return &fixedFilter{name: name, result: Result{Verdict: Allow}} }
+	flag := func(name string) *fixedFilter {
+		return &fixedFilter{name: name, result: Result{Verdict: Flag, Reason: name + " flagged"}}
+	}
+	reject := func(name string) *fixedFilter {
+		return &fixedFilter{name: name, result: Result{Verdict: Reject, Reason: name + " rejected"}}
+	}
+
+	tests := []struct {
+		name          string
+		filters       []Filter
+		expected      Decision
+		expectedError string
+	}{
+		{
+			name:     "Empty",
+			expected: Decision{Verdict: Allow},
+		},
+		{
+			name:     "Allow",
+			filters:  []Filter{allow("a"), allow("b")},
+			expected: Decision{Verdict: Allow},
+		},
+		{
+			name:     "Flag",
+			filters:  []Filter{flag("a"), allow("b"), flag("c")},
+			expected: Decision{Verdict: Flag, Violations: []Violation{{Filter: "a", Reason: "a flagged"}, {Filter: "c", Reason: "c flagged"}}},
+		},
+		{
+			name:          "Reject",
+			filters:       []Filter{reject("a"), flag("b"), reject("c")},
+			expected:      Decision{Verdict: Reject, Violations: []Violation{{Filter: "a", Reason: "a rejected"}, {Filter: "c", Reason: "c rejected"}}},
+			expectedError: "content rejected: a: a rejected; c: c rejected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := NewChain(tt.filters...).Check(context.Background(), Content{Kind: KindPost, Body: "body"})
+			assert.Equal(t, tt.expected, decision)
+			if tt.expected.Verdict == Flag {
+				assert.Equal(t, "a: a flagged; c: c flagged", decision.Reason())
+			}
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.EqualError(t, err, tt.expectedError)
+			assert.ErrorIs(t, err, ErrRejected)
+			var rejected *RejectedError
+			require.ErrorAs(t, err, &rejected)
+			assert.Equal(t, tt.expected.Violations, rejected.Violations)
+		})
+	}
+}
+
+func TestChainError(t *testing.T) {
+	failing := &fixedFilter{name: "broken", err: errors.New("db down")}
+	after := &fixedFilter{name: "after", result: Result{Verdict: Allow}}
+
+	_, err := NewChain(failing, after).Check(context.Background(), Content{})
+	assert.EqualError(t, err, "broken filter: db down")
+	assert.NotErrorIs(t, err, ErrRejected)
+	assert.Zero(t, after.calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewChain(after).Check(ctx, Content{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,276 @@
+package contentfilter
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/text/unicode/norm"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// duplicateMinLength is the length in bytes of normalized content below which it is not treated as
+// a duplicate, so short replies like "thanks!" may be repeated.
+const duplicateMinLength = 20
+
+// duplicateScanLimit is the number of recent posts or comments of a user compared to new content.
+const duplicateScanLimit = 100
+
+// lookalikes are the digits and symbols written in place of letters to get past word lists.
+var lookalikes = map[rune]rune{'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '@': 'a', '$': 's'}
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>()\[\]"']+`)
+
+// History tells the filters what a user posted lately, see database.ContentHistoryService.
+type History interface {
+	CountContent(userID int, kind string, window time.Duration) (int, error)
+	RecentContent(userID int, kind string, excludeID int, window time.Duration, limit int) ([]string, error)
+}
+
+// normalize reduces text to lowercase words of latin letters and digits, separated by single spaces.
+// It undoes the usual tricks to get past word lists: diacritics, lookalike digits and symbols,
+// zero-width characters and words spelled out letter by letter. Repeated letters are kept, see sameWord.
+func normalize(text string) string {
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+
+	for _, r := range norm.NFKD.String(text) {
+		r = unicode.ToLower(r)
+		if lookalike, ok := lookalikes[r]; ok {
+			r = lookalike
+		}
+		switch {
+		case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Cf, r):
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	// joins "s p a m" into "spam"
+	result := make([]string, 0, len(words))
+	spelled := ""
+	for _, w := range words {
+		if len([]rune(w)) == 1 {
+			spelled += w
+			continue
+		}
+		if spelled != "" {
+			result = append(result, spelled)
+			spelled = ""
+		}
+		result = append(result, w)
+	}
+	if spelled != "" {
+		result = append(result, spelled)
+	}
+
+	return strings.Join(result, " ")
+}
+
+// stretchedRun is the number of times a letter is repeated in a row to stretch a word, like
+// "spaaam". Fewer repeats are left alone, words like "good" and "ass" are spelled that way.
+const stretchedRun = 3
+
+type WordList struct {
+	name    string
+	words   []string
+	terms   [][]string
+	verdict Verdict
+}
+
+// NewWordList returns a filter giving verdict to content containing any of words. Words may be
+// phrases and are matched as whole words after both they and the content are normalized.
+func NewWordList(name string, words []string, verdict Verdict) Filter {
+	list := &WordList{
+		name:    name,
+		verdict: verdict,
+	}
+	for _, word := range words {
+		term := strings.Fields(normalize(word))
+		if len(term) == 0 {
+			continue
+		}
+		list.words = append(list.words, strings.TrimSpace(word))
+		list.terms = append(list.terms, term)
+	}
+	return list
+}
+
+func (list *WordList) Name() string {
+	return list.name
+}
+
+func (list *WordList) Check(_ context.Context, content Content) (Result, error) {
+	text := strings.Fields(normalize(content.Text()))
+
+	var found []string
+	for i, term := range list.terms {
+		if containsPhrase(text, term) {
+			found = append(found, fmt.Sprintf("%q", list.words[i]))
+		}
+	}
+	if len(found) == 0 {
+		return Result{Verdict: Allow}, nil
+	}
+
+	return Result{Verdict: list.verdict, Reason: "contains " + strings.Join(found, ", ")}, nil
+}
+
+// containsPhrase reports whether phrase appears in words, every word of it matched by sameWord.
+func containsPhrase(words, phrase []string) bool {
+	for start := 0; start+len(phrase) <= len(words); start++ {
+		matched := true
+		for i, term := range phrase {
+			if !sameWord(words[start+i], term) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// sameWord reports whether word is term, or term stretched by repeating letters like "spaaam"
+// or "freeee". A word that is not stretched has to match exactly, so "as" is not "ass".
+func sameWord(word, term string) bool {
+	if word == term {
+		return true
+	}
+	collapsed, stretched := collapse(word)
+	if !stretched {
+		return false
+	}
+	collapsedTerm, _ := collapse(term)
+	return collapsed == collapsedTerm
+}
+
+// collapse writes every run of a repeated letter once and reports whether a run was stretched.
+func collapse(word string) (string, bool) {
+	var b strings.Builder
+	var last rune
+	run, stretched := 0, false
+	for _, r := range word {
+		if r == last {
+			run++
+			if run >= stretchedRun {
+				stretched = true
+			}
+			continue
+		}
+		b.WriteRune(r)
+		last, run = r, 1
+	}
+	return b.String(), stretched
+}
+
+type LinkLimit struct {
+	max int
+}
+
+// NewLinkLimit returns a filter rejecting content with more than max links.
+func NewLinkLimit(max int) Filter {
+	return &LinkLimit{
+		max: max,
+	}
+}
+
+func (limit *LinkLimit) Name() string {
+	return "links"
+}
+
+func (limit *LinkLimit) Check(_ context.Context, content Content) (Result, error) {
+	links := len(linkPattern.FindAllStringIndex(content.Text(), -1))
+	if links <= limit.max {
+		return Result{Verdict: Allow}, nil
+	}
+
+	return Result{Verdict: Reject, Reason: fmt.Sprintf("contains %d links, at most %d are allowed", links, limit.max)}, nil
+}
+
+type Duplicate struct {
+	history History
+	window  time.Duration
+}
+
+// NewDuplicate returns a filter rejecting content that is the same, once normalized, as a post or
+// comment of the same kind the user saved within window. Editing content compares it to the rest.
+func NewDuplicate(history History, window time.Duration) Filter {
+	return &Duplicate{
+		history: history,
+		window:  window,
+	}
+}
+
+func (duplicate *Duplicate) Name() string {
+	return "duplicate"
+}
+
+func (duplicate *Duplicate) Check(_ context.Context, content Content) (Result, error) {
+	text := normalize(content.Text())
+	if len(text) < duplicateMinLength {
+		return Result{Verdict: Allow}, nil
+	}
+
+	recent, err := duplicate.history.RecentContent(content.UserID, content.Kind, content.ID, duplicate.window, duplicateScanLimit)
+	if err != nil {
+		return Result{}, err
+	}
+	for _, previous := range recent {
+		if normalize(previous) == text {
+			return Result{Verdict: Reject, Reason: fmt.Sprintf("repeats a %s saved within %s", content.Kind, duplicate.window)}, nil
+		}
+	}
+
+	return Result{Verdict: Allow}, nil
+}
+
+type RateQuota struct {
+	history History
+	window  time.Duration
+	limits  map[string]int
+}
+
+// NewRateQuota returns a filter rejecting new content once the user saved as many posts or comments
+// within window as limits allows for its kind. Kinds without a limit and edits are not limited.
+func NewRateQuota(history History, window time.Duration, limits map[string]int) Filter {
+	return &RateQuota{
+		history: history,
+		window:  window,
+		limits:  limits,
+	}
+}
+
+func (quota *RateQuota) Name() string {
+	return "quota"
+}
+
+func (quota *RateQuota) Check(_ context.Context, content Content) (Result, error) {
+	limit := quota.limits[content.Kind]
+	if limit <= 0 || content.ID != 0 {
+		return Result{Verdict: Allow}, nil
+	}
+
+	count, err := quota.history.CountContent(content.UserID, content.Kind, quota.window)
+	if err != nil {
+		return Result{}, err
+	}
+	if count < limit {
+		return Result{Verdict: Allow}, nil
+	}
+
+	return Result{Verdict: Reject, Reason: fmt.Sprintf("at most %d %ss may be saved within %s", limit, content.Kind, quota.window)}, nil
+}
@@ -0,0 +1,170 @@
+package contentfilter
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeHistory struct {
+	count  int
+	recent []string
+	err    error
+
+	excludeID int
+	window    time.Duration
+}
+
+func (h *fakeHistory) CountContent(userID int, kind string, window time.Duration) (int, error) {
+	h.window = window
+	return h.count, h.err
+}
+
+func (h *fakeHistory) RecentContent(userID int, kind string, excludeID int, window time.Duration, limit int) ([]string, error) {
+	h.excludeID = excludeID
+	h.window = window
+	return h.recent, h.err
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "Plain", text: "Buy cheap pills", expected: "buy cheap pills"},
+		{name: "Punctuation", text: "  Buy -- cheap,pills!!  ", expected: "buy cheap pills"},
+		{name: "Diacritics", text: "Crème fraîche", expected: "creme fraiche"},
+		{name: "Lookalikes", text: "fr33 m0n3y $p@m", expected: "free money spam"},
+		{name: "RepeatedLetters", text: "spaaaam good", expected: "spaaaam good"},
+		{name: "ZeroWidth", text: "sp\u200bam", expected: "spam"},
+		{name: "SpelledOut", text: "total s p a m here", expected: "total spam here"},
+		{name: "Fullwidth", text: "ＳＰＡＭ", expected: "spam"},
+		{name: "Empty", text: " ... ", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalize(tt.text))
+		})
+	}
+}
+
+func TestWordList(t *testing.T) {
+	filter := NewWordList("blocked_words", []string{"spam", " cheap pills", ""}, Reject)
+
+	tests := []struct {
+		name     string
+		content  Content
+		expected Result
+	}{
+		{name: "Clean", content: Content{Title: "Hello", Body: "world"}, expected: Result{Verdict: Allow}},
+		{name: "Word", content: Content{Body: "this is SPAM"}, expected: Result{Verdict: Reject, Reason: `contains "spam"`}},
+		{name: "Obfuscated", content: Content{Body: "this is s p @ m"}, expected: Result{Verdict: Reject, Reason: `contains "spam"`}},
+		{name: "InTitle", content: Content{Title: "Spam!", Body: "nothing"}, expected: Result{Verdict: Reject, Reason: `contains "spam"`}},
+		{name: "Phrase", content: Content{Body: "buy ch3ap   pills"}, expected: Result{Verdict: Reject, Reason: `contains "cheap pills"`}},
+		{name: "Both", content: Content{Body: "spam and cheap pills"}, expected: Result{Verdict: Reject, Reason: `contains "spam", "cheap pills"`}},
+		{name: "PartOfWord", content: Content{Body: "spammer cheap pillsbury"}, expected: Result{Verdict: Allow}},
+		{name: "Stretched", content: Content{Body: "spaaaam and cheeeap pillllls"}, expected: Result{Verdict: Reject, Reason: `contains "spam", "cheap pills"`}},
+		{name: "StretchedSpelledOut", content: Content{Body: "s p a a a m"}, expected: Result{Verdict: Reject, Reason: `contains "spam"`}},
+		{name: "CollapsedIsNotTheWord", content: Content{Body: "spam cheap pils"}, expected: Result{Verdict: Reject, Reason: `contains "spam"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := filter.Check(context.Background(), tt.content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestWordListRepeatedLetters(t *testing.T) {
+	filter := NewWordList("blocked_words", []string{"ass", "god", "pop", "free money"}, Reject)
+
+	tests := []struct {
+		name     string
+		content  Content
+		expected Result
+	}{
+		{name: "As", content: Content{Body: "as good as it gets"}, expected: Result{Verdict: Allow}},
+		{name: "Good", content: Content{Body: "good food"}, expected: Result{Verdict: Allow}},
+		{name: "Poop", content: Content{Body: "poop"}, expected: Result{Verdict: Allow}},
+		{name: "Exact", content: Content{Body: "ass"}, expected: Result{Verdict: Reject, Reason: `contains "ass"`}},
+		{name: "Stretched", content: Content{Body: "asssss"}, expected: Result{Verdict: Reject, Reason: `contains "ass"`}},
+		{name: "StretchedPhrase", content: Content{Body: "fr333 m0ney"}, expected: Result{Verdict: Reject, Reason: `contains "free money"`}},
+		{name: "PhraseNotStretched", content: Content{Body: "fre money"}, expected: Result{Verdict: Allow}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := filter.Check(context.Background(), tt.content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestLinkLimit(t *testing.T) {
+	filter := NewLinkLimit(2)
+
+	result, err := filter.Check(context.Background(), Content{Body: "see https://example.com and [docs](http://example.org/docs)"})
+	require.NoError(t, err)
+	assert.Equal(t, Allow, result.Verdict)
+
+	result, err = filter.Check(context.Background(), Content{Title: "www.example.com", Body: "HTTPS://a.example http://b.example"})
+	require.NoError(t, err)
+	assert.Equal(t, Result{Verdict: Reject, Reason: "contains 3 links, at most 2 are allowed"}, result)
+}
+
+func TestDuplicate(t *testing.T) {
+	history := &fakeHistory{recent: []string{"Something else entirely", "Check out my new blog\nIt is great"}}
+	filter := NewDuplicate(history, time.Hour)
+
+	result, err := filter.Check(context.Background(), Content{Kind: KindPost, ID: 7, Title: "check out my NEW blog!", Body: "it is great"})
+	require.NoError(t, err)
+	assert.Equal(t, Result{Verdict: Reject, Reason: "repeats a post saved within 1h0m0s"}, result)
+	assert.Equal(t, 7, history.excludeID)
+	assert.Equal(t, time.Hour, history.window)
+
+	result, err = filter.Check(context.Background(), Content{Kind: KindPost, Title: "A different post", Body: "with other words"})
+	require.NoError(t, err)
+	assert.Equal(t, Allow, result.Verdict)
+
+	// short content is never a duplicate
+	history.recent = []string{"thanks!"}
+	result, err = filter.Check(context.Background(), Content{Kind: KindComment, Body: "Thanks!"})
+	require.NoError(t, err)
+	assert.Equal(t, Allow, result.Verdict)
+
+	history.err = errors.New("db down")
+	_, err = filter.Check(context.Background(), Content{Kind: KindComment, Body: strings.Repeat("long comment ", 5)})
+	assert.ErrorIs(t, err, history.err)
+}
+
+func TestRateQuota(t *testing.T) {
+	history := &fakeHistory{}
+	filter := NewRateQuota(history, time.Hour, map[string]int{KindPost: 3})
+
+	history.count = 2
+	result, err := filter.Check(context.Background(), Content{Kind: KindPost})
+	require.NoError(t, err)
+	assert.Equal(t, Allow, result.Verdict)
+
+	history.count = 3
+	result, err = filter.Check(context.Background(), Content{Kind: KindPost})
+	require.NoError(t, err)
+	assert.Equal(t, Result{Verdict: Reject, Reason: "at most 3 posts may be saved within 1h0m0s"}, result)
+
+	// edits and kinds without a limit are not counted
+	result, err = filter.Check(context.Background(), Content{Kind: KindPost, ID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, Allow, result.Verdict)
+	result, err = filter.Check(context.Background(), Content{Kind: KindComment})
+	require.NoError(t, err)
+	assert.Equal(t, Allow, result.Verdict)
+}
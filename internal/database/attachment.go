@@ -0,0 +1,190 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go-rest-api-auth/internal/storage"
+	"io"
+	"log/slog"
+	"strconv"
+)
+
+// AttachmentDTO is a file attached to a post. Checksum is the hex SHA-256 of the content
+// and the key of its blob, UserId is 0 once the uploader deleted their account.
+type AttachmentDTO struct {
+	Id          int
+	PostId      int
+	UserId      int
+	Filename    string
+	Checksum    string
+	Size        int64
+	ContentType string
+	CreatedAt   pgtype.Timestamp
+}
+
+// attachmentColumns selects an attachment of the attachments table aliased a joined to its blob b.
+const attachmentColumns = `a.id, a.post_id, coalesce(a.user_id, 0), a.filename, a.checksum, b.size, b.content_type, a.created_at`
+
+type AttachmentServiceImplementation struct {
+	pg    *DbPool
+	blobs storage.BlobStore
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name AttachmentService --output ../../testing/mocks
+type AttachmentService interface {
+	CreateAttachment(attachment AttachmentDTO, content io.Reader) (AttachmentDTO, error)
+	GetAttachment(attachmentID int) (AttachmentDTO, error)
+	GetAttachments(postID int) ([]AttachmentDTO, error)
+	DeleteAttachment(attachmentID int) error
+	DeleteOrphanedBlobs(batch int) (int, error)
+}
+
+// NewAttachmentService returns a service keeping the attachment contents in blobs.
+func NewAttachmentService(pg *DbPool, blobs storage.BlobStore) AttachmentService {
+	return &AttachmentServiceImplementation{
+		pg:    pg,
+		blobs: blobs,
+	}
+}
+
+// CreateAttachment attaches content to a post. Checksum, Size and ContentType describe content,
+// which is only uploaded to the blob store when no blob has the same checksum yet.
+// It returns pgx.ErrNoRows when the post does not exist.
+func (service *AttachmentServiceImplementation) CreateAttachment(attachment AttachmentDTO, content io.Reader) (AttachmentDTO, error) {
+	args := pgx.NamedArgs{
+		"post_id":  attachment.PostId,
+		"user_id":  attachment.UserId,
+		"filename": attachment.Filename,
+		"checksum": attachment.Checksum,
+	}
+
+	var created AttachmentDTO
+	err := service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		var postID int
+		err := tx.Db.QueryRow(tx.Ctx, `SELECT id FROM posts WHERE id = @post_id FOR SHARE`, args).Scan(&postID)
+		if err != nil {
+			return err
+		}
+
+		err = putBlob(tx, service.blobs, attachment.Checksum, attachment.Size, attachment.ContentType, content)
+		if err != nil {
+			return err
+		}
+
+		query := `
+			WITH a AS (
+				INSERT INTO attachments (post_id, user_id, checksum, filename)
+				VALUES (@post_id, @user_id, @checksum, @filename)
+				RETURNING *
+			)
+			SELECT ` + attachmentColumns + ` FROM a JOIN blobs b ON b.checksum = a.checksum`
+		rows, err := tx.Db.Query(tx.Ctx, query, args)
+		if err != nil {
+			return err
+		}
+		created, err = pgx.CollectOneRow(rows, pgx.RowToStructByPos[AttachmentDTO])
+		return err
+	})
+	if err != nil {
+		service.pg.Log.Error("Error creating attachment", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(attachment.PostId)))
+		return AttachmentDTO{}, err
+	}
+
+	return created, nil
+}
+
+func (service *AttachmentServiceImplementation) GetAttachment(attachmentID int) (AttachmentDTO, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments a JOIN blobs b ON b.checksum = a.checksum WHERE a.id = @id`
+
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, pgx.NamedArgs{"id": attachmentID})
+	if err != nil {
+		service.pg.Log.Error("Error sql query getting attachment", slog.String("err", err.Error()))
+		return AttachmentDTO{}, err
+	}
+
+	attachment, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[AttachmentDTO])
+	if err != nil {
+		service.pg.Log.Error("Error getting attachment", slog.String("err", err.Error()), slog.String("attachmentid", strconv.Itoa(attachmentID)))
+		return AttachmentDTO{}, err
+	}
+
+	return attachment, nil
+}
+
+// GetAttachments returns every attachment of a post, oldest first.
+func (service *AttachmentServiceImplementation) GetAttachments(postID int) ([]AttachmentDTO, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments a JOIN blobs b ON b.checksum = a.checksum
+		WHERE a.post_id = @post_id ORDER BY a.id`
+
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, pgx.NamedArgs{"post_id": postID})
+	if err != nil {
+		service.pg.Log.Error("Error sql query getting attachments", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	attachments, err := pgx.CollectRows(rows, pgx.RowToStructByPos[AttachmentDTO])
+	if err != nil {
+		service.pg.Log.Error("Error scanning attachment", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// DeleteAttachment detaches a file from its post, the blob is left to DeleteOrphanedBlobs.
+func (service *AttachmentServiceImplementation) DeleteAttachment(attachmentID int) error {
+	_, err := service.pg.Db.Exec(service.pg.Ctx, `DELETE FROM attachments WHERE id = @id`, pgx.NamedArgs{"id": attachmentID})
+	if err != nil {
+		service.pg.Log.Error("Error deleting attachment", slog.String("err", err.Error()), slog.String("attachmentid", strconv.Itoa(attachmentID)))
+		return err
+	}
+	return nil
+}
+
+// DeleteOrphanedBlobs removes up to batch blobs no attachment or avatar refers to anymore,
+// from the blob store and then from the database. Blobs locked by an upload in progress are skipped,
+// a blob that fails to be removed from the store is kept and retried on the next call.
+func (service *AttachmentServiceImplementation) DeleteOrphanedBlobs(batch int) (int, error) {
+	deleted := 0
+	err := service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		query := `
+			SELECT checksum FROM blobs b
+			WHERE NOT EXISTS (SELECT 1 FROM attachments a WHERE a.checksum = b.checksum)
+				AND NOT EXISTS (SELECT 1 FROM avatar_variants v WHERE v.checksum = b.checksum)
+			ORDER BY created_at LIMIT @batch
+			FOR UPDATE SKIP LOCKED`
+		rows, err := tx.Db.Query(tx.Ctx, query, pgx.NamedArgs{"batch": batch})
+		if err != nil {
+			return err
+		}
+		checksums, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return err
+		}
+
+		var removed []string
+		for _, checksum := range checksums {
+			if err = service.blobs.Delete(tx.Ctx, checksum); err != nil {
+				tx.Log.Error("Error deleting blob", slog.String("err", err.Error()), slog.String("checksum", checksum))
+				continue
+			}
+			removed = append(removed, checksum)
+		}
+		if len(removed) == 0 {
+			return nil
+		}
+
+		_, err = tx.Db.Exec(tx.Ctx, `DELETE FROM blobs WHERE checksum = ANY(@checksums)`, pgx.NamedArgs{"checksums": removed})
+		if err != nil {
+			return err
+		}
+		deleted = len(removed)
+		return nil
+	})
+	if err != nil {
+		service.pg.Log.Error("Error deleting orphaned blobs", slog.String("err", err.Error()))
+		return 0, err
+	}
+
+	return deleted, nil
+}
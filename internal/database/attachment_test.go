@@ -0,0 +1,89 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-rest-api-auth/internal/storage"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestAttachments(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, prefix := newTestUser(t, pg)
+	blobs, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	attachments := NewAttachmentService(pg, blobs)
+
+	first, err := posts.CreatePost(PostDTO{Title: "first", UserId: author})
+	require.NoError(t, err)
+	second, err := posts.CreatePost(PostDTO{Title: "second", UserId: author})
+	require.NoError(t, err)
+
+	content := "attachment of " + prefix
+	sum := sha256.Sum256([]byte(content))
+	attachment := AttachmentDTO{
+		UserId:      author,
+		Filename:    "notes.txt",
+		Checksum:    hex.EncodeToString(sum[:]),
+		Size:        int64(len(content)),
+		ContentType: "text/plain; charset=utf-8",
+	}
+
+	attachment.PostId = first.Id
+	created, err := attachments.CreateAttachment(attachment, strings.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, attachment.Checksum, created.Checksum)
+	assert.Equal(t, attachment.Size, created.Size)
+	assert.Equal(t, "notes.txt", created.Filename)
+
+	// the same content is stored once, the reader of a duplicate is not even consumed
+	attachment.PostId = second.Id
+	duplicate, err := attachments.CreateAttachment(attachment, unreadableReader{t})
+	require.NoError(t, err)
+	assert.NotEqual(t, created.Id, duplicate.Id)
+
+	attachment.PostId = -1
+	_, err = attachments.CreateAttachment(attachment, strings.NewReader(content))
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	listed, err := attachments.GetAttachments(first.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []AttachmentDTO{created}, listed)
+
+	// deleting the first post leaves the blob to the second one
+	require.NoError(t, posts.DeletePost(first.Id))
+	_, err = attachments.DeleteOrphanedBlobs(100)
+	require.NoError(t, err)
+	stored, err := blobs.Get(pg.Ctx, attachment.Checksum)
+	require.NoError(t, err)
+	data, err := io.ReadAll(stored)
+	require.NoError(t, err)
+	require.NoError(t, stored.Close())
+	assert.Equal(t, content, string(data))
+
+	require.NoError(t, attachments.DeleteAttachment(duplicate.Id))
+	_, err = attachments.GetAttachment(duplicate.Id)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	deleted, err := attachments.DeleteOrphanedBlobs(100)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, 1)
+	_, err = blobs.Get(pg.Ctx, attachment.Checksum)
+	assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+}
+
+// unreadableReader fails the test when a reader that should be skipped is read.
+type unreadableReader struct {
+	t *testing.T
+}
+
+func (r unreadableReader) Read([]byte) (int, error) {
+	r.t.Error("content of a deduplicated attachment was read")
+	return 0, io.EOF
+}
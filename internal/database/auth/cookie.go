@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"go-rest-api-auth/config"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const hostCookiePrefix = "__Host-"
+
+// SessionCookie builds the session cookie with the attributes configured in config.SESSION.
+type SessionCookie struct {
+	name     string
+	domain   string
+	path     string
+	secure   bool
+	sameSite http.SameSite
+}
+
+func NewSessionCookie(cfg config.SESSION) *SessionCookie {
+	cookie := &SessionCookie{
+		name:     cfg.CookieName,
+		domain:   cfg.CookieDomain,
+		path:     cfg.CookiePath,
+		secure:   cfg.CookieSecure,
+		sameSite: parseSameSite(cfg.CookieSameSite),
+	}
+
+	if cookie.name == "" {
+		cookie.name = "session_id"
+	}
+	if cookie.path == "" {
+		cookie.path = "/"
+	}
+
+	// browsers reject SameSite=None cookies without Secure
+	if cookie.sameSite == http.SameSiteNoneMode {
+		cookie.secure = true
+	}
+
+	// __Host- cookies must be Secure, have Path=/ and no Domain
+	if cfg.CookieHostPrefix {
+		cookie.name = hostCookiePrefix + strings.TrimPrefix(cookie.name, hostCookiePrefix)
+		cookie.domain = ""
+		cookie.path = "/"
+		cookie.secure = true
+	}
+
+	return cookie
+}
+
+func parseSameSite(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
+
+func (c *SessionCookie) Name() string {
+	return c.name
+}
+
+// New returns a cookie holding sessionID that expires after ttl.
+func (c *SessionCookie) New(sessionID string, ttl time.Duration) *http.Cookie {
+	return &http.Cookie{
+		Name:     c.name,
+		Value:    sessionID,
+		Domain:   c.domain,
+		Path:     c.path,
+		Expires:  time.Now().Add(ttl),
+		MaxAge:   int(ttl.Seconds()),
+		Secure:   c.secure,
+		HttpOnly: true,
+		SameSite: c.sameSite,
+	}
+}
+
+// Expired returns a cookie that removes the session cookie from the client.
+func (c *SessionCookie) Expired() *http.Cookie {
+	return &http.Cookie{
+		Name:     c.name,
+		Value:    "",
+		Domain:   c.domain,
+		Path:     c.path,
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		Secure:   c.secure,
+		HttpOnly: true,
+		SameSite: c.sameSite,
+	}
+}
@@ -25,7 +25,7 @@ type JwtManagerImplementation struct {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name JwtManager --output ../../../testing/mocks
 type JwtManager interface {
-	GenerateJWT(userId string, tokenType string, ttl time.Duration) (string, error)
+	GenerateJWT(userId string, tokenVersion int, tokenType string, ttl time.Duration) (string, error)
 	ValidateJWT(reqToken string, expectedType string) (jwt.MapClaims, error)
 	SaveRefreshToken(refreshToken string) error
 	GetRefreshToken(userID int) (RefreshTokenDTO, error)
@@ -46,7 +46,17 @@ func NewJwtManager(cfg *config.Config, pg *database.DbPool) JwtManager {
 
 type CustomClaims struct {
 	jwt.RegisteredClaims
-	TokenType string `json:"token_type"`
+	TokenType    string `json:"token_type"`
+	TokenVersion int    `json:"ver"`
+}
+
+// ClaimsTokenVersion returns the user's token version the token was issued with.
+func ClaimsTokenVersion(claims jwt.MapClaims) (int, error) {
+	version, ok := claims["ver"].(float64)
+	if !ok {
+		return 0, fmt.Errorf("missing token version")
+	}
+	return int(version), nil
 }
 
 func (m *JwtManagerImplementation) GetterAccessExpiresAt() time.Duration {
@@ -57,7 +67,7 @@ func (m *JwtManagerImplementation) GetterRefreshExpiresAt() time.Duration {
 	return m.RefreshExpiresAt
 }
 
-func (m *JwtManagerImplementation) GenerateJWT(userId string, tokenType string, ttl time.Duration) (string, error) {
+func (m *JwtManagerImplementation) GenerateJWT(userId string, tokenVersion int, tokenType string, ttl time.Duration) (string, error) {
 	expirationTime := time.Now().Add(ttl)
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &CustomClaims{
@@ -65,7 +75,8 @@ func (m *JwtManagerImplementation) GenerateJWT(userId string, tokenType string,
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			Subject:   userId,
 		},
-		TokenType: tokenType,
+		TokenType:    tokenType,
+		TokenVersion: tokenVersion,
 	})
 
 	return token.SignedString([]byte(m.secret))
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+type memorySession struct {
+	session   Session
+	expiresAt time.Time
+}
+
+// MemorySessionStore keeps sessions in process memory. Intended for local development and tests.
+type MemorySessionStore struct {
+	mu        sync.RWMutex
+	sessions  map[string]memorySession
+	stop      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func NewMemorySessionStore(evictInterval time.Duration) SessionStore {
+	store := &MemorySessionStore{
+		sessions: make(map[string]memorySession),
+		stop:     make(chan struct{}),
+	}
+
+	store.wg.Add(1)
+	go store.evict(evictInterval)
+
+	return store
+}
+
+func (s *MemorySessionStore) evict(interval time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			s.mu.Lock()
+			for id, stored := range s.sessions {
+				if !now.Before(stored.expiresAt) {
+					delete(s.sessions, id)
+				}
+			}
+			s.mu.Unlock()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *MemorySessionStore) Set(sessionID string, session Session, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[sessionID] = memorySession{
+		session:   session,
+		expiresAt: time.Now().Add(ttl),
+	}
+	return nil
+}
+
+func (s *MemorySessionStore) Get(sessionID string) (Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.sessions[sessionID]
+	if !ok || !time.Now().Before(stored.expiresAt) {
+		return Session{}, ErrSessionNotFound
+	}
+	return stored.session, nil
+}
+
+func (s *MemorySessionStore) Delete(sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, sessionID)
+	return nil
+}
+
+func (s *MemorySessionStore) FindByUserID(userID string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	for id, stored := range s.sessions {
+		if stored.session.UserID == userID && now.Before(stored.expiresAt) {
+			return id, nil
+		}
+	}
+	return "", ErrSessionNotFound
+}
+
+func (s *MemorySessionStore) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+	})
+	s.wg.Wait()
+	return nil
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// PostgresSessionStore keeps sessions in the sessions table.
+// Expired rows are ignored on read and removed by a background sweeper.
+type PostgresSessionStore struct {
+	pg        *database.DbPool
+	stop      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func NewPostgresSessionStore(pg *database.DbPool, sweepInterval time.Duration) SessionStore {
+	store := &PostgresSessionStore{
+		pg:   pg,
+		stop: make(chan struct{}),
+	}
+
+	store.wg.Add(1)
+	go store.sweep(sweepInterval)
+
+	return store
+}
+
+func (s *PostgresSessionStore) sweep(interval time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_, err := s.pg.Db.Exec(s.pg.Ctx, `DELETE FROM sessions WHERE expires_at <= now()`)
+			if err != nil {
+				s.pg.Log.Error("Error sweeping expired sessions", slog.String("error", err.Error()))
+			}
+		case <-s.stop:
+			return
+		case <-s.pg.Ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *PostgresSessionStore) Set(sessionID string, session Session, ttl time.Duration) error {
+	query := `
+		INSERT INTO sessions (id, user_id, token_version, expires_at) VALUES (@id, @user_id, @token_version, @expires_at)
+		ON CONFLICT (id) DO UPDATE SET user_id = EXCLUDED.user_id, token_version = EXCLUDED.token_version, expires_at = EXCLUDED.expires_at
+	`
+	args := pgx.NamedArgs{
+		"id":            sessionID,
+		"user_id":       session.UserID,
+		"token_version": session.TokenVersion,
+		"expires_at":    time.Now().Add(ttl),
+	}
+	_, err := s.pg.Db.Exec(s.pg.Ctx, query, args)
+	return err
+}
+
+func (s *PostgresSessionStore) Get(sessionID string) (Session, error) {
+	query := `SELECT user_id, token_version FROM sessions WHERE id = @id AND expires_at > now()`
+	var session Session
+	err := s.pg.Db.QueryRow(s.pg.Ctx, query, pgx.NamedArgs{"id": sessionID}).Scan(&session.UserID, &session.TokenVersion)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Session{}, ErrSessionNotFound
+	} else if err != nil {
+		return Session{}, err
+	}
+	return session, nil
+}
+
+func (s *PostgresSessionStore) Delete(sessionID string) error {
+	_, err := s.pg.Db.Exec(s.pg.Ctx, `DELETE FROM sessions WHERE id = @id`, pgx.NamedArgs{"id": sessionID})
+	return err
+}
+
+func (s *PostgresSessionStore) FindByUserID(userID string) (string, error) {
+	query := `SELECT id FROM sessions WHERE user_id = @user_id AND expires_at > now() LIMIT 1`
+	var sessionID string
+	err := s.pg.Db.QueryRow(s.pg.Ctx, query, pgx.NamedArgs{"user_id": userID}).Scan(&sessionID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrSessionNotFound
+	} else if err != nil {
+		return "", err
+	}
+	return sessionID, nil
+}
+
+// Close stops the sweeper. The pool itself is owned and closed by the caller.
+func (s *PostgresSessionStore) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+	})
+	s.wg.Wait()
+	return nil
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"go-rest-api-auth/internal/database"
+	"strings"
+	"time"
+)
+
+const sessionKeyPrefix = "session:"
+
+type RedisSessionStore struct {
+	cacheClient *database.CacheClient
+}
+
+func NewRedisSessionStore(cacheClient *database.CacheClient) SessionStore {
+	return &RedisSessionStore{
+		cacheClient: cacheClient,
+	}
+}
+
+func (s *RedisSessionStore) Set(sessionID string, session Session, ttl time.Duration) error {
+	value, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return s.cacheClient.Cache.Set(s.cacheClient.Ctx, sessionKeyPrefix+sessionID, value, ttl).Err()
+}
+
+func (s *RedisSessionStore) Get(sessionID string) (Session, error) {
+	return s.get(sessionKeyPrefix + sessionID)
+}
+
+func (s *RedisSessionStore) get(key string) (Session, error) {
+	value, err := s.cacheClient.Cache.Get(s.cacheClient.Ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return Session{}, ErrSessionNotFound
+	} else if err != nil {
+		return Session{}, err
+	}
+
+	var session Session
+	if err = json.Unmarshal(value, &session); err != nil {
+		return Session{}, err
+	}
+	return session, nil
+}
+
+func (s *RedisSessionStore) Delete(sessionID string) error {
+	return s.cacheClient.Cache.Del(s.cacheClient.Ctx, sessionKeyPrefix+sessionID).Err()
+}
+
+func (s *RedisSessionStore) FindByUserID(userID string) (string, error) {
+	iter := s.cacheClient.Cache.Scan(s.cacheClient.Ctx, 0, sessionKeyPrefix+"*", 100).Iterator()
+	for iter.Next(s.cacheClient.Ctx) {
+		key := iter.Val()
+		session, err := s.get(key)
+		if errors.Is(err, ErrSessionNotFound) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+
+		if userID == session.UserID {
+			return strings.TrimPrefix(key, sessionKeyPrefix), nil
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return "", err
+	}
+
+	return "", ErrSessionNotFound
+}
+
+func (s *RedisSessionStore) Close() error {
+	return s.cacheClient.Cache.Close()
+}
@@ -1,35 +1,40 @@
 package auth
 
 import (
-	"errors"
-	"github.com/go-redis/redis/v8"
-	"go-rest-api-auth/internal/database"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type SessionManagerImplementation struct {
-	cacheClient        *database.CacheClient
+	store              SessionStore
+	secret             []byte
 	Ttl                time.Duration
 	ErrSessionNotFound error
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name SessionManager --output ../../../testing/mocks
 type SessionManager interface {
-	CreateSession(userID string) (string, error)
-	GetUserIdBySession(sessionID string) (string, error)
+	CreateSession(userID string, tokenVersion int) (string, error)
+	RegenerateSession(sessionID string, tokenVersion int) (string, error)
+	GetSession(sessionID string) (Session, error)
 	GetSessionByUserID(userID string) (string, error)
 	DeleteSession(sessionID string) error
 	GetterTtl() time.Duration
 	GetterErrSessionNotFound() error
 }
 
-func NewSessionManager(cacheClient *database.CacheClient, ttl time.Duration) SessionManager {
+func NewSessionManager(store SessionStore, ttl time.Duration, secret string) SessionManager {
 	return &SessionManagerImplementation{
-		cacheClient:        cacheClient,
+		store:              store,
+		secret:             []byte(secret),
 		Ttl:                ttl,
-		ErrSessionNotFound: errors.New("session not found"),
+		ErrSessionNotFound: ErrSessionNotFound,
 	}
 }
 
@@ -41,46 +46,81 @@ func (sm *SessionManagerImplementation) GetterErrSessionNotFound() error {
 	return sm.ErrSessionNotFound
 }
 
-func (sm *SessionManagerImplementation) CreateSession(userID string) (string, error) {
-	sessionID := uuid.New().String()
-	err := sm.cacheClient.Cache.Set(sm.cacheClient.Ctx, sessionID, userID, sm.Ttl).Err()
+// sign returns the cookie value for a raw session id: "<id>.<hmac>".
+func (sm *SessionManagerImplementation) sign(id string) string {
+	mac := hmac.New(sha256.New, sm.secret)
+	mac.Write([]byte(id))
+	return id + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verify checks the signature of a cookie value and returns the raw session id.
+// Tampered or malformed values are reported as ErrSessionNotFound so they never reach the store.
+func (sm *SessionManagerImplementation) verify(sessionID string) (string, error) {
+	id, signature, ok := strings.Cut(sessionID, ".")
+	if !ok || id == "" {
+		return "", fmt.Errorf("%w: malformed session id", sm.ErrSessionNotFound)
+	}
+
+	expected := sm.sign(id)[len(id)+1:]
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return "", fmt.Errorf("%w: invalid session signature", sm.ErrSessionNotFound)
+	}
+
+	return id, nil
+}
+
+func (sm *SessionManagerImplementation) CreateSession(userID string, tokenVersion int) (string, error) {
+	id := uuid.New().String()
+	err := sm.store.Set(id, Session{UserID: userID, TokenVersion: tokenVersion}, sm.Ttl)
 	if err != nil {
 		return "", err
 	}
-	return sessionID, nil
+	return sm.sign(id), nil
 }
 
-func (sm *SessionManagerImplementation) GetUserIdBySession(sessionID string) (string, error) {
-	userID, err := sm.cacheClient.Cache.Get(sm.cacheClient.Ctx, sessionID).Result()
-	if errors.Is(err, redis.Nil) {
-		return "", sm.ErrSessionNotFound
-	} else if err != nil {
+// RegenerateSession issues a fresh session id carrying tokenVersion for the owner of sessionID and
+// revokes the old one. It must be called whenever the privileges behind a session change to prevent
+// session fixation.
+func (sm *SessionManagerImplementation) RegenerateSession(sessionID string, tokenVersion int) (string, error) {
+	session, err := sm.GetSession(sessionID)
+	if err != nil {
 		return "", err
 	}
-	return userID, nil
-}
 
-func (sm *SessionManagerImplementation) GetSessionByUserID(userID string) (string, error) {
-	keys, err := sm.cacheClient.Cache.Keys(sm.cacheClient.Ctx, "*").Result()
+	newSessionID, err := sm.CreateSession(session.UserID, tokenVersion)
 	if err != nil {
 		return "", err
 	}
 
-	for _, key := range keys {
-		storedUserID, err := sm.cacheClient.Cache.Get(sm.cacheClient.Ctx, key).Result()
-		if err != nil {
-			return "", err
-		}
+	if err = sm.DeleteSession(sessionID); err != nil {
+		return "", err
+	}
 
-		if userID == storedUserID {
-			return key, nil
-		}
+	return newSessionID, nil
+}
+
+func (sm *SessionManagerImplementation) GetSession(sessionID string) (Session, error) {
+	id, err := sm.verify(sessionID)
+	if err != nil {
+		return Session{}, err
 	}
 
-	return "", sm.ErrSessionNotFound
+	return sm.store.Get(id)
+}
+
+func (sm *SessionManagerImplementation) GetSessionByUserID(userID string) (string, error) {
+	id, err := sm.store.FindByUserID(userID)
+	if err != nil {
+		return "", err
+	}
+	return sm.sign(id), nil
 }
 
 func (sm *SessionManagerImplementation) DeleteSession(sessionID string) error {
-	err := sm.cacheClient.Cache.Del(sm.cacheClient.Ctx, sessionID).Err()
-	return err
+	id, err := sm.verify(sessionID)
+	if err != nil {
+		return err
+	}
+
+	return sm.store.Delete(id)
 }
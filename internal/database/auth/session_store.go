@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// Session is the data kept for a session id.
+// TokenVersion is the owner's token version at the time the session was issued.
+type Session struct {
+	UserID       string `json:"user_id"`
+	TokenVersion int    `json:"token_version"`
+}
+
+// SessionStore persists session ids and the user they belong to.
+// Implementations must return ErrSessionNotFound for unknown or expired sessions.
+type SessionStore interface {
+	Set(sessionID string, session Session, ttl time.Duration) error
+	Get(sessionID string) (Session, error)
+	Delete(sessionID string) error
+	FindByUserID(userID string) (string, error)
+	Close() error
+}
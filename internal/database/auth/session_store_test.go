@@ -0,0 +1,118 @@
+package auth_test
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/database/auth"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+// testSessionStore is the conformance suite every SessionStore backend must pass.
+func testSessionStore(t *testing.T, store auth.SessionStore) {
+	t.Run("SetAndGet", func(t *testing.T) {
+		sessionID, userID := uuid.NewString(), uuid.NewString()
+		require.NoError(t, store.Set(sessionID, auth.Session{UserID: userID, TokenVersion: 3}, time.Minute))
+
+		got, err := store.Get(sessionID)
+		assert.NoError(t, err)
+		assert.Equal(t, auth.Session{UserID: userID, TokenVersion: 3}, got)
+	})
+
+	t.Run("GetUnknown", func(t *testing.T) {
+		_, err := store.Get(uuid.NewString())
+		assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+	})
+
+	t.Run("SetOverwrites", func(t *testing.T) {
+		sessionID := uuid.NewString()
+		require.NoError(t, store.Set(sessionID, auth.Session{UserID: "first"}, time.Minute))
+		require.NoError(t, store.Set(sessionID, auth.Session{UserID: "second", TokenVersion: 1}, time.Minute))
+
+		got, err := store.Get(sessionID)
+		assert.NoError(t, err)
+		assert.Equal(t, auth.Session{UserID: "second", TokenVersion: 1}, got)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		sessionID := uuid.NewString()
+		require.NoError(t, store.Set(sessionID, auth.Session{UserID: uuid.NewString()}, time.Minute))
+		require.NoError(t, store.Delete(sessionID))
+
+		_, err := store.Get(sessionID)
+		assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+	})
+
+	t.Run("DeleteUnknown", func(t *testing.T) {
+		assert.NoError(t, store.Delete(uuid.NewString()))
+	})
+
+	t.Run("FindByUserID", func(t *testing.T) {
+		sessionID, userID := uuid.NewString(), uuid.NewString()
+		require.NoError(t, store.Set(sessionID, auth.Session{UserID: userID}, time.Minute))
+
+		got, err := store.FindByUserID(userID)
+		assert.NoError(t, err)
+		assert.Equal(t, sessionID, got)
+
+		_, err = store.FindByUserID(uuid.NewString())
+		assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		sessionID, userID := uuid.NewString(), uuid.NewString()
+		require.NoError(t, store.Set(sessionID, auth.Session{UserID: userID}, time.Second))
+
+		time.Sleep(1500 * time.Millisecond)
+
+		_, err := store.Get(sessionID)
+		assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+		_, err = store.FindByUserID(userID)
+		assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+	})
+}
+
+func TestMemorySessionStore(t *testing.T) {
+	store := auth.NewMemorySessionStore(100 * time.Millisecond)
+	defer store.Close()
+
+	testSessionStore(t, store)
+}
+
+func TestRedisSessionStore(t *testing.T) {
+	redisUrl := os.Getenv("TEST_REDIS_URL")
+	if redisUrl == "" {
+		t.Skip("TEST_REDIS_URL not set")
+	}
+
+	log := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	cache, err := database.NewRedisClient(context.Background(), log, redisUrl)
+	require.NoError(t, err)
+
+	store := auth.NewRedisSessionStore(cache)
+	defer store.Close()
+
+	testSessionStore(t, store)
+}
+
+func TestPostgresSessionStore(t *testing.T) {
+	dbUrl := os.Getenv("TEST_DATABASE_URL")
+	if dbUrl == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	log := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	pg := database.NewDbPool(ctx, dbUrl, log)
+	database.SetupTables(ctx, log)
+
+	store := auth.NewPostgresSessionStore(pg, 100*time.Millisecond)
+	defer store.Close()
+
+	testSessionStore(t, store)
+}
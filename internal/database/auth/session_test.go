@@ -0,0 +1,32 @@
+package auth_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-rest-api-auth/internal/database/auth"
+	"testing"
+	"time"
+)
+
+func TestRegenerateSession(t *testing.T) {
+	store := auth.NewMemorySessionStore(time.Minute)
+	defer store.Close()
+	sessionManager := auth.NewSessionManager(store, time.Minute, "secret")
+
+	sessionID, err := sessionManager.CreateSession("1", 2)
+	require.NoError(t, err)
+
+	newSessionID, err := sessionManager.RegenerateSession(sessionID, 3)
+	require.NoError(t, err)
+	assert.NotEqual(t, sessionID, newSessionID)
+
+	session, err := sessionManager.GetSession(newSessionID)
+	assert.NoError(t, err)
+	assert.Equal(t, auth.Session{UserID: "1", TokenVersion: 3}, session)
+
+	_, err = sessionManager.GetSession(sessionID)
+	assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+
+	_, err = sessionManager.RegenerateSession(sessionID, 3)
+	assert.ErrorIs(t, err, auth.ErrSessionNotFound)
+}
@@ -0,0 +1,154 @@
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/avatar"
+	"go-rest-api-auth/internal/storage"
+	"log/slog"
+	"strconv"
+)
+
+// AvatarVariantDTO is a stored thumbnail of an avatar. Size is its edge in pixels
+// and Length its size in bytes.
+type AvatarVariantDTO struct {
+	Size        int
+	Checksum    string
+	Length      int64
+	ContentType string
+}
+
+type AvatarServiceImplementation struct {
+	pg    *DbPool
+	blobs storage.BlobStore
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name AvatarService --output ../../testing/mocks
+type AvatarService interface {
+	SetAvatar(userID int, variants []avatar.Variant) (string, error)
+	DeleteAvatar(userID int) error
+	GetAvatarVariant(avatarID string, size int) (AvatarVariantDTO, error)
+}
+
+// NewAvatarService returns a service keeping the avatar thumbnails in blobs.
+func NewAvatarService(pg *DbPool, blobs storage.BlobStore) AvatarService {
+	return &AvatarServiceImplementation{
+		pg:    pg,
+		blobs: blobs,
+	}
+}
+
+// SetAvatar replaces the avatar of a user with the given thumbnails and returns its id, the
+// checksum of the avatar.DefaultSize thumbnail. It returns pgx.ErrNoRows when the user does not exist.
+func (service *AvatarServiceImplementation) SetAvatar(userID int, variants []avatar.Variant) (string, error) {
+	avatarID := ""
+	for _, variant := range variants {
+		if variant.Size == avatar.DefaultSize {
+			avatarID = variant.Checksum
+		}
+	}
+	if avatarID == "" {
+		return "", fmt.Errorf("missing the %dpx thumbnail", avatar.DefaultSize)
+	}
+
+	args := pgx.NamedArgs{
+		"user_id": userID,
+		"avatar":  avatarID,
+	}
+
+	err := service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		// concurrent uploads of the same user wait for each other, the last one wins
+		var id int
+		err := tx.Db.QueryRow(tx.Ctx, `SELECT id FROM users WHERE id = @user_id FOR UPDATE`, args).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Db.Exec(tx.Ctx, `DELETE FROM avatar_variants WHERE user_id = @user_id`, args)
+		if err != nil {
+			return err
+		}
+
+		for _, variant := range variants {
+			err = putBlob(tx, service.blobs, variant.Checksum, int64(len(variant.Content)), variant.ContentType, bytes.NewReader(variant.Content))
+			if err != nil {
+				return err
+			}
+
+			query := `INSERT INTO avatar_variants (user_id, size, checksum) VALUES (@user_id, @size, @checksum)`
+			_, err = tx.Db.Exec(tx.Ctx, query, pgx.NamedArgs{
+				"user_id":  userID,
+				"size":     variant.Size,
+				"checksum": variant.Checksum,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Db.Exec(tx.Ctx, `UPDATE users SET avatar = @avatar WHERE id = @user_id`, args)
+		return err
+	})
+	if err != nil {
+		service.pg.Log.Error("Error setting avatar", slog.String("err", err.Error()), slog.String("userid", strconv.Itoa(userID)))
+		return "", err
+	}
+
+	return avatarID, nil
+}
+
+// DeleteAvatar removes the avatar of a user, the thumbnails are left to DeleteOrphanedBlobs.
+func (service *AvatarServiceImplementation) DeleteAvatar(userID int) error {
+	args := pgx.NamedArgs{"user_id": userID}
+
+	err := service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		_, err := tx.Db.Exec(tx.Ctx, `DELETE FROM avatar_variants WHERE user_id = @user_id`, args)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Db.Exec(tx.Ctx, `UPDATE users SET avatar = NULL WHERE id = @user_id`, args)
+		return err
+	})
+	if err != nil {
+		service.pg.Log.Error("Error deleting avatar", slog.String("err", err.Error()), slog.String("userid", strconv.Itoa(userID)))
+		return err
+	}
+
+	return nil
+}
+
+// GetAvatarVariant returns the thumbnail of the given size of an avatar, the default one for size 0.
+// Replaced avatars are not found anymore.
+func (service *AvatarServiceImplementation) GetAvatarVariant(avatarID string, size int) (AvatarVariantDTO, error) {
+	if size == 0 {
+		size = avatar.DefaultSize
+	}
+
+	// users uploading the same picture share the avatar id and get the same thumbnails
+	query := `
+		SELECT v.size, v.checksum, b.size, b.content_type
+		FROM users u
+		JOIN avatar_variants v ON v.user_id = u.id
+		JOIN blobs b ON b.checksum = v.checksum
+		WHERE u.avatar = @avatar AND v.size = @size
+		LIMIT 1`
+	args := pgx.NamedArgs{
+		"avatar": avatarID,
+		"size":   size,
+	}
+
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error sql query getting avatar", slog.String("err", err.Error()))
+		return AvatarVariantDTO{}, err
+	}
+
+	variant, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[AvatarVariantDTO])
+	if err != nil {
+		service.pg.Log.Error("Error getting avatar", slog.String("err", err.Error()), slog.String("avatar", avatarID))
+		return AvatarVariantDTO{}, err
+	}
+
+	return variant, nil
+}
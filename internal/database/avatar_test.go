@@ -0,0 +1,80 @@
+package database
+
+import (
+	"bytes"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-rest-api-auth/internal/avatar"
+	"go-rest-api-auth/internal/storage"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func testAvatar(t *testing.T, shade uint8) []avatar.Variant {
+	img := image.NewGray(image.Rect(0, 0, 8, 8))
+	img.SetGray(0, 0, color.Gray{Y: shade})
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	variants, err := avatar.Process(buf.Bytes(), 100)
+	require.NoError(t, err)
+	return variants
+}
+
+func TestAvatars(t *testing.T) {
+	pg, _ := newTestPool(t)
+	userID, _ := newTestUser(t, pg)
+	blobs, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	users := NewUserService(pg)
+	avatars := NewAvatarService(pg, blobs)
+	attachments := NewAttachmentService(pg, blobs)
+
+	first := testAvatar(t, 10)
+	avatarID, err := avatars.SetAvatar(userID, first)
+	require.NoError(t, err)
+	assert.Equal(t, first[0].Checksum, avatarID)
+
+	user, err := users.GetUserById(userID)
+	require.NoError(t, err)
+	assert.Equal(t, avatarID, user.Avatar)
+
+	for _, variant := range first {
+		stored, err := avatars.GetAvatarVariant(avatarID, variant.Size)
+		require.NoError(t, err)
+		assert.Equal(t, AvatarVariantDTO{
+			Size:        variant.Size,
+			Checksum:    variant.Checksum,
+			Length:      int64(len(variant.Content)),
+			ContentType: variant.ContentType,
+		}, stored)
+	}
+	byDefault, err := avatars.GetAvatarVariant(avatarID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, avatar.DefaultSize, byDefault.Size)
+
+	_, err = avatars.SetAvatar(-1, first)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// the replaced thumbnails are orphans, removed with their files
+	replacedID, err := avatars.SetAvatar(userID, testAvatar(t, 200))
+	require.NoError(t, err)
+	assert.NotEqual(t, avatarID, replacedID)
+	_, err = avatars.GetAvatarVariant(avatarID, 0)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	_, err = attachments.DeleteOrphanedBlobs(100)
+	require.NoError(t, err)
+	_, err = blobs.Get(pg.Ctx, first[0].Checksum)
+	assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+
+	require.NoError(t, avatars.DeleteAvatar(userID))
+	user, err = users.GetUserById(userID)
+	require.NoError(t, err)
+	assert.Empty(t, user.Avatar)
+	_, err = avatars.GetAvatarVariant(replacedID, 0)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/storage"
+	"io"
+)
+
+// putBlob registers content under its checksum and uploads it, unless a blob with the same
+// checksum is stored already. It must run in the transaction that inserts the row referring
+// to the blob: the upsert locks the blob row, so DeleteOrphanedBlobs can not remove it before
+// that row is committed, and waits for a running removal to finish before inserting again.
+func putBlob(tx *DbPool, blobs storage.BlobStore, checksum string, size int64, contentType string, content io.Reader) error {
+	query := `
+		INSERT INTO blobs (checksum, size, content_type) VALUES (@checksum, @size, @content_type)
+		ON CONFLICT (checksum) DO UPDATE SET checksum = excluded.checksum
+		RETURNING xmax = 0`
+	args := pgx.NamedArgs{
+		"checksum":     checksum,
+		"size":         size,
+		"content_type": contentType,
+	}
+
+	var inserted bool
+	if err := tx.Db.QueryRow(tx.Ctx, query, args).Scan(&inserted); err != nil {
+		return err
+	}
+	if !inserted {
+		return nil
+	}
+
+	return blobs.Put(tx.Ctx, checksum, content, size, contentType)
+}
@@ -0,0 +1,178 @@
+package database
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"log/slog"
+	"strconv"
+)
+
+var (
+	// ErrBlocked is returned when one of two users blocked the other and the action would reach across the block.
+	ErrBlocked = errors.New("user is blocked")
+	// ErrSelfBlock is returned when a user tries to block or mute themselves.
+	ErrSelfBlock = errors.New("users can not block or mute themselves")
+)
+
+// viewerBlockedBy is true when the user whose id is the given SQL expression blocked @viewer_id.
+func viewerBlockedBy(user string) string {
+	return `EXISTS (SELECT 1 FROM blocks WHERE blocker_id = ` + user + ` AND blocked_id = @viewer_id)`
+}
+
+// viewerSilenced is true when @viewer_id muted or blocked the user whose id is the given SQL expression,
+// whose content is then left out of what is listed to them.
+func viewerSilenced(user string) string {
+	return `(EXISTS (SELECT 1 FROM mutes WHERE muter_id = @viewer_id AND muted_id = ` + user + `)
+		OR EXISTS (SELECT 1 FROM blocks WHERE blocker_id = @viewer_id AND blocked_id = ` + user + `))`
+}
+
+// eitherBlocked is true when the users whose ids are the given SQL expressions blocked one another in any direction.
+func eitherBlocked(user, other string) string {
+	return `EXISTS (SELECT 1 FROM blocks WHERE (blocker_id = ` + user + ` AND blocked_id = ` + other + `)
+		OR (blocker_id = ` + other + ` AND blocked_id = ` + user + `))`
+}
+
+type BlockServiceImplementation struct {
+	pg       *DbPool
+	timeline *TimelineCache
+}
+
+// BlockService manages the blocks and mutes between users. A blocked user can not read, comment on or react
+// to the posts of the blocker and the two can not follow one another. A muted user is not told anything,
+// their posts and comments are only left out of the lists and the feed of the muter.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name BlockService --output ../../testing/mocks
+type BlockService interface {
+	BlockUser(blockerID, blockedID int) error
+	UnblockUser(blockerID, blockedID int) error
+	GetBlocks(userID int, page Page) ([]UserDTO, string, error)
+	MuteUser(muterID, mutedID int) error
+	UnmuteUser(muterID, mutedID int) error
+	GetMutes(userID int, page Page) ([]UserDTO, string, error)
+}
+
+// NewBlockService returns a service dropping the cached timelines of the users whose follows a block removes.
+func NewBlockService(pg *DbPool, timeline *TimelineCache) BlockService {
+	return &BlockServiceImplementation{
+		pg:       pg,
+		timeline: timeline,
+	}
+}
+
+// BlockUser makes blockerID block blockedID and removes the follows between them, blocking twice is a no-op.
+// It returns pgx.ErrNoRows when the blocked user does not exist.
+func (service *BlockServiceImplementation) BlockUser(blockerID, blockedID int) error {
+	if blockerID == blockedID {
+		return ErrSelfBlock
+	}
+
+	args := pgx.NamedArgs{
+		"blocker_id": blockerID,
+		"blocked_id": blockedID,
+	}
+
+	err := service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		query := `
+			WITH blocked AS (
+				SELECT id FROM users WHERE id = @blocked_id FOR SHARE
+			), added AS (
+				INSERT INTO blocks (blocker_id, blocked_id)
+				SELECT @blocker_id, id FROM blocked
+				ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+			)
+			SELECT id FROM blocked`
+		var id int
+		if err := tx.Db.QueryRow(tx.Ctx, query, args).Scan(&id); err != nil {
+			return err
+		}
+
+		query = `
+			DELETE FROM follows WHERE (follower_id = @blocker_id AND followee_id = @blocked_id)
+				OR (follower_id = @blocked_id AND followee_id = @blocker_id)`
+		_, err := tx.Db.Exec(tx.Ctx, query, args)
+		return err
+	})
+	if err != nil {
+		service.pg.Log.Error("Error blocking user", slog.String("err", err.Error()), slog.String("blocked_id", strconv.Itoa(blockedID)))
+		return err
+	}
+
+	service.timeline.drop(blockerID)
+	service.timeline.drop(blockedID)
+	return nil
+}
+
+// UnblockUser lifts a block, the follows it removed are not restored. Unblocking a user that was not blocked is a no-op.
+func (service *BlockServiceImplementation) UnblockUser(blockerID, blockedID int) error {
+	query := `DELETE FROM blocks WHERE blocker_id = @blocker_id AND blocked_id = @blocked_id`
+	args := pgx.NamedArgs{
+		"blocker_id": blockerID,
+		"blocked_id": blockedID,
+	}
+
+	_, err := service.pg.Db.Exec(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error unblocking user", slog.String("err", err.Error()), slog.String("blocked_id", strconv.Itoa(blockedID)))
+		return err
+	}
+
+	return nil
+}
+
+// GetBlocks returns a page of the users userID blocked, the latest blocked first.
+func (service *BlockServiceImplementation) GetBlocks(userID int, page Page) ([]UserDTO, string, error) {
+	return relatedUsers(service.pg, "blocks", "blocked_id", "blocker_id", userID, page)
+}
+
+// MuteUser makes muterID mute mutedID, muting twice is a no-op.
+// It returns pgx.ErrNoRows when the muted user does not exist.
+func (service *BlockServiceImplementation) MuteUser(muterID, mutedID int) error {
+	if muterID == mutedID {
+		return ErrSelfBlock
+	}
+
+	query := `
+		WITH muted AS (
+			SELECT id FROM users WHERE id = @muted_id FOR SHARE
+		), added AS (
+			INSERT INTO mutes (muter_id, muted_id)
+			SELECT @muter_id, id FROM muted
+			ON CONFLICT (muter_id, muted_id) DO NOTHING
+		)
+		SELECT id FROM muted`
+	args := pgx.NamedArgs{
+		"muter_id": muterID,
+		"muted_id": mutedID,
+	}
+
+	var id int
+	err := service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(&id)
+	if err != nil {
+		service.pg.Log.Error("Error muting user", slog.String("err", err.Error()), slog.String("muted_id", strconv.Itoa(mutedID)))
+		return err
+	}
+
+	return nil
+}
+
+// UnmuteUser lifts a mute, unmuting a user that was not muted is a no-op.
+func (service *BlockServiceImplementation) UnmuteUser(muterID, mutedID int) error {
+	query := `DELETE FROM mutes WHERE muter_id = @muter_id AND muted_id = @muted_id`
+	args := pgx.NamedArgs{
+		"muter_id": muterID,
+		"muted_id": mutedID,
+	}
+
+	_, err := service.pg.Db.Exec(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error unmuting user", slog.String("err", err.Error()), slog.String("muted_id", strconv.Itoa(mutedID)))
+		return err
+	}
+
+	return nil
+}
+
+// GetMutes returns a page of the users userID muted, the latest muted first.
+func (service *BlockServiceImplementation) GetMutes(userID int, page Page) ([]UserDTO, string, error) {
+	return relatedUsers(service.pg, "mutes", "muted_id", "muter_id", userID, page)
+}
@@ -0,0 +1,136 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBlockUser(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, _ := newTestUser(t, pg)
+	blocked, _ := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	comments := NewCommentService(pg)
+	reactions := NewReactionService(pg, []string{"like"})
+	follows := NewFollowService(pg, nil)
+	blocks := NewBlockService(pg, nil)
+
+	post, err := posts.CreatePost(PostDTO{Title: "public", UserId: author})
+	require.NoError(t, err)
+	comment, err := comments.CreateComment(CommentDTO{PostId: post.Id, UserId: author, Content: "first"})
+	require.NoError(t, err)
+	require.NoError(t, follows.FollowUser(blocked, author))
+	require.NoError(t, follows.FollowUser(author, blocked))
+
+	require.NoError(t, blocks.BlockUser(author, blocked))
+	// blocking twice is a no-op
+	require.NoError(t, blocks.BlockUser(author, blocked))
+	assert.ErrorIs(t, blocks.BlockUser(author, author), ErrSelfBlock)
+	assert.ErrorIs(t, blocks.BlockUser(author, -1), pgx.ErrNoRows)
+
+	// the block removes the follows in both directions
+	following, _, err := follows.GetFollowing(blocked, Page{})
+	require.NoError(t, err)
+	assert.Empty(t, following)
+	following, _, err = follows.GetFollowing(author, Page{})
+	require.NoError(t, err)
+	assert.Empty(t, following)
+
+	canView, err := posts.CanViewPost(post, blocked)
+	require.NoError(t, err)
+	assert.False(t, canView)
+	listed, _, err := posts.GetALlPosts(PostFilter{ViewerID: blocked, AuthorID: author}, Page{})
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+
+	_, err = comments.CreateComment(CommentDTO{PostId: post.Id, UserId: blocked, Content: "reply"})
+	assert.ErrorIs(t, err, ErrBlocked)
+	_, err = comments.CreateComment(CommentDTO{PostId: post.Id, ParentId: comment.Id, UserId: blocked, Content: "reply"})
+	assert.ErrorIs(t, err, ErrBlocked)
+	assert.ErrorIs(t, reactions.AddReaction(post.Id, blocked, "like"), ErrBlocked)
+	assert.ErrorIs(t, follows.FollowUser(blocked, author), ErrBlocked)
+	// the blocker can not reach across their own block either
+	assert.ErrorIs(t, follows.FollowUser(author, blocked), ErrBlocked)
+
+	blockedUsers, _, err := blocks.GetBlocks(author, Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{blocked}, userIDs(blockedUsers))
+
+	require.NoError(t, blocks.UnblockUser(author, blocked))
+	canView, err = posts.CanViewPost(post, blocked)
+	require.NoError(t, err)
+	assert.True(t, canView)
+	require.NoError(t, follows.FollowUser(blocked, author))
+}
+
+func TestMuteUser(t *testing.T) {
+	pg, _ := newTestPool(t)
+	reader, _ := newTestUser(t, pg)
+	muted, _ := newTestUser(t, pg)
+	other, _ := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	comments := NewCommentService(pg)
+	follows := NewFollowService(pg, nil)
+	blocks := NewBlockService(pg, nil)
+
+	mutedPost, err := posts.CreatePost(PostDTO{Title: "muted", UserId: muted})
+	require.NoError(t, err)
+	otherPost, err := posts.CreatePost(PostDTO{Title: "other", UserId: other})
+	require.NoError(t, err)
+	_, err = comments.CreateComment(CommentDTO{PostId: otherPost.Id, UserId: muted, Content: "muted"})
+	require.NoError(t, err)
+	otherComment, err := comments.CreateComment(CommentDTO{PostId: otherPost.Id, UserId: other, Content: "other"})
+	require.NoError(t, err)
+	require.NoError(t, follows.FollowUser(reader, muted))
+	require.NoError(t, follows.FollowUser(reader, other))
+
+	require.NoError(t, blocks.MuteUser(reader, muted))
+	// muting twice is a no-op
+	require.NoError(t, blocks.MuteUser(reader, muted))
+	assert.ErrorIs(t, blocks.MuteUser(reader, reader), ErrSelfBlock)
+	assert.ErrorIs(t, blocks.MuteUser(reader, -1), pgx.ErrNoRows)
+
+	feed, _, err := follows.GetFeed(reader, Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{otherPost.Id}, postIDs(feed))
+
+	listed, _, err := posts.GetALlPosts(PostFilter{ViewerID: reader}, Page{Limit: 100})
+	require.NoError(t, err)
+	assert.NotContains(t, postIDs(listed), mutedPost.Id)
+	assert.Contains(t, postIDs(listed), otherPost.Id)
+
+	// the posts of a muted author are still listed when asked for explicitly and readable
+	listed, _, err = posts.GetALlPosts(PostFilter{ViewerID: reader, AuthorID: muted}, Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{mutedPost.Id}, postIDs(listed))
+	canView, err := posts.CanViewPost(mutedPost, reader)
+	require.NoError(t, err)
+	assert.True(t, canView)
+
+	flat, _, err := comments.GetComments(otherPost.Id, reader, Page{})
+	require.NoError(t, err)
+	require.Len(t, flat, 1)
+	assert.Equal(t, otherComment.Id, flat[0].Id)
+	// the muted user is not told anything
+	flat, _, err = comments.GetComments(otherPost.Id, muted, Page{})
+	require.NoError(t, err)
+	assert.Len(t, flat, 2)
+	tree, _, err := comments.GetCommentTree(otherPost.Id, reader, 0, 2, Page{})
+	require.NoError(t, err)
+	require.Len(t, tree, 1)
+	assert.Equal(t, otherComment.Id, tree[0].Id)
+	tree, _, err = comments.GetCommentTree(otherPost.Id, 0, 0, 2, Page{})
+	require.NoError(t, err)
+	assert.Len(t, tree, 2)
+
+	mutedUsers, _, err := blocks.GetMutes(reader, Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{muted}, userIDs(mutedUsers))
+
+	require.NoError(t, blocks.UnmuteUser(reader, muted))
+	feed, _, err = follows.GetFeed(reader, Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{otherPost.Id, mutedPost.Id}, postIDs(feed))
+}
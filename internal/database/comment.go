@@ -0,0 +1,331 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"log/slog"
+	"strconv"
+)
+
+const (
+	DefaultCommentDepth = 3
+	MaxCommentDepth     = 10
+)
+
+// ErrInvalidComment is returned for replies to a comment that is deleted or belongs to another post.
+var ErrInvalidComment = errors.New("invalid comment")
+
+// CommentDTO is the storage representation of a comment. ParentId is 0 for top level comments
+// and UserId is 0 once the author deleted their account. A deleted comment keeps its place in
+// the thread with an empty content. Depth is only set by GetCommentTree, 0 for the roots.
+type CommentDTO struct {
+	Id         int
+	PostId     int
+	ParentId   int
+	UserId     int
+	Content    string
+	CreatedAt  pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+	Deleted    bool
+	ReplyCount int
+	Depth      int
+}
+
+// commentColumns selects a comment of the comments table aliased c, without its depth.
+const commentColumns = `c.id, c.post_id, coalesce(c.parent_id, 0), coalesce(c.user_id, 0), c.content,
+	c.created_at, c.updated_at, c.deleted_at IS NOT NULL,
+	(SELECT count(*) FROM comments r WHERE r.parent_id = c.id)::int`
+
+var commentSortColumns = map[string]sortColumn{
+	"id":         {column: "id"},
+	"created_at": {column: "created_at", parse: parseTimestamp},
+}
+
+type CommentServiceImplementation struct {
+	pg *DbPool
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name CommentService --output ../../testing/mocks
+type CommentService interface {
+	CreateComment(comment CommentDTO) (CommentDTO, error)
+	GetComment(commentID int) (CommentDTO, error)
+	UpdateComment(comment CommentDTO) (CommentDTO, error)
+	DeleteComment(commentID int) error
+	GetComments(postID, viewerID int, page Page) ([]CommentDTO, string, error)
+	GetCommentTree(postID, viewerID, parentID, depth int, page Page) ([]CommentDTO, string, error)
+}
+
+func NewCommentService(pg *DbPool) CommentService {
+	return &CommentServiceImplementation{
+		pg: pg,
+	}
+}
+
+// CreateComment adds a comment to a post, a non zero ParentId makes it a reply.
+// It returns pgx.ErrNoRows when the post does not exist, ErrInvalidComment
+// when the parent is deleted or not a comment of the same post and ErrBlocked
+// when the author of the post or of the parent and the commenter blocked one another.
+// It does not check whether the commenter may read the post, see PostService.CanViewPost.
+func (service *CommentServiceImplementation) CreateComment(comment CommentDTO) (CommentDTO, error) {
+	args := pgx.NamedArgs{
+		"post_id":   comment.PostId,
+		"parent_id": comment.ParentId,
+		"user_id":   comment.UserId,
+		"content":   comment.Content,
+	}
+
+	var created CommentDTO
+	err := service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		// lock the post, so it is not deleted between the checks and the insert
+		var postID int
+		var blocked bool
+		query := `SELECT id, ` + eitherBlocked("posts.user_id", "@user_id") + ` FROM posts WHERE id = @post_id FOR SHARE`
+		err := tx.Db.QueryRow(tx.Ctx, query, args).Scan(&postID, &blocked)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return ErrBlocked
+		}
+
+		if comment.ParentId != 0 {
+			var parentPostID int
+			var deleted bool
+			query = `SELECT post_id, deleted_at IS NOT NULL, ` + eitherBlocked("comments.user_id", "@user_id") + ` FROM comments WHERE id = @parent_id FOR SHARE`
+			err = tx.Db.QueryRow(tx.Ctx, query, args).Scan(&parentPostID, &deleted, &blocked)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return err
+			}
+			if parentPostID != comment.PostId {
+				return fmt.Errorf("%w: parent comment %d is not on post %d", ErrInvalidComment, comment.ParentId, comment.PostId)
+			}
+			if deleted {
+				return fmt.Errorf("%w: parent comment %d is deleted", ErrInvalidComment, comment.ParentId)
+			}
+			if blocked {
+				return ErrBlocked
+			}
+		}
+
+		query = `
+			INSERT INTO comments AS c (post_id, parent_id, user_id, content)
+			VALUES (@post_id, nullif(@parent_id, 0), @user_id, @content)
+			RETURNING ` + commentColumns
+		return scanComment(tx.Db.QueryRow(tx.Ctx, query, args), &created)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrInvalidComment) && !errors.Is(err, ErrBlocked) {
+			service.pg.Log.Error("Error creating comment", slog.String("err", err.Error()))
+		}
+		return CommentDTO{}, err
+	}
+
+	return created, nil
+}
+
+func (service *CommentServiceImplementation) GetComment(commentID int) (CommentDTO, error) {
+	query := `SELECT ` + commentColumns + ` FROM comments c WHERE c.id = @id`
+
+	var comment CommentDTO
+	err := scanComment(service.pg.Db.QueryRow(service.pg.Ctx, query, pgx.NamedArgs{"id": commentID}), &comment)
+	if err != nil {
+		service.pg.Log.Error("Error getting comment", slog.String("err", err.Error()), slog.String("commentid", strconv.Itoa(commentID)))
+		return CommentDTO{}, err
+	}
+
+	return comment, nil
+}
+
+// UpdateComment replaces the content of a comment, deleted comments can not be edited
+// and yield pgx.ErrNoRows.
+func (service *CommentServiceImplementation) UpdateComment(comment CommentDTO) (CommentDTO, error) {
+	query := `
+		UPDATE comments c SET content = @content, updated_at = CURRENT_TIMESTAMP
+		WHERE c.id = @id AND c.deleted_at IS NULL
+		RETURNING ` + commentColumns
+	args := pgx.NamedArgs{
+		"id":      comment.Id,
+		"content": comment.Content,
+	}
+
+	var updated CommentDTO
+	err := scanComment(service.pg.Db.QueryRow(service.pg.Ctx, query, args), &updated)
+	if err != nil {
+		service.pg.Log.Error("Error updating comment", slog.String("err", err.Error()), slog.String("commentid", strconv.Itoa(comment.Id)))
+		return CommentDTO{}, err
+	}
+
+	return updated, nil
+}
+
+// DeleteComment removes a comment without replies. A comment with replies is only
+// marked as deleted and its content cleared, so the replies keep their place in the thread.
+func (service *CommentServiceImplementation) DeleteComment(commentID int) error {
+	args := pgx.NamedArgs{"id": commentID}
+
+	return service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		// the lock keeps replies from being added while deciding
+		var hasReplies bool
+		query := `SELECT EXISTS (SELECT 1 FROM comments WHERE parent_id = c.id) FROM comments c WHERE c.id = @id FOR UPDATE`
+		err := tx.Db.QueryRow(tx.Ctx, query, args).Scan(&hasReplies)
+		if err != nil {
+			tx.Log.Error("Error locking comment to delete", slog.String("err", err.Error()), slog.String("commentid", strconv.Itoa(commentID)))
+			return err
+		}
+
+		query = `DELETE FROM comments WHERE id = @id`
+		if hasReplies {
+			query = `UPDATE comments SET content = '', deleted_at = coalesce(deleted_at, CURRENT_TIMESTAMP) WHERE id = @id`
+		}
+		_, err = tx.Db.Exec(tx.Ctx, query, args)
+		if err != nil {
+			tx.Log.Error("Error deleting comment", slog.String("err", err.Error()), slog.String("commentid", strconv.Itoa(commentID)))
+			return err
+		}
+
+		return nil
+	})
+}
+
+// GetComments returns a page of every comment of a post regardless of its place in the thread,
+// oldest first by default. Clients rebuild the threads from ParentId. Comments of the users
+// viewerID muted or blocked are left out.
+func (service *CommentServiceImplementation) GetComments(postID, viewerID int, page Page) ([]CommentDTO, string, error) {
+	ks, err := newKeyset(page, commentSortColumns, "created_at", SortAsc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	args := pgx.NamedArgs{"post_id": postID, "viewer_id": viewerID}
+	conditions := []string{"post_id = @post_id", "NOT " + viewerSilenced("c.user_id")}
+	condition, err := ks.condition(args)
+	if err != nil {
+		return nil, "", err
+	}
+	if condition != "" {
+		conditions = append(conditions, condition)
+	}
+
+	query := `SELECT ` + commentColumns + `, 0 FROM comments c` + whereClause(conditions) + ks.orderBy() + ks.limitClause(args)
+	comments, err := service.queryComments(query, args)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(comments) <= ks.limit {
+		return comments, "", nil
+	}
+
+	comments = comments[:ks.limit]
+	last := comments[len(comments)-1]
+	return comments, ks.nextCursor(commentCursorValue(ks.sort, last), last.Id), nil
+}
+
+// GetCommentTree returns a page of the direct replies to parentID, or of the top level comments
+// of the post when parentID is 0. Each is followed by its replies, depth first, so that the result
+// spans depth levels including the page itself. Comments below that are not loaded, the ReplyCount
+// of the deepest comments tells whether there are any. Comments of the users viewerID muted
+// or blocked are left out together with the replies to them.
+func (service *CommentServiceImplementation) GetCommentTree(postID, viewerID, parentID, depth int, page Page) ([]CommentDTO, string, error) {
+	if depth <= 0 {
+		depth = DefaultCommentDepth
+	}
+	if depth > MaxCommentDepth {
+		depth = MaxCommentDepth
+	}
+
+	ks, err := newKeyset(page, commentSortColumns, "created_at", SortAsc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	args := pgx.NamedArgs{
+		"post_id":   postID,
+		"viewer_id": viewerID,
+		"parent_id": parentID,
+		"depth":     depth,
+	}
+	conditions := []string{"post_id = @post_id", "parent_id IS NOT DISTINCT FROM nullif(@parent_id, 0)", "NOT " + viewerSilenced("comments.user_id")}
+	condition, err := ks.condition(args)
+	if err != nil {
+		return nil, "", err
+	}
+	if condition != "" {
+		conditions = append(conditions, condition)
+	}
+
+	// path puts every reply right after its parent, the roots in page order and replies by id,
+	// which is their creation order
+	query := `
+		WITH RECURSIVE roots AS (
+			SELECT id, row_number() OVER (` + ks.orderBy() + `)::int AS position FROM comments` +
+		whereClause(conditions) + ks.orderBy() + ks.limitClause(args) + `
+		), tree AS (
+			SELECT id, 0 AS depth, ARRAY[position] AS path FROM roots
+			UNION ALL
+			SELECT r.id, t.depth + 1, t.path || r.id
+			FROM comments r JOIN tree t ON r.parent_id = t.id
+			WHERE t.depth + 1 < @depth AND NOT ` + viewerSilenced("r.user_id") + `
+		)
+		SELECT ` + commentColumns + `, t.depth FROM tree t JOIN comments c ON c.id = t.id ORDER BY t.path`
+	comments, err := service.queryComments(query, args)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// the extra root fetched to detect the next page comes last, together with its replies
+	roots := 0
+	var last CommentDTO
+	for i, comment := range comments {
+		if comment.Depth != 0 {
+			continue
+		}
+		if roots == ks.limit {
+			return comments[:i], ks.nextCursor(commentCursorValue(ks.sort, last), last.Id), nil
+		}
+		roots++
+		last = comment
+	}
+
+	return comments, "", nil
+}
+
+func (service *CommentServiceImplementation) queryComments(query string, args pgx.NamedArgs) ([]CommentDTO, error) {
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error sql query getting comments", slog.String("err", err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments, err := pgx.CollectRows(rows, pgx.RowToStructByPos[CommentDTO])
+	if err != nil {
+		service.pg.Log.Error("Error scanning comment", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func scanComment(row pgx.Row, comment *CommentDTO) error {
+	return row.Scan(
+		&comment.Id,
+		&comment.PostId,
+		&comment.ParentId,
+		&comment.UserId,
+		&comment.Content,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+		&comment.Deleted,
+		&comment.ReplyCount,
+	)
+}
+
+func commentCursorValue(sort string, comment CommentDTO) string {
+	if sort == "created_at" {
+		return comment.CreatedAt.Time.Format(cursorTimestampLayout)
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCommentThreads(t *testing.T) {
+	pg, _ := newTestPool(t)
+	userID, _ := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	comments := NewCommentService(pg)
+
+	post, err := posts.CreatePost(PostDTO{Title: "discussed", UserId: userID})
+	require.NoError(t, err)
+	other, err := posts.CreatePost(PostDTO{Title: "other", UserId: userID})
+	require.NoError(t, err)
+
+	create := func(parentID int, content string) CommentDTO {
+		comment, err := comments.CreateComment(CommentDTO{PostId: post.Id, ParentId: parentID, UserId: userID, Content: content})
+		require.NoError(t, err)
+		return comment
+	}
+
+	// first
+	// ├── reply
+	// │   └── nested
+	// └── second reply
+	// second
+	first := create(0, "first")
+	reply := create(first.Id, "reply")
+	nested := create(reply.Id, "nested")
+	secondReply := create(first.Id, "second reply")
+	second := create(0, "second")
+
+	_, err = comments.CreateComment(CommentDTO{PostId: other.Id, ParentId: first.Id, UserId: userID, Content: "elsewhere"})
+	assert.ErrorIs(t, err, ErrInvalidComment)
+	_, err = comments.CreateComment(CommentDTO{PostId: -1, UserId: userID, Content: "nowhere"})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	loaded, err := posts.GetPost(post.Id)
+	require.NoError(t, err)
+	assert.Equal(t, 5, loaded.CommentCount)
+
+	tree, next, err := comments.GetCommentTree(post.Id, 0, 0, 2, Page{Limit: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, next)
+	assert.Equal(t, []int{first.Id, reply.Id, secondReply.Id}, commentIDs(tree))
+	assert.Equal(t, []int{0, 1, 1}, commentDepths(tree))
+	assert.Equal(t, 1, tree[1].ReplyCount, "the cut off reply is still counted")
+
+	tree, next, err = comments.GetCommentTree(post.Id, 0, 0, 2, Page{Limit: 1, Cursor: next})
+	require.NoError(t, err)
+	assert.Empty(t, next)
+	assert.Equal(t, []int{second.Id}, commentIDs(tree))
+
+	tree, _, err = comments.GetCommentTree(post.Id, 0, reply.Id, 0, Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{nested.Id}, commentIDs(tree))
+
+	// a deleted comment with replies stays as a placeholder, a leaf goes away
+	require.NoError(t, comments.DeleteComment(reply.Id))
+	require.NoError(t, comments.DeleteComment(secondReply.Id))
+
+	flat, _, err := comments.GetComments(post.Id, 0, Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{first.Id, reply.Id, nested.Id, second.Id}, commentIDs(flat))
+	assert.True(t, flat[1].Deleted)
+	assert.Empty(t, flat[1].Content)
+
+	_, err = comments.UpdateComment(CommentDTO{Id: reply.Id, Content: "back"})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	_, err = comments.CreateComment(CommentDTO{PostId: post.Id, ParentId: reply.Id, UserId: userID, Content: "late"})
+	assert.ErrorIs(t, err, ErrInvalidComment)
+
+	updated, err := comments.UpdateComment(CommentDTO{Id: nested.Id, Content: "edited"})
+	require.NoError(t, err)
+	assert.Equal(t, "edited", updated.Content)
+	assert.True(t, updated.UpdatedAt.Valid)
+
+	loaded, err = posts.GetPost(post.Id)
+	require.NoError(t, err)
+	assert.Equal(t, 3, loaded.CommentCount)
+}
+
+func commentIDs(comments []CommentDTO) []int {
+	ids := make([]int, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.Id)
+	}
+	return ids
+}
+
+func commentDepths(comments []CommentDTO) []int {
+	depths := make([]int, 0, len(comments))
+	for _, comment := range comments {
+		depths = append(depths, comment.Depth)
+	}
+	return depths
+}
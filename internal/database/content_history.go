@@ -0,0 +1,97 @@
+package database
+
+import (
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/contentfilter"
+	"log/slog"
+	"time"
+)
+
+// contentHistoryQueries select the posts and comments of @user_id created within the last @seconds.
+var contentHistoryQueries = map[string]struct{ count, recent string }{
+	contentfilter.KindPost: {
+		count: `SELECT count(*) FROM posts WHERE user_id = @user_id AND created_at > CURRENT_TIMESTAMP - make_interval(secs => @seconds)`,
+		recent: `
+			SELECT title || E'\n' || coalesce(content, '') FROM posts
+			WHERE user_id = @user_id AND id <> @exclude_id AND created_at > CURRENT_TIMESTAMP - make_interval(secs => @seconds)
+			ORDER BY created_at DESC, id DESC LIMIT @limit`,
+	},
+	contentfilter.KindComment: {
+		count: `SELECT count(*) FROM comments WHERE user_id = @user_id AND created_at > CURRENT_TIMESTAMP - make_interval(secs => @seconds)`,
+		recent: `
+			SELECT content FROM comments
+			WHERE user_id = @user_id AND id <> @exclude_id AND deleted_at IS NULL AND created_at > CURRENT_TIMESTAMP - make_interval(secs => @seconds)
+			ORDER BY created_at DESC, id DESC LIMIT @limit`,
+	},
+}
+
+type ContentHistoryServiceImplementation struct {
+	pg *DbPool
+}
+
+// ContentHistoryService tells the content filters what a user posted lately, it implements contentfilter.History.
+// Kind is contentfilter.KindPost or contentfilter.KindComment.
+type ContentHistoryService interface {
+	CountContent(userID int, kind string, window time.Duration) (int, error)
+	RecentContent(userID int, kind string, excludeID int, window time.Duration, limit int) ([]string, error)
+}
+
+func NewContentHistoryService(pg *DbPool) ContentHistoryService {
+	return &ContentHistoryServiceImplementation{
+		pg: pg,
+	}
+}
+
+// CountContent counts the posts or comments userID created within window, deleted comments included.
+func (service *ContentHistoryServiceImplementation) CountContent(userID int, kind string, window time.Duration) (int, error) {
+	queries, ok := contentHistoryQueries[kind]
+	if !ok {
+		return 0, fmt.Errorf("unknown content kind %q", kind)
+	}
+
+	args := pgx.NamedArgs{
+		"user_id": userID,
+		"seconds": window.Seconds(),
+	}
+
+	var count int
+	if err := service.pg.Db.QueryRow(service.pg.Ctx, queries.count, args).Scan(&count); err != nil {
+		service.pg.Log.Error("Error counting content", slog.String("err", err.Error()), slog.String("kind", kind))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// RecentContent returns the text of at most limit posts or comments userID created within window,
+// the latest first. Posts are their title and content on separate lines. The post or comment
+// excludeID is left out, so edits are not compared to themselves.
+func (service *ContentHistoryServiceImplementation) RecentContent(userID int, kind string, excludeID int, window time.Duration, limit int) ([]string, error) {
+	queries, ok := contentHistoryQueries[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown content kind %q", kind)
+	}
+
+	args := pgx.NamedArgs{
+		"user_id":    userID,
+		"exclude_id": excludeID,
+		"seconds":    window.Seconds(),
+		"limit":      limit,
+	}
+
+	rows, err := service.pg.Db.Query(service.pg.Ctx, queries.recent, args)
+	if err != nil {
+		service.pg.Log.Error("Error sql query getting recent content", slog.String("err", err.Error()), slog.String("kind", kind))
+		return nil, err
+	}
+	defer rows.Close()
+
+	texts, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		service.pg.Log.Error("Error scanning recent content", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	return texts, nil
+}
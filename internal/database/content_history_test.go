@@ -0,0 +1,51 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-rest-api-auth/internal/contentfilter"
+	"testing"
+	"time"
+)
+
+func TestContentHistory(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, _ := newTestUser(t, pg)
+	other, _ := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	comments := NewCommentService(pg)
+	history := NewContentHistoryService(pg)
+
+	first, err := posts.CreatePost(PostDTO{Title: "first", Content: "hello", UserId: author})
+	require.NoError(t, err)
+	second, err := posts.CreatePost(PostDTO{Title: "second", UserId: author})
+	require.NoError(t, err)
+	_, err = posts.CreatePost(PostDTO{Title: "other", UserId: other})
+	require.NoError(t, err)
+	comment, err := comments.CreateComment(CommentDTO{PostId: first.Id, UserId: author, Content: "kept"})
+	require.NoError(t, err)
+	deleted, err := comments.CreateComment(CommentDTO{PostId: first.Id, UserId: author, Content: "deleted"})
+	require.NoError(t, err)
+	require.NoError(t, comments.DeleteComment(deleted.Id))
+
+	count, err := history.CountContent(author, contentfilter.KindPost, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	// deleted comments still count against the quota
+	count, err = history.CountContent(author, contentfilter.KindComment, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	recent, err := history.RecentContent(author, contentfilter.KindPost, 0, time.Hour, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"second\n", "first\nhello"}, recent)
+	recent, err = history.RecentContent(author, contentfilter.KindPost, second.Id, time.Hour, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first\nhello"}, recent)
+	recent, err = history.RecentContent(author, contentfilter.KindComment, comment.Id, time.Hour, 10)
+	require.NoError(t, err)
+	assert.Empty(t, recent)
+
+	_, err = history.CountContent(author, "reaction", time.Hour)
+	assert.Error(t, err)
+}
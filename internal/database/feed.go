@@ -0,0 +1,253 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/jackc/pgx/v5"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const timelineKeyPrefix = "feed:"
+
+// feedCondition selects the published posts of the authors @viewer_id follows and the ones carrying
+// a tag they follow, leaving out their own posts and the ones of users they muted or blocked.
+// listedCondition still applies on top of it.
+var feedCondition = `status = 'published' AND user_id <> @viewer_id AND NOT ` + viewerSilenced("posts.user_id") + ` AND (
+	user_id IN (SELECT followee_id FROM follows WHERE follower_id = @viewer_id)
+	OR id IN (SELECT pt.post_id FROM posts_tags pt JOIN tag_follows tf ON tf.tag_id = pt.tag_id WHERE tf.user_id = @viewer_id)
+)`
+
+// feedSortColumns only allows the newest first order of a feed, see GetFeed.
+var feedSortColumns = map[string]sortColumn{
+	"published_at": {column: "published_at", parse: parseTimestamp},
+}
+
+// GetFeed returns a page of the feed of userID, see feedCondition, the latest published posts first.
+// The feed is collected from the followed authors and tags on read, with a TimelineCache the head
+// of it is collected once per cache lifetime.
+func (service *FollowServiceImplementation) GetFeed(userID int, page Page) ([]PostDTO, string, error) {
+	ks, err := newKeyset(page, feedSortColumns, "published_at", SortDesc)
+	if err != nil {
+		return nil, "", err
+	}
+	if ks.order != SortDesc {
+		return nil, "", fmt.Errorf("%w: a feed is only sorted newest first", ErrInvalidPage)
+	}
+
+	if service.timeline != nil {
+		posts, nextCursor, ok, err := service.cachedFeed(userID, ks)
+		if err == nil && ok {
+			return posts, nextCursor, nil
+		}
+		if err != nil {
+			service.pg.Log.Warn("Error reading cached timeline", slog.String("err", err.Error()), slog.String("user_id", strconv.Itoa(userID)))
+		}
+	}
+
+	args := pgx.NamedArgs{"viewer_id": userID}
+	conditions := []string{feedCondition, listedCondition}
+	condition, err := ks.condition(args)
+	if err != nil {
+		return nil, "", err
+	}
+	if condition != "" {
+		conditions = append(conditions, condition)
+	}
+
+	query := `SELECT ` + postColumns + ` FROM posts` + whereClause(conditions) + ks.orderBy() + ks.limitClause(args)
+	posts, err := queryPosts(service.pg, query, args)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(posts) <= ks.limit {
+		return posts, "", nil
+	}
+
+	posts = posts[:ks.limit]
+	last := posts[len(posts)-1]
+	return posts, ks.nextCursor(last.PublishedAt.Time.Format(cursorTimestampLayout), last.Id), nil
+}
+
+// cachedFeed serves the page from the cached timeline of userID, loading the timeline on a miss.
+// ok is false when the page lies past the cached head of the feed.
+func (service *FollowServiceImplementation) cachedFeed(userID int, ks keyset) ([]PostDTO, string, bool, error) {
+	tl, err := service.timeline.get(userID)
+	if errors.Is(err, redis.Nil) {
+		tl, err = service.loadTimeline(userID)
+		if err != nil {
+			return nil, "", false, err
+		}
+		if err = service.timeline.set(userID, tl); err != nil {
+			return nil, "", false, err
+		}
+	} else if err != nil {
+		return nil, "", false, err
+	}
+
+	entries, more, ok := tl.page(ks)
+	if !ok {
+		return nil, "", false, nil
+	}
+	if len(entries) == 0 {
+		return nil, "", true, nil
+	}
+
+	ids := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.Id)
+	}
+
+	// posts deleted, hidden, muted or unfollowed since the timeline was cached drop out of the page
+	args := pgx.NamedArgs{"viewer_id": userID, "ids": ids}
+	query := `SELECT ` + postColumns + ` FROM posts WHERE id = ANY(@ids) AND ` + feedCondition + ` AND ` + listedCondition
+	loaded, err := queryPosts(service.pg, query, args)
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	byID := make(map[int]PostDTO, len(loaded))
+	for _, post := range loaded {
+		byID[post.Id] = post
+	}
+	posts := make([]PostDTO, 0, len(loaded))
+	for _, id := range ids {
+		if post, found := byID[id]; found {
+			posts = append(posts, post)
+		}
+	}
+
+	if !more {
+		return posts, "", true, nil
+	}
+	last := entries[len(entries)-1]
+	return posts, ks.nextCursor(last.PublishedAt.Format(cursorTimestampLayout), last.Id), true, nil
+}
+
+// loadTimeline collects the head of the feed of userID, at most the cache size of posts.
+func (service *FollowServiceImplementation) loadTimeline(userID int) (timeline, error) {
+	query := `SELECT id, published_at FROM posts WHERE ` + feedCondition + ` AND ` + listedCondition +
+		` ORDER BY published_at DESC, id DESC LIMIT @limit`
+	args := pgx.NamedArgs{
+		"viewer_id": userID,
+		"limit":     service.timeline.size + 1,
+	}
+
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error loading timeline", slog.String("err", err.Error()), slog.String("user_id", strconv.Itoa(userID)))
+		return timeline{}, err
+	}
+	defer rows.Close()
+
+	entries, err := pgx.CollectRows(rows, pgx.RowToStructByPos[timelineEntry])
+	if err != nil {
+		service.pg.Log.Error("Error scanning timeline", slog.String("err", err.Error()))
+		return timeline{}, err
+	}
+
+	tl := timeline{Entries: entries, Complete: len(entries) <= service.timeline.size}
+	if !tl.Complete {
+		tl.Entries = tl.Entries[:service.timeline.size]
+	}
+	return tl, nil
+}
+
+// timelineEntry is a post of a cached timeline, enough to page through it without the database.
+type timelineEntry struct {
+	Id          int       `json:"id"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// timeline is the cached head of a feed, newest first. Complete is false when the feed goes on past Entries.
+type timeline struct {
+	Entries  []timelineEntry `json:"entries"`
+	Complete bool            `json:"complete"`
+}
+
+// page returns the entries of the page ks asks for and whether more entries follow them.
+// ok is false when the page reaches past the entries of an incomplete timeline.
+func (tl timeline) page(ks keyset) (entries []timelineEntry, more bool, ok bool) {
+	start := 0
+	if ks.after != nil {
+		after, err := time.Parse(cursorTimestampLayout, ks.after.Value)
+		if err != nil {
+			// the feed query reports the malformed cursor
+			return nil, false, false
+		}
+		start = sort.Search(len(tl.Entries), func(i int) bool {
+			entry := tl.Entries[i]
+			return entry.PublishedAt.Before(after) || (entry.PublishedAt.Equal(after) && entry.Id < ks.after.Id)
+		})
+	}
+
+	end := start + ks.limit
+	if end < len(tl.Entries) {
+		return tl.Entries[start:end], true, true
+	}
+	if !tl.Complete {
+		return nil, false, false
+	}
+	return tl.Entries[start:], false, true
+}
+
+// TimelineCache keeps the head of every feed in Redis for ttl, so paging through a feed does not
+// collect it from every followed author and tag again. Following or unfollowing drops the timeline
+// of the follower, new posts of followed authors show up once the timeline expires.
+// A nil TimelineCache caches nothing.
+type TimelineCache struct {
+	cacheClient *CacheClient
+	ttl         time.Duration
+	size        int
+}
+
+// NewTimelineCache returns a cache keeping the newest size posts of a feed for ttl.
+func NewTimelineCache(cacheClient *CacheClient, ttl time.Duration, size int) *TimelineCache {
+	return &TimelineCache{
+		cacheClient: cacheClient,
+		ttl:         ttl,
+		size:        size,
+	}
+}
+
+// get returns the cached timeline of userID, or redis.Nil when there is none.
+func (c *TimelineCache) get(userID int) (timeline, error) {
+	value, err := c.cacheClient.Cache.Get(c.cacheClient.Ctx, timelineKeyPrefix+strconv.Itoa(userID)).Bytes()
+	if err != nil {
+		return timeline{}, err
+	}
+
+	var tl timeline
+	if err = json.Unmarshal(value, &tl); err != nil {
+		return timeline{}, err
+	}
+	return tl, nil
+}
+
+func (c *TimelineCache) set(userID int, tl timeline) error {
+	value, err := json.Marshal(tl)
+	if err != nil {
+		return err
+	}
+	return c.cacheClient.Cache.Set(c.cacheClient.Ctx, timelineKeyPrefix+strconv.Itoa(userID), value, c.ttl).Err()
+}
+
+// drop forgets the timeline of userID. A failure is only logged, the timeline expires anyway.
+func (c *TimelineCache) drop(userID int) {
+	if c == nil {
+		return
+	}
+	err := c.cacheClient.Cache.Del(c.cacheClient.Ctx, timelineKeyPrefix+strconv.Itoa(userID)).Err()
+	if err != nil {
+		c.cacheClient.Log.Warn("Error dropping cached timeline", slog.String("err", err.Error()), slog.String("user_id", strconv.Itoa(userID)))
+	}
+}
+
+func (c *TimelineCache) Close() error {
+	return c.cacheClient.Cache.Close()
+}
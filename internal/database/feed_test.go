@@ -0,0 +1,126 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestFeed(t *testing.T) {
+	pg, _ := newTestPool(t)
+	reader, prefix := newTestUser(t, pg)
+	author, _ := newTestUser(t, pg)
+	stranger, _ := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	follows := NewFollowService(pg, nil)
+
+	followedTag := prefix + "-followed"
+	_, err := NewTagService(pg).UpsertTag(followedTag)
+	require.NoError(t, err)
+	require.NoError(t, follows.FollowUser(reader, author))
+	_, err = follows.FollowTag(reader, followedTag)
+	require.NoError(t, err)
+
+	create := func(post PostDTO) PostDTO {
+		created, err := posts.CreatePost(post)
+		require.NoError(t, err)
+		return created
+	}
+	public := create(PostDTO{Title: "public", UserId: author})
+	followers := create(PostDTO{Title: "followers", UserId: author, Visibility: PostVisibilityFollowers})
+	create(PostDTO{Title: "draft", UserId: author, Status: PostStatusDraft})
+	create(PostDTO{Title: "private", UserId: author, Visibility: PostVisibilityPrivate})
+	tagged := create(PostDTO{Title: "tagged", UserId: stranger, Tags: []string{followedTag}})
+	create(PostDTO{Title: "untagged", UserId: stranger})
+	create(PostDTO{Title: "hidden", UserId: stranger, Tags: []string{followedTag}, Visibility: PostVisibilityFollowers})
+	create(PostDTO{Title: "own", UserId: reader, Tags: []string{followedTag}})
+
+	feed, cursor, err := follows.GetFeed(reader, Page{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []int{tagged.Id, followers.Id}, postIDs(feed))
+	require.NotEmpty(t, cursor)
+
+	feed, cursor, err = follows.GetFeed(reader, Page{Limit: 2, Cursor: cursor})
+	require.NoError(t, err)
+	assert.Equal(t, []int{public.Id}, postIDs(feed))
+	assert.Empty(t, cursor)
+
+	require.NoError(t, follows.UnfollowUser(reader, author))
+	feed, _, err = follows.GetFeed(reader, Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{tagged.Id}, postIDs(feed))
+
+	_, _, err = follows.GetFeed(reader, Page{Order: SortAsc})
+	assert.ErrorIs(t, err, ErrInvalidPage)
+}
+
+func TestTimelinePage(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	entries := []timelineEntry{
+		{Id: 5, PublishedAt: now},
+		{Id: 4, PublishedAt: now},
+		{Id: 3, PublishedAt: now.Add(-time.Minute)},
+		{Id: 2, PublishedAt: now.Add(-2 * time.Minute)},
+		{Id: 1, PublishedAt: now.Add(-3 * time.Minute)},
+	}
+	after := func(entry timelineEntry) string {
+		return encodeCursor(cursor{Sort: "published_at", Order: SortDesc, Value: entry.PublishedAt.Format(cursorTimestampLayout), Id: entry.Id})
+	}
+
+	tests := []struct {
+		name     string
+		complete bool
+		page     Page
+		expected []timelineEntry
+		more     bool
+		ok       bool
+	}{
+		{
+			name:     "FirstPage",
+			page:     Page{Limit: 2},
+			expected: entries[:2],
+			more:     true,
+			ok:       true,
+		},
+		{
+			name:     "SameTimestamp",
+			page:     Page{Limit: 2, Cursor: after(entries[0])},
+			expected: entries[1:3],
+			more:     true,
+			ok:       true,
+		},
+		{
+			name:     "LastPageOfCompleteTimeline",
+			complete: true,
+			page:     Page{Limit: 2, Cursor: after(entries[2])},
+			expected: entries[3:],
+			ok:       true,
+		},
+		{
+			name:     "EmptyPageOfCompleteTimeline",
+			complete: true,
+			page:     Page{Limit: 2, Cursor: after(entries[4])},
+			expected: []timelineEntry{},
+			ok:       true,
+		},
+		{
+			name: "PastIncompleteTimeline",
+			page: Page{Limit: 2, Cursor: after(entries[2])},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := newKeyset(tt.page, feedSortColumns, "published_at", SortDesc)
+			require.NoError(t, err)
+
+			page, more, ok := timeline{Entries: entries, Complete: tt.complete}.page(ks)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.more, more)
+			if tt.ok {
+				assert.Equal(t, tt.expected, page)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package database
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"log/slog"
+	"strconv"
+)
+
+// ErrSelfFollow is returned when a user tries to follow themselves.
+var ErrSelfFollow = errors.New("users can not follow themselves")
+
+// relationSortColumns orders lists of followers, followed, blocked and muted users by when the relation started.
+var relationSortColumns = map[string]sortColumn{
+	"created_at": {column: "r.created_at", parse: parseTimestamp},
+}
+
+type FollowServiceImplementation struct {
+	pg       *DbPool
+	timeline *TimelineCache
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name FollowService --output ../../testing/mocks
+type FollowService interface {
+	FollowUser(followerID, followeeID int) error
+	UnfollowUser(followerID, followeeID int) error
+	GetFollowers(userID int, page Page) ([]UserDTO, string, error)
+	GetFollowing(userID int, page Page) ([]UserDTO, string, error)
+	FollowTag(userID int, tag string) (TagsDTO, error)
+	UnfollowTag(userID int, tag string) error
+	GetFollowedTags(userID int) ([]TagsDTO, error)
+	GetFeed(userID int, page Page) ([]PostDTO, string, error)
+}
+
+// NewFollowService returns a service building feeds from the database on every request,
+// unless timeline is not nil, see TimelineCache.
+func NewFollowService(pg *DbPool, timeline *TimelineCache) FollowService {
+	return &FollowServiceImplementation{
+		pg:       pg,
+		timeline: timeline,
+	}
+}
+
+// FollowUser makes followerID follow followeeID, following twice is a no-op.
+// It returns pgx.ErrNoRows when the followee does not exist and ErrBlocked
+// when the two blocked one another.
+func (service *FollowServiceImplementation) FollowUser(followerID, followeeID int) error {
+	if followerID == followeeID {
+		return ErrSelfFollow
+	}
+
+	query := `
+		WITH followee AS (
+			SELECT id, ` + eitherBlocked("users.id", "@follower_id") + ` AS blocked FROM users WHERE id = @followee_id FOR SHARE
+		), added AS (
+			INSERT INTO follows (follower_id, followee_id)
+			SELECT @follower_id, id FROM followee WHERE NOT blocked
+			ON CONFLICT (follower_id, followee_id) DO NOTHING
+		)
+		SELECT blocked FROM followee`
+	args := pgx.NamedArgs{
+		"follower_id": followerID,
+		"followee_id": followeeID,
+	}
+
+	var blocked bool
+	err := service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(&blocked)
+	if err != nil {
+		service.pg.Log.Error("Error following user", slog.String("err", err.Error()), slog.String("followee_id", strconv.Itoa(followeeID)))
+		return err
+	}
+	if blocked {
+		return ErrBlocked
+	}
+
+	service.timeline.drop(followerID)
+	return nil
+}
+
+// UnfollowUser stops followerID following followeeID, unfollowing a user that was not followed is a no-op.
+func (service *FollowServiceImplementation) UnfollowUser(followerID, followeeID int) error {
+	query := `DELETE FROM follows WHERE follower_id = @follower_id AND followee_id = @followee_id`
+	args := pgx.NamedArgs{
+		"follower_id": followerID,
+		"followee_id": followeeID,
+	}
+
+	_, err := service.pg.Db.Exec(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error unfollowing user", slog.String("err", err.Error()), slog.String("followee_id", strconv.Itoa(followeeID)))
+		return err
+	}
+
+	service.timeline.drop(followerID)
+	return nil
+}
+
+// GetFollowers returns a page of the users following userID, the latest followers first.
+func (service *FollowServiceImplementation) GetFollowers(userID int, page Page) ([]UserDTO, string, error) {
+	return relatedUsers(service.pg, "follows", "follower_id", "followee_id", userID, page)
+}
+
+// GetFollowing returns a page of the users userID follows, the latest followed first.
+func (service *FollowServiceImplementation) GetFollowing(userID int, page Page) ([]UserDTO, string, error) {
+	return relatedUsers(service.pg, "follows", "followee_id", "follower_id", userID, page)
+}
+
+// FollowTag makes userID follow a tag by its name or one of its aliases, following twice is a no-op.
+// It returns the followed tag, or pgx.ErrNoRows when there is no such tag.
+func (service *FollowServiceImplementation) FollowTag(userID int, tag string) (TagsDTO, error) {
+	query := `
+		WITH tag AS (
+			SELECT id, name FROM tags WHERE normalized = @normalized
+			UNION ALL
+			SELECT t.id, t.name FROM tag_aliases a JOIN tags t ON t.id = a.tag_id WHERE a.normalized = @normalized
+		), added AS (
+			INSERT INTO tag_follows (user_id, tag_id)
+			SELECT @user_id, id FROM tag
+			ON CONFLICT (user_id, tag_id) DO NOTHING
+		)
+		SELECT id, name FROM tag`
+	args := pgx.NamedArgs{
+		"user_id":    userID,
+		"normalized": NormalizeTag(tag),
+	}
+
+	var followed TagsDTO
+	err := service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(&followed.Id, &followed.Name)
+	if err != nil {
+		service.pg.Log.Error("Error following tag", slog.String("err", err.Error()), slog.String("tag", tag))
+		return TagsDTO{}, err
+	}
+
+	service.timeline.drop(userID)
+	return followed, nil
+}
+
+// UnfollowTag stops userID following a tag by its name or one of its aliases,
+// unfollowing a tag that was not followed is a no-op.
+func (service *FollowServiceImplementation) UnfollowTag(userID int, tag string) error {
+	query := `
+		DELETE FROM tag_follows WHERE user_id = @user_id AND tag_id IN (
+			SELECT id FROM tags WHERE normalized = @normalized
+			UNION
+			SELECT tag_id FROM tag_aliases WHERE normalized = @normalized
+		)`
+	args := pgx.NamedArgs{
+		"user_id":    userID,
+		"normalized": NormalizeTag(tag),
+	}
+
+	_, err := service.pg.Db.Exec(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error unfollowing tag", slog.String("err", err.Error()), slog.String("tag", tag))
+		return err
+	}
+
+	service.timeline.drop(userID)
+	return nil
+}
+
+// GetFollowedTags returns the tags userID follows, sorted by name.
+func (service *FollowServiceImplementation) GetFollowedTags(userID int) ([]TagsDTO, error) {
+	query := `SELECT t.id, t.name FROM tag_follows tf JOIN tags t ON t.id = tf.tag_id WHERE tf.user_id = @user_id ORDER BY t.name`
+	args := pgx.NamedArgs{"user_id": userID}
+
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error getting followed tags", slog.String("err", err.Error()), slog.String("user_id", strconv.Itoa(userID)))
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags, err := pgx.CollectRows(rows, pgx.RowToStructByPos[TagsDTO])
+	if err != nil {
+		service.pg.Log.Error("Error scanning followed tags", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// relatedUsers returns a page of the users in the listed column of the relations in table whose owner
+// column is userID, the latest relations first. table is one of the user to user relations, such as follows.
+func relatedUsers(pg *DbPool, table, listed, owner string, userID int, page Page) ([]UserDTO, string, error) {
+	ks, err := newKeyset(page, relationSortColumns, "created_at", SortDesc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	args := pgx.NamedArgs{"user_id": userID}
+	conditions := []string{"r." + owner + " = @user_id"}
+	condition, err := ks.condition(args)
+	if err != nil {
+		return nil, "", err
+	}
+	if condition != "" {
+		conditions = append(conditions, condition)
+	}
+
+	query := `SELECT ` + userColumns + `, r.created_at FROM ` + table + ` r JOIN users ON users.id = r.` + listed +
+		whereClause(conditions) + ks.orderBy() + ks.limitClause(args)
+
+	rows, err := pg.Db.Query(pg.Ctx, query, args)
+	if err != nil {
+		pg.Log.Error("Error getting related users", slog.String("err", err.Error()), slog.String("table", table), slog.String("user_id", strconv.Itoa(userID)))
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var users []UserDTO
+	var relatedAt []pgtype.Timestamp
+	for rows.Next() {
+		var user UserDTO
+		var at pgtype.Timestamp
+		if err = scanUser(rows, &user, &at); err != nil {
+			pg.Log.Error("Error scanning related users", slog.String("err", err.Error()))
+			return nil, "", err
+		}
+		users = append(users, user)
+		relatedAt = append(relatedAt, at)
+	}
+	if err = rows.Err(); err != nil {
+		pg.Log.Error("Error getting related users", slog.String("err", err.Error()))
+		return nil, "", err
+	}
+
+	if len(users) <= ks.limit {
+		return users, "", nil
+	}
+
+	users = users[:ks.limit]
+	last := len(users) - 1
+	return users, ks.nextCursor(relatedAt[last].Time.Format(cursorTimestampLayout), users[last].Id), nil
+}
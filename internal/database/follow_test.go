@@ -0,0 +1,97 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func userIDs(users []UserDTO) []int {
+	ids := make([]int, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.Id)
+	}
+	return ids
+}
+
+func TestFollowUsers(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, _ := newTestUser(t, pg)
+	first, _ := newTestUser(t, pg)
+	second, _ := newTestUser(t, pg)
+	follows := NewFollowService(pg, nil)
+	users := NewUserService(pg)
+
+	require.NoError(t, follows.FollowUser(first, author))
+	require.NoError(t, follows.FollowUser(second, author))
+	// following twice is a no-op
+	require.NoError(t, follows.FollowUser(first, author))
+	require.NoError(t, follows.FollowUser(author, first))
+
+	assert.ErrorIs(t, follows.FollowUser(author, author), ErrSelfFollow)
+	assert.ErrorIs(t, follows.FollowUser(author, -1), pgx.ErrNoRows)
+
+	found, err := users.GetUserById(author)
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.FollowerCount)
+	assert.Equal(t, 1, found.FollowingCount)
+
+	// the latest followers come first
+	followers, cursor, err := follows.GetFollowers(author, Page{Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []int{second}, userIDs(followers))
+	require.NotEmpty(t, cursor)
+	followers, cursor, err = follows.GetFollowers(author, Page{Limit: 1, Cursor: cursor})
+	require.NoError(t, err)
+	assert.Equal(t, []int{first}, userIDs(followers))
+	assert.Empty(t, cursor)
+
+	following, _, err := follows.GetFollowing(first, Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{author}, userIDs(following))
+	assert.Equal(t, 2, following[0].FollowerCount)
+
+	// unfollowing twice is a no-op
+	require.NoError(t, follows.UnfollowUser(first, author))
+	require.NoError(t, follows.UnfollowUser(first, author))
+	followers, _, err = follows.GetFollowers(author, Page{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{second}, userIDs(followers))
+
+	_, _, err = follows.GetFollowers(author, Page{Sort: "username"})
+	assert.ErrorIs(t, err, ErrInvalidPage)
+}
+
+func TestFollowTags(t *testing.T) {
+	pg, _ := newTestPool(t)
+	userID, prefix := newTestUser(t, pg)
+	tags := NewTagService(pg)
+	follows := NewFollowService(pg, nil)
+
+	golang, err := tags.UpsertTag(prefix + "-Go")
+	require.NoError(t, err)
+	rust, err := tags.UpsertTag(prefix + "-Rust")
+	require.NoError(t, err)
+	require.NoError(t, tags.AddTagAlias(golang.Id, prefix+"-golang"))
+
+	// aliases resolve to their tag
+	followed, err := follows.FollowTag(userID, prefix+"-GOLANG")
+	require.NoError(t, err)
+	assert.Equal(t, golang, followed)
+	_, err = follows.FollowTag(userID, prefix+"-go")
+	require.NoError(t, err)
+	_, err = follows.FollowTag(userID, prefix+"-missing")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// merging moves the followers to the target tag
+	require.NoError(t, tags.MergeTags(golang.Id, rust.Id))
+	followedTags, err := follows.GetFollowedTags(userID)
+	require.NoError(t, err)
+	assert.Equal(t, []TagsDTO{rust}, followedTags)
+
+	require.NoError(t, follows.UnfollowTag(userID, prefix+"-golang"))
+	followedTags, err = follows.GetFollowedTags(userID)
+	require.NoError(t, err)
+	assert.Empty(t, followedTags)
+}
@@ -0,0 +1,384 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"log/slog"
+	"slices"
+	"strconv"
+)
+
+const (
+	ReportStatusOpen     = "open"
+	ReportStatusResolved = "resolved"
+
+	ModerationActionDismiss = "dismiss"
+	ModerationActionHide    = "hide"
+	ModerationActionDelete  = "delete"
+	ModerationActionSuspend = "suspend"
+
+	// ReportReasonFlagged is the reason of reports filed by the content filters, see FlagPost.
+	ReportReasonFlagged = "flagged"
+)
+
+// ReportReasons are the reason codes a post may be reported for.
+var ReportReasons = []string{"spam", "harassment", "hate", "violence", "sexual", "misinformation", "other"}
+
+// ModerationActions are what a moderator may do about a report, see ResolveReport.
+var ModerationActions = []string{ModerationActionDismiss, ModerationActionHide, ModerationActionDelete, ModerationActionSuspend}
+
+var (
+	// ErrInvalidReport is returned for unknown reason codes and reports of one's own posts.
+	ErrInvalidReport = errors.New("invalid report")
+	// ErrInvalidModerationAction is returned for actions not in ModerationActions and for
+	// actions on a post that no longer exists.
+	ErrInvalidModerationAction = errors.New("invalid moderation action")
+	// ErrReportResolved is returned when acting on a report that was already resolved.
+	ErrReportResolved = errors.New("report is already resolved")
+)
+
+// ReportDTO is a report of a post and, once resolved, the moderation action resolving it.
+// PostId is 0 once the post is deleted and ReporterId once the reporter deleted their account.
+// AuthorId is the author of the reported post. Action, ModeratorId, Note and ResolvedAt are
+// zero while the report is open.
+type ReportDTO struct {
+	Id          int
+	PostId      int
+	PostTitle   string
+	AuthorId    int
+	ReporterId  int
+	Reason      string
+	Details     string
+	Status      string
+	CreatedAt   pgtype.Timestamp
+	Action      string
+	ModeratorId int
+	Note        string
+	ResolvedAt  pgtype.Timestamp
+}
+
+// ReportFilter narrows the moderation queue. Zero values are ignored.
+type ReportFilter struct {
+	Status   string
+	Reason   string
+	PostID   int
+	AuthorID int
+}
+
+// reportColumns selects a report together with the title of the post and the action resolving it.
+// The columns are selected in a subquery named reports, so the keyset and the filters refer to them unqualified.
+const reportColumns = `SELECT * FROM (
+	SELECT r.id, coalesce(r.post_id, 0) AS post_id, coalesce(p.title, '') AS post_title, r.author_id,
+		coalesce(r.reporter_id, 0) AS reporter_id, r.reason, r.details, r.status, r.created_at,
+		coalesce(a.action, '') AS action, coalesce(a.moderator_id, 0) AS moderator_id, coalesce(a.note, '') AS note,
+		a.created_at AS resolved_at
+	FROM reports r
+	LEFT JOIN posts p ON p.id = r.post_id
+	LEFT JOIN moderation_actions a ON a.id = r.action_id
+) reports`
+
+var reportSortColumns = map[string]sortColumn{
+	"id":         {column: "id"},
+	"created_at": {column: "created_at", parse: parseTimestamp},
+}
+
+type ModerationServiceImplementation struct {
+	pg *DbPool
+}
+
+// ModerationService collects reports of posts into a moderation queue and records what moderators do about them.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name ModerationService --output ../../testing/mocks
+type ModerationService interface {
+	ReportPost(report ReportDTO) (ReportDTO, error)
+	FlagPost(postID int, details string) (ReportDTO, error)
+	GetReports(filter ReportFilter, page Page) ([]ReportDTO, string, error)
+	ResolveReport(reportID, moderatorID int, action, note string) (ReportDTO, error)
+}
+
+func NewModerationService(pg *DbPool) ModerationService {
+	return &ModerationServiceImplementation{
+		pg: pg,
+	}
+}
+
+// ReportPost files a report of PostId by ReporterId for Reason, reporting a post twice while
+// the first report is open returns the first report. It returns pgx.ErrNoRows when the post
+// does not exist and ErrInvalidReport for unknown reasons and reports of one's own posts.
+func (service *ModerationServiceImplementation) ReportPost(report ReportDTO) (ReportDTO, error) {
+	if !slices.Contains(ReportReasons, report.Reason) {
+		return ReportDTO{}, fmt.Errorf("%w: %q is not one of %v", ErrInvalidReport, report.Reason, ReportReasons)
+	}
+
+	args := pgx.NamedArgs{
+		"post_id":     report.PostId,
+		"reporter_id": report.ReporterId,
+		"reason":      report.Reason,
+		"details":     report.Details,
+	}
+
+	var created ReportDTO
+	err := service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		var authorID int
+		query := `SELECT user_id FROM posts WHERE id = @post_id FOR SHARE`
+		if err := tx.Db.QueryRow(tx.Ctx, query, args).Scan(&authorID); err != nil {
+			return err
+		}
+		if authorID == report.ReporterId {
+			return fmt.Errorf("%w: users can not report their own posts", ErrInvalidReport)
+		}
+		args["author_id"] = authorID
+
+		// the select after the insert still sees the reports from before it, so it only finds an earlier open report
+		query = `
+			WITH added AS (
+				INSERT INTO reports (post_id, author_id, reporter_id, reason, details)
+				VALUES (@post_id, @author_id, @reporter_id, @reason, @details)
+				ON CONFLICT (post_id, reporter_id) WHERE status = 'open' DO NOTHING
+				RETURNING id
+			)
+			SELECT id FROM added
+			UNION ALL
+			SELECT id FROM reports WHERE post_id = @post_id AND reporter_id = @reporter_id AND status = 'open'`
+		var id int
+		if err := tx.Db.QueryRow(tx.Ctx, query, args).Scan(&id); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = getReport(tx, id)
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, ErrInvalidReport) && !errors.Is(err, pgx.ErrNoRows) {
+			service.pg.Log.Error("Error reporting post", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(report.PostId)))
+		}
+		return ReportDTO{}, err
+	}
+
+	return created, nil
+}
+
+// FlagPost files a report of a post flagged by the content filters, with no reporter and the
+// ReportReasonFlagged reason. Flagging a post with an open flag report updates its details instead.
+// It returns pgx.ErrNoRows when the post does not exist.
+func (service *ModerationServiceImplementation) FlagPost(postID int, details string) (ReportDTO, error) {
+	args := pgx.NamedArgs{
+		"post_id": postID,
+		"reason":  ReportReasonFlagged,
+		"details": details,
+	}
+
+	var flagged ReportDTO
+	err := service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		// locking the post for update keeps concurrent flags from filing two reports,
+		// the unique index of open reports does not cover reports without a reporter
+		var authorID int
+		query := `SELECT user_id FROM posts WHERE id = @post_id FOR UPDATE`
+		if err := tx.Db.QueryRow(tx.Ctx, query, args).Scan(&authorID); err != nil {
+			return err
+		}
+		args["author_id"] = authorID
+
+		query = `
+			WITH updated AS (
+				UPDATE reports SET details = @details
+				WHERE post_id = @post_id AND reporter_id IS NULL AND reason = @reason AND status = 'open'
+				RETURNING id
+			), added AS (
+				INSERT INTO reports (post_id, author_id, reason, details)
+				SELECT @post_id, @author_id, @reason, @details
+				WHERE NOT EXISTS (SELECT 1 FROM updated)
+				RETURNING id
+			)
+			SELECT id FROM updated
+			UNION ALL
+			SELECT id FROM added`
+		var id int
+		if err := tx.Db.QueryRow(tx.Ctx, query, args).Scan(&id); err != nil {
+			return err
+		}
+
+		var err error
+		flagged, err = getReport(tx, id)
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			service.pg.Log.Error("Error flagging post", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		}
+		return ReportDTO{}, err
+	}
+
+	return flagged, nil
+}
+
+// GetReports returns a page of the moderation queue, the oldest reports first by default.
+func (service *ModerationServiceImplementation) GetReports(filter ReportFilter, page Page) ([]ReportDTO, string, error) {
+	ks, err := newKeyset(page, reportSortColumns, "created_at", SortAsc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	args := pgx.NamedArgs{}
+	conditions := filter.conditions(args)
+	condition, err := ks.condition(args)
+	if err != nil {
+		return nil, "", err
+	}
+	if condition != "" {
+		conditions = append(conditions, condition)
+	}
+
+	query := reportColumns + whereClause(conditions) + ks.orderBy() + ks.limitClause(args)
+	reports, err := queryReports(service.pg, query, args)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(reports) <= ks.limit {
+		return reports, "", nil
+	}
+
+	reports = reports[:ks.limit]
+	last := reports[len(reports)-1]
+	return reports, ks.nextCursor(last.CreatedAt.Time.Format(cursorTimestampLayout), last.Id), nil
+}
+
+// ResolveReport records action by moderatorID with a note and carries it out: dismiss leaves the post
+// as it is, hide hides it from everyone but its author and admins, delete deletes it and suspend
+// suspends its author, revoking their tokens and sessions. The action resolves every open report
+// of the post. It returns pgx.ErrNoRows when the report does not exist, ErrReportResolved when
+// it is resolved already and ErrInvalidModerationAction for unknown actions.
+func (service *ModerationServiceImplementation) ResolveReport(reportID, moderatorID int, action, note string) (ReportDTO, error) {
+	if !slices.Contains(ModerationActions, action) {
+		return ReportDTO{}, fmt.Errorf("%w: %q is not one of %v", ErrInvalidModerationAction, action, ModerationActions)
+	}
+
+	args := pgx.NamedArgs{
+		"report_id":    reportID,
+		"moderator_id": moderatorID,
+		"action":       action,
+		"note":         note,
+	}
+
+	var resolved ReportDTO
+	err := service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		var postID pgtype.Int4
+		var authorID int
+		var status string
+		query := `SELECT post_id, author_id, status FROM reports WHERE id = @report_id FOR UPDATE`
+		if err := tx.Db.QueryRow(tx.Ctx, query, args).Scan(&postID, &authorID, &status); err != nil {
+			return err
+		}
+		if status != ReportStatusOpen {
+			return ErrReportResolved
+		}
+		if !postID.Valid && (action == ModerationActionHide || action == ModerationActionDelete) {
+			return fmt.Errorf("%w: the reported post is deleted", ErrInvalidModerationAction)
+		}
+		args["post_id"] = postID
+		args["author_id"] = authorID
+
+		query = `
+			INSERT INTO moderation_actions (moderator_id, post_id, author_id, action, note)
+			VALUES (@moderator_id, @post_id, @author_id, @action, @note)
+			RETURNING id`
+		var actionID int
+		if err := tx.Db.QueryRow(tx.Ctx, query, args).Scan(&actionID); err != nil {
+			return err
+		}
+		args["action_id"] = actionID
+
+		query = `
+			UPDATE reports SET status = 'resolved', action_id = @action_id
+			WHERE status = 'open' AND (id = @report_id OR post_id = @post_id)`
+		if _, err := tx.Db.Exec(tx.Ctx, query, args); err != nil {
+			return err
+		}
+
+		var effect string
+		switch action {
+		case ModerationActionHide:
+			effect = `UPDATE posts SET hidden_at = CURRENT_TIMESTAMP WHERE id = @post_id AND hidden_at IS NULL`
+		case ModerationActionDelete:
+			effect = `DELETE FROM posts WHERE id = @post_id`
+		case ModerationActionSuspend:
+			// bumping the token version revokes the tokens and sessions of the author, see BumpTokenVersion
+			effect = `
+				UPDATE users SET suspended_at = coalesce(suspended_at, CURRENT_TIMESTAMP), token_version = token_version + 1
+				WHERE id = @author_id`
+		}
+		if effect != "" {
+			if _, err := tx.Db.Exec(tx.Ctx, effect, args); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		resolved, err = getReport(tx, reportID)
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, ErrReportResolved) && !errors.Is(err, ErrInvalidModerationAction) && !errors.Is(err, pgx.ErrNoRows) {
+			service.pg.Log.Error("Error resolving report", slog.String("err", err.Error()), slog.String("report_id", strconv.Itoa(reportID)))
+		}
+		return ReportDTO{}, err
+	}
+
+	return resolved, nil
+}
+
+func (filter ReportFilter) conditions(args pgx.NamedArgs) []string {
+	var conditions []string
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = @status")
+		args["status"] = filter.Status
+	}
+	if filter.Reason != "" {
+		conditions = append(conditions, "reason = @reason")
+		args["reason"] = filter.Reason
+	}
+	if filter.PostID != 0 {
+		conditions = append(conditions, "post_id = @post_id")
+		args["post_id"] = filter.PostID
+	}
+	if filter.AuthorID != 0 {
+		conditions = append(conditions, "author_id = @author_id")
+		args["author_id"] = filter.AuthorID
+	}
+
+	return conditions
+}
+
+func getReport(pg *DbPool, reportID int) (ReportDTO, error) {
+	reports, err := queryReports(pg, reportColumns+` WHERE id = @id`, pgx.NamedArgs{"id": reportID})
+	if err != nil {
+		return ReportDTO{}, err
+	}
+	if len(reports) == 0 {
+		return ReportDTO{}, pgx.ErrNoRows
+	}
+	return reports[0], nil
+}
+
+// queryReports runs a query selecting reportColumns.
+func queryReports(pg *DbPool, query string, args pgx.NamedArgs) ([]ReportDTO, error) {
+	rows, err := pg.Db.Query(pg.Ctx, query, args)
+	if err != nil {
+		pg.Log.Error("Error sql query getting reports", slog.String("err", err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	reports, err := pgx.CollectRows(rows, pgx.RowToStructByPos[ReportDTO])
+	if err != nil {
+		pg.Log.Error("Error scanning report", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,205 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReportPost(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, _ := newTestUser(t, pg)
+	reporter, _ := newTestUser(t, pg)
+	other, _ := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	moderation := NewModerationService(pg)
+
+	post, err := posts.CreatePost(PostDTO{Title: "reported", UserId: author})
+	require.NoError(t, err)
+
+	report, err := moderation.ReportPost(ReportDTO{PostId: post.Id, ReporterId: reporter, Reason: "spam", Details: "ads"})
+	require.NoError(t, err)
+	assert.Equal(t, post.Id, report.PostId)
+	assert.Equal(t, "reported", report.PostTitle)
+	assert.Equal(t, author, report.AuthorId)
+	assert.Equal(t, ReportStatusOpen, report.Status)
+	assert.False(t, report.ResolvedAt.Valid)
+
+	// reporting twice while the first report is open returns the first report
+	again, err := moderation.ReportPost(ReportDTO{PostId: post.Id, ReporterId: reporter, Reason: "hate"})
+	require.NoError(t, err)
+	assert.Equal(t, report.Id, again.Id)
+	_, err = moderation.ReportPost(ReportDTO{PostId: post.Id, ReporterId: other, Reason: "harassment"})
+	require.NoError(t, err)
+
+	_, err = moderation.ReportPost(ReportDTO{PostId: post.Id, ReporterId: reporter, Reason: "boring"})
+	assert.ErrorIs(t, err, ErrInvalidReport)
+	_, err = moderation.ReportPost(ReportDTO{PostId: post.Id, ReporterId: author, Reason: "spam"})
+	assert.ErrorIs(t, err, ErrInvalidReport)
+	_, err = moderation.ReportPost(ReportDTO{PostId: -1, ReporterId: reporter, Reason: "spam"})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	reports, cursor, err := moderation.GetReports(ReportFilter{AuthorID: author}, Page{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, report.Id, reports[0].Id)
+	reports, cursor, err = moderation.GetReports(ReportFilter{AuthorID: author}, Page{Limit: 1, Cursor: cursor})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, "harassment", reports[0].Reason)
+	assert.Empty(t, cursor)
+
+	reports, _, err = moderation.GetReports(ReportFilter{AuthorID: author, Reason: "spam"}, Page{})
+	require.NoError(t, err)
+	assert.Len(t, reports, 1)
+
+	_, _, err = moderation.GetReports(ReportFilter{}, Page{Sort: "reason"})
+	assert.ErrorIs(t, err, ErrInvalidPage)
+}
+
+func TestResolveReport(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, _ := newTestUser(t, pg)
+	reporter, _ := newTestUser(t, pg)
+	other, _ := newTestUser(t, pg)
+	moderator, _ := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	users := NewUserService(pg)
+	moderation := NewModerationService(pg)
+
+	create := func(title string) (PostDTO, ReportDTO) {
+		post, err := posts.CreatePost(PostDTO{Title: title, UserId: author})
+		require.NoError(t, err)
+		report, err := moderation.ReportPost(ReportDTO{PostId: post.Id, ReporterId: reporter, Reason: "spam"})
+		require.NoError(t, err)
+		return post, report
+	}
+
+	t.Run("Dismiss", func(t *testing.T) {
+		post, report := create("dismissed")
+		second, err := moderation.ReportPost(ReportDTO{PostId: post.Id, ReporterId: other, Reason: "spam"})
+		require.NoError(t, err)
+
+		resolved, err := moderation.ResolveReport(report.Id, moderator, ModerationActionDismiss, "not spam")
+		require.NoError(t, err)
+		assert.Equal(t, ReportStatusResolved, resolved.Status)
+		assert.Equal(t, ModerationActionDismiss, resolved.Action)
+		assert.Equal(t, moderator, resolved.ModeratorId)
+		assert.Equal(t, "not spam", resolved.Note)
+		assert.True(t, resolved.ResolvedAt.Valid)
+
+		// the action resolves every open report of the post
+		reports, _, err := moderation.GetReports(ReportFilter{PostID: post.Id, Status: ReportStatusOpen}, Page{})
+		require.NoError(t, err)
+		assert.Empty(t, reports)
+		reports, _, err = moderation.GetReports(ReportFilter{PostID: post.Id}, Page{Sort: "id", Order: SortDesc})
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		assert.Equal(t, second.Id, reports[0].Id)
+		assert.Equal(t, ModerationActionDismiss, reports[0].Action)
+
+		_, err = moderation.ResolveReport(report.Id, moderator, ModerationActionHide, "")
+		assert.ErrorIs(t, err, ErrReportResolved)
+	})
+
+	t.Run("Hide", func(t *testing.T) {
+		post, report := create("hidden")
+		_, err := moderation.ResolveReport(report.Id, moderator, ModerationActionHide, "spam link")
+		require.NoError(t, err)
+
+		hidden, err := posts.GetPost(post.Id)
+		require.NoError(t, err)
+		assert.True(t, hidden.HiddenAt.Valid)
+
+		canView, err := posts.CanViewPost(hidden, 0)
+		require.NoError(t, err)
+		assert.False(t, canView)
+		canView, err = posts.CanViewPost(hidden, reporter)
+		require.NoError(t, err)
+		assert.False(t, canView)
+		canView, err = posts.CanViewPost(hidden, author)
+		require.NoError(t, err)
+		assert.True(t, canView)
+
+		listed, _, err := posts.GetALlPosts(PostFilter{ViewerID: reporter, AuthorID: author}, Page{})
+		require.NoError(t, err)
+		assert.NotContains(t, postIDs(listed), post.Id)
+		listed, _, err = posts.GetALlPosts(PostFilter{ViewerID: author, AuthorID: author}, Page{})
+		require.NoError(t, err)
+		assert.Contains(t, postIDs(listed), post.Id)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		post, report := create("deleted")
+		resolved, err := moderation.ResolveReport(report.Id, moderator, ModerationActionDelete, "")
+		require.NoError(t, err)
+		assert.Zero(t, resolved.PostId)
+
+		_, err = posts.GetPost(post.Id)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Suspend", func(t *testing.T) {
+		_, report := create("suspended")
+		before, err := users.GetTokenVersion(author)
+		require.NoError(t, err)
+
+		_, err = moderation.ResolveReport(report.Id, moderator, ModerationActionSuspend, "repeated spam")
+		require.NoError(t, err)
+
+		suspended, err := users.GetUserById(author)
+		require.NoError(t, err)
+		assert.True(t, suspended.Suspended)
+		assert.Equal(t, before+1, suspended.TokenVersion)
+	})
+
+	t.Run("InvalidAction", func(t *testing.T) {
+		_, report := create("invalid")
+		_, err := moderation.ResolveReport(report.Id, moderator, "ban", "")
+		assert.ErrorIs(t, err, ErrInvalidModerationAction)
+		_, err = moderation.ResolveReport(-1, moderator, ModerationActionDismiss, "")
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
+
+func TestFlagPost(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, _ := newTestUser(t, pg)
+	reporter, _ := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	moderation := NewModerationService(pg)
+
+	post, err := posts.CreatePost(PostDTO{Title: "flagged", UserId: author})
+	require.NoError(t, err)
+
+	flagged, err := moderation.FlagPost(post.Id, `flagged_words: contains "crypto"`)
+	require.NoError(t, err)
+	assert.Equal(t, post.Id, flagged.PostId)
+	assert.Equal(t, author, flagged.AuthorId)
+	assert.Zero(t, flagged.ReporterId)
+	assert.Equal(t, ReportReasonFlagged, flagged.Reason)
+	assert.Equal(t, ReportStatusOpen, flagged.Status)
+
+	// flagging the post again updates the open flag report, reports of users are separate
+	_, err = moderation.ReportPost(ReportDTO{PostId: post.Id, ReporterId: reporter, Reason: "spam"})
+	require.NoError(t, err)
+	again, err := moderation.FlagPost(post.Id, `flagged_words: contains "nft"`)
+	require.NoError(t, err)
+	assert.Equal(t, flagged.Id, again.Id)
+	assert.Equal(t, `flagged_words: contains "nft"`, again.Details)
+
+	reports, _, err := moderation.GetReports(ReportFilter{PostID: post.Id}, Page{})
+	require.NoError(t, err)
+	assert.Len(t, reports, 2)
+
+	// once resolved, the next flag files a new report
+	_, err = moderation.ResolveReport(flagged.Id, reporter, ModerationActionDismiss, "")
+	require.NoError(t, err)
+	next, err := moderation.FlagPost(post.Id, `flagged_words: contains "crypto"`)
+	require.NoError(t, err)
+	assert.NotEqual(t, flagged.Id, next.Id)
+
+	_, err = moderation.FlagPost(-1, "")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
@@ -0,0 +1,212 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+
+	SortAsc  = "asc"
+	SortDesc = "desc"
+
+	cursorTimestampLayout = "2006-01-02T15:04:05.999999"
+	cursorDateLayout      = "2006-01-02"
+)
+
+// ErrInvalidPage is returned for unknown sort fields, orders and malformed cursors.
+var ErrInvalidPage = errors.New("invalid page")
+
+// Page is a keyset page request. Cursor is the next_cursor of the previous page,
+// Sort and Order fall back to the defaults of the listed resource when empty.
+type Page struct {
+	Limit  int
+	Cursor string
+	Sort   string
+	Order  string
+}
+
+// cursor points right after the last row of a page. It carries the sort and order
+// it was issued for, so a client can not mix cursors of different orderings.
+type cursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v,omitempty"`
+	Id    int    `json:"id"`
+}
+
+// sortColumn is a whitelisted sort field. parse converts the cursor value back into
+// a query argument, it is nil for the id column that is already carried by the cursor.
+type sortColumn struct {
+	column string
+	parse  func(value string) (any, error)
+}
+
+func parseText(value string) (any, error) {
+	return value, nil
+}
+
+func parseReal(value string) (any, error) {
+	f, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return nil, err
+	}
+	return float32(f), nil
+}
+
+func parseTimestamp(value string) (any, error) {
+	t, err := time.Parse(cursorTimestampLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return pgtype.Timestamp{Time: t, Valid: true}, nil
+}
+
+func parseDate(value string) (any, error) {
+	t, err := time.Parse(cursorDateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return pgtype.Date{Time: t, Valid: true}, nil
+}
+
+// keyset is a validated Page ready to be turned into SQL.
+type keyset struct {
+	sort   string
+	order  string
+	column sortColumn
+	limit  int
+	after  *cursor
+}
+
+func newKeyset(page Page, columns map[string]sortColumn, defaultSort, defaultOrder string) (keyset, error) {
+	ks := keyset{
+		sort:  page.Sort,
+		order: strings.ToLower(page.Order),
+		limit: page.Limit,
+	}
+
+	if page.Cursor != "" {
+		after, err := decodeCursor(page.Cursor)
+		if err != nil {
+			return keyset{}, err
+		}
+		if (ks.sort != "" && ks.sort != after.Sort) || (ks.order != "" && ks.order != after.Order) {
+			return keyset{}, fmt.Errorf("%w: cursor was issued for a different sort order", ErrInvalidPage)
+		}
+		ks.sort, ks.order, ks.after = after.Sort, after.Order, &after
+	}
+
+	if ks.sort == "" {
+		ks.sort = defaultSort
+	}
+	column, ok := columns[ks.sort]
+	if !ok {
+		return keyset{}, fmt.Errorf("%w: unknown sort field %q", ErrInvalidPage, ks.sort)
+	}
+	ks.column = column
+
+	if ks.order == "" {
+		ks.order = defaultOrder
+	}
+	if ks.order != SortAsc && ks.order != SortDesc {
+		return keyset{}, fmt.Errorf("%w: unknown sort order %q", ErrInvalidPage, ks.order)
+	}
+
+	if ks.limit <= 0 {
+		ks.limit = DefaultPageLimit
+	}
+	if ks.limit > MaxPageLimit {
+		ks.limit = MaxPageLimit
+	}
+
+	return ks, nil
+}
+
+// condition returns the clause selecting rows after the cursor, or "" on the first page.
+func (ks keyset) condition(args pgx.NamedArgs) (string, error) {
+	if ks.after == nil {
+		return "", nil
+	}
+
+	operator := ">"
+	if ks.order == SortDesc {
+		operator = "<"
+	}
+
+	args["cursor_id"] = ks.after.Id
+	if ks.column.parse == nil {
+		return fmt.Sprintf("id %s @cursor_id", operator), nil
+	}
+
+	value, err := ks.column.parse(ks.after.Value)
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed cursor value", ErrInvalidPage)
+	}
+	args["cursor_value"] = value
+
+	return fmt.Sprintf("(%s, id) %s (@cursor_value, @cursor_id)", ks.column.column, operator), nil
+}
+
+// orderBy uses id as the tie breaker, so the ordering is total and pages never overlap.
+func (ks keyset) orderBy() string {
+	if ks.column.parse == nil {
+		return fmt.Sprintf(" ORDER BY id %s", ks.order)
+	}
+	return fmt.Sprintf(" ORDER BY %s %s, id %s", ks.column.column, ks.order, ks.order)
+}
+
+// limitClause fetches one extra row to find out whether there is a next page.
+func (ks keyset) limitClause(args pgx.NamedArgs) string {
+	args["limit"] = ks.limit + 1
+	return " LIMIT @limit"
+}
+
+// nextCursor returns the cursor pointing after the row with the given sort value and id.
+func (ks keyset) nextCursor(value string, id int) string {
+	c := cursor{Sort: ks.sort, Order: ks.order, Id: id}
+	if ks.column.parse != nil {
+		c.Value = value
+	}
+	return encodeCursor(c)
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidPage)
+	}
+
+	var c cursor
+	if err = json.Unmarshal(data, &c); err != nil || c.Sort == "" {
+		return cursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidPage)
+	}
+
+	return c, nil
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// likePrefix escapes LIKE wildcards in prefix and turns it into a prefix pattern.
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewKeyset(t *testing.T) {
+	titleCursor := encodeCursor(cursor{Sort: "title", Order: SortAsc, Value: "Go", Id: 7})
+
+	tests := []struct {
+		name          string
+		page          Page
+		expectedErr   error
+		expectedSort  string
+		expectedOrder string
+		expectedLimit int
+	}{
+		{
+			name:          "Defaults",
+			page:          Page{},
+			expectedSort:  "created_at",
+			expectedOrder: SortDesc,
+			expectedLimit: DefaultPageLimit,
+		},
+		{
+			name:          "LimitIsClamped",
+			page:          Page{Limit: MaxPageLimit + 1, Sort: "title", Order: "ASC"},
+			expectedSort:  "title",
+			expectedOrder: SortAsc,
+			expectedLimit: MaxPageLimit,
+		},
+		{
+			name:          "CursorCarriesSortOrder",
+			page:          Page{Limit: 5, Cursor: titleCursor},
+			expectedSort:  "title",
+			expectedOrder: SortAsc,
+			expectedLimit: 5,
+		},
+		{
+			name:        "UnknownSortField",
+			page:        Page{Sort: "content"},
+			expectedErr: ErrInvalidPage,
+		},
+		{
+			name:        "UnknownSortOrder",
+			page:        Page{Order: "sideways"},
+			expectedErr: ErrInvalidPage,
+		},
+		{
+			name:        "MalformedCursor",
+			page:        Page{Cursor: "not a cursor"},
+			expectedErr: ErrInvalidPage,
+		},
+		{
+			name:        "CursorForDifferentSort",
+			page:        Page{Cursor: titleCursor, Sort: "created_at"},
+			expectedErr: ErrInvalidPage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := newKeyset(tt.page, postSortColumns, "created_at", SortDesc)
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSort, ks.sort)
+			assert.Equal(t, tt.expectedOrder, ks.order)
+			assert.Equal(t, tt.expectedLimit, ks.limit)
+		})
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 123456000, time.UTC)
+	post := PostDTO{Id: 42, CreatedAt: pgtype.Timestamp{Time: createdAt, Valid: true}}
+
+	first, err := newKeyset(Page{}, postSortColumns, "created_at", SortDesc)
+	assert.NoError(t, err)
+
+	args := pgx.NamedArgs{}
+	condition, err := first.condition(args)
+	assert.NoError(t, err)
+	assert.Empty(t, condition)
+	assert.Equal(t, " ORDER BY created_at desc, id desc", first.orderBy())
+
+	next, err := newKeyset(Page{Cursor: first.nextCursor(postCursorValue(first.sort, post), post.Id)}, postSortColumns, "created_at", SortDesc)
+	assert.NoError(t, err)
+
+	condition, err = next.condition(args)
+	assert.NoError(t, err)
+	assert.Equal(t, "(created_at, id) < (@cursor_value, @cursor_id)", condition)
+	assert.Equal(t, pgx.NamedArgs{
+		"cursor_id":    42,
+		"cursor_value": pgtype.Timestamp{Time: createdAt, Valid: true},
+	}, args)
+}
+
+func TestKeysetConditionById(t *testing.T) {
+	ks, err := newKeyset(Page{Cursor: encodeCursor(cursor{Sort: "id", Order: SortAsc, Id: 3})}, userSortColumns, "id", SortAsc)
+	assert.NoError(t, err)
+
+	args := pgx.NamedArgs{}
+	condition, err := ks.condition(args)
+	assert.NoError(t, err)
+	assert.Equal(t, "id > @cursor_id", condition)
+	assert.Equal(t, pgx.NamedArgs{"cursor_id": 3}, args)
+	assert.Equal(t, " ORDER BY id asc", ks.orderBy())
+}
+
+func TestLikePrefix(t *testing.T) {
+	assert.Equal(t, `50\%\_off\\%`, likePrefix(`50%_off\`))
+}
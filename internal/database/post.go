@@ -1,42 +1,122 @@
 package database
 
 import (
+	"errors"
+	"fmt"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"golang.org/x/sync/errgroup"
+	"go-rest-api-auth/internal/markup"
+	"go-rest-api-auth/internal/slug"
 	"log/slog"
 	"slices"
 	"strings"
 	"time"
 )
 
+const (
+	PostStatusDraft     = "draft"
+	PostStatusScheduled = "scheduled"
+	PostStatusPublished = "published"
+	PostStatusArchived  = "archived"
+)
+
+// ErrInvalidPostStatus is returned for post states a post can not be created or listed in.
+var ErrInvalidPostStatus = errors.New("invalid post status")
+
+// PostDTO is the storage representation of a post, see views.Post for the API one.
+// ContentHTML, Excerpt, WordCount and ReadingTime are rendered from Content on write, see markup.Render.
+// PublishedAt is when a published post went public, or when a scheduled one will.
+// Slug identifies the post in permalinks, see GetPostBySlug. Visibility decides who else
+// than the author reads the post, see CanViewPost. HiddenAt is set once a moderator hid
+// the post, which is then only shown to its author and admins.
 type PostDTO struct {
-	Id        int              `json:"id,omitempty"`
-	Title     string           `json:"title,omitempty"`
-	Content   string           `json:"content,omitempty"`
-	UserId    int              `json:"user_id,omitempty"`
-	CreatedAt pgtype.Timestamp `json:"created_at"`
-	Tags      []string         `json:"tags,omitempty"`
+	Id            int
+	Title         string
+	Slug          string
+	Content       string
+	ContentFormat string
+	ContentHTML   string
+	Excerpt       string
+	WordCount     int
+	ReadingTime   int
+	UserId        int
+	CreatedAt     pgtype.Timestamp
+	Status        string
+	PublishedAt   pgtype.Timestamp
+	Visibility    string
+	HiddenAt      pgtype.Timestamp
+	Tags          []string
+	CommentCount  int
+	Reactions     map[string]int
+	MyReactions   []string `db:"-"`
+}
+
+// PostFilter narrows the list of posts. Zero values are ignored,
+// CreatedTo is exclusive and a post has to carry every tag in Tags.
+// Tags may be aliases, with IncludeDescendants a tag also matches
+// posts carrying one of its descendant tags.
+// Posts that are not published are only listed to their author ViewerID,
+// the others as their visibility allows, see listedCondition. Posts of users
+// the viewer muted or blocked are left out, unless AuthorID asks for them.
+type PostFilter struct {
+	ViewerID           int
+	Status             string
+	AuthorID           int
+	Tags               []string
+	IncludeDescendants bool
+	CreatedFrom        time.Time
+	CreatedTo          time.Time
+	TitlePrefix        string
+}
+
+// postColumns selects a post together with its tags, comment count and reaction counts, aggregated
+// in the same statement so loading any number of posts costs a single query. tags is NULL for posts
+// without tags. MyReactions depends on the caller and is left to ReactionService.GetUserReactions.
+const postColumns = `id, title, coalesce(slug, '') AS slug, content, content_format, coalesce(content_html, '') AS content_html, excerpt, word_count, reading_time,
+user_id, created_at, status, published_at, visibility, hidden_at, (
+	SELECT array_agg(t.name ORDER BY t.name) FROM posts_tags pt JOIN tags t ON t.id = pt.tag_id WHERE pt.post_id = posts.id
+) AS tags, (
+	SELECT count(*) FROM comments c WHERE c.post_id = posts.id AND c.deleted_at IS NULL
+)::int AS comment_count, coalesce((
+	SELECT jsonb_object_agg(rc.type, rc.count) FROM post_reaction_counts rc WHERE rc.post_id = posts.id AND rc.count > 0
+), '{}') AS reactions`
+
+var postSortColumns = map[string]sortColumn{
+	"id":         {column: "id"},
+	"created_at": {column: "created_at", parse: parseTimestamp},
+	"title":      {column: "title", parse: parseText},
 }
 
 type PostServiceImplementation struct {
-	tagsService TagsService
-	pg          *DbPool
+	tagsService       TagsService
+	pg                *DbPool
+	maxTagsPerPost    int
+	revisionRetention int
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name PostService --output ../../testing/mocks
 type PostService interface {
 	CreatePost(post PostDTO) (PostDTO, error)
 	DeletePost(postID int) error
-	UpdatePost(post PostDTO) error
+	UpdatePost(post PostDTO, editorID int) error
 	GetPost(postID int) (PostDTO, error)
-	GetALlPosts() ([]PostDTO, error)
+	GetPostBySlug(slug string) (PostDTO, error)
+	CanViewPost(post PostDTO, viewerID int) (bool, error)
+	GetALlPosts(filter PostFilter, page Page) ([]PostDTO, string, error)
+	SearchPosts(text string, filter PostFilter, page Page) ([]PostSearchResultDTO, string, error)
+	PublishPost(postID int, publishAt time.Time) (PostDTO, error)
+	UnpublishPost(postID int, archive bool) (PostDTO, error)
+	PublishScheduled(batch int) (int, error)
+	RestoreRevision(postID, revision, editorID int) (PostDTO, error)
 }
 
-func NewPostService(pg *DbPool, tagsService TagsService) PostService {
+// NewPostService returns a service keeping the last revisionRetention revisions of every post, 0 keeps them all.
+func NewPostService(pg *DbPool, tagsService TagsService, maxTagsPerPost, revisionRetention int) PostService {
 	return &PostServiceImplementation{
-		tagsService: tagsService,
-		pg:          pg,
+		tagsService:       tagsService,
+		pg:                pg,
+		maxTagsPerPost:    maxTagsPerPost,
+		revisionRetention: revisionRetention,
 	}
 }
 
@@ -53,47 +133,123 @@ func (service *PostServiceImplementation) DeletePost(postID int) error {
 	return nil
 }
 
+// CreatePost inserts the post, its tags and relations in one transaction. A post is
+// published right away unless Status is PostStatusDraft, scheduling goes through PublishPost.
+// The content is rendered according to ContentFormat, plain text by default.
+// Slug is a custom slug, by default the post gets the slug of its title. Posts are public by default.
 func (service *PostServiceImplementation) CreatePost(post PostDTO) (PostDTO, error) {
 	var createdPost PostDTO
 
+	status := post.Status
+	if status == "" {
+		status = PostStatusPublished
+	}
+	if status != PostStatusPublished && status != PostStatusDraft {
+		return PostDTO{}, fmt.Errorf("%w: posts are created as %s or %s, not %q", ErrInvalidPostStatus, PostStatusPublished, PostStatusDraft, status)
+	}
+
+	visibility := post.Visibility
+	if visibility == "" {
+		visibility = PostVisibilityPublic
+	}
+	if err := validateVisibility(visibility); err != nil {
+		return PostDTO{}, err
+	}
+
+	format := post.ContentFormat
+	if format == "" {
+		format = markup.FormatPlain
+	}
+	rendered, err := markup.Render(format, post.Content)
+	if err != nil {
+		return PostDTO{}, err
+	}
+
 	createdAt := pgtype.Timestamp{
 		Time:  time.Now(),
 		Valid: true,
 	}
 
 	args := pgx.NamedArgs{
-		"title":      post.Title,
-		"content":    post.Content,
-		"user_id":    post.UserId,
-		"created_at": createdAt,
+		"title":          post.Title,
+		"content":        post.Content,
+		"user_id":        post.UserId,
+		"created_at":     createdAt,
+		"status":         status,
+		"visibility":     visibility,
+		"content_format": format,
+		"content_html":   rendered.HTML,
+		"excerpt":        rendered.Excerpt,
+		"word_count":     rendered.WordCount,
+		"reading_time":   rendered.ReadingTime,
 	}
 
-	query := `INSERT INTO posts (title, content, user_id, created_at) VALUES (@title, @content, @user_id, @created_at) RETURNING id, title, content, user_id, created_at`
+	query := `
+		INSERT INTO posts (title, content, user_id, created_at, status, published_at, visibility,
+			content_format, content_html, excerpt, word_count, reading_time)
+		VALUES (@title, @content, @user_id, @created_at, @status, CASE WHEN @status = 'published' THEN @created_at END, @visibility,
+			@content_format, @content_html, @excerpt, @word_count, @reading_time)
+		RETURNING id, title, content, content_format, content_html, excerpt, word_count, reading_time,
+			user_id, created_at, status, published_at, visibility`
 
-	err := service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(
-		&createdPost.Id,
-		&createdPost.Title,
-		&createdPost.Content,
-		&createdPost.UserId,
-		&createdPost.CreatedAt,
-	)
-	if err != nil {
-		service.pg.Log.Error("Error creating post", slog.String("err", err.Error()))
+	// fail before opening a transaction, CreateTagsToPost cleans the tags again
+	if _, err := service.cleanTags(post.Tags); err != nil {
 		return PostDTO{}, err
 	}
+	if post.Slug != "" {
+		if err := slug.Validate(post.Slug); err != nil {
+			return PostDTO{}, err
+		}
+	}
 
-	if post.Tags != nil {
-		err = service.CreateTagsToPost(&createdPost, post.Tags, false)
+	err = service.inTx(func(tx *PostServiceImplementation) error {
+		err := tx.pg.Db.QueryRow(tx.pg.Ctx, query, args).Scan(
+			&createdPost.Id,
+			&createdPost.Title,
+			&createdPost.Content,
+			&createdPost.ContentFormat,
+			&createdPost.ContentHTML,
+			&createdPost.Excerpt,
+			&createdPost.WordCount,
+			&createdPost.ReadingTime,
+			&createdPost.UserId,
+			&createdPost.CreatedAt,
+			&createdPost.Status,
+			&createdPost.PublishedAt,
+			&createdPost.Visibility,
+		)
 		if err != nil {
-			service.pg.Log.Error("Error add tags to post", slog.String("err", err.Error()))
-			return PostDTO{}, err
+			tx.pg.Log.Error("Error creating post", slog.String("err", err.Error()))
+			return err
+		}
+
+		createdPost.Slug, err = tx.setSlug(createdPost.Id, createdPost.Title, post.Slug)
+		if err != nil {
+			return err
+		}
+
+		if post.Tags != nil {
+			err = tx.CreateTagsToPost(&createdPost, post.Tags, false)
+			if err != nil {
+				tx.pg.Log.Error("Error add tags to post", slog.String("err", err.Error()))
+				return err
+			}
 		}
+
+		return tx.recordRevision(createdPost.Id, post.UserId)
+	})
+	if err != nil {
+		return PostDTO{}, err
 	}
 
 	return createdPost, nil
 }
 
-func (service *PostServiceImplementation) UpdatePost(post PostDTO) error {
+// UpdatePost updates the post and replaces its tags in one transaction, the result
+// is recorded as a new revision by editorID. A new content or ContentFormat renders the post again.
+// A custom Slug replaces the slug of the post, otherwise a new title gives it the slug of the title
+// unless its slug is a custom one. Previous slugs keep leading to the post. A Visibility changes who reads it.
+func (service *PostServiceImplementation) UpdatePost(post PostDTO, editorID int) error {
 	query := `UPDATE posts SET `
 	var setClauses []string
 	args := pgx.NamedArgs{"id": post.Id}
@@ -106,30 +262,93 @@ func (service *PostServiceImplementation) UpdatePost(post PostDTO) error {
 		setClauses = append(setClauses, "content = @content")
 		args["content"] = post.Content
 	}
-	if post.Tags != nil {
-		err := service.CreateTagsToPost(&PostDTO{Id: post.Id, Tags: nil}, post.Tags, true)
-		if err != nil {
-			service.pg.Log.Error("Error adding tags to post update", slog.String("err", err.Error()))
+	if post.ContentFormat != "" {
+		if err := markup.ValidateFormat(post.ContentFormat); err != nil {
 			return err
 		}
+		setClauses = append(setClauses, "content_format = @content_format")
+		args["content_format"] = post.ContentFormat
+	}
+	if post.Visibility != "" {
+		if err := validateVisibility(post.Visibility); err != nil {
+			return err
+		}
+		setClauses = append(setClauses, "visibility = @visibility")
+		args["visibility"] = post.Visibility
 	}
 
 	query += strings.Join(setClauses, ", ") + " WHERE id = @id"
 
-	_, err := service.pg.Db.Exec(service.pg.Ctx, query, args)
-	if err != nil {
-		service.pg.Log.Error("Error updating post", slog.String("err", err.Error()))
+	if _, err := service.cleanTags(post.Tags); err != nil {
 		return err
 	}
-	return nil
+	if post.Slug != "" {
+		if err := slug.Validate(post.Slug); err != nil {
+			return err
+		}
+	}
+
+	return service.inTx(func(tx *PostServiceImplementation) error {
+		if err := tx.lockPost(post.Id); err != nil {
+			return err
+		}
+		if err := tx.recordBaseline(post.Id); err != nil {
+			return err
+		}
+
+		if len(setClauses) > 0 {
+			_, err := tx.pg.Db.Exec(tx.pg.Ctx, query, args)
+			if err != nil {
+				tx.pg.Log.Error("Error updating post", slog.String("err", err.Error()))
+				return err
+			}
+		}
+
+		if post.Content != "" || post.ContentFormat != "" {
+			if err := tx.renderPost(post.Id); err != nil {
+				return err
+			}
+		}
+
+		if post.Slug != "" {
+			if _, err := tx.setSlug(post.Id, "", post.Slug); err != nil {
+				return err
+			}
+		} else if post.Title != "" {
+			if err := tx.refreshSlug(post.Id); err != nil {
+				return err
+			}
+		}
+
+		if post.Tags != nil {
+			err := tx.CreateTagsToPost(&PostDTO{Id: post.Id, Tags: nil}, post.Tags, true)
+			if err != nil {
+				tx.pg.Log.Error("Error adding tags to post update", slog.String("err", err.Error()))
+				return err
+			}
+		}
+
+		return tx.recordRevision(post.Id, editorID)
+	})
+}
+
+// inTx runs fn with a copy of the service bound to a transaction, see DbPool.InTx.
+func (service *PostServiceImplementation) inTx(fn func(tx *PostServiceImplementation) error) error {
+	return service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		return fn(&PostServiceImplementation{
+			tagsService:       NewTagService(tx),
+			pg:                tx,
+			maxTagsPerPost:    service.maxTagsPerPost,
+			revisionRetention: service.revisionRetention,
+		})
+	})
 }
 
 func (service *PostServiceImplementation) GetPost(postID int) (PostDTO, error) {
-	query := `SELECT id, title, content, user_id, created_at FROM posts WHERE id = @id`
+	query := `SELECT ` + postColumns + ` FROM posts WHERE id = @id`
 	args := pgx.NamedArgs{"id": postID}
-	row := service.pg.Db.QueryRow(service.pg.Ctx, query, args)
 	post := PostDTO{}
-	err := row.Scan(&post.Id, &post.Title, &post.Content, &post.UserId, &post.CreatedAt)
+	err := scanPost(service.pg.Db.QueryRow(service.pg.Ctx, query, args), &post)
 	if err != nil {
 		service.pg.Log.Error("Error getting post", slog.String("err", err.Error()))
 		return PostDTO{}, err
@@ -138,95 +357,204 @@ func (service *PostServiceImplementation) GetPost(postID int) (PostDTO, error) {
 	return post, nil
 }
 
-func (service *PostServiceImplementation) GetALlPosts() ([]PostDTO, error) {
-	query := `SELECT id, title, content, user_id, created_at FROM posts`
+// GetALlPosts returns a page of posts matching filter and the cursor of the next page,
+// which is empty on the last one. Posts are sorted by created_at descending by default.
+func (service *PostServiceImplementation) GetALlPosts(filter PostFilter, page Page) ([]PostDTO, string, error) {
+	ks, err := newKeyset(page, postSortColumns, "created_at", SortDesc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	args := pgx.NamedArgs{}
+	conditions := filter.conditions(args)
+	condition, err := ks.condition(args)
+	if err != nil {
+		return nil, "", err
+	}
+	if condition != "" {
+		conditions = append(conditions, condition)
+	}
 
-	rows, err := service.pg.Db.Query(service.pg.Ctx, query)
+	query := `SELECT ` + postColumns + ` FROM posts` + whereClause(conditions) + ks.orderBy() + ks.limitClause(args)
+	posts, err := queryPosts(service.pg, query, args)
 	if err != nil {
-		service.pg.Log.Error("Error sql query getting all posts", slog.String("err", err.Error()))
+		return nil, "", err
+	}
+
+	if len(posts) <= ks.limit {
+		return posts, "", nil
+	}
+
+	posts = posts[:ks.limit]
+	last := posts[len(posts)-1]
+	return posts, ks.nextCursor(postCursorValue(ks.sort, last), last.Id), nil
+}
+
+func (filter PostFilter) conditions(args pgx.NamedArgs) []string {
+	conditions := []string{listedCondition}
+	args["viewer_id"] = filter.ViewerID
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = @status")
+		args["status"] = filter.Status
+	}
+
+	if filter.AuthorID != 0 {
+		conditions = append(conditions, "user_id = @author_id")
+		args["author_id"] = filter.AuthorID
+	} else {
+		conditions = append(conditions, "NOT "+viewerSilenced("posts.user_id"))
+	}
+	if tags := normalizeTags(filter.Tags); len(tags) > 0 {
+		// matched pairs every tag a post may carry with the requested name it stands for,
+		// UNION drops repeated rows, so the walk down the tree ends even on a broken hierarchy
+		conditions = append(conditions, `id IN (
+			WITH RECURSIVE matched AS (
+				SELECT id, normalized AS requested FROM tags WHERE normalized = ANY(@tags)
+				UNION
+				SELECT tag_id, normalized FROM tag_aliases WHERE normalized = ANY(@tags)
+				UNION
+				SELECT t.id, m.requested FROM tags t JOIN matched m ON t.parent_id = m.id WHERE @descendants::boolean
+			)
+			SELECT pt.post_id FROM posts_tags pt JOIN matched m ON m.id = pt.tag_id
+			GROUP BY pt.post_id HAVING COUNT(DISTINCT m.requested) = @tags_count
+		)`)
+		args["tags"] = tags
+		args["tags_count"] = len(tags)
+		args["descendants"] = filter.IncludeDescendants
+	}
+	if !filter.CreatedFrom.IsZero() {
+		conditions = append(conditions, "created_at >= @created_from")
+		args["created_from"] = pgtype.Timestamp{Time: filter.CreatedFrom, Valid: true}
+	}
+	if !filter.CreatedTo.IsZero() {
+		conditions = append(conditions, "created_at < @created_to")
+		args["created_to"] = pgtype.Timestamp{Time: filter.CreatedTo, Valid: true}
+	}
+	if filter.TitlePrefix != "" {
+		conditions = append(conditions, "title ILIKE @title_prefix")
+		args["title_prefix"] = likePrefix(filter.TitlePrefix)
+	}
+
+	return conditions
+}
+
+func postCursorValue(sort string, post PostDTO) string {
+	switch sort {
+	case "created_at":
+		return post.CreatedAt.Time.Format(cursorTimestampLayout)
+	case "title":
+		return post.Title
+	default:
+		return ""
+	}
+}
+
+// normalizeTags returns the distinct normalized forms of tags.
+func normalizeTags(tags []string) []string {
+	var result []string
+	for _, tag := range tags {
+		if tag = NormalizeTag(tag); tag != "" && !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// scanPost scans a row selecting postColumns.
+func scanPost(row pgx.Row, post *PostDTO) error {
+	return row.Scan(
+		&post.Id,
+		&post.Title,
+		&post.Slug,
+		&post.Content,
+		&post.ContentFormat,
+		&post.ContentHTML,
+		&post.Excerpt,
+		&post.WordCount,
+		&post.ReadingTime,
+		&post.UserId,
+		&post.CreatedAt,
+		&post.Status,
+		&post.PublishedAt,
+		&post.Visibility,
+		&post.HiddenAt,
+		&post.Tags,
+		&post.CommentCount,
+		&post.Reactions,
+	)
+}
+
+// queryPosts runs a query selecting postColumns.
+func queryPosts(pg *DbPool, query string, args pgx.NamedArgs) ([]PostDTO, error) {
+	rows, err := pg.Db.Query(pg.Ctx, query, args)
+	if err != nil {
+		pg.Log.Error("Error sql query getting posts", slog.String("err", err.Error()))
 		return nil, err
 	}
 	defer rows.Close()
 
-	var posts []PostDTO
-	for rows.Next() {
-		var post PostDTO
+	posts, err := pgx.CollectRows(rows, pgx.RowToStructByPos[PostDTO])
+	if err != nil {
+		pg.Log.Error("Error scanning post", slog.String("err", err.Error()))
+		return nil, err
+	}
 
-		err = rows.Scan(&post.Id, &post.Title, &post.Content, &post.UserId, &post.CreatedAt)
-		if err != nil {
-			service.pg.Log.Error("Error scanning post", slog.String("err", err.Error()))
-			return nil, err
-		}
+	return posts, nil
+}
 
-		tagsDto, err := service.tagsService.GetPostTagsRelation(post.Id)
-		if err != nil {
-			service.pg.Log.Error("Error getting post tags relation", slog.String("err", err.Error()))
+// cleanTags cleans tags and drops the ones normalizing to an already listed tag.
+// It fails when a tag is invalid or there are more than maxTagsPerPost of them.
+func (service *PostServiceImplementation) cleanTags(tags []string) ([]string, error) {
+	var cleaned, normalized []string
+	for _, tag := range tags {
+		name := CleanTagName(tag)
+		if err := ValidateTagName(name); err != nil {
 			return nil, err
 		}
-		for _, tag := range tagsDto {
-			post.Tags = append(post.Tags, tag.Name)
+		if slices.Contains(normalized, NormalizeTag(name)) {
+			continue
 		}
+		cleaned = append(cleaned, name)
+		normalized = append(normalized, NormalizeTag(name))
+	}
 
-		posts = append(posts, post)
+	if len(cleaned) > service.maxTagsPerPost {
+		return nil, fmt.Errorf("%w: a post can have at most %d tags", ErrInvalidTag, service.maxTagsPerPost)
 	}
 
-	return posts, nil
+	return cleaned, nil
 }
 
+// CreateTagsToPost links tags to post, creating the missing ones. The statements run one
+// after another because a transaction owns a single connection, call it from inTx.
 func (service *PostServiceImplementation) CreateTagsToPost(post *PostDTO, tags []string, removeAll bool) error {
-	tagsChan := make(chan TagsDTO, len(tags))
-	eg, ctx := errgroup.WithContext(service.pg.Ctx)
+	tags, err := service.cleanTags(tags)
+	if err != nil {
+		return err
+	}
 
 	if removeAll {
-		if err := service.tagsService.DeletePostTagsRelation(post.Id); err != nil {
+		if err = service.tagsService.DeletePostTagsRelation(post.Id); err != nil {
 			service.pg.Log.Error("error deleting tags relation", slog.String("err", err.Error()))
 			return err
 		}
 	}
 
 	for _, tag := range tags {
-		tag := tag
-		eg.Go(func() error {
-			tagDTO, err := service.tagsService.GetTagByName(tag)
-			if err != nil {
-				tagDTO, err = service.tagsService.CreateTag(TagsDTO{Name: tag})
-				if err != nil {
-					service.pg.Log.Error("error creating tag", slog.String("err", err.Error()))
-					return err
-				}
-			}
-
-			select {
-			case tagsChan <- tagDTO:
-				return nil
-			case <-ctx.Done():
-				return ctx.Err()
-			}
-		})
-	}
-
-	go func() {
-		_ = eg.Wait()
-		close(tagsChan)
-	}()
+		tagDTO, err := service.tagsService.UpsertTag(tag)
+		if err != nil {
+			service.pg.Log.Error("error upserting tag", slog.String("err", err.Error()))
+			return err
+		}
 
-	for tag := range tagsChan {
-		tag := tag
-		if !slices.Contains(post.Tags, tag.Name) {
-			post.Tags = append(post.Tags, tag.Name)
-			eg.Go(func() error {
-				err := service.tagsService.CreatePostTagsRelation(tag, *post)
-				if err != nil {
-					service.pg.Log.Error("error creating post-tag relation", slog.String("err", err.Error()))
-					return err
-				}
-				return nil
-			})
+		err = service.tagsService.CreatePostTagsRelation(tagDTO, *post)
+		if err != nil {
+			service.pg.Log.Error("error creating post-tag relation", slog.String("err", err.Error()))
+			return err
 		}
+		post.Tags = append(post.Tags, tagDTO.Name)
 	}
 
-	if err := eg.Wait(); err != nil {
-		return err
-	}
 	return nil
 }
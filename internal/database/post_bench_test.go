@@ -0,0 +1,130 @@
+package database
+
+import (
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"testing"
+)
+
+const (
+	benchPosts       = 100
+	benchTagsPerPost = 5
+)
+
+// newBenchPool seeds one author with benchPosts posts carrying benchTagsPerPost tags each.
+func newBenchPool(b *testing.B) (*DbPool, *queryCounter, int) {
+	pg, counter := newTestPool(b)
+	userID, prefix := newTestUser(b, pg)
+	ctx, db := pg.Ctx, pg.Db
+
+	for i := 0; i < benchPosts; i++ {
+		var postID int
+		err := db.QueryRow(ctx, `INSERT INTO posts (title, user_id) VALUES ($1, $2) RETURNING id`, fmt.Sprintf("post %d", i), userID).Scan(&postID)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for j := 0; j < benchTagsPerPost; j++ {
+			_, err = db.Exec(ctx, `
+				WITH tag AS (
+					INSERT INTO tags (name) VALUES ($1) ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id
+				)
+				INSERT INTO posts_tags (post_id, tag_id) SELECT $2, id FROM tag`, fmt.Sprintf("%s-%d", prefix, j), postID)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	return pg, counter, userID
+}
+
+// loadPostsPerRow is how posts used to be loaded: one query for the posts,
+// then one for the tag ids of every post and one more for every tag.
+func loadPostsPerRow(pg *DbPool, tags TagsService, userID int) ([]PostDTO, error) {
+	rows, err := pg.Db.Query(pg.Ctx, `SELECT id, title, content, user_id, created_at FROM posts WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (PostDTO, error) {
+		var post PostDTO
+		err := row.Scan(&post.Id, &post.Title, &post.Content, &post.UserId, &post.CreatedAt)
+		return post, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range posts {
+		rows, err = pg.Db.Query(pg.Ctx, `SELECT tag_id FROM posts_tags WHERE post_id = $1`, posts[i].Id)
+		if err != nil {
+			return nil, err
+		}
+		tagIDs, err := pgx.CollectRows(rows, pgx.RowTo[int])
+		if err != nil {
+			return nil, err
+		}
+		for _, tagID := range tagIDs {
+			tag, err := tags.GetTagByID(tagID)
+			if err != nil {
+				return nil, err
+			}
+			posts[i].Tags = append(posts[i].Tags, tag.Name)
+		}
+	}
+
+	return posts, nil
+}
+
+func reportQueries(b *testing.B, counter *queryCounter) {
+	b.ReportMetric(float64(counter.queries.Load())/float64(b.N), "queries/op")
+}
+
+func BenchmarkLoadPostsPerRow(b *testing.B) {
+	pg, counter, userID := newBenchPool(b)
+	tags := NewTagService(pg)
+
+	counter.queries.Store(0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		posts, err := loadPostsPerRow(pg, tags, userID)
+		if err != nil || len(posts) != benchPosts {
+			b.Fatal(err, len(posts))
+		}
+	}
+	reportQueries(b, counter)
+}
+
+func BenchmarkGetALlPosts(b *testing.B) {
+	pg, counter, userID := newBenchPool(b)
+	service := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+
+	counter.queries.Store(0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		posts, _, err := service.GetALlPosts(PostFilter{AuthorID: userID}, Page{Limit: benchPosts})
+		if err != nil || len(posts) != benchPosts || len(posts[0].Tags) != benchTagsPerPost {
+			b.Fatal(err, len(posts))
+		}
+	}
+	reportQueries(b, counter)
+}
+
+func BenchmarkGetPost(b *testing.B) {
+	pg, counter, userID := newBenchPool(b)
+	service := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+
+	posts, _, err := service.GetALlPosts(PostFilter{AuthorID: userID}, Page{Limit: 1})
+	if err != nil || len(posts) != 1 {
+		b.Fatal(err)
+	}
+
+	counter.queries.Store(0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		post, err := service.GetPost(posts[0].Id)
+		if err != nil || len(post.Tags) != benchTagsPerPost {
+			b.Fatal(err, post.Tags)
+		}
+	}
+	reportQueries(b, counter)
+}
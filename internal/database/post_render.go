@@ -0,0 +1,88 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/markup"
+	"log/slog"
+	"strconv"
+)
+
+// renderBatch is how many posts renderPendingPosts loads at once.
+const renderBatch = 100
+
+// renderPost renders the current content of a post and stores the result with it,
+// call it from inTx after changing the content or its format.
+func (service *PostServiceImplementation) renderPost(postID int) error {
+	var content, format string
+	query := `SELECT coalesce(content, ''), content_format FROM posts WHERE id = @id`
+	err := service.pg.Db.QueryRow(service.pg.Ctx, query, pgx.NamedArgs{"id": postID}).Scan(&content, &format)
+	if err != nil {
+		service.pg.Log.Error("Error loading post to render", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return err
+	}
+
+	rendered, err := markup.Render(format, content)
+	if err != nil {
+		return err
+	}
+
+	return storeRendered(service.pg, postID, rendered)
+}
+
+func storeRendered(pg *DbPool, postID int, rendered markup.Rendered) error {
+	query := `
+		UPDATE posts SET content_html = @content_html, excerpt = @excerpt, word_count = @word_count, reading_time = @reading_time
+		WHERE id = @id`
+	args := pgx.NamedArgs{
+		"id":           postID,
+		"content_html": rendered.HTML,
+		"excerpt":      rendered.Excerpt,
+		"word_count":   rendered.WordCount,
+		"reading_time": rendered.ReadingTime,
+	}
+
+	_, err := pg.Db.Exec(pg.Ctx, query, args)
+	if err != nil {
+		pg.Log.Error("Error storing rendered post", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return err
+	}
+	return nil
+}
+
+// renderPendingPosts renders the posts stored before contents were rendered on write,
+// they have no content_html yet. It returns how many posts it rendered.
+func renderPendingPosts(pg *DbPool) (int, error) {
+	rendered := 0
+	for {
+		query := `SELECT id, coalesce(content, ''), content_format FROM posts WHERE content_html IS NULL ORDER BY id LIMIT @limit`
+		rows, err := pg.Db.Query(pg.Ctx, query, pgx.NamedArgs{"limit": renderBatch})
+		if err != nil {
+			return rendered, err
+		}
+
+		type pending struct {
+			Id      int
+			Content string
+			Format  string
+		}
+		posts, err := pgx.CollectRows(rows, pgx.RowToStructByPos[pending])
+		if err != nil {
+			return rendered, err
+		}
+
+		for _, post := range posts {
+			result, err := markup.Render(post.Format, post.Content)
+			if err != nil {
+				return rendered, err
+			}
+			if err = storeRendered(pg, post.Id, result); err != nil {
+				return rendered, err
+			}
+			rendered++
+		}
+
+		if len(posts) < renderBatch {
+			return rendered, nil
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-rest-api-auth/internal/markup"
+	"testing"
+)
+
+func TestPostRendering(t *testing.T) {
+	pg, _ := newTestPool(t)
+	userID, _ := newTestUser(t, pg)
+	service := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+
+	content := "# Hi\n\nsome *text* <script>x()</script>"
+	created, err := service.CreatePost(PostDTO{Title: "markdown", Content: content, ContentFormat: markup.FormatMarkdown, UserId: userID})
+	require.NoError(t, err)
+	assert.Equal(t, markup.FormatMarkdown, created.ContentFormat)
+	assert.Equal(t, "<h1>Hi</h1>\n\n<p>some <em>text</em> </p>\n", created.ContentHTML)
+	assert.Equal(t, "Hi some text", created.Excerpt)
+	assert.Equal(t, 3, created.WordCount)
+	assert.Equal(t, 1, created.ReadingTime)
+
+	_, err = service.CreatePost(PostDTO{Title: "html", Content: "<p>x</p>", ContentFormat: "html", UserId: userID})
+	assert.ErrorIs(t, err, markup.ErrInvalidFormat)
+
+	// switching the format renders the same content again
+	require.NoError(t, service.UpdatePost(PostDTO{Id: created.Id, ContentFormat: markup.FormatPlain}, userID))
+	post, err := service.GetPost(created.Id)
+	require.NoError(t, err)
+	assert.Equal(t, markup.FormatPlain, post.ContentFormat)
+	assert.Equal(t, "<p># Hi</p>\n<p>some *text* &lt;script&gt;x()&lt;/script&gt;</p>\n", post.ContentHTML)
+
+	// a restored revision brings its format back
+	restored, err := service.RestoreRevision(created.Id, 1, userID)
+	require.NoError(t, err)
+	assert.Equal(t, markup.FormatMarkdown, restored.ContentFormat)
+	assert.Equal(t, created.ContentHTML, restored.ContentHTML)
+
+	// posts stored before rendering on write are rendered by renderPendingPosts
+	_, err = pg.Db.Exec(pg.Ctx, `UPDATE posts SET content_html = NULL, excerpt = '', word_count = 0 WHERE id = $1`, created.Id)
+	require.NoError(t, err)
+	rendered, err := renderPendingPosts(pg)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, rendered, 1)
+
+	post, err = service.GetPost(created.Id)
+	require.NoError(t, err)
+	assert.Equal(t, created.ContentHTML, post.ContentHTML)
+	assert.Equal(t, 3, post.WordCount)
+}
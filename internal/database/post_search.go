@@ -0,0 +1,106 @@
+package database
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+const (
+	// searchConfig is the text search configuration of the posts.search column.
+	searchConfig = "english"
+
+	titleHeadlineOptions   = "HighlightAll=true"
+	snippetHeadlineOptions = "MaxFragments=2, MinWords=10, MaxWords=30, FragmentDelimiter=\" … \""
+)
+
+// ErrEmptySearch is returned for a search text without any word to look for.
+var ErrEmptySearch = errors.New("empty search query")
+
+// PostSearchResultDTO is a post matching a search. TitleHighlight and Snippet
+// wrap the matched words in <b></b>, Snippet holds the best fragments of the content.
+type PostSearchResultDTO struct {
+	PostDTO
+	Rank           float32
+	TitleHighlight string
+	Snippet        string
+}
+
+var searchSortColumns = map[string]sortColumn{
+	"rank":       {column: "rank", parse: parseReal},
+	"id":         {column: "id"},
+	"created_at": {column: "created_at", parse: parseTimestamp},
+}
+
+// SearchPosts runs a full-text search over post titles and contents. text uses the
+// websearch syntax: quoted phrases, OR and -excluded words. Title matches rank above
+// content matches, results are ordered by rank unless page asks for another order.
+func (service *PostServiceImplementation) SearchPosts(text string, filter PostFilter, page Page) ([]PostSearchResultDTO, string, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, "", ErrEmptySearch
+	}
+
+	ks, err := newKeyset(page, searchSortColumns, "rank", SortDesc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	args := pgx.NamedArgs{
+		"config":          searchConfig,
+		"text":            text,
+		"title_options":   titleHeadlineOptions,
+		"snippet_options": snippetHeadlineOptions,
+	}
+	conditions := append(filter.conditions(args), "search @@ q.query")
+	condition, err := ks.condition(args)
+	if err != nil {
+		return nil, "", err
+	}
+	var after []string
+	if condition != "" {
+		after = append(after, condition)
+	}
+
+	// headlines are expensive, so they are only built for the rows of the page
+	query := `
+		WITH q AS (SELECT websearch_to_tsquery(@config::regconfig, @text) AS query)
+		SELECT id, title, slug, content, content_format, content_html, excerpt, word_count, reading_time,
+			user_id, created_at, status, published_at, visibility, hidden_at, tags, comment_count, reactions, rank,
+			ts_headline(@config::regconfig, title, q.query, @title_options),
+			ts_headline(@config::regconfig, content, q.query, @snippet_options)
+		FROM (
+			SELECT * FROM (
+				SELECT ` + postColumns + `, ts_rank_cd(search, q.query) AS rank FROM posts, q` + whereClause(conditions) + `
+			) matches` + whereClause(after) + ks.orderBy() + ks.limitClause(args) + `
+		) results, q` + ks.orderBy()
+
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error sql query searching posts", slog.String("err", err.Error()))
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	results, err := pgx.CollectRows(rows, pgx.RowToStructByPos[PostSearchResultDTO])
+	if err != nil {
+		service.pg.Log.Error("Error scanning post search result", slog.String("err", err.Error()))
+		return nil, "", err
+	}
+
+	if len(results) <= ks.limit {
+		return results, "", nil
+	}
+
+	results = results[:ks.limit]
+	last := results[len(results)-1]
+	return results, ks.nextCursor(searchCursorValue(ks.sort, last), last.Id), nil
+}
+
+func searchCursorValue(sort string, result PostSearchResultDTO) string {
+	if sort == "rank" {
+		return strconv.FormatFloat(float64(result.Rank), 'g', -1, 32)
+	}
+	return postCursorValue(sort, result.PostDTO)
+}
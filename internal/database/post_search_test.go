@@ -0,0 +1,61 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSearchPostsRejectsEmptyQuery(t *testing.T) {
+	service := &PostServiceImplementation{}
+
+	_, _, err := service.SearchPosts("  ", PostFilter{}, Page{})
+	assert.ErrorIs(t, err, ErrEmptySearch)
+}
+
+func TestSearchPosts(t *testing.T) {
+	pg, _ := newTestPool(t)
+	userID, prefix := newTestUser(t, pg)
+	service := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+
+	inTitle, err := service.CreatePost(PostDTO{Title: "Tuning connection pools", Content: "Pick the size carefully.", UserId: userID, Tags: []string{prefix + "-go"}})
+	require.NoError(t, err)
+	inContent, err := service.CreatePost(PostDTO{Title: "Notes", Content: "The connection pool was too small, so we grew it.", UserId: userID})
+	require.NoError(t, err)
+	_, err = service.CreatePost(PostDTO{Title: "MySQL connection pools", Content: "Not relevant here.", UserId: userID})
+	require.NoError(t, err)
+
+	filter := PostFilter{AuthorID: userID}
+
+	results, next, err := service.SearchPosts(`"connection pool" -mysql`, filter, Page{})
+	require.NoError(t, err)
+	assert.Empty(t, next)
+	require.Len(t, results, 2)
+	assert.Equal(t, inTitle.Id, results[0].Id, "title matches rank first")
+	assert.Equal(t, inContent.Id, results[1].Id)
+	assert.Greater(t, results[0].Rank, results[1].Rank)
+	assert.Contains(t, results[0].TitleHighlight, "<b>connection</b>")
+	assert.Contains(t, results[1].Snippet, "<b>connection</b> <b>pool</b>")
+
+	first, next, err := service.SearchPosts("connection", filter, Page{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	require.NotEmpty(t, next)
+
+	seen := map[int]bool{first[0].Id: true}
+	for next != "" {
+		var page []PostSearchResultDTO
+		page, next, err = service.SearchPosts("connection", filter, Page{Limit: 1, Cursor: next})
+		require.NoError(t, err)
+		for _, result := range page {
+			assert.False(t, seen[result.Id], "pages must not overlap")
+			seen[result.Id] = true
+		}
+	}
+	assert.Len(t, seen, 3)
+
+	results, _, err = service.SearchPosts("connection", PostFilter{AuthorID: userID, Tags: []string{prefix + "-go"}}, Page{})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, inTitle.Id, results[0].Id)
+}
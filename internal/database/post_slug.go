@@ -0,0 +1,191 @@
+package database
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/slug"
+	"log/slog"
+	"strconv"
+)
+
+// slugBatch is how many posts slugPendingPosts loads at once.
+const slugBatch = 100
+
+// ErrSlugTaken is returned for a custom slug another post has, or had before a title change.
+var ErrSlugTaken = errors.New("slug already taken")
+
+// GetPostBySlug returns the post with the given slug, or the one that had it before.
+// Slug of the result is the current one, which differs from slug for a previous one.
+func (service *PostServiceImplementation) GetPostBySlug(postSlug string) (PostDTO, error) {
+	query := `SELECT ` + postColumns + ` FROM posts WHERE id = (SELECT post_id FROM post_slugs WHERE slug = @slug)`
+	args := pgx.NamedArgs{"slug": postSlug}
+	post := PostDTO{}
+	err := scanPost(service.pg.Db.QueryRow(service.pg.Ctx, query, args), &post)
+	if err != nil {
+		service.pg.Log.Error("Error getting post by slug", slog.String("err", err.Error()), slog.String("slug", postSlug))
+		return PostDTO{}, err
+	}
+
+	return post, nil
+}
+
+// setSlug gives a post the custom slug when there is one, otherwise the slug of its title,
+// suffixed with -2, -3... when other posts have or had it. Every slug a post gets is kept
+// in post_slugs, so links with a previous one still find the post. It returns the new slug.
+func (service *PostServiceImplementation) setSlug(postID int, title, custom string) (string, error) {
+	postSlug := custom
+	if custom != "" {
+		claimed, err := service.claimSlug(postID, custom)
+		if err != nil {
+			return "", err
+		}
+		if !claimed {
+			return "", ErrSlugTaken
+		}
+	} else {
+		base := slug.Make(title)
+		taken, err := service.takenSlugs(postID, base)
+		if err != nil {
+			return "", err
+		}
+
+		for n := 1; ; n++ {
+			postSlug = base
+			if n > 1 {
+				postSlug = slug.WithSuffix(base, n)
+			}
+			if taken[postSlug] {
+				continue
+			}
+
+			claimed, err := service.claimSlug(postID, postSlug)
+			if err != nil {
+				return "", err
+			}
+			if claimed {
+				break
+			}
+			// claimed by a concurrent transaction meanwhile
+			taken[postSlug] = true
+		}
+	}
+
+	query := `UPDATE posts SET slug = @slug, custom_slug = @custom WHERE id = @id`
+	args := pgx.NamedArgs{
+		"id":     postID,
+		"slug":   postSlug,
+		"custom": custom != "",
+	}
+	if _, err := service.pg.Db.Exec(service.pg.Ctx, query, args); err != nil {
+		service.pg.Log.Error("Error setting post slug", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return "", err
+	}
+
+	return postSlug, nil
+}
+
+// refreshSlug gives a post the slug of its current title, call it from inTx after changing
+// the title. Custom slugs are kept.
+func (service *PostServiceImplementation) refreshSlug(postID int) error {
+	var title string
+	var custom bool
+	query := `SELECT title, custom_slug FROM posts WHERE id = @id`
+	err := service.pg.Db.QueryRow(service.pg.Ctx, query, pgx.NamedArgs{"id": postID}).Scan(&title, &custom)
+	if err != nil {
+		service.pg.Log.Error("Error loading post title", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return err
+	}
+	if custom {
+		return nil
+	}
+
+	_, err = service.setSlug(postID, title, "")
+	return err
+}
+
+// claimSlug records postSlug as a slug of the post and reports whether it could,
+// it can not when the slug belongs to another post.
+func (service *PostServiceImplementation) claimSlug(postID int, postSlug string) (bool, error) {
+	// DO UPDATE instead of DO NOTHING, so RETURNING yields a slug the post already had as well
+	query := `
+		INSERT INTO post_slugs (slug, post_id) VALUES (@slug, @post_id)
+		ON CONFLICT (slug) DO UPDATE SET post_id = excluded.post_id WHERE post_slugs.post_id = excluded.post_id
+		RETURNING post_id`
+	args := pgx.NamedArgs{
+		"slug":    postSlug,
+		"post_id": postID,
+	}
+
+	var id int
+	err := service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		service.pg.Log.Error("Error claiming post slug", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return false, err
+	}
+	return true, nil
+}
+
+// takenSlugs returns the slugs of other posts that base or one of its suffixed forms could collide with.
+func (service *PostServiceImplementation) takenSlugs(postID int, base string) (map[string]bool, error) {
+	query := `SELECT slug FROM post_slugs WHERE (slug = @base OR slug LIKE @prefix) AND post_id <> @post_id`
+	args := pgx.NamedArgs{
+		"base":    base,
+		"prefix":  base + "-%",
+		"post_id": postID,
+	}
+
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error sql query getting taken slugs", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	slugs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		service.pg.Log.Error("Error scanning taken slugs", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	taken := make(map[string]bool, len(slugs))
+	for _, s := range slugs {
+		taken[s] = true
+	}
+	return taken, nil
+}
+
+// slugPendingPosts gives a slug to the posts stored before posts had slugs.
+// It returns how many posts it gave one.
+func slugPendingPosts(pg *DbPool) (int, error) {
+	service := &PostServiceImplementation{pg: pg}
+	slugged := 0
+	for {
+		query := `SELECT id, title FROM posts WHERE slug IS NULL ORDER BY id LIMIT @limit`
+		rows, err := pg.Db.Query(pg.Ctx, query, pgx.NamedArgs{"limit": slugBatch})
+		if err != nil {
+			return slugged, err
+		}
+
+		type pending struct {
+			Id    int
+			Title string
+		}
+		posts, err := pgx.CollectRows(rows, pgx.RowToStructByPos[pending])
+		if err != nil {
+			return slugged, err
+		}
+
+		for _, post := range posts {
+			if _, err = service.setSlug(post.Id, post.Title, ""); err != nil {
+				return slugged, err
+			}
+			slugged++
+		}
+
+		if len(posts) < slugBatch {
+			return slugged, nil
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-rest-api-auth/internal/slug"
+	"testing"
+)
+
+func TestPostSlugs(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, prefix := newTestUser(t, pg)
+	other, _ := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+
+	first, err := posts.CreatePost(PostDTO{Title: "Crème brûlée " + prefix, UserId: author})
+	require.NoError(t, err)
+	assert.Equal(t, "creme-brulee-"+prefix, first.Slug)
+
+	// slugs are unique across authors
+	second, err := posts.CreatePost(PostDTO{Title: "Creme Brulee " + prefix, UserId: other})
+	require.NoError(t, err)
+	assert.Equal(t, "creme-brulee-"+prefix+"-2", second.Slug)
+
+	found, err := posts.GetPostBySlug(second.Slug)
+	require.NoError(t, err)
+	assert.Equal(t, second.Id, found.Id)
+	assert.Equal(t, second.Slug, found.Slug)
+
+	// a new title gives a new slug, the previous one still leads to the post
+	err = posts.UpdatePost(PostDTO{Id: first.Id, Title: "Tiramisu " + prefix}, author)
+	require.NoError(t, err)
+	found, err = posts.GetPostBySlug("creme-brulee-" + prefix)
+	require.NoError(t, err)
+	assert.Equal(t, first.Id, found.Id)
+	assert.Equal(t, "tiramisu-"+prefix, found.Slug)
+
+	// the previous slug stays with its post, a third post with the same title does not get it
+	third, err := posts.CreatePost(PostDTO{Title: "Crème brûlée " + prefix, UserId: other})
+	require.NoError(t, err)
+	assert.Equal(t, "creme-brulee-"+prefix+"-3", third.Slug)
+
+	// going back to a previous title gives the post its previous slug again
+	err = posts.UpdatePost(PostDTO{Id: first.Id, Title: "Crème brûlée " + prefix}, author)
+	require.NoError(t, err)
+	found, err = posts.GetPost(first.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "creme-brulee-"+prefix, found.Slug)
+
+	_, err = posts.GetPostBySlug("missing-" + prefix)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestPostCustomSlugs(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, prefix := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+
+	created, err := posts.CreatePost(PostDTO{Title: "Some title", Slug: "custom-" + prefix, UserId: author})
+	require.NoError(t, err)
+	assert.Equal(t, "custom-"+prefix, created.Slug)
+
+	// a custom slug survives title changes
+	err = posts.UpdatePost(PostDTO{Id: created.Id, Title: "Another title"}, author)
+	require.NoError(t, err)
+	found, err := posts.GetPost(created.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "custom-"+prefix, found.Slug)
+
+	err = posts.UpdatePost(PostDTO{Id: created.Id, Slug: "renamed-" + prefix}, author)
+	require.NoError(t, err)
+	found, err = posts.GetPostBySlug("custom-" + prefix)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed-"+prefix, found.Slug)
+
+	// current and previous slugs of a post are taken
+	_, err = posts.CreatePost(PostDTO{Title: "Some title", Slug: "renamed-" + prefix, UserId: author})
+	assert.ErrorIs(t, err, ErrSlugTaken)
+	_, err = posts.CreatePost(PostDTO{Title: "Some title", Slug: "custom-" + prefix, UserId: author})
+	assert.ErrorIs(t, err, ErrSlugTaken)
+
+	_, err = posts.CreatePost(PostDTO{Title: "Some title", Slug: "Not A Slug", UserId: author})
+	assert.ErrorIs(t, err, slug.ErrInvalidSlug)
+
+	// deleting a post frees its slugs
+	require.NoError(t, posts.DeletePost(created.Id))
+	reused, err := posts.CreatePost(PostDTO{Title: "Some title", Slug: "custom-" + prefix, UserId: author})
+	require.NoError(t, err)
+	assert.Equal(t, "custom-"+prefix, reused.Slug)
+}
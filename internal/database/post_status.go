@@ -0,0 +1,101 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// PublishPost publishes a post right away when publishAt is zero or not in the future,
+// otherwise it schedules the post to be published at publishAt by PublishScheduled.
+// Publishing a published post keeps its original publishing time.
+// It returns pgx.ErrNoRows when the post does not exist.
+func (service *PostServiceImplementation) PublishPost(postID int, publishAt time.Time) (PostDTO, error) {
+	// publish_at is converted to the database time zone, like CURRENT_TIMESTAMP is
+	query := `
+		UPDATE posts SET
+			status = CASE WHEN @publish_at::timestamptz > CURRENT_TIMESTAMP THEN 'scheduled' ELSE 'published' END,
+			published_at = CASE
+				WHEN @publish_at::timestamptz > CURRENT_TIMESTAMP THEN @publish_at::timestamptz
+				WHEN status = 'published' THEN published_at
+				ELSE CURRENT_TIMESTAMP
+			END
+		WHERE id = @id
+		RETURNING id`
+	args := pgx.NamedArgs{
+		"id":         postID,
+		"publish_at": pgtype.Timestamptz{Time: publishAt, Valid: !publishAt.IsZero()},
+	}
+
+	return service.changeStatus(postID, query, args)
+}
+
+// UnpublishPost takes a post back to a draft, or archives it. An archived post
+// keeps the time it was published at, if it ever was.
+// It returns pgx.ErrNoRows when the post does not exist.
+func (service *PostServiceImplementation) UnpublishPost(postID int, archive bool) (PostDTO, error) {
+	status := PostStatusDraft
+	if archive {
+		status = PostStatusArchived
+	}
+
+	query := `
+		UPDATE posts SET
+			status = @status,
+			published_at = CASE WHEN @status = 'archived' AND status IN ('published', 'archived') THEN published_at END
+		WHERE id = @id
+		RETURNING id`
+	args := pgx.NamedArgs{
+		"id":     postID,
+		"status": status,
+	}
+
+	return service.changeStatus(postID, query, args)
+}
+
+// PublishScheduled publishes at most batch scheduled posts that are due and returns how many
+// it published. Rows locked by a concurrent call are skipped, so any number of app instances
+// can run it at the same time without publishing a post twice or waiting on each other.
+func (service *PostServiceImplementation) PublishScheduled(batch int) (int, error) {
+	query := `
+		UPDATE posts SET status = 'published'
+		WHERE id IN (
+			SELECT id FROM posts
+			WHERE status = 'scheduled' AND published_at <= CURRENT_TIMESTAMP
+			ORDER BY published_at
+			LIMIT @batch
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	tag, err := service.pg.Db.Exec(service.pg.Ctx, query, pgx.NamedArgs{"batch": batch})
+	if err != nil {
+		service.pg.Log.Error("Error publishing scheduled posts", slog.String("err", err.Error()))
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// changeStatus runs a status update returning the post id and reads the updated post
+// in the same transaction.
+func (service *PostServiceImplementation) changeStatus(postID int, query string, args pgx.NamedArgs) (PostDTO, error) {
+	var post PostDTO
+	err := service.inTx(func(tx *PostServiceImplementation) error {
+		var id int
+		err := tx.pg.Db.QueryRow(tx.pg.Ctx, query, args).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		post, err = tx.GetPost(id)
+		return err
+	})
+	if err != nil {
+		service.pg.Log.Error("Error changing post status", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return PostDTO{}, err
+	}
+
+	return post, nil
+}
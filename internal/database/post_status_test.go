@@ -0,0 +1,72 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPostStatus(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, _ := newTestUser(t, pg)
+	reader, _ := newTestUser(t, pg)
+	service := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+
+	draft, err := service.CreatePost(PostDTO{Title: "draft", UserId: author, Status: PostStatusDraft})
+	require.NoError(t, err)
+	assert.Equal(t, PostStatusDraft, draft.Status)
+	assert.False(t, draft.PublishedAt.Valid)
+
+	_, err = service.CreatePost(PostDTO{Title: "archived", UserId: author, Status: PostStatusArchived})
+	assert.ErrorIs(t, err, ErrInvalidPostStatus)
+
+	listed := func(viewerID int) []int {
+		posts, _, err := service.GetALlPosts(PostFilter{ViewerID: viewerID, AuthorID: author}, Page{})
+		require.NoError(t, err)
+		return postIDs(posts)
+	}
+	assert.Equal(t, []int{draft.Id}, listed(author))
+	assert.Empty(t, listed(reader))
+
+	scheduled, err := service.PublishPost(draft.Id, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, PostStatusScheduled, scheduled.Status)
+	assert.Empty(t, listed(reader))
+
+	// make it due
+	_, err = pg.Db.Exec(pg.Ctx, `UPDATE posts SET published_at = CURRENT_TIMESTAMP - interval '1 minute' WHERE id = $1`, draft.Id)
+	require.NoError(t, err)
+	published, err := service.PublishScheduled(100)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, published, 1)
+
+	post, err := service.GetPost(draft.Id)
+	require.NoError(t, err)
+	assert.Equal(t, PostStatusPublished, post.Status)
+	assert.Equal(t, []int{draft.Id}, listed(reader))
+
+	// publishing again keeps the publishing time
+	again, err := service.PublishPost(draft.Id, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, post.PublishedAt, again.PublishedAt)
+
+	archived, err := service.UnpublishPost(draft.Id, true)
+	require.NoError(t, err)
+	assert.Equal(t, PostStatusArchived, archived.Status)
+	assert.Equal(t, post.PublishedAt, archived.PublishedAt)
+	assert.Empty(t, listed(reader))
+
+	unpublished, err := service.UnpublishPost(draft.Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, PostStatusDraft, unpublished.Status)
+	assert.False(t, unpublished.PublishedAt.Valid)
+}
+
+func postIDs(posts []PostDTO) []int {
+	ids := make([]int, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.Id)
+	}
+	return ids
+}
@@ -0,0 +1,194 @@
+package database
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+const (
+	testMaxTagsPerPost    = 10
+	testRevisionRetention = 5
+)
+
+// queryCounter counts the statements sent to the database.
+type queryCounter struct {
+	queries atomic.Int64
+}
+
+func (c *queryCounter) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	c.queries.Add(1)
+	return ctx
+}
+
+func (c *queryCounter) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+// newTestPool connects to TEST_DATABASE_URL, skipping the test when it is not set.
+func newTestPool(tb testing.TB) (*DbPool, *queryCounter) {
+	dbUrl := os.Getenv("TEST_DATABASE_URL")
+	if dbUrl == "" {
+		tb.Skip("TEST_DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	log := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	cfg, err := pgxpool.ParseConfig(dbUrl)
+	require.NoError(tb, err)
+	counter := &queryCounter{}
+	cfg.ConnConfig.Tracer = counter
+
+	db, err := pgxpool.NewWithConfig(ctx, cfg)
+	require.NoError(tb, err)
+	tb.Cleanup(db.Close)
+
+	pg := &DbPool{Db: db, Ctx: ctx, Log: log, pool: db}
+	pgInstance = pg
+	SetupTables(ctx, log)
+
+	return pg, counter
+}
+
+// newTestUser creates a user removed with everything it owns after the test. The returned
+// prefix is unique to the test, tags named "<prefix>-..." are removed as well.
+func newTestUser(tb testing.TB, pg *DbPool) (int, string) {
+	prefix := uuid.NewString()[:8]
+
+	var userID int
+	err := pg.Db.QueryRow(pg.Ctx, `INSERT INTO users (username, password) VALUES ($1, '') RETURNING id`, "test-"+prefix).Scan(&userID)
+	require.NoError(tb, err)
+
+	tb.Cleanup(func() {
+		_, _ = pg.Db.Exec(pg.Ctx, `DELETE FROM users WHERE id = $1`, userID)
+		_, _ = pg.Db.Exec(pg.Ctx, `DELETE FROM tags WHERE name LIKE $1`, prefix+"-%")
+	})
+
+	return userID, prefix
+}
+
+func countPosts(t *testing.T, pg *DbPool, userID int) int {
+	var count int
+	err := pg.Db.QueryRow(pg.Ctx, `SELECT count(*) FROM posts WHERE user_id = $1`, userID).Scan(&count)
+	require.NoError(t, err)
+	return count
+}
+
+func TestCreatePostIsAtomic(t *testing.T) {
+	pg, _ := newTestPool(t)
+	userID, prefix := newTestUser(t, pg)
+	service := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+
+	created, err := service.CreatePost(PostDTO{Title: "ok", UserId: userID, Tags: []string{prefix + "-a", prefix + "-b"}})
+	require.NoError(t, err)
+
+	post, err := service.GetPost(created.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{prefix + "-a", prefix + "-b"}, post.Tags)
+
+	// a tag row whose display name is taken under another normalized form,
+	// so upserting it fails after the post and the first tag were inserted
+	_, err = pg.Db.Exec(pg.Ctx, `INSERT INTO tags (name, normalized) VALUES ($1, $2)`, prefix+"-Z", prefix+"-other")
+	require.NoError(t, err)
+
+	_, err = service.CreatePost(PostDTO{Title: "broken", UserId: userID, Tags: []string{prefix + "-c", prefix + "-Z"}})
+	assert.Error(t, err)
+
+	assert.Equal(t, 1, countPosts(t, pg, userID))
+	_, err = NewTagService(pg).GetTagByName(prefix + "-c")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestUpdatePostIsAtomic(t *testing.T) {
+	pg, _ := newTestPool(t)
+	userID, prefix := newTestUser(t, pg)
+	service := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+
+	created, err := service.CreatePost(PostDTO{Title: "original", UserId: userID, Tags: []string{prefix + "-a"}})
+	require.NoError(t, err)
+
+	_, err = pg.Db.Exec(pg.Ctx, `INSERT INTO tags (name, normalized) VALUES ($1, $2)`, prefix+"-Z", prefix+"-other")
+	require.NoError(t, err)
+
+	err = service.UpdatePost(PostDTO{Id: created.Id, Title: "updated", Tags: []string{prefix + "-Z"}}, userID)
+	assert.Error(t, err)
+
+	post, err := service.GetPost(created.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "original", post.Title)
+	assert.Equal(t, []string{prefix + "-a"}, post.Tags)
+}
+
+func TestCreatePostNormalizesTags(t *testing.T) {
+	pg, _ := newTestPool(t)
+	userID, prefix := newTestUser(t, pg)
+	service := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+
+	first, err := service.CreatePost(PostDTO{Title: "first", UserId: userID, Tags: []string{"  " + prefix + "-Go  Lang "}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{prefix + "-Go Lang"}, first.Tags)
+
+	// the first spelling stays the display name
+	second, err := service.CreatePost(PostDTO{Title: "second", UserId: userID, Tags: []string{prefix + "-go lang", prefix + "-GO LANG"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{prefix + "-Go Lang"}, second.Tags)
+
+	posts, _, err := service.GetALlPosts(PostFilter{AuthorID: userID, Tags: []string{prefix + "-GO   lang"}}, Page{})
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+}
+
+func TestUpsertTagIsConcurrencySafe(t *testing.T) {
+	pg, _ := newTestPool(t)
+	_, prefix := newTestUser(t, pg)
+	tags := NewTagService(pg)
+
+	const workers = 16
+	ids := make(chan int, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := prefix + "-new"
+			if i%2 == 0 {
+				name = strings.ToUpper(name)
+			}
+			tag, err := tags.UpsertTag(name)
+			assert.NoError(t, err)
+			ids <- tag.Id
+		}(i)
+	}
+	wg.Wait()
+	close(ids)
+
+	first := <-ids
+	for id := range ids {
+		assert.Equal(t, first, id)
+	}
+}
+
+func TestUnitOfWorkRollsBack(t *testing.T) {
+	pg, _ := newTestPool(t)
+	userID, _ := newTestUser(t, pg)
+
+	errAbort := assert.AnError
+	err := NewUnitOfWork(pg, testMaxTagsPerPost, testRevisionRetention).Do(context.Background(), func(repos Repositories) error {
+		_, err := repos.Posts.CreatePost(PostDTO{Title: "first", UserId: userID})
+		require.NoError(t, err)
+		_, err = repos.Posts.CreatePost(PostDTO{Title: "second", UserId: userID})
+		require.NoError(t, err)
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	assert.Equal(t, 0, countPosts(t, pg, userID))
+}
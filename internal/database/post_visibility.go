@@ -0,0 +1,79 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"log/slog"
+	"slices"
+	"strconv"
+)
+
+const (
+	PostVisibilityPublic    = "public"
+	PostVisibilityUnlisted  = "unlisted"
+	PostVisibilityFollowers = "followers"
+	PostVisibilityPrivate   = "private"
+)
+
+// PostVisibilities are the valid visibilities of a post, see CanViewPost.
+var PostVisibilities = []string{PostVisibilityPublic, PostVisibilityUnlisted, PostVisibilityFollowers, PostVisibilityPrivate}
+
+// ErrInvalidPostVisibility is returned for visibilities not in PostVisibilities.
+var ErrInvalidPostVisibility = errors.New("invalid post visibility")
+
+// viewerIsAdmin is true when @viewer_id is an admin, who may read private posts of anyone.
+const viewerIsAdmin = `EXISTS (SELECT 1 FROM users WHERE id = @viewer_id AND role = 'admin')`
+
+// viewerFollows is true when @viewer_id follows the author whose id is the given SQL expression.
+func viewerFollows(author string) string {
+	return `EXISTS (SELECT 1 FROM follows WHERE follower_id = @viewer_id AND followee_id = ` + author + `)`
+}
+
+// listedCondition selects the posts listed to @viewer_id: their own posts and published ones that
+// are public, followers-only posts of authors they follow, and private ones for admins. Unlisted
+// posts are only reachable by a direct link and never listed, unless to their author. Posts of
+// authors who blocked the viewer and posts hidden by a moderator are never listed to them.
+var listedCondition = `(user_id = @viewer_id OR (status = 'published' AND hidden_at IS NULL AND NOT ` + viewerBlockedBy("posts.user_id") + ` AND (visibility = 'public'
+	OR (visibility = 'followers' AND (` + viewerFollows("posts.user_id") + ` OR ` + viewerIsAdmin + `))
+	OR (visibility = 'private' AND ` + viewerIsAdmin + `))))`
+
+func validateVisibility(visibility string) error {
+	if !slices.Contains(PostVisibilities, visibility) {
+		return fmt.Errorf("%w: %q is not one of %v", ErrInvalidPostVisibility, visibility, PostVisibilities)
+	}
+	return nil
+}
+
+// CanViewPost reports whether viewerID, 0 for an anonymous reader, may read post by a direct link.
+// Authors and admins may read any post. Others may read published posts that are public or
+// unlisted, and followers-only ones when they follow the author, unless the author blocked them
+// or a moderator hid the post.
+func (service *PostServiceImplementation) CanViewPost(post PostDTO, viewerID int) (bool, error) {
+	if viewerID != 0 && post.UserId == viewerID {
+		return true, nil
+	}
+	readable := post.Status == PostStatusPublished && !post.HiddenAt.Valid
+	open := readable && (post.Visibility == PostVisibilityPublic || post.Visibility == PostVisibilityUnlisted)
+	if viewerID == 0 {
+		return open, nil
+	}
+
+	query := `SELECT ` + viewerIsAdmin + ` OR (NOT ` + viewerBlockedBy("@author_id") + `
+		AND (@open OR (@followers AND ` + viewerFollows("@author_id") + `)))`
+	args := pgx.NamedArgs{
+		"viewer_id": viewerID,
+		"author_id": post.UserId,
+		"open":      open,
+		"followers": readable && post.Visibility == PostVisibilityFollowers,
+	}
+
+	var allowed bool
+	err := service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(&allowed)
+	if err != nil {
+		service.pg.Log.Error("Error checking post visibility", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(post.Id)))
+		return false, err
+	}
+
+	return allowed, nil
+}
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"testing"
+)
+
+func TestPostVisibility(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, _ := newTestUser(t, pg)
+	reader, _ := newTestUser(t, pg)
+	follower, _ := newTestUser(t, pg)
+	admin, _ := newTestUser(t, pg)
+	service := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+
+	_, err := pg.Db.Exec(pg.Ctx, `INSERT INTO follows (follower_id, followee_id) VALUES ($1, $2)`, follower, author)
+	require.NoError(t, err)
+	_, err = pg.Db.Exec(pg.Ctx, `UPDATE users SET role = $1 WHERE id = $2`, RoleAdmin, admin)
+	require.NoError(t, err)
+
+	posts := make(map[string]PostDTO)
+	for _, visibility := range PostVisibilities {
+		posts[visibility], err = service.CreatePost(PostDTO{Title: visibility, UserId: author, Visibility: visibility})
+		require.NoError(t, err)
+		assert.Equal(t, visibility, posts[visibility].Visibility)
+	}
+	draft, err := service.CreatePost(PostDTO{Title: "draft", UserId: author, Status: PostStatusDraft, Visibility: PostVisibilityPublic})
+	require.NoError(t, err)
+
+	_, err = service.CreatePost(PostDTO{Title: "secret", UserId: author, Visibility: "secret"})
+	assert.ErrorIs(t, err, ErrInvalidPostVisibility)
+
+	defaulted, err := service.CreatePost(PostDTO{Title: "default", UserId: reader})
+	require.NoError(t, err)
+	assert.Equal(t, PostVisibilityPublic, defaulted.Visibility)
+
+	listed := func(viewerID int) []int {
+		result, _, err := service.GetALlPosts(PostFilter{ViewerID: viewerID, AuthorID: author}, Page{Sort: "id", Order: SortAsc})
+		require.NoError(t, err)
+		return postIDs(result)
+	}
+	ids := func(visibilities ...string) []int {
+		var result []int
+		for _, visibility := range visibilities {
+			result = append(result, posts[visibility].Id)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		viewerID int
+		listed   []int
+		readable []string
+	}{
+		{
+			name:     "Anonymous",
+			listed:   ids(PostVisibilityPublic),
+			readable: []string{PostVisibilityPublic, PostVisibilityUnlisted},
+		},
+		{
+			name:     "Reader",
+			viewerID: reader,
+			listed:   ids(PostVisibilityPublic),
+			readable: []string{PostVisibilityPublic, PostVisibilityUnlisted},
+		},
+		{
+			name:     "Follower",
+			viewerID: follower,
+			listed:   ids(PostVisibilityPublic, PostVisibilityFollowers),
+			readable: []string{PostVisibilityPublic, PostVisibilityUnlisted, PostVisibilityFollowers},
+		},
+		{
+			name:     "Admin",
+			viewerID: admin,
+			listed:   ids(PostVisibilityPublic, PostVisibilityFollowers, PostVisibilityPrivate),
+			readable: PostVisibilities,
+		},
+		{
+			name:     "Author",
+			viewerID: author,
+			listed:   append(ids(PostVisibilities...), draft.Id),
+			readable: PostVisibilities,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.listed, listed(tt.viewerID))
+
+			for _, visibility := range PostVisibilities {
+				visible, err := service.CanViewPost(posts[visibility], tt.viewerID)
+				require.NoError(t, err)
+				assert.Equal(t, slices.Contains(tt.readable, visibility), visible, visibility)
+			}
+
+			visible, err := service.CanViewPost(draft, tt.viewerID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.viewerID == author || tt.viewerID == admin, visible, "draft")
+		})
+	}
+}
@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"log/slog"
 	"os"
@@ -9,11 +11,15 @@ import (
 )
 
 type DbPool struct {
-	Db  *pgxpool.Pool
-	Ctx context.Context
-	Log *slog.Logger
+	Db   DB
+	Ctx  context.Context
+	Log  *slog.Logger
+	pool *pgxpool.Pool
 }
 
+// pgUniqueViolation is the SQLSTATE of unique constraint violations.
+const pgUniqueViolation = "23505"
+
 var (
 	pgInstance *DbPool
 	pgOnce     sync.Once
@@ -28,9 +34,10 @@ func NewDbPool(ctx context.Context, dbUrl string, log *slog.Logger) *DbPool {
 		}
 
 		pgInstance = &DbPool{
-			Db:  db,
-			Ctx: ctx,
-			Log: log,
+			Db:   db,
+			Ctx:  ctx,
+			Log:  log,
+			pool: db,
 		}
 	})
 
@@ -57,6 +64,20 @@ func SetupTables(ctx context.Context, log *slog.Logger) {
 
 	log.Info("Created user table")
 
+	query = `ALTER TABLE users ADD COLUMN IF NOT EXISTS token_version INTEGER NOT NULL DEFAULT 0`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to add token_version to users table", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	query = `ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user'`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to add role to users table", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
 	query = `
 		CREATE TABLE IF NOT EXISTS posts (
 			id SERIAL PRIMARY KEY,
@@ -106,6 +127,48 @@ func SetupTables(ctx context.Context, log *slog.Logger) {
 
 	log.Info("Created ManyToMany tags <=> posts table")
 
+	// tags are unique by their normalized form, tags created before it existed
+	// that only differ in case or whitespace are merged into the oldest one
+	query = `
+		ALTER TABLE tags ADD COLUMN IF NOT EXISTS normalized VARCHAR(50);
+		UPDATE tags SET normalized = lower(btrim(regexp_replace(name, '\s+', ' ', 'g'))) WHERE normalized IS NULL;
+		INSERT INTO posts_tags (post_id, tag_id)
+			SELECT pt.post_id, keep.id FROM posts_tags pt
+			JOIN tags t ON t.id = pt.tag_id
+			JOIN (SELECT normalized, min(id) AS id FROM tags GROUP BY normalized) keep ON keep.normalized = t.normalized
+			WHERE t.id <> keep.id
+			ON CONFLICT DO NOTHING;
+		DELETE FROM tags t USING (SELECT normalized, min(id) AS id FROM tags GROUP BY normalized) keep
+			WHERE t.normalized = keep.normalized AND t.id <> keep.id;
+		ALTER TABLE tags ALTER COLUMN normalized SET NOT NULL;
+		CREATE UNIQUE INDEX IF NOT EXISTS tags_normalized_idx ON tags (normalized)
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to normalize tags", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Normalized tags")
+
+	query = `
+		ALTER TABLE tags ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES tags(id) ON DELETE SET NULL;
+		CREATE INDEX IF NOT EXISTS tags_parent_id_idx ON tags (parent_id);
+		CREATE TABLE IF NOT EXISTS tag_aliases (
+			normalized VARCHAR(50) PRIMARY KEY,
+			tag_id INTEGER NOT NULL,
+			FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS tag_aliases_tag_id_idx ON tag_aliases (tag_id)
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create tag hierarchy and aliases", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created tag hierarchy and aliases")
+
 	query = `
 		CREATE TABLE IF NOT EXISTS refresh_tokens (
 		    id SERIAL PRIMARY KEY,
@@ -123,12 +186,412 @@ func SetupTables(ctx context.Context, log *slog.Logger) {
 
 	log.Info("Created refresh_tokens table")
 
+	query = `
+		CREATE TABLE IF NOT EXISTS sessions (
+		    id TEXT PRIMARY KEY,
+		    user_id TEXT NOT NULL,
+		    token_version INTEGER NOT NULL DEFAULT 0,
+		    expires_at TIMESTAMPTZ NOT NULL
+		);
+		ALTER TABLE sessions ALTER COLUMN expires_at TYPE TIMESTAMPTZ;
+		CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions (user_id);
+		CREATE INDEX IF NOT EXISTS sessions_expires_at_idx ON sessions (expires_at)
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create sessions table", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created sessions table")
+
+	// keyset pagination indexes, every sort field is paired with id as the tie breaker
+	query = `
+		CREATE INDEX IF NOT EXISTS posts_created_at_id_idx ON posts (created_at, id);
+		CREATE INDEX IF NOT EXISTS posts_user_id_created_at_idx ON posts (user_id, created_at, id);
+		CREATE INDEX IF NOT EXISTS posts_title_id_idx ON posts (title, id);
+		CREATE INDEX IF NOT EXISTS users_date_joined_id_idx ON users (date_joined, id)
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create pagination indexes", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created pagination indexes")
+
+	// the text search configuration has to match searchConfig in post_search.go
+	query = `
+		ALTER TABLE posts ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(content, '')), 'B')
+		) STORED;
+		CREATE INDEX IF NOT EXISTS posts_search_idx ON posts USING GIN (search)
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create posts search index", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created posts search index")
+
+	// a deleted comment with replies keeps its row with deleted_at set, so the thread stays intact
+	query = `
+		CREATE TABLE IF NOT EXISTS comments (
+			id SERIAL PRIMARY KEY,
+			post_id INTEGER NOT NULL,
+			parent_id INTEGER,
+			user_id INTEGER,
+			content TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP,
+			deleted_at TIMESTAMP,
+			FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+			FOREIGN KEY (parent_id) REFERENCES comments(id) ON DELETE CASCADE,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+		);
+		CREATE INDEX IF NOT EXISTS comments_post_id_created_at_idx ON comments (post_id, created_at, id);
+		CREATE INDEX IF NOT EXISTS comments_parent_id_idx ON comments (parent_id)
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create comments table", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created comments table")
+
+	// post_reaction_counts is kept in step with post_reactions by a trigger, so listing posts
+	// never counts reactions row by row. A trigger also catches reactions removed by deleting a user.
+	query = `
+		CREATE TABLE IF NOT EXISTS post_reactions (
+			id BIGSERIAL PRIMARY KEY,
+			post_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			type VARCHAR(32) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (post_id, user_id, type),
+			FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS post_reactions_post_id_created_at_idx ON post_reactions (post_id, created_at, id);
+		CREATE TABLE IF NOT EXISTS post_reaction_counts (
+			post_id INTEGER NOT NULL,
+			type VARCHAR(32) NOT NULL,
+			count INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (post_id, type),
+			FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+		);
+		CREATE OR REPLACE FUNCTION count_post_reactions() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'INSERT' THEN
+				INSERT INTO post_reaction_counts (post_id, type, count) VALUES (NEW.post_id, NEW.type, 1)
+				ON CONFLICT (post_id, type) DO UPDATE SET count = post_reaction_counts.count + 1;
+			ELSE
+				UPDATE post_reaction_counts SET count = count - 1 WHERE post_id = OLD.post_id AND type = OLD.type;
+			END IF;
+			RETURN NULL;
+		END
+		$$ LANGUAGE plpgsql;
+		DROP TRIGGER IF EXISTS post_reactions_count ON post_reactions;
+		CREATE TRIGGER post_reactions_count AFTER INSERT OR DELETE ON post_reactions
+			FOR EACH ROW EXECUTE FUNCTION count_post_reactions()
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create reactions tables", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created reactions tables")
+
+	// posts created before publishing states existed are published as of their creation,
+	// the partial index serves the scheduled publisher
+	query = `
+		ALTER TABLE posts ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'published'
+			CHECK (status IN ('draft', 'scheduled', 'published', 'archived'));
+		ALTER TABLE posts ADD COLUMN IF NOT EXISTS published_at TIMESTAMP;
+		UPDATE posts SET published_at = created_at WHERE status = 'published' AND published_at IS NULL;
+		CREATE INDEX IF NOT EXISTS posts_scheduled_idx ON posts (published_at) WHERE status = 'scheduled'
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to add post status", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Added post status")
+
+	// revisions are snapshots of a post, tags by name so they survive the tags being deleted
+	query = `
+		CREATE TABLE IF NOT EXISTS post_revisions (
+			id BIGSERIAL PRIMARY KEY,
+			post_id INTEGER NOT NULL,
+			revision INTEGER NOT NULL,
+			editor_id INTEGER,
+			title VARCHAR(255) NOT NULL,
+			content TEXT NOT NULL,
+			tags TEXT[] NOT NULL DEFAULT '{}',
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (post_id, revision),
+			FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+			FOREIGN KEY (editor_id) REFERENCES users(id) ON DELETE SET NULL
+		)
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create post revisions table", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created post revisions table")
+
+	// content_html, excerpt, word_count and reading_time are rendered from the content on write,
+	// content_html is NULL for posts stored before, they are rendered below
+	query = `
+		ALTER TABLE posts ADD COLUMN IF NOT EXISTS content_format VARCHAR(16) NOT NULL DEFAULT 'plain'
+			CHECK (content_format IN ('plain', 'markdown'));
+		ALTER TABLE posts ADD COLUMN IF NOT EXISTS content_html TEXT;
+		ALTER TABLE posts ADD COLUMN IF NOT EXISTS excerpt TEXT NOT NULL DEFAULT '';
+		ALTER TABLE posts ADD COLUMN IF NOT EXISTS word_count INTEGER NOT NULL DEFAULT 0;
+		ALTER TABLE posts ADD COLUMN IF NOT EXISTS reading_time INTEGER NOT NULL DEFAULT 0;
+		ALTER TABLE post_revisions ADD COLUMN IF NOT EXISTS content_format VARCHAR(16) NOT NULL DEFAULT 'plain'
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to add post content format", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	rendered, err := renderPendingPosts(pgInstance)
+	if err != nil {
+		log.Debug("Failed to render posts", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Added post content format", slog.Int("rendered", rendered))
+
+	// blobs are stored once per content, attachments of any number of posts share them.
+	// Deleting a post leaves its blobs behind, DeleteOrphanedBlobs removes them with their files.
+	query = `
+		CREATE TABLE IF NOT EXISTS blobs (
+			checksum CHAR(64) PRIMARY KEY,
+			size BIGINT NOT NULL,
+			content_type VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE IF NOT EXISTS attachments (
+			id SERIAL PRIMARY KEY,
+			post_id INTEGER NOT NULL,
+			user_id INTEGER,
+			checksum CHAR(64) NOT NULL,
+			filename VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL,
+			FOREIGN KEY (checksum) REFERENCES blobs(checksum)
+		);
+		CREATE INDEX IF NOT EXISTS attachments_post_idx ON attachments (post_id, id);
+		CREATE INDEX IF NOT EXISTS attachments_checksum_idx ON attachments (checksum)
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create attachments tables", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created attachments tables")
+
+	// users.avatar is the checksum of the default variant, which identifies the avatar in its URL.
+	// Replaced and deleted avatars leave their blobs to DeleteOrphanedBlobs, like attachments.
+	query = `
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar CHAR(64);
+		CREATE TABLE IF NOT EXISTS avatar_variants (
+			user_id INTEGER NOT NULL,
+			size INTEGER NOT NULL,
+			checksum CHAR(64) NOT NULL,
+			PRIMARY KEY (user_id, size),
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (checksum) REFERENCES blobs(checksum)
+		);
+		CREATE INDEX IF NOT EXISTS avatar_variants_checksum_idx ON avatar_variants (checksum);
+		CREATE INDEX IF NOT EXISTS users_avatar_idx ON users (avatar)
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create avatar tables", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created avatar tables")
+
+	// post_slugs holds every slug a post ever had, so links with a previous one still find it,
+	// posts.slug is the current one. Posts stored before have no slug yet, they get one below
+	query = `
+		ALTER TABLE posts ADD COLUMN IF NOT EXISTS slug VARCHAR(100) UNIQUE;
+		ALTER TABLE posts ADD COLUMN IF NOT EXISTS custom_slug BOOLEAN NOT NULL DEFAULT false;
+		CREATE TABLE IF NOT EXISTS post_slugs (
+			slug VARCHAR(100) PRIMARY KEY,
+			post_id INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS post_slugs_post_id_idx ON post_slugs (post_id)
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create post slugs table", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	slugged, err := slugPendingPosts(pgInstance)
+	if err != nil {
+		log.Debug("Failed to give posts a slug", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created post slugs table", slog.Int("slugged", slugged))
+
+	// followers-only posts are shown to the users following their author, see CanViewPost
+	query = `
+		ALTER TABLE posts ADD COLUMN IF NOT EXISTS visibility VARCHAR(16) NOT NULL DEFAULT 'public'
+			CHECK (visibility IN ('public', 'unlisted', 'followers', 'private'));
+		CREATE TABLE IF NOT EXISTS follows (
+			follower_id INTEGER NOT NULL,
+			followee_id INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (follower_id, followee_id),
+			CHECK (follower_id <> followee_id),
+			FOREIGN KEY (follower_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (followee_id) REFERENCES users(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS follows_followee_idx ON follows (followee_id, follower_id)
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to add post visibility", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Added post visibility")
+
+	// feeds are collected on read from the follows above and the followed tags, see GetFeed
+	query = `
+		CREATE TABLE IF NOT EXISTS tag_follows (
+			user_id INTEGER NOT NULL,
+			tag_id INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (user_id, tag_id),
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS follows_follower_created_at_idx ON follows (follower_id, created_at);
+		CREATE INDEX IF NOT EXISTS follows_followee_created_at_idx ON follows (followee_id, created_at);
+		CREATE INDEX IF NOT EXISTS posts_tags_tag_id_idx ON posts_tags (tag_id, post_id);
+		CREATE INDEX IF NOT EXISTS posts_user_id_published_at_idx ON posts (user_id, published_at, id) WHERE status = 'published'
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create tag follows table", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created tag follows table")
+
+	// blocks keep users apart both ways, mutes only hide the muted user from the muter, see BlockService
+	query = `
+		CREATE TABLE IF NOT EXISTS blocks (
+			blocker_id INTEGER NOT NULL,
+			blocked_id INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (blocker_id, blocked_id),
+			CHECK (blocker_id <> blocked_id),
+			FOREIGN KEY (blocker_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (blocked_id) REFERENCES users(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS blocks_blocked_idx ON blocks (blocked_id, blocker_id);
+		CREATE INDEX IF NOT EXISTS blocks_blocker_created_at_idx ON blocks (blocker_id, created_at);
+		CREATE TABLE IF NOT EXISTS mutes (
+			muter_id INTEGER NOT NULL,
+			muted_id INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (muter_id, muted_id),
+			CHECK (muter_id <> muted_id),
+			FOREIGN KEY (muter_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (muted_id) REFERENCES users(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS mutes_muter_created_at_idx ON mutes (muter_id, created_at)
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create blocks and mutes tables", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created blocks and mutes tables")
+
+	// reports feed the moderation queue, the actions resolving them outlive the reported posts, see ModerationService
+	query = `
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP;
+		ALTER TABLE posts ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMP;
+		CREATE TABLE IF NOT EXISTS moderation_actions (
+			id SERIAL PRIMARY KEY,
+			moderator_id INTEGER,
+			post_id INTEGER,
+			author_id INTEGER,
+			action VARCHAR(16) NOT NULL CHECK (action IN ('dismiss', 'hide', 'delete', 'suspend')),
+			note TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (moderator_id) REFERENCES users(id) ON DELETE SET NULL,
+			FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE SET NULL
+		);
+		CREATE TABLE IF NOT EXISTS reports (
+			id SERIAL PRIMARY KEY,
+			post_id INTEGER,
+			author_id INTEGER NOT NULL,
+			reporter_id INTEGER,
+			reason VARCHAR(32) NOT NULL,
+			details TEXT NOT NULL DEFAULT '',
+			status VARCHAR(16) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved')),
+			action_id INTEGER,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE SET NULL,
+			FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (reporter_id) REFERENCES users(id) ON DELETE SET NULL,
+			FOREIGN KEY (action_id) REFERENCES moderation_actions(id) ON DELETE SET NULL
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS reports_open_reporter_idx ON reports (post_id, reporter_id) WHERE status = 'open';
+		CREATE INDEX IF NOT EXISTS reports_status_created_at_idx ON reports (status, created_at, id)
+	`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create moderation tables", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created moderation tables")
+
+	// the content filters count and compare the latest comments of a user, see ContentHistoryService
+	query = `CREATE INDEX IF NOT EXISTS comments_user_id_created_at_idx ON comments (user_id, created_at, id)`
+	_, err = pgInstance.Db.Exec(ctx, query)
+	if err != nil {
+		log.Debug("Failed to create content filter indexes", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	log.Info("Created content filter indexes")
 }
 
 func (pg *DbPool) Ping(ctx context.Context) error {
-	return pg.Db.Ping(ctx)
+	return pg.pool.Ping(ctx)
 }
 
 func (pg *DbPool) Close() {
-	pg.Db.Close()
+	pg.pool.Close()
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
 }
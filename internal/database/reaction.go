@@ -0,0 +1,231 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"log/slog"
+	"slices"
+	"strconv"
+)
+
+// ErrInvalidReaction is returned for reaction types that are not configured.
+var ErrInvalidReaction = errors.New("invalid reaction")
+
+// ReactionDTO is a reaction of a user to a post, as listed by GetReactions.
+type ReactionDTO struct {
+	Id        int
+	UserId    int
+	Username  string
+	Type      string
+	CreatedAt pgtype.Timestamp
+}
+
+var reactionSortColumns = map[string]sortColumn{
+	"id":         {column: "id"},
+	"created_at": {column: "created_at", parse: parseTimestamp},
+}
+
+type ReactionServiceImplementation struct {
+	pg    *DbPool
+	types []string
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name ReactionService --output ../../testing/mocks
+type ReactionService interface {
+	AddReaction(postID, userID int, reaction string) error
+	RemoveReaction(postID, userID int, reaction string) error
+	GetReactions(postID int, reaction string, page Page) ([]ReactionDTO, string, error)
+	GetUserReactions(userID int, postIDs []int) (map[int][]string, error)
+}
+
+// NewReactionService returns a service accepting only the given reaction types.
+func NewReactionService(pg *DbPool, types []string) ReactionService {
+	return &ReactionServiceImplementation{
+		pg:    pg,
+		types: types,
+	}
+}
+
+func (service *ReactionServiceImplementation) validate(reaction string) error {
+	if !slices.Contains(service.types, reaction) {
+		return fmt.Errorf("%w: unknown reaction type %q", ErrInvalidReaction, reaction)
+	}
+	return nil
+}
+
+// AddReaction reacts to a post, adding the same reaction twice is a no-op.
+// It returns pgx.ErrNoRows when the post does not exist and ErrBlocked when
+// its author and the user blocked one another. It does not check whether the user
+// may read the post, see PostService.CanViewPost.
+func (service *ReactionServiceImplementation) AddReaction(postID, userID int, reaction string) error {
+	if err := service.validate(reaction); err != nil {
+		return err
+	}
+
+	query := `
+		WITH post AS (
+			SELECT id, ` + eitherBlocked("posts.user_id", "@user_id") + ` AS blocked FROM posts WHERE id = @post_id FOR SHARE
+		), added AS (
+			INSERT INTO post_reactions (post_id, user_id, type)
+			SELECT id, @user_id, @type FROM post WHERE NOT blocked
+			ON CONFLICT (post_id, user_id, type) DO NOTHING
+		)
+		SELECT blocked FROM post`
+	args := pgx.NamedArgs{
+		"post_id": postID,
+		"user_id": userID,
+		"type":    reaction,
+	}
+
+	var blocked bool
+	err := service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(&blocked)
+	if err != nil {
+		service.pg.Log.Error("Error adding reaction", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return err
+	}
+	if blocked {
+		return ErrBlocked
+	}
+
+	return nil
+}
+
+// RemoveReaction takes a reaction back, removing a reaction that was never added is a no-op.
+func (service *ReactionServiceImplementation) RemoveReaction(postID, userID int, reaction string) error {
+	if err := service.validate(reaction); err != nil {
+		return err
+	}
+
+	query := `DELETE FROM post_reactions WHERE post_id = @post_id AND user_id = @user_id AND type = @type`
+	args := pgx.NamedArgs{
+		"post_id": postID,
+		"user_id": userID,
+		"type":    reaction,
+	}
+
+	_, err := service.pg.Db.Exec(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error removing reaction", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return err
+	}
+
+	return nil
+}
+
+// GetReactions returns a page of the reactions to a post, newest first by default.
+// An empty reaction lists every type.
+func (service *ReactionServiceImplementation) GetReactions(postID int, reaction string, page Page) ([]ReactionDTO, string, error) {
+	if reaction != "" {
+		if err := service.validate(reaction); err != nil {
+			return nil, "", err
+		}
+	}
+
+	ks, err := newKeyset(page, reactionSortColumns, "created_at", SortDesc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	args := pgx.NamedArgs{
+		"post_id": postID,
+		"type":    reaction,
+	}
+	var conditions []string
+	condition, err := ks.condition(args)
+	if err != nil {
+		return nil, "", err
+	}
+	if condition != "" {
+		conditions = append(conditions, condition)
+	}
+
+	query := `
+		SELECT id, user_id, username, type, created_at FROM (
+			SELECT r.id, r.user_id, u.username, r.type, r.created_at
+			FROM post_reactions r JOIN users u ON u.id = r.user_id
+			WHERE r.post_id = @post_id AND (@type = '' OR r.type = @type)
+		) reactions` + whereClause(conditions) + ks.orderBy() + ks.limitClause(args)
+
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error sql query getting reactions", slog.String("err", err.Error()))
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	reactions, err := pgx.CollectRows(rows, pgx.RowToStructByPos[ReactionDTO])
+	if err != nil {
+		service.pg.Log.Error("Error scanning reaction", slog.String("err", err.Error()))
+		return nil, "", err
+	}
+
+	if len(reactions) <= ks.limit {
+		return reactions, "", nil
+	}
+
+	reactions = reactions[:ks.limit]
+	last := reactions[len(reactions)-1]
+	var value string
+	if ks.sort == "created_at" {
+		value = last.CreatedAt.Time.Format(cursorTimestampLayout)
+	}
+	return reactions, ks.nextCursor(value, last.Id), nil
+}
+
+// GetUserReactions returns the reaction types of a user by post id, for the given posts only.
+// Posts without a reaction of the user are missing from the map.
+func (service *ReactionServiceImplementation) GetUserReactions(userID int, postIDs []int) (map[int][]string, error) {
+	reactions := make(map[int][]string)
+	if len(postIDs) == 0 {
+		return reactions, nil
+	}
+
+	query := `
+		SELECT post_id, array_agg(type ORDER BY type) FROM post_reactions
+		WHERE user_id = @user_id AND post_id = ANY(@post_ids)
+		GROUP BY post_id`
+	args := pgx.NamedArgs{
+		"user_id":  userID,
+		"post_ids": postIDs,
+	}
+
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error sql query getting user reactions", slog.String("err", err.Error()), slog.String("userid", strconv.Itoa(userID)))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var postID int
+	var types []string
+	_, err = pgx.ForEachRow(rows, []any{&postID, &types}, func() error {
+		reactions[postID] = types
+		return nil
+	})
+	if err != nil {
+		service.pg.Log.Error("Error scanning user reactions", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	return reactions, nil
+}
+
+// FillMyReactions sets MyReactions of every post to the reactions of userID, with a single query.
+func FillMyReactions(service ReactionService, userID int, posts []PostDTO) error {
+	postIDs := make([]int, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.Id
+	}
+
+	mine, err := service.GetUserReactions(userID, postIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range posts {
+		posts[i].MyReactions = mine[posts[i].Id]
+	}
+	return nil
+}
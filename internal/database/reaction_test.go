@@ -0,0 +1,58 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReactions(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, _ := newTestUser(t, pg)
+	fan, _ := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	reactions := NewReactionService(pg, []string{"like", "love"})
+
+	post, err := posts.CreatePost(PostDTO{Title: "liked", UserId: author})
+	require.NoError(t, err)
+
+	require.NoError(t, reactions.AddReaction(post.Id, author, "like"))
+	require.NoError(t, reactions.AddReaction(post.Id, fan, "like"))
+	require.NoError(t, reactions.AddReaction(post.Id, fan, "like"))
+	require.NoError(t, reactions.AddReaction(post.Id, fan, "love"))
+	assert.ErrorIs(t, reactions.AddReaction(post.Id, fan, "angry"), ErrInvalidReaction)
+	assert.ErrorIs(t, reactions.AddReaction(-1, fan, "like"), pgx.ErrNoRows)
+
+	loaded, err := posts.GetPost(post.Id)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"like": 2, "love": 1}, loaded.Reactions)
+
+	mine, err := reactions.GetUserReactions(fan, []int{post.Id})
+	require.NoError(t, err)
+	assert.Equal(t, map[int][]string{post.Id: {"like", "love"}}, mine)
+
+	page, next, err := reactions.GetReactions(post.Id, "", Page{Limit: 2})
+	require.NoError(t, err)
+	require.NotEmpty(t, next)
+	assert.Len(t, page, 2)
+	rest, next, err := reactions.GetReactions(post.Id, "", Page{Limit: 2, Cursor: next})
+	require.NoError(t, err)
+	assert.Empty(t, next)
+	assert.Len(t, rest, 1)
+
+	likes, _, err := reactions.GetReactions(post.Id, "like", Page{})
+	require.NoError(t, err)
+	assert.Len(t, likes, 2)
+
+	require.NoError(t, reactions.RemoveReaction(post.Id, fan, "love"))
+	require.NoError(t, reactions.RemoveReaction(post.Id, fan, "love"))
+
+	// the counts follow reactions removed along with their user
+	_, err = pg.Db.Exec(pg.Ctx, `DELETE FROM users WHERE id = $1`, fan)
+	require.NoError(t, err)
+
+	loaded, err = posts.GetPost(post.Id)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"like": 1}, loaded.Reactions)
+}
@@ -2,9 +2,9 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"github.com/go-redis/redis/v8"
 	"log/slog"
-	"os"
 )
 
 type CacheClient struct {
@@ -13,23 +13,21 @@ type CacheClient struct {
 	Log   *slog.Logger
 }
 
-func NewRedisClient(ctx context.Context, log *slog.Logger, redisUrl string) *CacheClient {
+func NewRedisClient(ctx context.Context, log *slog.Logger, redisUrl string) (*CacheClient, error) {
 	opt, err := redis.ParseURL(redisUrl)
 	if err != nil {
-		log.Error("Error parsing redis url", slog.String("error", err.Error()))
-		os.Exit(1)
+		return nil, fmt.Errorf("error parsing redis url: %w", err)
 	}
 
 	rdb := redis.NewClient(opt)
-	ok := rdb.Ping(ctx).Err()
-	if ok != nil {
-		log.Error("Error connecting to redis", slog.String("error", ok.Error()))
-		os.Exit(1)
+	if err = rdb.Ping(ctx).Err(); err != nil {
+		_ = rdb.Close()
+		return nil, fmt.Errorf("error connecting to redis: %w", err)
 	}
 
 	return &CacheClient{
 		Cache: rdb,
 		Ctx:   ctx,
 		Log:   log,
-	}
+	}, nil
 }
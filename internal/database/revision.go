@@ -0,0 +1,233 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"log/slog"
+	"strconv"
+)
+
+// RevisionDTO is a snapshot of a post taken on every change. Revision counts up from 1 per post,
+// EditorId is 0 once the editor deleted their account.
+type RevisionDTO struct {
+	Id            int
+	PostId        int
+	Revision      int
+	EditorId      int
+	Title         string
+	Content       string
+	ContentFormat string
+	Tags          []string
+	CreatedAt     pgtype.Timestamp
+}
+
+const revisionColumns = `id, post_id, revision, coalesce(editor_id, 0), title, content, content_format, tags, created_at`
+
+// revisions are listed by id, which follows the revision numbers
+var revisionSortColumns = map[string]sortColumn{
+	"id": {column: "id"},
+}
+
+type RevisionServiceImplementation struct {
+	pg *DbPool
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name RevisionService --output ../../testing/mocks
+type RevisionService interface {
+	GetRevisions(postID int, page Page) ([]RevisionDTO, string, error)
+	GetRevision(postID, revision int) (RevisionDTO, error)
+}
+
+func NewRevisionService(pg *DbPool) RevisionService {
+	return &RevisionServiceImplementation{
+		pg: pg,
+	}
+}
+
+// GetRevisions returns a page of the revisions of a post, newest first by default.
+func (service *RevisionServiceImplementation) GetRevisions(postID int, page Page) ([]RevisionDTO, string, error) {
+	ks, err := newKeyset(page, revisionSortColumns, "id", SortDesc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	args := pgx.NamedArgs{"post_id": postID}
+	conditions := []string{"post_id = @post_id"}
+	condition, err := ks.condition(args)
+	if err != nil {
+		return nil, "", err
+	}
+	if condition != "" {
+		conditions = append(conditions, condition)
+	}
+
+	query := `SELECT ` + revisionColumns + ` FROM post_revisions` + whereClause(conditions) + ks.orderBy() + ks.limitClause(args)
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error sql query getting revisions", slog.String("err", err.Error()))
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	revisions, err := pgx.CollectRows(rows, pgx.RowToStructByPos[RevisionDTO])
+	if err != nil {
+		service.pg.Log.Error("Error scanning revision", slog.String("err", err.Error()))
+		return nil, "", err
+	}
+
+	if len(revisions) <= ks.limit {
+		return revisions, "", nil
+	}
+
+	revisions = revisions[:ks.limit]
+	return revisions, ks.nextCursor("", revisions[len(revisions)-1].Id), nil
+}
+
+// GetRevision returns a revision of a post, pgx.ErrNoRows when it does not exist
+// or was dropped by the retention limit.
+func (service *RevisionServiceImplementation) GetRevision(postID, revision int) (RevisionDTO, error) {
+	query := `SELECT ` + revisionColumns + ` FROM post_revisions WHERE post_id = @post_id AND revision = @revision`
+	args := pgx.NamedArgs{
+		"post_id":  postID,
+		"revision": revision,
+	}
+
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error sql query getting revision", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return RevisionDTO{}, err
+	}
+
+	rev, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[RevisionDTO])
+	if err != nil {
+		service.pg.Log.Error("Error getting revision", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return RevisionDTO{}, err
+	}
+
+	return rev, nil
+}
+
+// RestoreRevision makes a post look like one of its revisions again, which is recorded
+// as a new revision by editorID. It returns pgx.ErrNoRows when the revision does not exist.
+func (service *PostServiceImplementation) RestoreRevision(postID, revision, editorID int) (PostDTO, error) {
+	var post PostDTO
+	err := service.inTx(func(tx *PostServiceImplementation) error {
+		if err := tx.lockPost(postID); err != nil {
+			return err
+		}
+
+		rev, err := NewRevisionService(tx.pg).GetRevision(postID, revision)
+		if err != nil {
+			return err
+		}
+
+		query := `UPDATE posts SET title = @title, content = @content, content_format = @content_format WHERE id = @id`
+		args := pgx.NamedArgs{
+			"id":             postID,
+			"title":          rev.Title,
+			"content":        rev.Content,
+			"content_format": rev.ContentFormat,
+		}
+		if _, err = tx.pg.Db.Exec(tx.pg.Ctx, query, args); err != nil {
+			tx.pg.Log.Error("Error restoring post", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+			return err
+		}
+
+		if err = tx.renderPost(postID); err != nil {
+			return err
+		}
+
+		if err = tx.refreshSlug(postID); err != nil {
+			return err
+		}
+
+		if err = tx.CreateTagsToPost(&PostDTO{Id: postID}, rev.Tags, true); err != nil {
+			tx.pg.Log.Error("Error restoring post tags", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+			return err
+		}
+
+		if err = tx.recordRevision(postID, editorID); err != nil {
+			return err
+		}
+
+		post, err = tx.GetPost(postID)
+		return err
+	})
+	if err != nil {
+		return PostDTO{}, err
+	}
+
+	return post, nil
+}
+
+// lockPost locks a post for the rest of the transaction, so its revisions are numbered
+// one after the other. It returns pgx.ErrNoRows when the post does not exist.
+func (service *PostServiceImplementation) lockPost(postID int) error {
+	var id int
+	err := service.pg.Db.QueryRow(service.pg.Ctx, `SELECT id FROM posts WHERE id = @id FOR UPDATE`, pgx.NamedArgs{"id": postID}).Scan(&id)
+	if err != nil {
+		service.pg.Log.Error("Error locking post", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return err
+	}
+	return nil
+}
+
+// recordBaseline records the state of a post without revisions, one created before
+// revisions existed, as its first revision by the author, so the first edit can be undone.
+func (service *PostServiceImplementation) recordBaseline(postID int) error {
+	query := `
+		INSERT INTO post_revisions (post_id, revision, editor_id, title, content, content_format, tags, created_at)
+		SELECT p.id, 1, p.user_id, p.title, coalesce(p.content, ''), p.content_format, ` + revisionTags + `,
+			coalesce(p.created_at, CURRENT_TIMESTAMP)
+		FROM posts p
+		WHERE p.id = @id AND NOT EXISTS (SELECT 1 FROM post_revisions WHERE post_id = p.id)`
+
+	_, err := service.pg.Db.Exec(service.pg.Ctx, query, pgx.NamedArgs{"id": postID})
+	if err != nil {
+		service.pg.Log.Error("Error recording baseline revision", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return err
+	}
+	return nil
+}
+
+// recordRevision records the current state of a post as its next revision and drops
+// the oldest revisions beyond the retention limit, 0 keeps them all.
+func (service *PostServiceImplementation) recordRevision(postID, editorID int) error {
+	query := `
+		INSERT INTO post_revisions (post_id, revision, editor_id, title, content, content_format, tags)
+		SELECT p.id, coalesce((SELECT max(revision) FROM post_revisions WHERE post_id = p.id), 0) + 1,
+			nullif(@editor_id, 0), p.title, coalesce(p.content, ''), p.content_format, ` + revisionTags + `
+		FROM posts p
+		WHERE p.id = @id`
+	args := pgx.NamedArgs{
+		"id":        postID,
+		"editor_id": editorID,
+	}
+
+	_, err := service.pg.Db.Exec(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error recording revision", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return err
+	}
+
+	if service.revisionRetention <= 0 {
+		return nil
+	}
+
+	query = `
+		DELETE FROM post_revisions
+		WHERE post_id = @id AND revision <= (SELECT max(revision) FROM post_revisions WHERE post_id = @id) - @retention`
+	args["retention"] = service.revisionRetention
+
+	_, err = service.pg.Db.Exec(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error dropping old revisions", slog.String("err", err.Error()), slog.String("postid", strconv.Itoa(postID)))
+		return err
+	}
+	return nil
+}
+
+// revisionTags snapshots the tags of the post aliased p.
+const revisionTags = `coalesce((
+	SELECT array_agg(t.name ORDER BY t.name) FROM posts_tags pt JOIN tags t ON t.id = pt.tag_id WHERE pt.post_id = p.id
+), '{}')`
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPostRevisions(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, prefix := newTestUser(t, pg)
+	editor, _ := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	revisions := NewRevisionService(pg)
+
+	created, err := posts.CreatePost(PostDTO{Title: "first", Content: "one", UserId: author, Tags: []string{prefix + "-a"}})
+	require.NoError(t, err)
+
+	err = posts.UpdatePost(PostDTO{Id: created.Id, Title: "second", Tags: []string{prefix + "-b"}}, editor)
+	require.NoError(t, err)
+
+	first, err := revisions.GetRevision(created.Id, 1)
+	require.NoError(t, err)
+	assert.Equal(t, author, first.EditorId)
+	assert.Equal(t, "first", first.Title)
+	assert.Equal(t, "one", first.Content)
+	assert.Equal(t, []string{prefix + "-a"}, first.Tags)
+
+	second, err := revisions.GetRevision(created.Id, 2)
+	require.NoError(t, err)
+	assert.Equal(t, editor, second.EditorId)
+	assert.Equal(t, "second", second.Title)
+	assert.Equal(t, "one", second.Content)
+	assert.Equal(t, []string{prefix + "-b"}, second.Tags)
+
+	restored, err := posts.RestoreRevision(created.Id, 1, author)
+	require.NoError(t, err)
+	assert.Equal(t, "first", restored.Title)
+	assert.Equal(t, []string{prefix + "-a"}, restored.Tags)
+
+	listed, next, err := revisions.GetRevisions(created.Id, Page{})
+	require.NoError(t, err)
+	assert.Empty(t, next)
+	require.Len(t, listed, 3)
+	assert.Equal(t, []int{3, 2, 1}, []int{listed[0].Revision, listed[1].Revision, listed[2].Revision})
+
+	_, err = posts.RestoreRevision(created.Id, 42, author)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestPostRevisionsBaselineAndRetention(t *testing.T) {
+	pg, _ := newTestPool(t)
+	author, _ := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	revisions := NewRevisionService(pg)
+
+	created, err := posts.CreatePost(PostDTO{Title: "before revisions", UserId: author})
+	require.NoError(t, err)
+
+	// a post created before revisions were recorded gets its baseline on the first update
+	_, err = pg.Db.Exec(pg.Ctx, `DELETE FROM post_revisions WHERE post_id = $1`, created.Id)
+	require.NoError(t, err)
+
+	err = posts.UpdatePost(PostDTO{Id: created.Id, Title: "edited"}, author)
+	require.NoError(t, err)
+	baseline, err := revisions.GetRevision(created.Id, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "before revisions", baseline.Title)
+
+	for i := 0; i < testRevisionRetention; i++ {
+		require.NoError(t, posts.UpdatePost(PostDTO{Id: created.Id, Content: string(rune('a' + i))}, author))
+	}
+
+	listed, _, err := revisions.GetRevisions(created.Id, Page{Order: SortAsc})
+	require.NoError(t, err)
+	require.Len(t, listed, testRevisionRetention)
+	// the baseline, the edit and the updates made 2+testRevisionRetention revisions
+	assert.Equal(t, 3, listed[0].Revision)
+
+	_, err = revisions.GetRevision(created.Id, 1)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	err = posts.UpdatePost(PostDTO{Id: -1, Title: "missing"}, author)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
@@ -1,16 +1,52 @@
 package database
 
 import (
+	"errors"
+	"fmt"
 	"github.com/jackc/pgx/v5"
 	"log/slog"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
+// MaxTagLength is the length of the tags.name and tags.normalized columns.
+const MaxTagLength = 50
+
+var (
+	// ErrInvalidTag is returned for empty or too long tags and for too many tags on a post.
+	ErrInvalidTag = errors.New("invalid tag")
+	// ErrTagExists is returned when a rename or an alias collides with another tag or alias.
+	ErrTagExists = errors.New("tag already exists")
+	// ErrTagCycle is returned when a parent would make a tag its own ancestor.
+	ErrTagCycle = errors.New("tag hierarchy cycle")
+)
+
+// tagTreeLock is the advisory lock key serializing changes to the tag hierarchy,
+// a cycle check only holds while no other transaction moves tags around.
+const tagTreeLock = 0x7461677472656500
+
 type TagsDTO struct {
 	Id   int
 	Name string
 }
 
+// TagDetailsDTO is a tag with its place in the hierarchy and its aliases.
+type TagDetailsDTO struct {
+	Id       int
+	Name     string
+	ParentID int
+	Aliases  []string
+	Children []TagsDTO
+}
+
+// TagCountDTO is a tag with the number of posts carrying it.
+type TagCountDTO struct {
+	Id        int
+	Name      string
+	PostCount int
+}
+
 type TagsServiceImplementation struct {
 	pg *DbPool
 }
@@ -18,6 +54,7 @@ type TagsServiceImplementation struct {
 //go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name TagsService --output ../../testing/mocks
 type TagsService interface {
 	CreateTag(tag TagsDTO) (TagsDTO, error)
+	UpsertTag(tagName string) (TagsDTO, error)
 	DeleteTag(tagID int) error
 	GetALlTags() ([]TagsDTO, error)
 	GetTagByName(tagName string) (TagsDTO, error)
@@ -25,6 +62,13 @@ type TagsService interface {
 	CreatePostTagsRelation(tag TagsDTO, post PostDTO) error
 	DeletePostTagsRelation(postId int) error
 	GetPostTagsRelation(postID int) ([]TagsDTO, error)
+	SearchTags(prefix string, limit int) ([]TagCountDTO, error)
+	RenameTag(tagID int, name string) (TagsDTO, error)
+	MergeTags(sourceID, targetID int) error
+	GetTagDetails(tagID int) (TagDetailsDTO, error)
+	AddTagAlias(tagID int, alias string) error
+	DeleteTagAlias(tagID int, alias string) error
+	SetTagParent(tagID, parentID int) error
 }
 
 func NewTagService(pg *DbPool) TagsService {
@@ -33,10 +77,36 @@ func NewTagService(pg *DbPool) TagsService {
 	}
 }
 
+// CleanTagName trims a tag and collapses inner whitespace, it is the form a tag is displayed in.
+func CleanTagName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// NormalizeTag returns the canonical form tags are compared by, so "Go", " go " and "GO" are one tag.
+func NormalizeTag(name string) string {
+	return strings.ToLower(CleanTagName(name))
+}
+
+// ValidateTagName checks a cleaned tag name.
+func ValidateTagName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: tag must not be empty", ErrInvalidTag)
+	}
+	if utf8.RuneCountInString(name) > MaxTagLength {
+		return fmt.Errorf("%w: tag %q is longer than %d characters", ErrInvalidTag, name, MaxTagLength)
+	}
+	return nil
+}
+
 func (service *TagsServiceImplementation) CreateTag(tag TagsDTO) (TagsDTO, error) {
-	args := pgx.NamedArgs{"name": tag.Name}
+	name := CleanTagName(tag.Name)
+	if err := ValidateTagName(name); err != nil {
+		return TagsDTO{}, err
+	}
+
+	args := pgx.NamedArgs{"name": name, "normalized": NormalizeTag(name)}
 
-	query := `INSERT INTO tags (name) VALUES (@name) RETURNING id, name`
+	query := `INSERT INTO tags (name, normalized) VALUES (@name, @normalized) RETURNING id, name`
 
 	var createdTag TagsDTO
 	err := service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(
@@ -52,6 +122,42 @@ func (service *TagsServiceImplementation) CreateTag(tag TagsDTO) (TagsDTO, error
 	return createdTag, nil
 }
 
+// UpsertTag returns the tag with the same normalized form as tagName, creating it when
+// there is none. An alias resolves to its canonical tag. It is a single statement, so
+// concurrent callers always get the same tag. The first spelling a tag is created with
+// is kept as its display name.
+func (service *TagsServiceImplementation) UpsertTag(tagName string) (TagsDTO, error) {
+	name := CleanTagName(tagName)
+	if err := ValidateTagName(name); err != nil {
+		return TagsDTO{}, err
+	}
+
+	args := pgx.NamedArgs{"name": name, "normalized": NormalizeTag(name)}
+
+	// DO UPDATE instead of DO NOTHING, so RETURNING yields the existing row as well
+	query := `
+		WITH alias AS (
+			SELECT t.id, t.name FROM tag_aliases a JOIN tags t ON t.id = a.tag_id
+			WHERE a.normalized = @normalized
+		), upserted AS (
+			INSERT INTO tags (name, normalized)
+			SELECT @name, @normalized WHERE NOT EXISTS (SELECT 1 FROM alias)
+			ON CONFLICT (normalized) DO UPDATE SET normalized = EXCLUDED.normalized
+			RETURNING id, name
+		)
+		SELECT id, name FROM alias UNION ALL SELECT id, name FROM upserted
+	`
+
+	var tag TagsDTO
+	err := service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(&tag.Id, &tag.Name)
+	if err != nil {
+		service.pg.Log.Error("Error upserting tag into database", slog.String("error", err.Error()), slog.String("tag", name))
+		return TagsDTO{}, err
+	}
+
+	return tag, nil
+}
+
 func (service *TagsServiceImplementation) DeleteTag(tagID int) error {
 	query := `DELETE FROM tags WHERE id = @id`
 	args := pgx.NamedArgs{"id": tagID}
@@ -76,9 +182,14 @@ func (service *TagsServiceImplementation) GetALlTags() ([]TagsDTO, error) {
 	return pgx.CollectRows(rows, pgx.RowToStructByPos[TagsDTO])
 }
 
+// GetTagByName finds a tag by its name or one of its aliases.
 func (service *TagsServiceImplementation) GetTagByName(tagName string) (TagsDTO, error) {
-	query := `SELECT id, name FROM tags WHERE name = @name`
-	args := pgx.NamedArgs{"name": tagName}
+	query := `
+		SELECT id, name FROM tags WHERE normalized = @normalized
+		UNION ALL
+		SELECT t.id, t.name FROM tag_aliases a JOIN tags t ON t.id = a.tag_id WHERE a.normalized = @normalized
+	`
+	args := pgx.NamedArgs{"normalized": NormalizeTag(tagName)}
 	row := service.pg.Db.QueryRow(service.pg.Ctx, query, args)
 	tag := TagsDTO{}
 	err := row.Scan(&tag.Id, &tag.Name)
@@ -109,7 +220,7 @@ func (service *TagsServiceImplementation) CreatePostTagsRelation(tag TagsDTO, po
 		"tag_id":  tag.Id,
 	}
 
-	query := `INSERT INTO posts_tags (post_id, tag_id) VALUES (@post_id, @tag_id)`
+	query := `INSERT INTO posts_tags (post_id, tag_id) VALUES (@post_id, @tag_id) ON CONFLICT DO NOTHING`
 
 	_, err := service.pg.Db.Exec(service.pg.Ctx, query, args)
 	if err != nil {
@@ -132,7 +243,7 @@ func (service *TagsServiceImplementation) DeletePostTagsRelation(postId int) err
 }
 
 func (service *TagsServiceImplementation) GetPostTagsRelation(postID int) ([]TagsDTO, error) {
-	query := `SELECT tag_id FROM posts_tags WHERE post_id = @post_id`
+	query := `SELECT t.id, t.name FROM posts_tags pt JOIN tags t ON t.id = pt.tag_id WHERE pt.post_id = @post_id ORDER BY t.name`
 	rows, err := service.pg.Db.Query(service.pg.Ctx, query, pgx.NamedArgs{"post_id": postID})
 	if err != nil {
 		service.pg.Log.Error("Error get post tags relation from database", slog.String("error", err.Error()), slog.String("query", query))
@@ -140,29 +251,325 @@ func (service *TagsServiceImplementation) GetPostTagsRelation(postID int) ([]Tag
 	}
 	defer rows.Close()
 
-	var tagIDs []int
-	for rows.Next() {
-		var tagID int
-		if err = rows.Scan(&tagID); err != nil {
-			service.pg.Log.Error("Error scanning row post tags relation", slog.String("error", err.Error()))
-			return nil, err
-		}
-		tagIDs = append(tagIDs, tagID)
+	tags, err := pgx.CollectRows(rows, pgx.RowToStructByPos[TagsDTO])
+	if err != nil {
+		service.pg.Log.Error("Error scanning row post tags relation", slog.String("error", err.Error()))
+		return nil, err
 	}
 
-	if len(tagIDs) == 0 {
+	if len(tags) == 0 {
 		return nil, nil
 	}
 
-	var tags []TagsDTO
-	for _, tagID := range tagIDs {
-		tag, err := service.GetTagByID(tagID)
+	return tags, nil
+}
+
+// SearchTags returns up to limit tags whose normalized form or one of whose aliases
+// starts with prefix, most used first. An empty prefix lists every tag.
+func (service *TagsServiceImplementation) SearchTags(prefix string, limit int) ([]TagCountDTO, error) {
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+
+	query := `
+		SELECT t.id, t.name, count(pt.post_id)::int AS post_count
+		FROM tags t LEFT JOIN posts_tags pt ON pt.tag_id = t.id
+		WHERE t.normalized LIKE @prefix
+			OR EXISTS (SELECT 1 FROM tag_aliases a WHERE a.tag_id = t.id AND a.normalized LIKE @prefix)
+		GROUP BY t.id
+		ORDER BY post_count DESC, t.normalized
+		LIMIT @limit
+	`
+	args := pgx.NamedArgs{
+		"prefix": likePrefix(NormalizeTag(prefix)),
+		"limit":  limit,
+	}
+
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error searching tags in database", slog.String("error", err.Error()), slog.String("prefix", prefix))
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[TagCountDTO])
+}
+
+// RenameTag changes the display name of a tag. It fails with ErrTagExists when the
+// new name normalizes to another tag or to an alias of another tag. An alias of the
+// tag itself is dropped, since the tag is now found by that name anyway.
+func (service *TagsServiceImplementation) RenameTag(tagID int, name string) (TagsDTO, error) {
+	name = CleanTagName(name)
+	if err := ValidateTagName(name); err != nil {
+		return TagsDTO{}, err
+	}
+
+	args := pgx.NamedArgs{
+		"id":         tagID,
+		"name":       name,
+		"normalized": NormalizeTag(name),
+	}
+
+	var tag TagsDTO
+	err := service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		var aliasOf int
+		err := tx.Db.QueryRow(tx.Ctx, `SELECT tag_id FROM tag_aliases WHERE normalized = @normalized FOR UPDATE`, args).Scan(&aliasOf)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+		case err != nil:
+			return err
+		case aliasOf != tagID:
+			return fmt.Errorf("%w: %q", ErrTagExists, name)
+		default:
+			if _, err = tx.Db.Exec(tx.Ctx, `DELETE FROM tag_aliases WHERE normalized = @normalized`, args); err != nil {
+				return err
+			}
+		}
+
+		query := `UPDATE tags SET name = @name, normalized = @normalized WHERE id = @id RETURNING id, name`
+		return tx.Db.QueryRow(tx.Ctx, query, args).Scan(&tag.Id, &tag.Name)
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return TagsDTO{}, fmt.Errorf("%w: %q", ErrTagExists, name)
+		}
+		if !errors.Is(err, ErrTagExists) {
+			service.pg.Log.Error("Error renaming tag in database", slog.String("error", err.Error()), slog.String("tagid", strconv.Itoa(tagID)))
+		}
+		return TagsDTO{}, err
+	}
+
+	return tag, nil
+}
+
+// MergeTags moves every post and follower of the source tag to the target tag and deletes the source tag.
+// The source name and aliases become aliases of the target, so they keep resolving to it,
+// and the children of the source are moved up to its parent.
+func (service *TagsServiceImplementation) MergeTags(sourceID, targetID int) error {
+	if sourceID == targetID {
+		return fmt.Errorf("%w: can not merge a tag into itself", ErrInvalidTag)
+	}
+
+	return service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		if err := lockTagTree(tx); err != nil {
+			return err
+		}
+
+		// lock both tags, so neither is renamed, merged or deleted concurrently
+		var locked int
+		err := tx.Db.QueryRow(tx.Ctx, `SELECT count(*) FROM (SELECT id FROM tags WHERE id IN (@source_id, @target_id) FOR UPDATE) t`, pgx.NamedArgs{
+			"source_id": sourceID,
+			"target_id": targetID,
+		}).Scan(&locked)
+		if err != nil {
+			tx.Log.Error("Error locking tags to merge", slog.String("error", err.Error()))
+			return err
+		}
+		if locked != 2 {
+			return pgx.ErrNoRows
+		}
+
+		query := `
+			INSERT INTO posts_tags (post_id, tag_id)
+			SELECT post_id, @target_id FROM posts_tags WHERE tag_id = @source_id
+			ON CONFLICT DO NOTHING
+		`
+		_, err = tx.Db.Exec(tx.Ctx, query, pgx.NamedArgs{"source_id": sourceID, "target_id": targetID})
+		if err != nil {
+			tx.Log.Error("Error moving posts to merged tag", slog.String("error", err.Error()))
+			return err
+		}
+
+		args := pgx.NamedArgs{"source_id": sourceID, "target_id": targetID}
+
+		_, err = tx.Db.Exec(tx.Ctx, `UPDATE tag_aliases SET tag_id = @target_id WHERE tag_id = @source_id`, args)
+		if err != nil {
+			tx.Log.Error("Error moving aliases to merged tag", slog.String("error", err.Error()))
+			return err
+		}
+
+		query = `
+			INSERT INTO tag_follows (user_id, tag_id, created_at)
+			SELECT user_id, @target_id, created_at FROM tag_follows WHERE tag_id = @source_id
+			ON CONFLICT DO NOTHING
+		`
+		_, err = tx.Db.Exec(tx.Ctx, query, args)
+		if err != nil {
+			tx.Log.Error("Error moving followers to merged tag", slog.String("error", err.Error()))
+			return err
+		}
+
+		query = `INSERT INTO tag_aliases (normalized, tag_id) SELECT normalized, @target_id FROM tags WHERE id = @source_id`
+		_, err = tx.Db.Exec(tx.Ctx, query, args)
+		if err != nil {
+			tx.Log.Error("Error adding merged tag name as alias", slog.String("error", err.Error()))
+			return err
+		}
+
+		// this only shortens paths, so it can not introduce a cycle
+		query = `UPDATE tags SET parent_id = (SELECT parent_id FROM tags WHERE id = @source_id) WHERE parent_id = @source_id`
+		_, err = tx.Db.Exec(tx.Ctx, query, args)
+		if err != nil {
+			tx.Log.Error("Error moving children of merged tag", slog.String("error", err.Error()))
+			return err
+		}
+
+		// the source relations are removed by ON DELETE CASCADE
+		_, err = tx.Db.Exec(tx.Ctx, `DELETE FROM tags WHERE id = @id`, pgx.NamedArgs{"id": sourceID})
+		if err != nil {
+			tx.Log.Error("Error deleting merged tag", slog.String("error", err.Error()))
+			return err
+		}
+
+		return nil
+	})
+}
+
+// lockTagTree serializes hierarchy changes until the end of the transaction.
+func lockTagTree(tx *DbPool) error {
+	_, err := tx.Db.Exec(tx.Ctx, `SELECT pg_advisory_xact_lock(@key)`, pgx.NamedArgs{"key": tagTreeLock})
+	if err != nil {
+		tx.Log.Error("Error locking tag hierarchy", slog.String("error", err.Error()))
+	}
+	return err
+}
+
+// GetTagDetails returns a tag with its parent, aliases and direct children.
+func (service *TagsServiceImplementation) GetTagDetails(tagID int) (TagDetailsDTO, error) {
+	query := `
+		SELECT t.id, t.name, coalesce(t.parent_id, 0),
+			coalesce((SELECT array_agg(a.normalized ORDER BY a.normalized) FROM tag_aliases a WHERE a.tag_id = t.id), '{}')
+		FROM tags t WHERE t.id = @id
+	`
+	args := pgx.NamedArgs{"id": tagID}
+
+	var tag TagDetailsDTO
+	err := service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(&tag.Id, &tag.Name, &tag.ParentID, &tag.Aliases)
+	if err != nil {
+		service.pg.Log.Error("Error getting tag details from database", slog.String("error", err.Error()), slog.String("tagid", strconv.Itoa(tagID)))
+		return TagDetailsDTO{}, err
+	}
+
+	rows, err := service.pg.Db.Query(service.pg.Ctx, `SELECT id, name FROM tags WHERE parent_id = @id ORDER BY normalized`, args)
+	if err != nil {
+		service.pg.Log.Error("Error getting tag children from database", slog.String("error", err.Error()), slog.String("tagid", strconv.Itoa(tagID)))
+		return TagDetailsDTO{}, err
+	}
+	defer rows.Close()
+
+	tag.Children, err = pgx.CollectRows(rows, pgx.RowToStructByPos[TagsDTO])
+	if err != nil {
+		service.pg.Log.Error("Error scanning tag children", slog.String("error", err.Error()))
+		return TagDetailsDTO{}, err
+	}
+
+	return tag, nil
+}
+
+// AddTagAlias makes alias resolve to the tag. It fails with ErrTagExists when the alias
+// already names a tag or is an alias of another tag, merge the tags instead.
+func (service *TagsServiceImplementation) AddTagAlias(tagID int, alias string) error {
+	alias = CleanTagName(alias)
+	if err := ValidateTagName(alias); err != nil {
+		return err
+	}
+
+	args := pgx.NamedArgs{"id": tagID, "normalized": NormalizeTag(alias)}
+
+	err := service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		var existing int
+		err := tx.Db.QueryRow(tx.Ctx, `SELECT id FROM tags WHERE normalized = @normalized FOR SHARE`, args).Scan(&existing)
+		if err == nil {
+			return fmt.Errorf("%w: %q", ErrTagExists, alias)
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		query := `
+			INSERT INTO tag_aliases (normalized, tag_id) VALUES (@normalized, @id)
+			ON CONFLICT (normalized) DO UPDATE SET tag_id = EXCLUDED.tag_id WHERE tag_aliases.tag_id = EXCLUDED.tag_id
+		`
+		result, err := tx.Db.Exec(tx.Ctx, query, args)
 		if err != nil {
-			service.pg.Log.Error("Error add tags to post from post tags relation from database", slog.String("error", err.Error()))
-			return nil, err
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("%w: %q", ErrTagExists, alias)
 		}
-		tags = append(tags, tag)
+		return nil
+	})
+	if err != nil && !errors.Is(err, ErrTagExists) {
+		service.pg.Log.Error("Error adding tag alias to database", slog.String("error", err.Error()), slog.String("alias", alias))
 	}
+	return err
+}
 
-	return tags, nil
+// DeleteTagAlias removes an alias of the tag, it returns pgx.ErrNoRows when the tag has no such alias.
+func (service *TagsServiceImplementation) DeleteTagAlias(tagID int, alias string) error {
+	query := `DELETE FROM tag_aliases WHERE normalized = @normalized AND tag_id = @id`
+	args := pgx.NamedArgs{"id": tagID, "normalized": NormalizeTag(alias)}
+
+	result, err := service.pg.Db.Exec(service.pg.Ctx, query, args)
+	if err != nil {
+		service.pg.Log.Error("Error deleting tag alias from database", slog.String("error", err.Error()), slog.String("alias", alias))
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// SetTagParent moves a tag under parentID, a parentID of 0 makes it a root tag.
+// It fails with ErrTagCycle when the parent is the tag itself or one of its descendants.
+func (service *TagsServiceImplementation) SetTagParent(tagID, parentID int) error {
+	if tagID == parentID {
+		return fmt.Errorf("%w: a tag can not be its own parent", ErrTagCycle)
+	}
+
+	return service.pg.InTx(service.pg.Ctx, func(tx *DbPool) error {
+		if err := lockTagTree(tx); err != nil {
+			return err
+		}
+
+		args := pgx.NamedArgs{"id": tagID, "parent_id": parentID}
+
+		if parentID != 0 {
+			// walk up from the new parent, reaching the tag means the tag is an ancestor of its parent
+			query := `
+				WITH RECURSIVE ancestors AS (
+					SELECT id, parent_id FROM tags WHERE id = @parent_id
+					UNION
+					SELECT t.id, t.parent_id FROM tags t JOIN ancestors a ON t.id = a.parent_id
+				)
+				SELECT count(*) > 0, coalesce(bool_or(id = @id), false) FROM ancestors
+			`
+			var parentExists, cycle bool
+			err := tx.Db.QueryRow(tx.Ctx, query, args).Scan(&parentExists, &cycle)
+			if err != nil {
+				tx.Log.Error("Error checking tag ancestors", slog.String("error", err.Error()))
+				return err
+			}
+			if !parentExists {
+				return pgx.ErrNoRows
+			}
+			if cycle {
+				return fmt.Errorf("%w: tag %d is a descendant of tag %d", ErrTagCycle, parentID, tagID)
+			}
+		}
+
+		result, err := tx.Db.Exec(tx.Ctx, `UPDATE tags SET parent_id = nullif(@parent_id, 0) WHERE id = @id`, args)
+		if err != nil {
+			tx.Log.Error("Error setting tag parent", slog.String("error", err.Error()), slog.String("tagid", strconv.Itoa(tagID)))
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return pgx.ErrNoRows
+		}
+		return nil
+	})
 }
@@ -0,0 +1,184 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		name               string
+		tag                string
+		expectedClean      string
+		expectedNormalized string
+	}{
+		{name: "AlreadyClean", tag: "go", expectedClean: "go", expectedNormalized: "go"},
+		{name: "Case", tag: "Go", expectedClean: "Go", expectedNormalized: "go"},
+		{name: "Whitespace", tag: "  Web \t Dev\n", expectedClean: "Web Dev", expectedNormalized: "web dev"},
+		{name: "Unicode", tag: "Привет Мир", expectedClean: "Привет Мир", expectedNormalized: "привет мир"},
+		{name: "Blank", tag: " \t ", expectedClean: "", expectedNormalized: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedClean, CleanTagName(tt.tag))
+			assert.Equal(t, tt.expectedNormalized, NormalizeTag(tt.tag))
+		})
+	}
+}
+
+func TestCleanTags(t *testing.T) {
+	service := &PostServiceImplementation{maxTagsPerPost: 3}
+
+	tests := []struct {
+		name        string
+		tags        []string
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name:     "DuplicatesKeepFirstSpelling",
+			tags:     []string{"Go", " go ", "GO", "web  dev", "Web Dev"},
+			expected: []string{"Go", "web dev"},
+		},
+		{
+			name:     "DuplicatesDoNotCountTowardsTheLimit",
+			tags:     []string{"a", "b", "c", "A", "B"},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:        "TooManyTags",
+			tags:        []string{"a", "b", "c", "d"},
+			expectedErr: true,
+		},
+		{
+			name:        "EmptyTag",
+			tags:        []string{"a", "  "},
+			expectedErr: true,
+		},
+		{
+			name:     "MaxLength",
+			tags:     []string{strings.Repeat("я", MaxTagLength)},
+			expected: []string{strings.Repeat("я", MaxTagLength)},
+		},
+		{
+			name:        "TooLong",
+			tags:        []string{strings.Repeat("я", MaxTagLength+1)},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleaned, err := service.cleanTags(tt.tags)
+			if tt.expectedErr {
+				assert.ErrorIs(t, err, ErrInvalidTag)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, cleaned)
+		})
+	}
+}
+
+func TestTagsAdministration(t *testing.T) {
+	pg, _ := newTestPool(t)
+	userID, prefix := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	tags := NewTagService(pg)
+
+	_, err := posts.CreatePost(PostDTO{Title: "first", UserId: userID, Tags: []string{prefix + "-go", prefix + "-golang"}})
+	require.NoError(t, err)
+	_, err = posts.CreatePost(PostDTO{Title: "second", UserId: userID, Tags: []string{prefix + "-golang"}})
+	require.NoError(t, err)
+
+	found, err := tags.SearchTags(strings.ToUpper(prefix), 10)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, prefix+"-golang", found[0].Name)
+	assert.Equal(t, 2, found[0].PostCount)
+	assert.Equal(t, 1, found[1].PostCount)
+
+	goTag, golangTag := found[1], found[0]
+
+	_, err = tags.RenameTag(goTag.Id, strings.ToUpper(prefix)+"-GOLANG")
+	assert.ErrorIs(t, err, ErrTagExists)
+
+	renamed, err := tags.RenameTag(goTag.Id, prefix+"-Go")
+	require.NoError(t, err)
+	assert.Equal(t, prefix+"-Go", renamed.Name)
+
+	require.NoError(t, tags.MergeTags(golangTag.Id, goTag.Id))
+
+	found, err = tags.SearchTags(prefix, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []TagCountDTO{{Id: goTag.Id, Name: prefix + "-Go", PostCount: 2}}, found)
+
+	assert.ErrorIs(t, tags.MergeTags(golangTag.Id, goTag.Id), pgx.ErrNoRows)
+}
+
+func TestTagAliasesAndHierarchy(t *testing.T) {
+	pg, _ := newTestPool(t)
+	userID, prefix := newTestUser(t, pg)
+	posts := NewPostService(pg, NewTagService(pg), testMaxTagsPerPost, testRevisionRetention)
+	tags := NewTagService(pg)
+
+	databases, err := tags.UpsertTag(prefix + "-databases")
+	require.NoError(t, err)
+	postgres, err := tags.UpsertTag(prefix + "-postgres")
+	require.NoError(t, err)
+	goTag, err := tags.UpsertTag(prefix + "-go")
+	require.NoError(t, err)
+
+	require.NoError(t, tags.AddTagAlias(goTag.Id, prefix+"-GoLang"))
+	assert.ErrorIs(t, tags.AddTagAlias(postgres.Id, prefix+"-golang"), ErrTagExists)
+	assert.ErrorIs(t, tags.AddTagAlias(postgres.Id, prefix+"-go"), ErrTagExists)
+
+	resolved, err := tags.UpsertTag(prefix + "-golang")
+	require.NoError(t, err)
+	assert.Equal(t, goTag, resolved)
+
+	post, err := posts.CreatePost(PostDTO{Title: "aliased", UserId: userID, Tags: []string{prefix + "-golang", prefix + "-postgres"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{goTag.Name, postgres.Name}, post.Tags)
+
+	require.NoError(t, tags.SetTagParent(postgres.Id, databases.Id))
+	assert.ErrorIs(t, tags.SetTagParent(databases.Id, postgres.Id), ErrTagCycle)
+	assert.ErrorIs(t, tags.SetTagParent(databases.Id, databases.Id), ErrTagCycle)
+
+	filter := PostFilter{AuthorID: userID, Tags: []string{prefix + "-databases"}}
+	found, _, err := posts.GetALlPosts(filter, Page{})
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	filter.IncludeDescendants = true
+	found, _, err = posts.GetALlPosts(filter, Page{})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, post.Id, found[0].Id)
+
+	found, _, err = posts.GetALlPosts(PostFilter{AuthorID: userID, Tags: []string{prefix + "-golang"}}, Page{})
+	require.NoError(t, err)
+	assert.Len(t, found, 1)
+
+	details, err := tags.GetTagDetails(databases.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []TagsDTO{postgres}, details.Children)
+
+	// merging keeps the source name resolving and moves its children up
+	require.NoError(t, tags.MergeTags(databases.Id, goTag.Id))
+	details, err = tags.GetTagDetails(goTag.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{prefix + "-databases", prefix + "-golang"}, details.Aliases)
+
+	details, err = tags.GetTagDetails(postgres.Id)
+	require.NoError(t, err)
+	assert.Zero(t, details.ParentID)
+
+	require.NoError(t, tags.DeleteTagAlias(goTag.Id, prefix+"-golang"))
+	assert.ErrorIs(t, tags.DeleteTagAlias(goTag.Id, prefix+"-golang"), pgx.ErrNoRows)
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"log/slog"
+)
+
+// DB is implemented by both *pgxpool.Pool and pgx.Tx, so services work the same
+// whether they run on the pool or inside a transaction. Begin on a pgx.Tx opens a savepoint.
+type DB interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// InTx runs fn with a DbPool bound to a new transaction, committing it when fn returns nil
+// and rolling it back otherwise. Called on a DbPool that is already a transaction,
+// it nests through a savepoint, so the outer transaction still decides the final outcome.
+func (pg *DbPool) InTx(ctx context.Context, fn func(tx *DbPool) error) error {
+	tx, err := pg.Db.Begin(ctx)
+	if err != nil {
+		pg.Log.Error("Error beginning transaction", slog.String("error", err.Error()))
+		return err
+	}
+
+	defer func() {
+		// a no-op once the transaction is committed
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			pg.Log.Error("Error rolling back transaction", slog.String("error", err.Error()))
+		}
+	}()
+
+	if err = fn(&DbPool{Db: tx, Ctx: ctx, Log: pg.Log}); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		pg.Log.Error("Error committing transaction", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// Repositories are the services bound to a single transaction.
+type Repositories struct {
+	Users    UserService
+	Posts    PostService
+	Tags     TagsService
+	Comments CommentService
+}
+
+func newRepositories(pg *DbPool, maxTagsPerPost, revisionRetention int) Repositories {
+	tags := NewTagService(pg)
+	return Repositories{
+		Users:    NewUserService(pg),
+		Posts:    NewPostService(pg, tags, maxTagsPerPost, revisionRetention),
+		Tags:     tags,
+		Comments: NewCommentService(pg),
+	}
+}
+
+type UnitOfWorkImplementation struct {
+	pg                *DbPool
+	maxTagsPerPost    int
+	revisionRetention int
+}
+
+// UnitOfWork lets handlers group several service calls into one request-scoped transaction.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name UnitOfWork --output ../../testing/mocks
+type UnitOfWork interface {
+	// Do runs fn in a transaction bound to ctx. Everything done through repos commits
+	// when fn returns nil and rolls back when it returns an error or ctx is canceled.
+	Do(ctx context.Context, fn func(repos Repositories) error) error
+}
+
+func NewUnitOfWork(pg *DbPool, maxTagsPerPost, revisionRetention int) UnitOfWork {
+	return &UnitOfWorkImplementation{
+		pg:                pg,
+		maxTagsPerPost:    maxTagsPerPost,
+		revisionRetention: revisionRetention,
+	}
+}
+
+func (uow *UnitOfWorkImplementation) Do(ctx context.Context, fn func(repos Repositories) error) error {
+	return uow.pg.InTx(ctx, func(tx *DbPool) error {
+		return fn(newRepositories(tx, uow.maxTagsPerPost, uow.revisionRetention))
+	})
+}
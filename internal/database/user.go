@@ -1,21 +1,66 @@
 package database
 
 import (
+	"errors"
+	"fmt"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"go-rest-api-auth/internal/utils"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 )
 
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Roles are the valid roles of a user.
+var Roles = []string{RoleUser, RoleModerator, RoleAdmin}
+
+// ErrInvalidRole is returned for roles not in Roles.
+var ErrInvalidRole = errors.New("invalid role")
+
+// UserDTO is the storage representation of a user. It must never be sent to clients as is,
+// use the views package instead. Avatar is the id of the avatar, empty when there is none.
+// FollowerCount and FollowingCount are counted when the user is loaded, see userColumns.
+// Suspended users can not log in, see ModerationService.
 type UserDTO struct {
-	Id          int
-	Username    string
-	Password    string
-	Description string
-	DateJoined  pgtype.Date
+	Id             int
+	Username       string
+	Password       string
+	Description    string
+	DateJoined     pgtype.Date
+	TokenVersion   int
+	Role           string
+	Avatar         string
+	Suspended      bool
+	FollowerCount  int
+	FollowingCount int
+}
+
+// userColumns selects a user together with the number of their followers and of the users they follow.
+const userColumns = `id, username, password, description, date_joined, token_version, role, coalesce(avatar, '') AS avatar, suspended_at IS NOT NULL AS suspended, (
+	SELECT count(*) FROM follows f WHERE f.followee_id = users.id
+)::int AS follower_count, (
+	SELECT count(*) FROM follows f WHERE f.follower_id = users.id
+)::int AS following_count`
+
+// UserFilter narrows the list of users. Zero values are ignored and JoinedTo is exclusive.
+type UserFilter struct {
+	JoinedFrom     time.Time
+	JoinedTo       time.Time
+	UsernamePrefix string
+}
+
+var userSortColumns = map[string]sortColumn{
+	"id":          {column: "id"},
+	"username":    {column: "username", parse: parseText},
+	"date_joined": {column: "date_joined", parse: parseDate},
 }
 
 type UserServiceImplementation struct {
@@ -28,8 +73,12 @@ type UserService interface {
 	DeleteUser(userID int) error
 	UpdateUser(user UserDTO) error
 	GetUserById(userID int) (UserDTO, error)
-	GetALlUsers() ([]UserDTO, error)
+	GetALlUsers(filter UserFilter, page Page) ([]UserDTO, string, error)
 	GetUserByName(username string) (UserDTO, error)
+	ChangePassword(userID int, password string) (int, error)
+	GetTokenVersion(userID int) (int, error)
+	BumpTokenVersion(userID int) (int, error)
+	SetRole(userID int, role string) (int, error)
 }
 
 func NewUserService(pg *DbPool) UserService {
@@ -57,10 +106,10 @@ func (service *UserServiceImplementation) CreateUser(user UserDTO) (UserDTO, err
 	}
 	var query string
 	if user.Description == "" {
-		query = `INSERT INTO users (username, password, date_joined) VALUES (@username, @password, @dateJoined) RETURNING id, username, password, description, date_joined`
+		query = `INSERT INTO users (username, password, date_joined) VALUES (@username, @password, @dateJoined) RETURNING id, username, password, description, date_joined, token_version, role`
 	} else {
 		args["description"] = user.Description
-		query = `INSERT INTO users (username, password, description, date_joined) VALUES (@username, @password, @description, @dateJoined) RETURNING id, username, password, description, date_joined`
+		query = `INSERT INTO users (username, password, description, date_joined) VALUES (@username, @password, @description, @dateJoined) RETURNING id, username, password, description, date_joined, token_version, role`
 	}
 	var createdUser UserDTO
 	err = service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(
@@ -69,6 +118,8 @@ func (service *UserServiceImplementation) CreateUser(user UserDTO) (UserDTO, err
 		&createdUser.Password,
 		&createdUser.Description,
 		&createdUser.DateJoined,
+		&createdUser.TokenVersion,
+		&createdUser.Role,
 	)
 	if err != nil {
 		service.pg.Log.Error("Error creating new user in database", slog.String("username", user.Username))
@@ -100,15 +151,6 @@ func (service *UserServiceImplementation) UpdateUser(user UserDTO) error {
 		setClauses = append(setClauses, "username = @username")
 		args["username"] = user.Username
 	}
-	if user.Password != "" {
-		setClauses = append(setClauses, "password = @password")
-		hashedPassword, err := utils.HashPassword(user.Password)
-		if err != nil {
-			service.pg.Log.Error("Error hashing password in update user in database", slog.String("password", user.Password))
-			return err
-		}
-		args["password"] = hashedPassword
-	}
 	if user.Description != "" {
 		setClauses = append(setClauses, "description = @description")
 		args["description"] = user.Description
@@ -124,14 +166,38 @@ func (service *UserServiceImplementation) UpdateUser(user UserDTO) error {
 	return nil
 }
 
+// ChangePassword stores a new password and bumps the token version,
+// so every token and session issued with the old password stops working.
+func (service *UserServiceImplementation) ChangePassword(userID int, password string) (int, error) {
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		service.pg.Log.Error("Error hashing password in change password", slog.String("user_id", strconv.Itoa(userID)))
+		return 0, err
+	}
+
+	query := `UPDATE users SET password = @password, token_version = token_version + 1 WHERE id = @id RETURNING token_version`
+	args := pgx.NamedArgs{
+		"id":       userID,
+		"password": hashedPassword,
+	}
+	var tokenVersion int
+	err = service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(&tokenVersion)
+	if err != nil {
+		service.pg.Log.Error("Error changing password in database", slog.String("user_id", strconv.Itoa(userID)), slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return tokenVersion, nil
+}
+
 func (service *UserServiceImplementation) GetUserById(userID int) (UserDTO, error) {
-	query := `SELECT id,username,password,description,date_joined FROM users WHERE id = @id`
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = @id`
 	args := pgx.NamedArgs{
 		"id": userID,
 	}
 	row := service.pg.Db.QueryRow(service.pg.Ctx, query, args)
 	user := UserDTO{}
-	err := row.Scan(&user.Id, &user.Username, &user.Password, &user.Description, &user.DateJoined)
+	err := scanUser(row, &user)
 	if err != nil {
 		service.pg.Log.Error("Error getting user by id from database", slog.String("user_id", strconv.Itoa(userID)), slog.String("error", err.Error()))
 		return UserDTO{}, err
@@ -141,13 +207,13 @@ func (service *UserServiceImplementation) GetUserById(userID int) (UserDTO, erro
 }
 
 func (service *UserServiceImplementation) GetUserByName(username string) (UserDTO, error) {
-	query := `SELECT id,username,password,description,date_joined FROM users WHERE username = @username`
+	query := `SELECT ` + userColumns + ` FROM users WHERE username = @username`
 	args := pgx.NamedArgs{
 		"username": username,
 	}
 	row := service.pg.Db.QueryRow(service.pg.Ctx, query, args)
 	user := UserDTO{}
-	err := row.Scan(&user.Id, &user.Username, &user.Password, &user.Description, &user.DateJoined)
+	err := scanUser(row, &user)
 	if err != nil {
 		service.pg.Log.Error("Error getting user by name from database", slog.String("username", username), slog.String("error", err.Error()))
 		return UserDTO{}, err
@@ -156,15 +222,145 @@ func (service *UserServiceImplementation) GetUserByName(username string) (UserDT
 	return user, nil
 }
 
-func (service *UserServiceImplementation) GetALlUsers() ([]UserDTO, error) {
-	query := `SELECT id,username,password,description,date_joined FROM users`
+// GetALlUsers returns a page of users matching filter and the cursor of the next page,
+// which is empty on the last one. Users are sorted by id ascending by default.
+func (service *UserServiceImplementation) GetALlUsers(filter UserFilter, page Page) ([]UserDTO, string, error) {
+	ks, err := newKeyset(page, userSortColumns, "id", SortAsc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	args := pgx.NamedArgs{}
+	conditions := filter.conditions(args)
+	condition, err := ks.condition(args)
+	if err != nil {
+		return nil, "", err
+	}
+	if condition != "" {
+		conditions = append(conditions, condition)
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users` + whereClause(conditions) + ks.orderBy() + ks.limitClause(args)
 
-	rows, err := service.pg.Db.Query(service.pg.Ctx, query)
+	rows, err := service.pg.Db.Query(service.pg.Ctx, query, args)
 	if err != nil {
-		service.pg.Log.Error("Error getting all users from database")
-		return nil, err
+		service.pg.Log.Error("Error getting all users from database", slog.String("error", err.Error()))
+		return nil, "", err
 	}
 	defer rows.Close()
 
-	return pgx.CollectRows(rows, pgx.RowToStructByPos[UserDTO])
+	users, err := pgx.CollectRows(rows, pgx.RowToStructByPos[UserDTO])
+	if err != nil {
+		service.pg.Log.Error("Error scanning users from database", slog.String("error", err.Error()))
+		return nil, "", err
+	}
+
+	if len(users) <= ks.limit {
+		return users, "", nil
+	}
+
+	users = users[:ks.limit]
+	last := users[len(users)-1]
+	return users, ks.nextCursor(userCursorValue(ks.sort, last), last.Id), nil
+}
+
+func (filter UserFilter) conditions(args pgx.NamedArgs) []string {
+	var conditions []string
+
+	if !filter.JoinedFrom.IsZero() {
+		conditions = append(conditions, "date_joined >= @joined_from")
+		args["joined_from"] = pgtype.Date{Time: filter.JoinedFrom, Valid: true}
+	}
+	if !filter.JoinedTo.IsZero() {
+		conditions = append(conditions, "date_joined < @joined_to")
+		args["joined_to"] = pgtype.Date{Time: filter.JoinedTo, Valid: true}
+	}
+	if filter.UsernamePrefix != "" {
+		conditions = append(conditions, "username ILIKE @username_prefix")
+		args["username_prefix"] = likePrefix(filter.UsernamePrefix)
+	}
+
+	return conditions
+}
+
+// scanUser scans a row selecting userColumns, followed by the extra destinations.
+func scanUser(row pgx.Row, user *UserDTO, extra ...any) error {
+	return row.Scan(append([]any{
+		&user.Id,
+		&user.Username,
+		&user.Password,
+		&user.Description,
+		&user.DateJoined,
+		&user.TokenVersion,
+		&user.Role,
+		&user.Avatar,
+		&user.Suspended,
+		&user.FollowerCount,
+		&user.FollowingCount,
+	}, extra...)...)
+}
+
+func userCursorValue(sort string, user UserDTO) string {
+	switch sort {
+	case "username":
+		return user.Username
+	case "date_joined":
+		return user.DateJoined.Time.Format(cursorDateLayout)
+	default:
+		return ""
+	}
+}
+
+func (service *UserServiceImplementation) GetTokenVersion(userID int) (int, error) {
+	query := `SELECT token_version FROM users WHERE id = @id`
+	args := pgx.NamedArgs{
+		"id": userID,
+	}
+	var tokenVersion int
+	err := service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(&tokenVersion)
+	if err != nil {
+		service.pg.Log.Error("Error getting token version from database", slog.String("user_id", strconv.Itoa(userID)), slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return tokenVersion, nil
+}
+
+// BumpTokenVersion revokes every access token, refresh token and session issued to the user so far.
+func (service *UserServiceImplementation) BumpTokenVersion(userID int) (int, error) {
+	query := `UPDATE users SET token_version = token_version + 1 WHERE id = @id RETURNING token_version`
+	args := pgx.NamedArgs{
+		"id": userID,
+	}
+	var tokenVersion int
+	err := service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(&tokenVersion)
+	if err != nil {
+		service.pg.Log.Error("Error bumping token version in database", slog.String("user_id", strconv.Itoa(userID)), slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return tokenVersion, nil
+}
+
+// SetRole changes the role of a user and bumps their token version in the same statement, so the
+// tokens and sessions issued with the previous role stop working right away. It returns the new
+// token version, pgx.ErrNoRows when the user does not exist and ErrInvalidRole for roles not in Roles.
+func (service *UserServiceImplementation) SetRole(userID int, role string) (int, error) {
+	if !slices.Contains(Roles, role) {
+		return 0, fmt.Errorf("%w: %q is not one of %v", ErrInvalidRole, role, Roles)
+	}
+
+	query := `UPDATE users SET role = @role, token_version = token_version + 1 WHERE id = @id RETURNING token_version`
+	args := pgx.NamedArgs{
+		"id":   userID,
+		"role": role,
+	}
+	var tokenVersion int
+	err := service.pg.Db.QueryRow(service.pg.Ctx, query, args).Scan(&tokenVersion)
+	if err != nil {
+		service.pg.Log.Error("Error setting role in database", slog.String("user_id", strconv.Itoa(userID)), slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return tokenVersion, nil
 }
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSetRole(t *testing.T) {
+	pg, _ := newTestPool(t)
+	userID, _ := newTestUser(t, pg)
+	users := NewUserService(pg)
+
+	before, err := users.GetUserById(userID)
+	require.NoError(t, err)
+
+	tokenVersion, err := users.SetRole(userID, RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, before.TokenVersion+1, tokenVersion)
+
+	user, err := users.GetUserById(userID)
+	require.NoError(t, err)
+	assert.Equal(t, RoleAdmin, user.Role)
+	assert.Equal(t, tokenVersion, user.TokenVersion)
+
+	_, err = users.SetRole(userID, "owner")
+	assert.ErrorIs(t, err, ErrInvalidRole)
+	_, err = users.SetRole(-1, RoleUser)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
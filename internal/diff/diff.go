@@ -0,0 +1,218 @@
+// Package diff produces line based unified diffs.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	contextLines = 3
+	// maxEdits bounds the work spent on very different texts, beyond it the
+	// diff replaces everything between the common prefix and suffix
+	maxEdits = 1000
+)
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type op struct {
+	kind opKind
+	line string
+}
+
+// Unified returns the unified diff turning from into to, labeled with the given names,
+// or "" when both are the same.
+func Unified(fromName, toName, from, to string) string {
+	ops := edits(splitLines(from), splitLines(to))
+
+	var sb strings.Builder
+	// positions of each op in from and to, 0 based
+	fromPos, toPos := make([]int, len(ops)+1), make([]int, len(ops)+1)
+	for i, o := range ops {
+		fromPos[i+1], toPos[i+1] = fromPos[i], toPos[i]
+		if o.kind != opInsert {
+			fromPos[i+1]++
+		}
+		if o.kind != opDelete {
+			toPos[i+1]++
+		}
+	}
+
+	for i := 0; i < len(ops); {
+		change := nextChange(ops, i)
+		if change < 0 {
+			break
+		}
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+		}
+
+		start := max(change-contextLines, i)
+		end := change + 1
+		for {
+			next := nextChange(ops, end)
+			if next < 0 || next-end > 2*contextLines {
+				break
+			}
+			end = next + 1
+		}
+		end = min(end+contextLines, len(ops))
+
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n",
+			hunkRange(fromPos[start], fromPos[end]-fromPos[start]),
+			hunkRange(toPos[start], toPos[end]-toPos[start]))
+		for _, o := range ops[start:end] {
+			sb.WriteByte(byte(o.kind))
+			sb.WriteString(o.line)
+			sb.WriteByte('\n')
+		}
+
+		i = end
+	}
+
+	return sb.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+func nextChange(ops []op, from int) int {
+	for i := from; i < len(ops); i++ {
+		if ops[i].kind != opEqual {
+			return i
+		}
+	}
+	return -1
+}
+
+// hunkRange formats a hunk range the way GNU diff does, start is 0 based.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
+
+// edits returns the edit script turning a into b, the common prefix and suffix
+// are matched before diffing what is left.
+func edits(a, b []string) []op {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var ops []op
+	for _, line := range a[:prefix] {
+		ops = append(ops, op{opEqual, line})
+	}
+	ops = append(ops, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, op{opEqual, line})
+	}
+	return ops
+}
+
+// myers finds a shortest edit script with the Myers algorithm. trace keeps the
+// furthest reaching x of every diagonal k in [-d, d] as it was before round d.
+func myers(a, b []string) []op {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return replace(a, b)
+	}
+
+	offset := n + m
+	v := make([]int, 2*offset+2)
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		if d > maxEdits {
+			return replace(a, b)
+		}
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+	}
+
+	return replace(a, b)
+}
+
+func backtrack(a, b []string, trace [][]int) []op {
+	var reversed []op
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		prevX, prevY := 0, 0
+		if d > 0 {
+			at := func(k int) int { return trace[d][k+d] }
+			k := x - y
+			prevK := k - 1
+			if k == -d || (k != d && at(k-1) < at(k+1)) {
+				prevK = k + 1
+			}
+			prevX = at(prevK)
+			prevY = prevX - prevK
+		}
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, op{opEqual, a[x-1]})
+			x, y = x-1, y-1
+		}
+		if d > 0 {
+			if x == prevX {
+				reversed = append(reversed, op{opInsert, b[prevY]})
+			} else {
+				reversed = append(reversed, op{opDelete, a[prevX]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	ops := make([]op, 0, len(reversed))
+	for i := len(reversed) - 1; i >= 0; i-- {
+		ops = append(ops, reversed[i])
+	}
+	return ops
+}
+
+func replace(a, b []string) []op {
+	ops := make([]op, 0, len(a)+len(b))
+	for _, line := range a {
+		ops = append(ops, op{opDelete, line})
+	}
+	for _, line := range b {
+		ops = append(ops, op{opInsert, line})
+	}
+	return ops
+}
@@ -0,0 +1,94 @@
+package diff
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{
+			name: "Same",
+			from: "a\nb\n",
+			to:   "a\nb\n",
+		},
+		{
+			name:     "FromEmpty",
+			from:     "",
+			to:       "a\nb\n",
+			expected: "--- r1\n+++ r2\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:     "ToEmpty",
+			from:     "a\n",
+			to:       "",
+			expected: "--- r1\n+++ r2\n@@ -1 +0,0 @@\n-a\n",
+		},
+		{
+			name:     "ChangedLine",
+			from:     "1\n2\n3\n4\n5\n6\n7\n8\n",
+			to:       "1\n2\n3\n4\nfive\n6\n7\n8\n",
+			expected: "--- r1\n+++ r2\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "SeparateHunks",
+			from: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			to:   "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			expected: "--- r1\n+++ r2\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+				"@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+		{
+			name:     "MergedHunks",
+			from:     "1\n2\n3\n4\n5\n6\n7\n8\n",
+			to:       "one\n2\n3\n4\n5\n6\n7\neight\n",
+			expected: "--- r1\n+++ r2\n@@ -1,8 +1,8 @@\n-1\n+one\n 2\n 3\n 4\n 5\n 6\n 7\n-8\n+eight\n",
+		},
+		{
+			name:     "Interleaved",
+			from:     "a\nb\nc\na\nb\nb\na\n",
+			to:       "c\nb\na\nb\na\nc\n",
+			expected: "--- r1\n+++ r2\n@@ -1,7 +1,6 @@\n-a\n-b\n c\n+b\n a\n b\n-b\n a\n+c\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Unified("r1", "r2", tt.from, tt.to))
+		})
+	}
+}
+
+func TestEditsRebuildBothSides(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, rnd.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + rnd.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 200; i++ {
+		a, b := randomLines(), randomLines()
+
+		var from, to []string
+		for _, o := range edits(a, b) {
+			if o.kind != opInsert {
+				from = append(from, o.line)
+			}
+			if o.kind != opDelete {
+				to = append(to, o.line)
+			}
+		}
+
+		assert.Equal(t, strings.Join(a, "\n"), strings.Join(from, "\n"))
+		assert.Equal(t, strings.Join(b, "\n"), strings.Join(to, "\n"))
+	}
+}
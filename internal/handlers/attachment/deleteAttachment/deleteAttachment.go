@@ -0,0 +1,74 @@
+package deleteAttachment
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the delete attachment response payload.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// New lets the author remove an attachment from a post. The content stays in the blob store
+// until the blob cleaner finds no attachment refers to it anymore.
+func New(log *slog.Logger, posts database.PostService, service database.AttachmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Delete attachment")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+
+		attachmentID, err := strconv.Atoi(r.PathValue("attachmentID"))
+		if err != nil {
+			log.Error("Invalid attachment id", slog.String("attachment_id", r.PathValue("attachmentID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid attachment id")
+			return
+		}
+
+		post, err := posts.GetPost(postID)
+		if err != nil {
+			log.Error("post not found", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+		if post.UserId != userID {
+			log.Error("post belongs to another user", slog.Int("post_id", postID), slog.Int("user_id", userID))
+			utils.SendError(w, "Forbidden")
+			return
+		}
+
+		attachment, err := service.GetAttachment(attachmentID)
+		if err != nil || attachment.PostId != postID {
+			log.Error("attachment not found", slog.Int("post_id", postID), slog.Int("attachment_id", attachmentID))
+			utils.SendError(w, "attachment not found")
+			return
+		}
+
+		if err = service.DeleteAttachment(attachmentID); err != nil {
+			log.Error("failed to delete attachment", slog.Int("attachment_id", attachmentID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to delete attachment")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+		})
+	}
+}
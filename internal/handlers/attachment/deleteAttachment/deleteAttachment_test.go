@@ -0,0 +1,142 @@
+package deleteAttachment_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/attachment/deleteAttachment"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestDeleteAttachmentHandler(t *testing.T) {
+	tests := []struct {
+		name              string
+		postID            string
+		attachmentID      string
+		mockPost          database.PostDTO
+		mockGetError      error
+		skipGet           bool
+		mockAttachment    database.AttachmentDTO
+		mockAttachmentErr error
+		skipAttachment    bool
+		skipDelete        bool
+		mockError         error
+		expectedBody      deleteAttachment.Response
+	}{
+		{
+			name:           "SuccessfulDelete",
+			postID:         "7",
+			attachmentID:   "3",
+			mockPost:       database.PostDTO{Id: 7, UserId: 123},
+			mockAttachment: database.AttachmentDTO{Id: 3, PostId: 7},
+			expectedBody:   deleteAttachment.Response{Status: "OK"},
+		},
+		{
+			name:           "ErrorDelete",
+			postID:         "7",
+			attachmentID:   "3",
+			mockPost:       database.PostDTO{Id: 7, UserId: 123},
+			mockAttachment: database.AttachmentDTO{Id: 3, PostId: 7},
+			mockError:      errors.New("query error"),
+			expectedBody:   deleteAttachment.Response{Status: "Bad Request", Error: "failed to delete attachment"},
+		},
+		{
+			name:           "AttachmentOfAnotherPost",
+			postID:         "7",
+			attachmentID:   "3",
+			mockPost:       database.PostDTO{Id: 7, UserId: 123},
+			mockAttachment: database.AttachmentDTO{Id: 3, PostId: 8},
+			skipDelete:     true,
+			expectedBody:   deleteAttachment.Response{Status: "Bad Request", Error: "attachment not found"},
+		},
+		{
+			name:              "AttachmentNotFound",
+			postID:            "7",
+			attachmentID:      "3",
+			mockPost:          database.PostDTO{Id: 7, UserId: 123},
+			mockAttachmentErr: pgx.ErrNoRows,
+			skipDelete:        true,
+			expectedBody:      deleteAttachment.Response{Status: "Bad Request", Error: "attachment not found"},
+		},
+		{
+			name:           "NotAuthor",
+			postID:         "7",
+			attachmentID:   "3",
+			mockPost:       database.PostDTO{Id: 7, UserId: 4},
+			skipAttachment: true,
+			skipDelete:     true,
+			expectedBody:   deleteAttachment.Response{Status: "Bad Request", Error: "Forbidden"},
+		},
+		{
+			name:           "PostNotFound",
+			postID:         "7",
+			attachmentID:   "3",
+			mockGetError:   pgx.ErrNoRows,
+			skipAttachment: true,
+			skipDelete:     true,
+			expectedBody:   deleteAttachment.Response{Status: "Bad Request", Error: "post not found"},
+		},
+		{
+			name:           "InvalidAttachmentID",
+			postID:         "7",
+			attachmentID:   "abc",
+			skipGet:        true,
+			skipAttachment: true,
+			skipDelete:     true,
+			expectedBody:   deleteAttachment.Response{Status: "Bad Request", Error: "Invalid attachment id"},
+		},
+		{
+			name:           "InvalidPostID",
+			postID:         "abc",
+			attachmentID:   "3",
+			skipGet:        true,
+			skipAttachment: true,
+			skipDelete:     true,
+			expectedBody:   deleteAttachment.Response{Status: "Bad Request", Error: "Invalid post id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPosts := new(mocks.PostService)
+			if !tt.skipGet {
+				mockPosts.On("GetPost", 7).Return(tt.mockPost, tt.mockGetError)
+			}
+			defer mockPosts.AssertExpectations(t)
+
+			mockService := new(mocks.AttachmentService)
+			if !tt.skipAttachment {
+				mockService.On("GetAttachment", 3).Return(tt.mockAttachment, tt.mockAttachmentErr)
+			}
+			if !tt.skipDelete {
+				mockService.On("DeleteAttachment", 3).Return(tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /posts/{postID}/attachments/{attachmentID}", deleteAttachment.New(logger, mockPosts, mockService))
+
+			req := httptest.NewRequest(http.MethodDelete, "/posts/"+tt.postID+"/attachments/"+tt.attachmentID, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody deleteAttachment.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
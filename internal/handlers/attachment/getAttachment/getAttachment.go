@@ -0,0 +1,96 @@
+package getAttachment
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/storage"
+	"go-rest-api-auth/internal/utils"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Response represents the get attachment error payload, the content itself is sent as is.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// New streams the content of an attachment from the blob store. Images are shown inline and
+// other files are downloaded. The checksum is the ETag, so clients can revalidate for free,
+// the attachments of a post are only served to the readers of the post.
+func New(log *slog.Logger, posts database.PostService, service database.AttachmentService, blobs storage.BlobStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get attachment")
+
+		attachmentID, err := strconv.Atoi(r.PathValue("attachmentID"))
+		if err != nil {
+			log.Error("Invalid attachment id", slog.String("attachment_id", r.PathValue("attachmentID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid attachment id")
+			return
+		}
+
+		attachment, err := service.GetAttachment(attachmentID)
+		if err != nil {
+			log.Error("attachment not found", slog.Int("attachment_id", attachmentID), slog.String("error", err.Error()))
+			utils.SendError(w, "attachment not found")
+			return
+		}
+
+		post, err := posts.GetPost(attachment.PostId)
+		if err != nil {
+			log.Error("post not found", slog.Int("post_id", attachment.PostId), slog.String("error", err.Error()))
+			utils.SendError(w, "attachment not found")
+			return
+		}
+
+		viewerID, _ := utils.ContextUserID(r.Context())
+		visible, err := posts.CanViewPost(post, viewerID)
+		if err != nil {
+			log.Error("failed to check post visibility", slog.Int("post_id", post.Id), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to check post visibility")
+			return
+		}
+		if !visible {
+			log.Error("post not visible", slog.Int("post_id", post.Id), slog.String("status", post.Status), slog.String("visibility", post.Visibility))
+			utils.SendError(w, "attachment not found")
+			return
+		}
+
+		etag := `"` + attachment.Checksum + `"`
+		// the post can be unpublished at any time, so caches always revalidate
+		w.Header().Set("Cache-Control", "private, no-cache")
+		w.Header().Set("ETag", etag)
+		if match := r.Header.Get("If-None-Match"); match != "" && strings.Contains(match, etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		content, err := blobs.Get(r.Context(), attachment.Checksum)
+		if err != nil {
+			log.Error("failed to read attachment", slog.Int("attachment_id", attachmentID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to read attachment")
+			return
+		}
+		defer content.Close()
+
+		disposition := "attachment"
+		if strings.HasPrefix(attachment.ContentType, "image/") {
+			disposition = "inline"
+		}
+
+		w.Header().Set("Content-Type", attachment.ContentType)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename}))
+		w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+		w.Header().Set("Content-Security-Policy", "sandbox")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+
+		if _, err = io.Copy(w, content); err != nil {
+			log.Error("failed to send attachment", slog.Int("attachment_id", attachmentID), slog.String("error", err.Error()))
+		}
+	}
+}
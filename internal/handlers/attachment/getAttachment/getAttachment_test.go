@@ -0,0 +1,204 @@
+package getAttachment_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/attachment/getAttachment"
+	"go-rest-api-auth/internal/storage"
+	"go-rest-api-auth/testing/mocks"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGetAttachmentHandler(t *testing.T) {
+	image := database.AttachmentDTO{Id: 3, PostId: 7, UserId: 4, Filename: "photo.png", Checksum: "ab12", Size: 7, ContentType: "image/png"}
+	document := database.AttachmentDTO{Id: 3, PostId: 7, UserId: 4, Filename: "résumé.pdf", Checksum: "cd34", Size: 7, ContentType: "application/pdf"}
+
+	tests := []struct {
+		name              string
+		attachmentID      string
+		ifNoneMatch       string
+		mockAttachment    database.AttachmentDTO
+		mockAttachmentErr error
+		skipAttachment    bool
+		mockPost          database.PostDTO
+		mockGetError      error
+		skipGet           bool
+		hidden            bool
+		skipBlob          bool
+		mockBlobError     error
+		expectedStatus    int
+		expectedHeaders   map[string]string
+		expectedContent   string
+		expectedErrorBody *getAttachment.Response
+	}{
+		{
+			name:           "InlineImage",
+			attachmentID:   "3",
+			mockAttachment: image,
+			mockPost:       database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Content-Type":           "image/png",
+				"Content-Disposition":    `inline; filename=photo.png`,
+				"Content-Length":         "7",
+				"ETag":                   `"ab12"`,
+				"X-Content-Type-Options": "nosniff",
+			},
+			expectedContent: "content",
+		},
+		{
+			name:           "DownloadedDocument",
+			attachmentID:   "3",
+			mockAttachment: document,
+			mockPost:       database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Content-Type":        "application/pdf",
+				"Content-Disposition": `attachment; filename*=utf-8''r%C3%A9sum%C3%A9.pdf`,
+				"ETag":                `"cd34"`,
+			},
+			expectedContent: "content",
+		},
+		{
+			name:            "OwnDraft",
+			attachmentID:    "3",
+			mockAttachment:  image,
+			mockPost:        database.PostDTO{Id: 7, UserId: 123, Status: "draft"},
+			expectedStatus:  http.StatusOK,
+			expectedContent: "content",
+		},
+		{
+			name:            "NotModified",
+			attachmentID:    "3",
+			ifNoneMatch:     `"ef56", "ab12"`,
+			mockAttachment:  image,
+			mockPost:        database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			skipBlob:        true,
+			expectedStatus:  http.StatusNotModified,
+			expectedHeaders: map[string]string{"ETag": `"ab12"`},
+		},
+		{
+			name:              "BlobMissing",
+			attachmentID:      "3",
+			mockAttachment:    image,
+			mockPost:          database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			mockBlobError:     storage.ErrBlobNotFound,
+			expectedStatus:    http.StatusOK,
+			expectedErrorBody: &getAttachment.Response{Status: "Bad Request", Error: "failed to read attachment"},
+		},
+		{
+			name:              "DraftOfAnotherUser",
+			attachmentID:      "3",
+			mockAttachment:    image,
+			mockPost:          database.PostDTO{Id: 7, UserId: 4, Status: "draft"},
+			hidden:            true,
+			skipBlob:          true,
+			expectedStatus:    http.StatusOK,
+			expectedErrorBody: &getAttachment.Response{Status: "Bad Request", Error: "attachment not found"},
+		},
+		{
+			name:              "PostNotFound",
+			attachmentID:      "3",
+			mockAttachment:    image,
+			mockGetError:      pgx.ErrNoRows,
+			skipBlob:          true,
+			expectedStatus:    http.StatusOK,
+			expectedErrorBody: &getAttachment.Response{Status: "Bad Request", Error: "attachment not found"},
+		},
+		{
+			name:              "AttachmentNotFound",
+			attachmentID:      "3",
+			mockAttachmentErr: pgx.ErrNoRows,
+			skipGet:           true,
+			skipBlob:          true,
+			expectedStatus:    http.StatusOK,
+			expectedErrorBody: &getAttachment.Response{Status: "Bad Request", Error: "attachment not found"},
+		},
+		{
+			name:              "InvalidAttachmentID",
+			attachmentID:      "abc",
+			skipAttachment:    true,
+			skipGet:           true,
+			skipBlob:          true,
+			expectedStatus:    http.StatusOK,
+			expectedErrorBody: &getAttachment.Response{Status: "Bad Request", Error: "Invalid attachment id"},
+		},
+		{
+			name:              "ErrorGetAttachment",
+			attachmentID:      "3",
+			mockAttachmentErr: errors.New("query error"),
+			skipGet:           true,
+			skipBlob:          true,
+			expectedStatus:    http.StatusOK,
+			expectedErrorBody: &getAttachment.Response{Status: "Bad Request", Error: "attachment not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.AttachmentService)
+			if !tt.skipAttachment {
+				mockService.On("GetAttachment", 3).Return(tt.mockAttachment, tt.mockAttachmentErr)
+			}
+			defer mockService.AssertExpectations(t)
+
+			mockPosts := new(mocks.PostService)
+			if !tt.skipGet {
+				mockPosts.On("GetPost", 7).Return(tt.mockPost, tt.mockGetError)
+				if tt.mockGetError == nil {
+					mockPosts.On("CanViewPost", tt.mockPost, 123).Return(!tt.hidden, nil)
+				}
+			}
+			defer mockPosts.AssertExpectations(t)
+
+			mockBlobs := new(mocks.BlobStore)
+			if !tt.skipBlob {
+				var content io.ReadCloser
+				if tt.mockBlobError == nil {
+					content = io.NopCloser(strings.NewReader("content"))
+				}
+				mockBlobs.On("Get", mock.Anything, tt.mockAttachment.Checksum).Return(content, tt.mockBlobError)
+			}
+			defer mockBlobs.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /attachments/{attachmentID}", getAttachment.New(logger, mockPosts, mockService, mockBlobs))
+
+			req := httptest.NewRequest(http.MethodGet, "/attachments/"+tt.attachmentID, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for header, value := range tt.expectedHeaders {
+				assert.Equal(t, value, w.Header().Get(header), header)
+			}
+
+			if tt.expectedErrorBody != nil {
+				var responseBody getAttachment.Response
+				err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+				assert.NoError(t, err)
+				assert.Equal(t, *tt.expectedErrorBody, responseBody)
+				return
+			}
+			assert.Equal(t, tt.expectedContent, w.Body.String())
+		})
+	}
+}
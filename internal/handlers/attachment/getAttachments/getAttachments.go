@@ -0,0 +1,65 @@
+package getAttachments
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the get attachments response payload.
+// swagger:model
+type Response struct {
+	Status      string             `json:"status"`
+	Error       string             `json:"error,omitempty"`
+	Attachments []views.Attachment `json:"attachments"`
+}
+
+// New lists the attachments of a post, oldest first. The attachments of a post are
+// only shown to the readers of the post.
+func New(log *slog.Logger, posts database.PostService, service database.AttachmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get attachments")
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+
+		post, err := posts.GetPost(postID)
+		if err != nil {
+			log.Error("post not found", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		viewerID, _ := utils.ContextUserID(r.Context())
+		visible, err := posts.CanViewPost(post, viewerID)
+		if err != nil {
+			log.Error("failed to check post visibility", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to check post visibility")
+			return
+		}
+		if !visible {
+			log.Error("post not visible", slog.Int("post_id", postID), slog.String("status", post.Status), slog.String("visibility", post.Visibility))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		attachments, err := service.GetAttachments(postID)
+		if err != nil {
+			log.Error("get attachments failed", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "get attachments failed")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:      http.StatusText(http.StatusOK),
+			Attachments: views.NewAttachments(attachments),
+		})
+	}
+}
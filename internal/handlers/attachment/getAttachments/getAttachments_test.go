@@ -0,0 +1,125 @@
+package getAttachments_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/attachment/getAttachments"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetAttachmentsHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		postID       string
+		mockPost     database.PostDTO
+		mockGetError error
+		skipGet      bool
+		hidden       bool
+		skipMock     bool
+		mockResponse []database.AttachmentDTO
+		mockError    error
+		expectedBody getAttachments.Response
+	}{
+		{
+			name:     "SuccessfulGetAttachments",
+			postID:   "7",
+			mockPost: database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			mockResponse: []database.AttachmentDTO{
+				{Id: 1, PostId: 7, UserId: 4, Filename: "photo.png", Checksum: "ab", Size: 10, ContentType: "image/png"},
+				{Id: 2, PostId: 7, UserId: 4, Filename: "notes.txt", Checksum: "cd", Size: 5, ContentType: "text/plain; charset=utf-8"},
+			},
+			expectedBody: getAttachments.Response{
+				Status: "OK",
+				Attachments: []views.Attachment{
+					{Id: 1, PostId: 7, UserId: 4, Filename: "photo.png", Checksum: "ab", Size: 10, ContentType: "image/png"},
+					{Id: 2, PostId: 7, UserId: 4, Filename: "notes.txt", Checksum: "cd", Size: 5, ContentType: "text/plain; charset=utf-8"},
+				},
+			},
+		},
+		{
+			name:         "OwnDraft",
+			postID:       "7",
+			mockPost:     database.PostDTO{Id: 7, UserId: 123, Status: "draft"},
+			mockResponse: []database.AttachmentDTO{},
+			expectedBody: getAttachments.Response{
+				Status:      "OK",
+				Attachments: []views.Attachment{},
+			},
+		},
+		{
+			name:         "DraftOfAnotherUser",
+			postID:       "7",
+			mockPost:     database.PostDTO{Id: 7, UserId: 4, Status: "draft"},
+			hidden:       true,
+			skipMock:     true,
+			expectedBody: getAttachments.Response{Status: "Bad Request", Error: "post not found"},
+		},
+		{
+			name:         "ErrorGetAttachments",
+			postID:       "7",
+			mockPost:     database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			mockError:    errors.New("query error"),
+			expectedBody: getAttachments.Response{Status: "Bad Request", Error: "get attachments failed"},
+		},
+		{
+			name:         "PostNotFound",
+			postID:       "7",
+			mockGetError: pgx.ErrNoRows,
+			skipMock:     true,
+			expectedBody: getAttachments.Response{Status: "Bad Request", Error: "post not found"},
+		},
+		{
+			name:         "InvalidPostID",
+			postID:       "abc",
+			skipGet:      true,
+			skipMock:     true,
+			expectedBody: getAttachments.Response{Status: "Bad Request", Error: "Invalid post id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPosts := new(mocks.PostService)
+			if !tt.skipGet {
+				mockPosts.On("GetPost", 7).Return(tt.mockPost, tt.mockGetError)
+				if tt.mockGetError == nil {
+					mockPosts.On("CanViewPost", tt.mockPost, 123).Return(!tt.hidden, nil)
+				}
+			}
+			defer mockPosts.AssertExpectations(t)
+
+			mockService := new(mocks.AttachmentService)
+			if !tt.skipMock {
+				mockService.On("GetAttachments", 7).Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /posts/{postID}/attachments", getAttachments.New(logger, mockPosts, mockService))
+
+			req := httptest.NewRequest(http.MethodGet, "/posts/"+tt.postID+"/attachments", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody getAttachments.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package uploadAttachment
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// FileField is the multipart form field holding the uploaded file.
+	FileField = "file"
+
+	// formOverhead is allowed on top of the file size for the multipart boundaries and headers.
+	formOverhead = 64 << 10
+
+	maxFilenameLength = 255
+)
+
+// Response represents the upload attachment response payload.
+// swagger:model
+type Response struct {
+	Status     string           `json:"status"`
+	Error      string           `json:"error,omitempty"`
+	Attachment views.Attachment `json:"attachment"`
+}
+
+// New lets the author attach a file to a post, sent as the "file" field of a multipart form.
+// The content type is sniffed from the content rather than trusted from the client and must be
+// one of allowedTypes, files larger than maxSize bytes are rejected before they are stored.
+func New(log *slog.Logger, posts database.PostService, service database.AttachmentService, maxSize int64, allowedTypes []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Upload attachment")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+
+		post, err := posts.GetPost(postID)
+		if err != nil {
+			log.Error("post not found", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+		if post.UserId != userID {
+			log.Error("post belongs to another user", slog.Int("post_id", postID), slog.Int("user_id", userID))
+			utils.SendError(w, "Forbidden")
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize+formOverhead)
+		reader, err := r.MultipartReader()
+		if err != nil {
+			log.Error("invalid multipart form", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid multipart form")
+			return
+		}
+
+		part, err := nextFilePart(reader)
+		if err != nil {
+			log.Error("file not found in form", slog.String("error", err.Error()))
+			if isTooLarge(err) {
+				utils.SendError(w, "file too large")
+				return
+			}
+			utils.SendError(w, "file is required")
+			return
+		}
+		defer part.Close()
+
+		// the content is spooled to disk so the checksum is known before anything is stored
+		file, err := os.CreateTemp("", "attachment-*")
+		if err != nil {
+			log.Error("failed to create temporary file", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to upload attachment")
+			return
+		}
+		defer func() {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}()
+
+		hash := sha256.New()
+		size, err := io.Copy(io.MultiWriter(file, hash), io.LimitReader(part, maxSize+1))
+		if err != nil {
+			log.Error("failed to read file", slog.String("error", err.Error()))
+			if isTooLarge(err) {
+				utils.SendError(w, "file too large")
+				return
+			}
+			utils.SendError(w, "failed to read file")
+			return
+		}
+		if size > maxSize {
+			log.Error("file too large", slog.Int64("max_size", maxSize))
+			utils.SendError(w, "file too large")
+			return
+		}
+		if size == 0 {
+			log.Error("empty file")
+			utils.SendError(w, "empty file")
+			return
+		}
+
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			log.Error("failed to rewind file", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to upload attachment")
+			return
+		}
+		detected, err := mimetype.DetectReader(file)
+		if err != nil {
+			log.Error("failed to detect content type", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to upload attachment")
+			return
+		}
+		if !mimetype.EqualsAny(detected.String(), allowedTypes...) {
+			log.Error("unsupported file type", slog.String("content_type", detected.String()))
+			utils.SendError(w, "unsupported file type")
+			return
+		}
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			log.Error("failed to rewind file", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to upload attachment")
+			return
+		}
+
+		attachment, err := service.CreateAttachment(database.AttachmentDTO{
+			PostId:      postID,
+			UserId:      userID,
+			Filename:    sanitizeFilename(part.FileName()),
+			Checksum:    hex.EncodeToString(hash.Sum(nil)),
+			Size:        size,
+			ContentType: detected.String(),
+		}, file)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error("post not found", slog.Int("post_id", postID))
+			utils.SendError(w, "post not found")
+			return
+		}
+		if err != nil {
+			log.Error("failed to upload attachment", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to upload attachment")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:     http.StatusText(http.StatusOK),
+			Attachment: views.NewAttachment(attachment),
+		})
+	}
+}
+
+// nextFilePart skips the other fields of the form up to the file.
+func nextFilePart(reader *multipart.Reader) (*multipart.Part, error) {
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == FileField {
+			return part, nil
+		}
+		_ = part.Close()
+	}
+}
+
+func isTooLarge(err error) bool {
+	var maxBytesError *http.MaxBytesError
+	return errors.As(err, &maxBytesError)
+}
+
+// sanitizeFilename keeps the base name of a client path, whatever its separators, without
+// control characters and cut to what the attachments table holds.
+func sanitizeFilename(name string) string {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+	if runes := []rune(name); len(runes) > maxFilenameLength {
+		name = string(runes[:maxFilenameLength])
+	}
+	if name == "" || name == "." || name == ".." {
+		return FileField
+	}
+	return name
+}
@@ -0,0 +1,258 @@
+package uploadAttachment_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/attachment/uploadAttachment"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+const testMaxSize = 64
+
+var (
+	pngContent  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00")
+	pngChecksum = checksum(pngContent)
+)
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func multipartBody(t *testing.T, field, filename string, content []byte) (*bytes.Buffer, string) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	assert.NoError(t, writer.WriteField("caption", "ignored"))
+	part, err := writer.CreateFormFile(field, filename)
+	assert.NoError(t, err)
+	_, err = part.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func TestUploadAttachmentHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		postID         string
+		field          string
+		filename       string
+		content        []byte
+		contentType    string
+		mockPost       database.PostDTO
+		mockGetError   error
+		skipGet        bool
+		expectedCreate *database.AttachmentDTO
+		mockResponse   database.AttachmentDTO
+		mockError      error
+		expectedBody   uploadAttachment.Response
+	}{
+		{
+			name:     "SuccessfulUpload",
+			postID:   "7",
+			field:    "file",
+			filename: "photo.png",
+			content:  pngContent,
+			mockPost: database.PostDTO{Id: 7, UserId: 123},
+			expectedCreate: &database.AttachmentDTO{
+				PostId: 7, UserId: 123, Filename: "photo.png", Checksum: pngChecksum, Size: int64(len(pngContent)), ContentType: "image/png",
+			},
+			mockResponse: database.AttachmentDTO{
+				Id: 3, PostId: 7, UserId: 123, Filename: "photo.png", Checksum: pngChecksum, Size: int64(len(pngContent)), ContentType: "image/png",
+			},
+			expectedBody: uploadAttachment.Response{
+				Status: "OK",
+				Attachment: views.Attachment{
+					Id: 3, PostId: 7, UserId: 123, Filename: "photo.png", Checksum: pngChecksum, Size: int64(len(pngContent)), ContentType: "image/png",
+				},
+			},
+		},
+		{
+			name:     "SniffedContentType",
+			postID:   "7",
+			field:    "file",
+			filename: "notes.png",
+			content:  []byte("just some notes"),
+			mockPost: database.PostDTO{Id: 7, UserId: 123},
+			expectedCreate: &database.AttachmentDTO{
+				PostId: 7, UserId: 123, Filename: "notes.png", Checksum: checksum([]byte("just some notes")), Size: 15, ContentType: "text/plain; charset=utf-8",
+			},
+			mockResponse: database.AttachmentDTO{Id: 4, PostId: 7, UserId: 123, Filename: "notes.png", ContentType: "text/plain; charset=utf-8"},
+			expectedBody: uploadAttachment.Response{
+				Status:     "OK",
+				Attachment: views.Attachment{Id: 4, PostId: 7, UserId: 123, Filename: "notes.png", ContentType: "text/plain; charset=utf-8"},
+			},
+		},
+		{
+			name:     "WindowsPathFilename",
+			postID:   "7",
+			field:    "file",
+			filename: `C:\Users\me\photo.png`,
+			content:  pngContent,
+			mockPost: database.PostDTO{Id: 7, UserId: 123},
+			expectedCreate: &database.AttachmentDTO{
+				PostId: 7, UserId: 123, Filename: "photo.png", Checksum: pngChecksum, Size: int64(len(pngContent)), ContentType: "image/png",
+			},
+			mockResponse: database.AttachmentDTO{Id: 3, PostId: 7, UserId: 123, Filename: "photo.png"},
+			expectedBody: uploadAttachment.Response{
+				Status:     "OK",
+				Attachment: views.Attachment{Id: 3, PostId: 7, UserId: 123, Filename: "photo.png"},
+			},
+		},
+		{
+			name:         "UnsupportedFileType",
+			postID:       "7",
+			field:        "file",
+			filename:     "page.png",
+			content:      []byte("<html><script>alert(1)</script></html>"),
+			mockPost:     database.PostDTO{Id: 7, UserId: 123},
+			expectedBody: uploadAttachment.Response{Status: "Bad Request", Error: "unsupported file type"},
+		},
+		{
+			name:         "FileTooLarge",
+			postID:       "7",
+			field:        "file",
+			filename:     "notes.txt",
+			content:      bytes.Repeat([]byte("a"), testMaxSize+1),
+			mockPost:     database.PostDTO{Id: 7, UserId: 123},
+			expectedBody: uploadAttachment.Response{Status: "Bad Request", Error: "file too large"},
+		},
+		{
+			name:         "EmptyFile",
+			postID:       "7",
+			field:        "file",
+			filename:     "notes.txt",
+			mockPost:     database.PostDTO{Id: 7, UserId: 123},
+			expectedBody: uploadAttachment.Response{Status: "Bad Request", Error: "empty file"},
+		},
+		{
+			name:         "MissingFile",
+			postID:       "7",
+			field:        "image",
+			filename:     "photo.png",
+			content:      pngContent,
+			mockPost:     database.PostDTO{Id: 7, UserId: 123},
+			expectedBody: uploadAttachment.Response{Status: "Bad Request", Error: "file is required"},
+		},
+		{
+			name:         "NotMultipart",
+			postID:       "7",
+			contentType:  "application/json",
+			mockPost:     database.PostDTO{Id: 7, UserId: 123},
+			expectedBody: uploadAttachment.Response{Status: "Bad Request", Error: "invalid multipart form"},
+		},
+		{
+			name:     "PostDeletedMeanwhile",
+			postID:   "7",
+			field:    "file",
+			filename: "photo.png",
+			content:  pngContent,
+			mockPost: database.PostDTO{Id: 7, UserId: 123},
+			expectedCreate: &database.AttachmentDTO{
+				PostId: 7, UserId: 123, Filename: "photo.png", Checksum: pngChecksum, Size: int64(len(pngContent)), ContentType: "image/png",
+			},
+			mockError:    pgx.ErrNoRows,
+			expectedBody: uploadAttachment.Response{Status: "Bad Request", Error: "post not found"},
+		},
+		{
+			name:     "ErrorCreate",
+			postID:   "7",
+			field:    "file",
+			filename: "photo.png",
+			content:  pngContent,
+			mockPost: database.PostDTO{Id: 7, UserId: 123},
+			expectedCreate: &database.AttachmentDTO{
+				PostId: 7, UserId: 123, Filename: "photo.png", Checksum: pngChecksum, Size: int64(len(pngContent)), ContentType: "image/png",
+			},
+			mockError:    errors.New("store error"),
+			expectedBody: uploadAttachment.Response{Status: "Bad Request", Error: "failed to upload attachment"},
+		},
+		{
+			name:         "NotAuthor",
+			postID:       "7",
+			field:        "file",
+			filename:     "photo.png",
+			content:      pngContent,
+			mockPost:     database.PostDTO{Id: 7, UserId: 4},
+			expectedBody: uploadAttachment.Response{Status: "Bad Request", Error: "Forbidden"},
+		},
+		{
+			name:         "PostNotFound",
+			postID:       "7",
+			field:        "file",
+			filename:     "photo.png",
+			content:      pngContent,
+			mockGetError: pgx.ErrNoRows,
+			expectedBody: uploadAttachment.Response{Status: "Bad Request", Error: "post not found"},
+		},
+		{
+			name:         "InvalidPostID",
+			postID:       "abc",
+			skipGet:      true,
+			expectedBody: uploadAttachment.Response{Status: "Bad Request", Error: "Invalid post id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPosts := new(mocks.PostService)
+			if !tt.skipGet {
+				mockPosts.On("GetPost", 7).Return(tt.mockPost, tt.mockGetError)
+			}
+			defer mockPosts.AssertExpectations(t)
+
+			mockService := new(mocks.AttachmentService)
+			if tt.expectedCreate != nil {
+				mockService.On("CreateAttachment", *tt.expectedCreate, mock.Anything).
+					Run(func(args mock.Arguments) {
+						content, err := io.ReadAll(args.Get(1).(io.Reader))
+						assert.NoError(t, err)
+						assert.Equal(t, tt.content, content)
+					}).
+					Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /posts/{postID}/attachments", uploadAttachment.New(logger, mockPosts, mockService, testMaxSize, []string{"image/png", "text/plain"}))
+
+			var body io.Reader = strings.NewReader(`{"file": "photo.png"}`)
+			contentType := tt.contentType
+			if tt.field != "" {
+				body, contentType = multipartBody(t, tt.field, tt.filename, tt.content)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/posts/"+tt.postID+"/attachments", body)
+			req.Header.Set("Content-Type", contentType)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody uploadAttachment.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package jwtChangePassword
+
+import (
+	"encoding/json"
+	"github.com/go-playground/validator/v10"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/database/auth"
+	"go-rest-api-auth/internal/handlers/auth/password"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request represents the change password request payload.
+// swagger:model
+type Request struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+// Response represents the change password response payload.
+// Every token issued before the change is revoked, the new pair replaces them.
+// swagger:model
+type Response struct {
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func New(log *slog.Logger, tokenManager auth.JwtManager, userService database.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("JWT Change password")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			utils.SendError(w, "Invalid user context")
+			return
+		}
+
+		//get request body info
+		var req Request
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		//validating request body info
+		err = validator.New().Struct(req)
+		if err != nil {
+			log.Error("failed to validate request", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate request")
+			return
+		}
+
+		tokenVersion, err := password.Change(log, userService, userID, req.CurrentPassword, req.NewPassword)
+		if err != nil {
+			utils.SendError(w, err.Error())
+			return
+		}
+
+		err = tokenManager.DeleteRefreshToken(userID)
+		if err != nil {
+			log.Error("failed to delete refresh token", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to delete refresh token")
+			return
+		}
+
+		accessToken, err := tokenManager.GenerateJWT(strconv.Itoa(userID), tokenVersion, "access", tokenManager.GetterAccessExpiresAt())
+		if err != nil {
+			log.Error("failed to generate access token", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to generate access token")
+			return
+		}
+
+		refreshToken, err := tokenManager.GenerateJWT(strconv.Itoa(userID), tokenVersion, "refresh", tokenManager.GetterRefreshExpiresAt())
+		if err != nil {
+			log.Error("failed to generate refresh token", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to generate refresh token")
+			return
+		}
+
+		err = tokenManager.SaveRefreshToken(refreshToken)
+		if err != nil {
+			log.Error("failed to save refresh token", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to save refresh token")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:       http.StatusText(http.StatusOK),
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package jwtChangePassword_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	jwtChangePassword "go-rest-api-auth/internal/handlers/auth/jwt/changePassword"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestJwtChangePassword(t *testing.T) {
+	tests := []struct {
+		name              string
+		reqBody           string
+		changePasswordErr error
+		expectedStatus    string
+		expectedError     string
+	}{
+		{
+			name:           "TestJwtChangePassword_ValidateRequestBodyError",
+			reqBody:        "{\"current_password\":\"\",\"new_password\":\"\"}",
+			expectedStatus: "Bad Request",
+			expectedError:  "failed to validate request",
+		},
+		{
+			name:           "TestJwtChangePassword_WeakPassword",
+			reqBody:        "{\"current_password\":\"oldpassword1\",\"new_password\":\"short\"}",
+			expectedStatus: "Bad Request",
+			expectedError:  "password must be at least 8 characters long",
+		},
+		{
+			name:           "TestJwtChangePassword_NoDigit",
+			reqBody:        "{\"current_password\":\"oldpassword1\",\"new_password\":\"onlyletters\"}",
+			expectedStatus: "Bad Request",
+			expectedError:  "password must contain at least one letter and one digit",
+		},
+		{
+			name:           "TestJwtChangePassword_InvalidCurrentPassword",
+			reqBody:        "{\"current_password\":\"wrongpassword1\",\"new_password\":\"newpassword1\"}",
+			expectedStatus: "Bad Request",
+			expectedError:  "invalid current password",
+		},
+		{
+			name:              "TestJwtChangePassword_ChangePasswordError",
+			reqBody:           "{\"current_password\":\"oldpassword1\",\"new_password\":\"newpassword1\"}",
+			changePasswordErr: errors.New("database error"),
+			expectedStatus:    "Bad Request",
+			expectedError:     "failed to change password",
+		},
+		{
+			name:           "TestJwtChangePassword_Success",
+			reqBody:        "{\"current_password\":\"oldpassword1\",\"new_password\":\"newpassword1\"}",
+			expectedStatus: "OK",
+			expectedError:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTokenManager := new(mocks.JwtManager)
+			mockUserService := new(mocks.UserService)
+			log := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			hashedPassword, _ := utils.HashPassword("oldpassword1")
+			mockUserService.On("GetUserById", 1).Return(database.UserDTO{Id: 1, Username: "testuser", Password: hashedPassword}, nil)
+			mockUserService.On("ChangePassword", 1, "newpassword1").Return(4, tt.changePasswordErr)
+
+			mockTokenManager.On("GetterAccessExpiresAt").Return(time.Minute)
+			mockTokenManager.On("GetterRefreshExpiresAt").Return(time.Hour)
+			mockTokenManager.On("DeleteRefreshToken", 1).Return(nil)
+			mockTokenManager.On("GenerateJWT", "1", 4, "access", time.Minute).Return("access123", nil)
+			mockTokenManager.On("GenerateJWT", "1", 4, "refresh", time.Hour).Return("refresh123", nil)
+			mockTokenManager.On("SaveRefreshToken", "refresh123").Return(nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/me/password", bytes.NewBuffer([]byte(tt.reqBody)))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "1"))
+			w := httptest.NewRecorder()
+
+			handler := jwtChangePassword.New(log, mockTokenManager, mockUserService)
+			handler(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			var respBody jwtChangePassword.Response
+			err := json.NewDecoder(resp.Body).Decode(&respBody)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedStatus, respBody.Status)
+			assert.Equal(t, tt.expectedError, respBody.Error)
+			if tt.expectedStatus == "OK" {
+				assert.Equal(t, "access123", respBody.AccessToken)
+				assert.Equal(t, "refresh123", respBody.RefreshToken)
+			}
+		})
+	}
+}
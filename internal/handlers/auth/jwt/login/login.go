@@ -61,14 +61,20 @@ func New(log *slog.Logger, tokenManager auth.JwtManager, userService database.Us
 			return
 		}
 
-		accessToken, err := tokenManager.GenerateJWT(strconv.Itoa(user.Id), "access", tokenManager.GetterAccessExpiresAt())
+		if user.Suspended {
+			log.Info("suspended user", slog.String("username", req.Username))
+			utils.SendError(w, "user is suspended")
+			return
+		}
+
+		accessToken, err := tokenManager.GenerateJWT(strconv.Itoa(user.Id), user.TokenVersion, "access", tokenManager.GetterAccessExpiresAt())
 		if err != nil {
 			log.Error("failed to generate access token", slog.String("username", req.Username), slog.String("error", err.Error()))
 			utils.SendError(w, "failed to generate access token")
 			return
 		}
 
-		refreshToken, err := tokenManager.GenerateJWT(strconv.Itoa(user.Id), "refresh", tokenManager.GetterRefreshExpiresAt())
+		refreshToken, err := tokenManager.GenerateJWT(strconv.Itoa(user.Id), user.TokenVersion, "refresh", tokenManager.GetterRefreshExpiresAt())
 		if err != nil {
 			log.Error("failed to generate refresh token", slog.String("username", req.Username), slog.String("error", err.Error()))
 			utils.SendError(w, "failed to generate refresh token")
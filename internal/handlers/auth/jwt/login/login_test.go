@@ -26,6 +26,7 @@ func TestJwtLogin(t *testing.T) {
 		tokenGenRefreshErr  error
 		saveRefreshTokenErr error
 		checkPasswordHash   bool
+		suspended           bool
 		expectedStatus      string
 		expectedError       string
 	}{
@@ -76,6 +77,13 @@ func TestJwtLogin(t *testing.T) {
 			expectedStatus:      "Bad Request",
 			expectedError:       "failed to save refresh token",
 		},
+		{
+			name:           "TestJwtLogin_SuspendedUser",
+			reqBody:        "{\"username\":\"testuser\",\"password\":\"testpassword\"}",
+			suspended:      true,
+			expectedStatus: "Bad Request",
+			expectedError:  "user is suspended",
+		},
 		{
 			name:           "TestJwtLogin_Success",
 			reqBody:        "{\"username\":\"testuser\",\"password\":\"testpassword\"}",
@@ -105,7 +113,7 @@ func TestJwtLogin(t *testing.T) {
 				mockUserService.On("GetUserByName", "testuser").Return(database.UserDTO{}, tt.userServiceErr)
 			} else {
 				hashedPassword, _ := utils.HashPassword("testpassword")
-				mockUserService.On("GetUserByName", "testuser").Return(database.UserDTO{Id: 1, Username: "testuser", Password: hashedPassword}, nil)
+				mockUserService.On("GetUserByName", "testuser").Return(database.UserDTO{Id: 1, Username: "testuser", Password: hashedPassword, Suspended: tt.suspended}, nil)
 			}
 
 			if tt.checkPasswordHash == false {
@@ -115,15 +123,15 @@ func TestJwtLogin(t *testing.T) {
 			}
 
 			if tt.tokenGenAccessErr != nil {
-				mockTokenManager.On("GenerateJWT", "1", "access", mockTokenManager.GetterAccessExpiresAt()).Return("", tt.tokenGenAccessErr)
+				mockTokenManager.On("GenerateJWT", "1", 0, "access", mockTokenManager.GetterAccessExpiresAt()).Return("", tt.tokenGenAccessErr)
 			} else {
-				mockTokenManager.On("GenerateJWT", "1", "access", mockTokenManager.GetterAccessExpiresAt()).Return("access123", nil)
+				mockTokenManager.On("GenerateJWT", "1", 0, "access", mockTokenManager.GetterAccessExpiresAt()).Return("access123", nil)
 			}
 
 			if tt.tokenGenRefreshErr != nil {
-				mockTokenManager.On("GenerateJWT", "1", "refresh", mockTokenManager.GetterRefreshExpiresAt()).Return("", tt.tokenGenRefreshErr)
+				mockTokenManager.On("GenerateJWT", "1", 0, "refresh", mockTokenManager.GetterRefreshExpiresAt()).Return("", tt.tokenGenRefreshErr)
 			} else {
-				mockTokenManager.On("GenerateJWT", "1", "refresh", mockTokenManager.GetterRefreshExpiresAt()).Return("refresh123", nil)
+				mockTokenManager.On("GenerateJWT", "1", 0, "refresh", mockTokenManager.GetterRefreshExpiresAt()).Return("refresh123", nil)
 			}
 
 			if tt.saveRefreshTokenErr != nil {
@@ -5,7 +5,6 @@ import (
 	"go-rest-api-auth/internal/utils"
 	"log/slog"
 	"net/http"
-	"strconv"
 )
 
 // Response represents the jwt logout response payload.
@@ -19,21 +18,14 @@ func New(log *slog.Logger, tokenManager auth.JwtManager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Info("JWT  Logout user")
 
-		userIDValue := r.Context().Value("user_id")
-		if userIDValue == nil {
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
 			log.Error("user_id not found in context")
 			utils.SendError(w, "Invalid user context")
 			return
 		}
 
-		userID, err := strconv.Atoi(userIDValue.(string))
-		if err != nil {
-			log.Error("Invalid user ID format")
-			utils.SendError(w, "Invalid user ID")
-			return
-		}
-
-		err = tokenManager.DeleteRefreshToken(userID)
+		err := tokenManager.DeleteRefreshToken(userID)
 		if err != nil {
 			log.Error("Error deleting refresh token")
 			utils.SendError(w, "Error deleting refresh token")
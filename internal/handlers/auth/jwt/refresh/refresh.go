@@ -3,6 +3,7 @@ package refresh
 import (
 	"encoding/json"
 	"github.com/go-playground/validator/v10"
+	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/database/auth"
 	"go-rest-api-auth/internal/utils"
 	"log/slog"
@@ -25,7 +26,7 @@ type Response struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
-func New(log *slog.Logger, tokenManager auth.JwtManager) http.HandlerFunc {
+func New(log *slog.Logger, tokenManager auth.JwtManager, userService database.UserService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Info("Refresh user's tokens")
 
@@ -71,6 +72,20 @@ func New(log *slog.Logger, tokenManager auth.JwtManager) http.HandlerFunc {
 			return
 		}
 
+		tokenVersion, err := auth.ClaimsTokenVersion(refreshTokenClaim)
+		if err != nil {
+			log.Error("failed to get token version", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate token")
+			return
+		}
+
+		currentTokenVersion, err := userService.GetTokenVersion(userID)
+		if err != nil {
+			log.Error("failed to get user token version", slog.String("userID", strconv.Itoa(userID)), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to get user token version")
+			return
+		}
+
 		err = tokenManager.DeleteRefreshToken(userID)
 		if err != nil {
 			log.Error("failed to delete refresh token from database", slog.String("error", err.Error()))
@@ -78,14 +93,20 @@ func New(log *slog.Logger, tokenManager auth.JwtManager) http.HandlerFunc {
 			return
 		}
 
-		accessToken, err := tokenManager.GenerateJWT(strconv.Itoa(userID), "access", tokenManager.GetterAccessExpiresAt())
+		if tokenVersion != currentTokenVersion {
+			log.Error("refresh token has been revoked", slog.String("userID", strconv.Itoa(userID)))
+			utils.SendError(w, "refresh token has been revoked")
+			return
+		}
+
+		accessToken, err := tokenManager.GenerateJWT(strconv.Itoa(userID), currentTokenVersion, "access", tokenManager.GetterAccessExpiresAt())
 		if err != nil {
 			log.Error("failed to generate access token", slog.String("userID", strconv.Itoa(userID)), slog.String("error", err.Error()))
 			utils.SendError(w, err.Error())
 			return
 		}
 
-		refreshToken, err := tokenManager.GenerateJWT(strconv.Itoa(userID), "refresh", tokenManager.GetterRefreshExpiresAt())
+		refreshToken, err := tokenManager.GenerateJWT(strconv.Itoa(userID), currentTokenVersion, "refresh", tokenManager.GetterRefreshExpiresAt())
 		if err != nil {
 			log.Error("failed to generate refresh token", slog.String("userID", strconv.Itoa(userID)), slog.String("error", err.Error()))
 			utils.SendError(w, err.Error())
@@ -24,6 +24,8 @@ func TestRefreshHandler(t *testing.T) {
 		validateJWTErr      error
 		isRefreshTokenErr   error
 		isRefreshTokenValid bool
+		tokenVersion        int
+		getTokenVersionErr  error
 		deleteTokenErr      error
 		generateAccessErr   error
 		generateRefreshErr  error
@@ -55,6 +57,26 @@ func TestRefreshHandler(t *testing.T) {
 			expectedStatus:      "Bad Request",
 			expectedResponse:    refresh.Response{Status: "Bad Request", Error: "refresh token not found"},
 		},
+		{
+			name: "GetTokenVersionError",
+			requestBody: refresh.Request{
+				RefreshToken: "valid_token",
+			},
+			isRefreshTokenValid: true,
+			getTokenVersionErr:  errors.New("user not found"),
+			expectedStatus:      "Bad Request",
+			expectedResponse:    refresh.Response{Status: "Bad Request", Error: "failed to get user token version"},
+		},
+		{
+			name: "RevokedToken",
+			requestBody: refresh.Request{
+				RefreshToken: "valid_token",
+			},
+			isRefreshTokenValid: true,
+			tokenVersion:        1,
+			expectedStatus:      "Bad Request",
+			expectedResponse:    refresh.Response{Status: "Bad Request", Error: "refresh token has been revoked"},
+		},
 		{
 			name: "Success",
 			requestBody: refresh.Request{
@@ -73,15 +95,17 @@ func TestRefreshHandler(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockTokenManager := new(mocks.JwtManager)
+			mockUserService := new(mocks.UserService)
 
 			mockTokenManager.On("GetterAccessExpiresAt").Return(time.Minute)
 			mockTokenManager.On("GetterRefreshExpiresAt").Return(time.Hour)
 
-			mockTokenManager.On("ValidateJWT", mock.Anything, "refresh").Return(jwt.MapClaims{"sub": "123"}, tt.validateJWTErr)
+			mockTokenManager.On("ValidateJWT", mock.Anything, "refresh").Return(jwt.MapClaims{"sub": "123", "ver": float64(0)}, tt.validateJWTErr)
+			mockUserService.On("GetTokenVersion", 123).Return(tt.tokenVersion, tt.getTokenVersionErr)
 			mockTokenManager.On("IsRefreshTokenValid", mock.Anything).Return(tt.isRefreshTokenValid, tt.isRefreshTokenErr)
 			mockTokenManager.On("DeleteRefreshToken", mock.Anything).Return(tt.deleteTokenErr)
-			mockTokenManager.On("GenerateJWT", mock.Anything, "access", mock.Anything).Return("new_access_token", tt.generateAccessErr)
-			mockTokenManager.On("GenerateJWT", mock.Anything, "refresh", mock.Anything).Return("new_refresh_token", tt.generateRefreshErr)
+			mockTokenManager.On("GenerateJWT", mock.Anything, 0, "access", mock.Anything).Return("new_access_token", tt.generateAccessErr)
+			mockTokenManager.On("GenerateJWT", mock.Anything, 0, "refresh", mock.Anything).Return("new_refresh_token", tt.generateRefreshErr)
 			mockTokenManager.On("SaveRefreshToken", mock.Anything).Return(tt.saveTokenErr)
 
 			var body []byte
@@ -93,7 +117,7 @@ func TestRefreshHandler(t *testing.T) {
 
 			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 
-			handler := refresh.New(logger, mockTokenManager)
+			handler := refresh.New(logger, mockTokenManager, mockUserService)
 			handler(w, req)
 
 			resp := w.Result()
@@ -0,0 +1,39 @@
+// Package password holds the step the jwt and session change password handlers share.
+package password
+
+import (
+	"errors"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+)
+
+// Change checks newPassword against the password policy and currentPassword against the one of the user,
+// then stores newPassword and returns the bumped token version of the user. The message of the error
+// is meant for the client, the cause is logged.
+func Change(log *slog.Logger, userService database.UserService, userID int, currentPassword, newPassword string) (int, error) {
+	err := utils.ValidatePassword(newPassword)
+	if err != nil {
+		log.Error("new password rejected by policy", slog.Int("user_id", userID), slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	user, err := userService.GetUserById(userID)
+	if err != nil {
+		log.Error("failed to get user", slog.Int("user_id", userID))
+		return 0, errors.New("failed to get user")
+	}
+
+	if !utils.CheckPasswordHash(currentPassword, user.Password) {
+		log.Error("invalid current password", slog.Int("user_id", userID))
+		return 0, errors.New("invalid current password")
+	}
+
+	tokenVersion, err := userService.ChangePassword(userID, newPassword)
+	if err != nil {
+		log.Error("failed to change password", slog.Int("user_id", userID), slog.String("error", err.Error()))
+		return 0, errors.New("failed to change password")
+	}
+
+	return tokenVersion, nil
+}
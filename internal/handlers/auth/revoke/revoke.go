@@ -0,0 +1,41 @@
+package revoke
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the log out everywhere response payload.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// New bumps the caller's token version, which revokes every access token,
+// refresh token and session issued to them so far, including the current one.
+func New(log *slog.Logger, userService database.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Log out user everywhere")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			utils.SendError(w, "Invalid user context")
+			return
+		}
+
+		_, err := userService.BumpTokenVersion(userID)
+		if err != nil {
+			log.Error("Error revoking user credentials", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			utils.SendError(w, "Error revoking user credentials")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+		})
+	}
+}
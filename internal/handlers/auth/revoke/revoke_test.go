@@ -0,0 +1,77 @@
+package revoke_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/handlers/auth/revoke"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestRevoke(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		bumpErr        error
+		expectedStatus string
+		expectedError  string
+	}{
+		{
+			name:           "TestRevoke_NoContextUserID",
+			userID:         "",
+			expectedStatus: "Bad Request",
+			expectedError:  "Invalid user context",
+		},
+		{
+			name:           "TestRevoke_BumpTokenVersionError",
+			userID:         "123",
+			bumpErr:        errors.New("database error"),
+			expectedStatus: "Bad Request",
+			expectedError:  "Error revoking user credentials",
+		},
+		{
+			name:           "TestRevoke_Success",
+			userID:         "123",
+			expectedStatus: "OK",
+			expectedError:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserService := new(mocks.UserService)
+			if tt.userID != "" {
+				mockUserService.On("BumpTokenVersion", 123).Return(1, tt.bumpErr)
+			}
+			defer mockUserService.AssertExpectations(t)
+
+			req := httptest.NewRequest(http.MethodPost, "/logout_all", nil)
+			if tt.userID != "" {
+				ctx := context.WithValue(req.Context(), "user_id", tt.userID)
+				req = req.WithContext(ctx)
+			}
+			w := httptest.NewRecorder()
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			handler := revoke.New(logger, mockUserService)
+			handler(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			var respBody revoke.Response
+			err := json.NewDecoder(resp.Body).Decode(&respBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedStatus, respBody.Status)
+			assert.Equal(t, tt.expectedError, respBody.Error)
+		})
+	}
+}
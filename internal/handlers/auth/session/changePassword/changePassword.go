@@ -0,0 +1,86 @@
+package sessionChangePassword
+
+import (
+	"encoding/json"
+	"github.com/go-playground/validator/v10"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/database/auth"
+	"go-rest-api-auth/internal/handlers/auth/password"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+)
+
+// Request represents the change password request payload.
+// swagger:model
+type Request struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+// Response represents the change password response payload.
+// Every session issued before the change is revoked, the new session replaces them.
+// swagger:model
+type Response struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	SessionID string `json:"session_id"`
+}
+
+func New(log *slog.Logger, sessionManager auth.SessionManager, userService database.UserService, sessionCookie *auth.SessionCookie) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Session Change password")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			utils.SendError(w, "Invalid user context")
+			return
+		}
+
+		cookie, err := r.Cookie(sessionCookie.Name())
+		if err != nil {
+			log.Error("session cookie not found", slog.Int("user_id", userID))
+			utils.SendError(w, "Unauthorized user")
+			return
+		}
+
+		//get request body info
+		var req Request
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		//validating request body info
+		err = validator.New().Struct(req)
+		if err != nil {
+			log.Error("failed to validate request", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate request")
+			return
+		}
+
+		tokenVersion, err := password.Change(log, userService, userID, req.CurrentPassword, req.NewPassword)
+		if err != nil {
+			utils.SendError(w, err.Error())
+			return
+		}
+
+		//the new token version revokes every other session, the current one is replaced by a fresh id
+		sessionID, err := sessionManager.RegenerateSession(cookie.Value, tokenVersion)
+		if err != nil {
+			log.Error("failed to regenerate session", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to regenerate session")
+			return
+		}
+
+		http.SetCookie(w, sessionCookie.New(sessionID, sessionManager.GetterTtl()))
+
+		utils.Send(w, Response{
+			Status:    http.StatusText(http.StatusOK),
+			SessionID: sessionID,
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package sessionChangePassword_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/config"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/database/auth"
+	sessionChangePassword "go-rest-api-auth/internal/handlers/auth/session/changePassword"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSessionChangePassword(t *testing.T) {
+	tests := []struct {
+		name              string
+		reqBody           string
+		changePasswordErr error
+		regenerateErr     error
+		expectedStatus    string
+		expectedError     string
+	}{
+		{
+			name:           "TestSessionChangePassword_DecodeRequestBodyError",
+			reqBody:        "{ invalid json }",
+			expectedStatus: "Bad Request",
+			expectedError:  "failed to decode request body",
+		},
+		{
+			name:           "TestSessionChangePassword_WeakPassword",
+			reqBody:        "{\"current_password\":\"oldpassword1\",\"new_password\":\"short\"}",
+			expectedStatus: "Bad Request",
+			expectedError:  "password must be at least 8 characters long",
+		},
+		{
+			name:           "TestSessionChangePassword_InvalidCurrentPassword",
+			reqBody:        "{\"current_password\":\"wrongpassword1\",\"new_password\":\"newpassword1\"}",
+			expectedStatus: "Bad Request",
+			expectedError:  "invalid current password",
+		},
+		{
+			name:              "TestSessionChangePassword_ChangePasswordError",
+			reqBody:           "{\"current_password\":\"oldpassword1\",\"new_password\":\"newpassword1\"}",
+			changePasswordErr: errors.New("database error"),
+			expectedStatus:    "Bad Request",
+			expectedError:     "failed to change password",
+		},
+		{
+			name:           "TestSessionChangePassword_RegenerateSessionError",
+			reqBody:        "{\"current_password\":\"oldpassword1\",\"new_password\":\"newpassword1\"}",
+			regenerateErr:  errors.New("store error"),
+			expectedStatus: "Bad Request",
+			expectedError:  "failed to regenerate session",
+		},
+		{
+			name:           "TestSessionChangePassword_Success",
+			reqBody:        "{\"current_password\":\"oldpassword1\",\"new_password\":\"newpassword1\"}",
+			expectedStatus: "OK",
+			expectedError:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSessionManager := new(mocks.SessionManager)
+			mockUserService := new(mocks.UserService)
+			log := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			hashedPassword, _ := utils.HashPassword("oldpassword1")
+			mockUserService.On("GetUserById", 1).Return(database.UserDTO{Id: 1, Username: "testuser", Password: hashedPassword}, nil)
+			mockUserService.On("ChangePassword", 1, "newpassword1").Return(4, tt.changePasswordErr)
+
+			mockSessionManager.On("GetterTtl").Return(time.Minute)
+			mockSessionManager.On("RegenerateSession", "old-session", 4).Return("new-session", tt.regenerateErr)
+
+			req := httptest.NewRequest(http.MethodPost, "/me/password", bytes.NewBuffer([]byte(tt.reqBody)))
+			req.AddCookie(&http.Cookie{Name: "session_id", Value: "old-session"})
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "1"))
+			w := httptest.NewRecorder()
+
+			sessionCookie := auth.NewSessionCookie(config.SESSION{CookieName: "session_id"})
+			handler := sessionChangePassword.New(log, mockSessionManager, mockUserService, sessionCookie)
+			handler(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			var respBody sessionChangePassword.Response
+			err := json.NewDecoder(resp.Body).Decode(&respBody)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedStatus, respBody.Status)
+			assert.Equal(t, tt.expectedError, respBody.Error)
+			if tt.expectedStatus == "OK" {
+				mockSessionManager.AssertCalled(t, "RegenerateSession", "old-session", 4)
+				cookies := resp.Cookies()
+				assert.Len(t, cookies, 1)
+				assert.Equal(t, "new-session", cookies[0].Value)
+			}
+		})
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
-	"time"
 )
 
 // Request represents the session login request payload.
@@ -28,7 +27,7 @@ type Response struct {
 	SessionID string `json:"session_id"`
 }
 
-func New(log *slog.Logger, sessionManager auth.SessionManager, userService database.UserService) http.HandlerFunc {
+func New(log *slog.Logger, sessionManager auth.SessionManager, userService database.UserService, sessionCookie *auth.SessionCookie) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Info("Session Login user")
 
@@ -62,26 +61,47 @@ func New(log *slog.Logger, sessionManager auth.SessionManager, userService datab
 			return
 		}
 
-		_, sessionExists := sessionManager.GetSessionByUserID(strconv.Itoa(user.Id))
-		if !errors.Is(sessionExists, sessionManager.GetterErrSessionNotFound()) {
-			log.Error("session already exists", slog.String("username", req.Username))
-			utils.SendError(w, "session already exists")
+		if user.Suspended {
+			log.Info("suspended user", slog.String("username", req.Username))
+			utils.SendError(w, "user is suspended")
 			return
 		}
 
-		sessionID, err := sessionManager.CreateSession(strconv.Itoa(user.Id))
+		//never reuse a session id issued before login (session fixation)
+		if cookie, err := r.Cookie(sessionCookie.Name()); err == nil {
+			err = sessionManager.DeleteSession(cookie.Value)
+			if err != nil && !errors.Is(err, sessionManager.GetterErrSessionNotFound()) {
+				log.Error("failed to delete previous session", slog.String("username", req.Username), slog.String("error", err.Error()))
+				utils.SendError(w, "failed to delete previous session")
+				return
+			}
+		}
+
+		existingID, err := sessionManager.GetSessionByUserID(strconv.Itoa(user.Id))
+		if !errors.Is(err, sessionManager.GetterErrSessionNotFound()) {
+			//a session issued before the token version was bumped is revoked and must not block logging in again
+			if err != nil || !isStale(sessionManager, existingID, user.TokenVersion) {
+				log.Error("session already exists", slog.String("username", req.Username))
+				utils.SendError(w, "session already exists")
+				return
+			}
+
+			err = sessionManager.DeleteSession(existingID)
+			if err != nil && !errors.Is(err, sessionManager.GetterErrSessionNotFound()) {
+				log.Error("failed to delete revoked session", slog.String("username", req.Username), slog.String("error", err.Error()))
+				utils.SendError(w, "failed to delete revoked session")
+				return
+			}
+		}
+
+		sessionID, err := sessionManager.CreateSession(strconv.Itoa(user.Id), user.TokenVersion)
 		if err != nil {
 			log.Error("failed to create session", slog.String("username", req.Username))
 			utils.SendError(w, err.Error())
 			return
 		}
 
-		http.SetCookie(w, &http.Cookie{
-			Name:     "session_id",
-			Value:    sessionID,
-			Expires:  time.Now().Add(sessionManager.GetterTtl()),
-			HttpOnly: true,
-		})
+		http.SetCookie(w, sessionCookie.New(sessionID, sessionManager.GetterTtl()))
 
 		utils.Send(w, Response{
 			Status:    http.StatusText(http.StatusOK),
@@ -89,3 +109,13 @@ func New(log *slog.Logger, sessionManager auth.SessionManager, userService datab
 		})
 	}
 }
+
+// isStale reports whether the session sessionID was issued before the token version of its user was bumped.
+// A session that expired meanwhile is stale as well.
+func isStale(sessionManager auth.SessionManager, sessionID string, tokenVersion int) bool {
+	session, err := sessionManager.GetSession(sessionID)
+	if errors.Is(err, sessionManager.GetterErrSessionNotFound()) {
+		return true
+	}
+	return err == nil && session.TokenVersion < tokenVersion
+}
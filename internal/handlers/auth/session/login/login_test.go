@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/config"
 	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/database/auth"
 	sessionLogin "go-rest-api-auth/internal/handlers/auth/session/login"
 	"go-rest-api-auth/internal/utils"
 	"go-rest-api-auth/testing/mocks"
@@ -21,10 +23,16 @@ func TestSessionLogin(t *testing.T) {
 	tests := []struct {
 		name              string
 		reqBody           string
+		previousSession   string
+		deleteSessionErr  error
 		userServiceErr    error
 		sessionManagerErr error
 		checkPasswordHash bool
+		suspended         bool
 		sessionExists     bool
+		sessionVersion    int
+		tokenVersion      int
+		deleteStaleErr    error
 		createSessionErr  error
 		expectedStatus    string
 		expectedError     string
@@ -62,6 +70,25 @@ func TestSessionLogin(t *testing.T) {
 			expectedStatus: "Bad Request",
 			expectedError:  "session already exists",
 		},
+		{
+			name:           "TestSessionLogin_StaleSessionReplaced",
+			reqBody:        "{\"username\":\"testuser\",\"password\":\"testpassword\"}",
+			sessionExists:  true,
+			sessionVersion: 1,
+			tokenVersion:   2,
+			expectedStatus: "OK",
+			expectedError:  "",
+		},
+		{
+			name:           "TestSessionLogin_DeleteStaleSessionError",
+			reqBody:        "{\"username\":\"testuser\",\"password\":\"testpassword\"}",
+			sessionExists:  true,
+			sessionVersion: 1,
+			tokenVersion:   2,
+			deleteStaleErr: errors.New("redis down"),
+			expectedStatus: "Bad Request",
+			expectedError:  "failed to delete revoked session",
+		},
 		{
 			name:             "TestSessionLogin_CreateSessionError",
 			reqBody:          "{\"username\":\"testuser\",\"password\":\"testpassword\"}",
@@ -69,6 +96,28 @@ func TestSessionLogin(t *testing.T) {
 			expectedStatus:   "Bad Request",
 			expectedError:    "create session error",
 		},
+		{
+			name:             "TestSessionLogin_DeletePreviousSessionError",
+			reqBody:          "{\"username\":\"testuser\",\"password\":\"testpassword\"}",
+			previousSession:  "attacker-session",
+			deleteSessionErr: errors.New("redis down"),
+			expectedStatus:   "Bad Request",
+			expectedError:    "failed to delete previous session",
+		},
+		{
+			name:            "TestSessionLogin_PreviousSessionDeleted",
+			reqBody:         "{\"username\":\"testuser\",\"password\":\"testpassword\"}",
+			previousSession: "attacker-session",
+			expectedStatus:  "OK",
+			expectedError:   "",
+		},
+		{
+			name:           "TestSessionLogin_SuspendedUser",
+			reqBody:        "{\"username\":\"testuser\",\"password\":\"testpassword\"}",
+			suspended:      true,
+			expectedStatus: "Bad Request",
+			expectedError:  "user is suspended",
+		},
 		{
 			name:           "TestSessionLogin_Success",
 			reqBody:        "{\"username\":\"testuser\",\"password\":\"testpassword\"}",
@@ -88,9 +137,19 @@ func TestSessionLogin(t *testing.T) {
 				t.Fatal(err)
 			}
 
+			if tt.previousSession != "" {
+				req.AddCookie(&http.Cookie{Name: "__Host-session_id", Value: tt.previousSession})
+				mockSessionManager.On("DeleteSession", tt.previousSession).Return(tt.deleteSessionErr)
+			}
+
 			w := httptest.NewRecorder()
 
-			handler := sessionLogin.New(log, mockSessionManager, mockUserService)
+			sessionCookie := auth.NewSessionCookie(config.SESSION{
+				CookieName:       "session_id",
+				CookieSameSite:   "strict",
+				CookieHostPrefix: true,
+			})
+			handler := sessionLogin.New(log, mockSessionManager, mockUserService, sessionCookie)
 
 			mockSessionManager.On("GetterErrSessionNotFound").Return(errors.New("session not found"))
 			mockSessionManager.On("GetterTtl").Return(time.Minute)
@@ -99,23 +158,31 @@ func TestSessionLogin(t *testing.T) {
 				mockUserService.On("GetUserByName", "testuser").Return(database.UserDTO{}, tt.userServiceErr)
 			} else {
 				hashedPassword, _ := utils.HashPassword("testpassword")
-				mockUserService.On("GetUserByName", "testuser").Return(database.UserDTO{Id: 1, Username: "testuser", Password: hashedPassword}, nil)
+				mockUserService.On("GetUserByName", "testuser").Return(database.UserDTO{Id: 1, Username: "testuser", Password: hashedPassword, TokenVersion: tt.tokenVersion, Suspended: tt.suspended}, nil)
 			}
 
 			if tt.sessionExists {
-				mockSessionManager.On("GetSessionByUserID", "1").Return("", nil)
+				mockSessionManager.On("GetSessionByUserID", "1").Return("existing-session", nil)
+				mockSessionManager.On("GetSession", "existing-session").Return(auth.Session{UserID: "1", TokenVersion: tt.sessionVersion}, nil)
+				if tt.sessionVersion < tt.tokenVersion {
+					mockSessionManager.On("DeleteSession", "existing-session").Return(tt.deleteStaleErr)
+				}
 			} else {
 				mockSessionManager.On("GetSessionByUserID", "1").Return("", mockSessionManager.GetterErrSessionNotFound())
 			}
 
 			if tt.createSessionErr != nil {
-				mockSessionManager.On("CreateSession", "1").Return("", tt.createSessionErr)
+				mockSessionManager.On("CreateSession", "1", tt.tokenVersion).Return("", tt.createSessionErr)
 			} else {
-				mockSessionManager.On("CreateSession", "1").Return("session123", nil)
+				mockSessionManager.On("CreateSession", "1", tt.tokenVersion).Return("session123", nil)
 			}
 
 			handler(w, req)
 
+			if tt.sessionExists && tt.sessionVersion < tt.tokenVersion {
+				mockSessionManager.AssertCalled(t, "DeleteSession", "existing-session")
+			}
+
 			resp := w.Result()
 			defer resp.Body.Close()
 
@@ -127,6 +194,17 @@ func TestSessionLogin(t *testing.T) {
 
 			assert.Equal(t, tt.expectedStatus, respBody.Status)
 			assert.Equal(t, tt.expectedError, respBody.Error)
+
+			if tt.expectedStatus == "OK" {
+				cookies := resp.Cookies()
+				assert.Len(t, cookies, 1)
+				assert.Equal(t, "__Host-session_id", cookies[0].Name)
+				assert.Equal(t, "session123", cookies[0].Value)
+				assert.Equal(t, "/", cookies[0].Path)
+				assert.True(t, cookies[0].Secure)
+				assert.True(t, cookies[0].HttpOnly)
+				assert.Equal(t, http.SameSiteStrictMode, cookies[0].SameSite)
+			}
 		})
 	}
 }
@@ -6,8 +6,6 @@ import (
 
 	"log/slog"
 	"net/http"
-
-	"time"
 )
 
 // Response represents the session logout response payload.
@@ -17,11 +15,11 @@ type Response struct {
 	Error  string `json:"error,omitempty"`
 }
 
-func New(log *slog.Logger, sessionManager auth.SessionManager) http.HandlerFunc {
+func New(log *slog.Logger, sessionManager auth.SessionManager, sessionCookie *auth.SessionCookie) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Info("Session Logout user")
 
-		cookie, err := r.Cookie("session_id")
+		cookie, err := r.Cookie(sessionCookie.Name())
 		if err != nil {
 			log.Error("Error getting cookie", slog.String("error", err.Error()))
 			utils.SendError(w, "Error getting cookie")
@@ -35,11 +33,7 @@ func New(log *slog.Logger, sessionManager auth.SessionManager) http.HandlerFunc
 			return
 		}
 
-		http.SetCookie(w, &http.Cookie{
-			Name:    "session_id",
-			Value:   "",
-			Expires: time.Unix(0, 0),
-		})
+		http.SetCookie(w, sessionCookie.Expired())
 
 		utils.Send(w, Response{
 			Status: http.StatusText(http.StatusOK),
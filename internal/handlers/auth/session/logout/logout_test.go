@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/config"
+	"go-rest-api-auth/internal/database/auth"
 	sessionLogout "go-rest-api-auth/internal/handlers/auth/session/logout"
 	"go-rest-api-auth/testing/mocks"
 	"log/slog"
@@ -59,7 +61,7 @@ func TestSessionLogout(t *testing.T) {
 			}
 			w := httptest.NewRecorder()
 
-			handler := sessionLogout.New(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})), mockSessionManager)
+			handler := sessionLogout.New(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})), mockSessionManager, auth.NewSessionCookie(config.SESSION{CookieName: "session_id"}))
 			handler(w, req)
 
 			resp := w.Result()
@@ -70,6 +72,14 @@ func TestSessionLogout(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expectedStatus, respBody.Status)
 			assert.Equal(t, tt.expectedError, respBody.Error)
+
+			if tt.expectedStatus == "OK" {
+				cookies := resp.Cookies()
+				assert.Len(t, cookies, 1)
+				assert.Equal(t, "session_id", cookies[0].Name)
+				assert.Equal(t, "", cookies[0].Value)
+				assert.Equal(t, -1, cookies[0].MaxAge)
+			}
 		})
 	}
 }
@@ -0,0 +1,75 @@
+package getAvatar
+
+import (
+	"go-rest-api-auth/internal/avatar"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/storage"
+	"go-rest-api-auth/internal/utils"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Response represents the get avatar error payload, the picture itself is sent as is.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// New serves a thumbnail of an avatar to anyone. The avatar id is the checksum of its default
+// thumbnail, so the content behind a URL never changes and may be cached for good.
+func New(log *slog.Logger, service database.AvatarService, blobs storage.BlobStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get avatar")
+
+		avatarID := r.PathValue("avatarID")
+
+		size, err := utils.QueryInt(r.URL.Query(), "size")
+		if err != nil || (size != 0 && !slices.Contains(avatar.Sizes, size)) {
+			log.Error("invalid size", slog.String("size", r.URL.Query().Get("size")))
+			utils.SendError(w, "invalid size")
+			return
+		}
+
+		variant, err := service.GetAvatarVariant(avatarID, size)
+		if err != nil {
+			log.Error("avatar not found", slog.String("avatar_id", avatarID), slog.String("error", err.Error()))
+			utils.SendError(w, "avatar not found")
+			return
+		}
+
+		etag := `"` + variant.Checksum + `"`
+		if match := r.Header.Get("If-None-Match"); match != "" && strings.Contains(match, etag) {
+			setCacheHeaders(w, etag)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		content, err := blobs.Get(r.Context(), variant.Checksum)
+		if err != nil {
+			log.Error("failed to read avatar", slog.String("avatar_id", avatarID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to read avatar")
+			return
+		}
+		defer content.Close()
+
+		setCacheHeaders(w, etag)
+		w.Header().Set("Content-Type", variant.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(variant.Length, 10))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+
+		if _, err = io.Copy(w, content); err != nil {
+			log.Error("failed to send avatar", slog.String("avatar_id", avatarID), slog.String("error", err.Error()))
+		}
+	}
+}
+
+func setCacheHeaders(w http.ResponseWriter, etag string) {
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("ETag", etag)
+}
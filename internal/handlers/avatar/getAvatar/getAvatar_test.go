@@ -0,0 +1,150 @@
+package getAvatar_test
+
+import (
+	"encoding/json"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/avatar/getAvatar"
+	"go-rest-api-auth/internal/storage"
+	"go-rest-api-auth/testing/mocks"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGetAvatarHandler(t *testing.T) {
+	thumbnail := database.AvatarVariantDTO{Size: 64, Checksum: "cd34", Length: 7, ContentType: "image/jpeg"}
+
+	tests := []struct {
+		name              string
+		query             string
+		ifNoneMatch       string
+		skipVariant       bool
+		expectedSize      int
+		mockVariant       database.AvatarVariantDTO
+		mockVariantError  error
+		skipBlob          bool
+		mockBlobError     error
+		expectedStatus    int
+		expectedHeaders   map[string]string
+		expectedContent   string
+		expectedErrorBody *getAvatar.Response
+	}{
+		{
+			name:           "DefaultSize",
+			mockVariant:    database.AvatarVariantDTO{Size: 256, Checksum: "ab12", Length: 7, ContentType: "image/png"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Content-Type":           "image/png",
+				"Content-Length":         "7",
+				"Cache-Control":          "public, max-age=31536000, immutable",
+				"ETag":                   `"ab12"`,
+				"X-Content-Type-Options": "nosniff",
+			},
+			expectedContent: "picture",
+		},
+		{
+			name:            "Thumbnail",
+			query:           "?size=64",
+			expectedSize:    64,
+			mockVariant:     thumbnail,
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"Content-Type": "image/jpeg", "ETag": `"cd34"`},
+			expectedContent: "picture",
+		},
+		{
+			name:            "NotModified",
+			query:           "?size=64",
+			ifNoneMatch:     `"cd34"`,
+			expectedSize:    64,
+			mockVariant:     thumbnail,
+			skipBlob:        true,
+			expectedStatus:  http.StatusNotModified,
+			expectedHeaders: map[string]string{"Cache-Control": "public, max-age=31536000, immutable", "ETag": `"cd34"`},
+		},
+		{
+			name:              "BlobMissing",
+			mockVariant:       thumbnail,
+			mockBlobError:     storage.ErrBlobNotFound,
+			expectedStatus:    http.StatusOK,
+			expectedHeaders:   map[string]string{"Cache-Control": "", "ETag": ""},
+			expectedErrorBody: &getAvatar.Response{Status: "Bad Request", Error: "failed to read avatar"},
+		},
+		{
+			name:              "AvatarNotFound",
+			mockVariantError:  pgx.ErrNoRows,
+			skipBlob:          true,
+			expectedStatus:    http.StatusOK,
+			expectedErrorBody: &getAvatar.Response{Status: "Bad Request", Error: "avatar not found"},
+		},
+		{
+			name:              "UnknownSize",
+			query:             "?size=100",
+			skipVariant:       true,
+			skipBlob:          true,
+			expectedStatus:    http.StatusOK,
+			expectedErrorBody: &getAvatar.Response{Status: "Bad Request", Error: "invalid size"},
+		},
+		{
+			name:              "InvalidSize",
+			query:             "?size=big",
+			skipVariant:       true,
+			skipBlob:          true,
+			expectedStatus:    http.StatusOK,
+			expectedErrorBody: &getAvatar.Response{Status: "Bad Request", Error: "invalid size"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.AvatarService)
+			if !tt.skipVariant {
+				mockService.On("GetAvatarVariant", "ab12", tt.expectedSize).Return(tt.mockVariant, tt.mockVariantError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			mockBlobs := new(mocks.BlobStore)
+			if !tt.skipBlob {
+				var content io.ReadCloser
+				if tt.mockBlobError == nil {
+					content = io.NopCloser(strings.NewReader("picture"))
+				}
+				mockBlobs.On("Get", mock.Anything, tt.mockVariant.Checksum).Return(content, tt.mockBlobError)
+			}
+			defer mockBlobs.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /avatars/{avatarID}", getAvatar.New(logger, mockService, mockBlobs))
+
+			req := httptest.NewRequest(http.MethodGet, "/avatars/ab12"+tt.query, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for header, value := range tt.expectedHeaders {
+				assert.Equal(t, value, w.Header().Get(header), header)
+			}
+
+			if tt.expectedErrorBody != nil {
+				var responseBody getAvatar.Response
+				err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+				assert.NoError(t, err)
+				assert.Equal(t, *tt.expectedErrorBody, responseBody)
+				return
+			}
+			assert.Equal(t, tt.expectedContent, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package blockUser
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+)
+
+// Request represents the block user request payload.
+// swagger:model
+type Request struct {
+	UserID int `json:"user_id" validate:"required,gt=0"`
+}
+
+// Response represents the block user response payload.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	UserID int    `json:"user_id,omitempty"`
+}
+
+// New makes the current user block another user, which also removes the follows between the two.
+// Blocking twice is a no-op.
+func New(log *slog.Logger, service database.BlockService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Block user")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		var req Request
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		err = validator.New().Struct(req)
+		if err != nil {
+			log.Error("failed to validate request", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate request")
+			return
+		}
+
+		err = service.BlockUser(userID, req.UserID)
+		if err != nil {
+			log.Error("failed to block user", slog.Int("user_id", req.UserID), slog.String("error", err.Error()))
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				utils.SendError(w, "user not found")
+			case errors.Is(err, database.ErrSelfBlock):
+				utils.SendError(w, err.Error())
+			default:
+				utils.SendError(w, "failed to block user")
+			}
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			UserID: req.UserID,
+		})
+	}
+}
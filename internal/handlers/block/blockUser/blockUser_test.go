@@ -0,0 +1,102 @@
+package blockUser_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/block/blockUser"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestBlockUserHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestBody  blockUser.Request
+		skipMock     bool
+		mockError    error
+		expectedBody blockUser.Response
+	}{
+		{
+			name:        "SuccessfulBlock",
+			requestBody: blockUser.Request{UserID: 7},
+			expectedBody: blockUser.Response{
+				Status: "OK",
+				UserID: 7,
+			},
+		},
+		{
+			name:        "MissingUserID",
+			requestBody: blockUser.Request{},
+			skipMock:    true,
+			expectedBody: blockUser.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
+		{
+			name:        "UserNotFound",
+			requestBody: blockUser.Request{UserID: 7},
+			mockError:   pgx.ErrNoRows,
+			expectedBody: blockUser.Response{
+				Status: "Bad Request",
+				Error:  "user not found",
+			},
+		},
+		{
+			name:        "SelfBlock",
+			requestBody: blockUser.Request{UserID: 7},
+			mockError:   database.ErrSelfBlock,
+			expectedBody: blockUser.Response{
+				Status: "Bad Request",
+				Error:  "users can not block or mute themselves",
+			},
+		},
+		{
+			name:        "ErrorBlock",
+			requestBody: blockUser.Request{UserID: 7},
+			mockError:   errors.New("query error"),
+			expectedBody: blockUser.Response{
+				Status: "Bad Request",
+				Error:  "failed to block user",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.BlockService)
+			if !tt.skipMock {
+				mockService.On("BlockUser", 123, tt.requestBody.UserID).Return(tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /me/blocks", blockUser.New(logger, mockService))
+
+			body, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/me/blocks", bytes.NewReader(body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody blockUser.Response
+			err = json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
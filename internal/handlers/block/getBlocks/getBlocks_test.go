@@ -0,0 +1,108 @@
+package getBlocks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/block/getBlocks"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetBlocksHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		skipMock     bool
+		expectedPage database.Page
+		mockResponse []database.UserDTO
+		mockCursor   string
+		mockError    error
+		expectedBody getBlocks.Response
+	}{
+		{
+			name:         "SuccessfulGetBlocks",
+			url:          "/me/blocks?limit=1",
+			expectedPage: database.Page{Limit: 1},
+			mockResponse: []database.UserDTO{{Id: 2, Username: "blocked", Password: "hash"}},
+			mockCursor:   "next",
+			expectedBody: getBlocks.Response{
+				Status:     "OK",
+				Users:      []views.PublicUser{{Id: 2, Username: "blocked"}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name:         "NoBlocks",
+			url:          "/me/blocks?cursor=abc",
+			expectedPage: database.Page{Cursor: "abc"},
+			expectedBody: getBlocks.Response{
+				Status: "OK",
+				Users:  []views.PublicUser{},
+			},
+		},
+		{
+			name:     "InvalidLimit",
+			url:      "/me/blocks?limit=abc",
+			skipMock: true,
+			expectedBody: getBlocks.Response{
+				Status: "Bad Request",
+				Error:  "invalid limit",
+			},
+		},
+		{
+			name:         "InvalidCursor",
+			url:          "/me/blocks?cursor=abc",
+			expectedPage: database.Page{Cursor: "abc"},
+			mockError:    fmt.Errorf("%w: malformed cursor", database.ErrInvalidPage),
+			expectedBody: getBlocks.Response{
+				Status: "Bad Request",
+				Error:  "invalid page: malformed cursor",
+			},
+		},
+		{
+			name:      "ErrorGetBlocks",
+			url:       "/me/blocks",
+			mockError: errors.New("query error"),
+			expectedBody: getBlocks.Response{
+				Status: "Bad Request",
+				Error:  "get blocks failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.BlockService)
+			if !tt.skipMock {
+				mockService.On("GetBlocks", 123, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /me/blocks", getBlocks.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody getBlocks.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package unblockUser
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the unblock user response payload.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	UserID int    `json:"user_id,omitempty"`
+}
+
+// New lifts a block of the current user, unblocking a user that was not blocked is a no-op.
+func New(log *slog.Logger, service database.BlockService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Unblock user")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		blockedID, err := strconv.Atoi(r.PathValue("userID"))
+		if err != nil {
+			log.Error("Invalid user id", slog.String("user_id", r.PathValue("userID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid user id")
+			return
+		}
+
+		err = service.UnblockUser(userID, blockedID)
+		if err != nil {
+			log.Error("failed to unblock user", slog.Int("user_id", blockedID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to unblock user")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			UserID: blockedID,
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package createComment
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/contentfilter"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request represents the creation comment request payload.
+// swagger:model
+type Request struct {
+	Content  string `json:"content" validate:"required,max=10000"`
+	ParentID int    `json:"parent_id,omitempty" validate:"gte=0"`
+}
+
+// Response represents the creation comment response payload. Violations lists why the content filters rejected the comment.
+// swagger:model
+type Response struct {
+	Status     string                    `json:"status"`
+	Error      string                    `json:"error,omitempty"`
+	Violations []contentfilter.Violation `json:"violations,omitempty"`
+	Comment    views.Comment             `json:"comment"`
+}
+
+// New runs the comment through the content filters before adding it. The moderation queue
+// only holds posts, comments the filters flag are added and logged for review. Posts the user
+// may not read are not found.
+func New(log *slog.Logger, service database.CommentService, posts database.PostService, filters contentfilter.Pipeline) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Create comment")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+
+		var req Request
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		err = validator.New().Struct(req)
+		if err != nil {
+			log.Error("failed to validate request", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate request")
+			return
+		}
+
+		post, err := posts.GetPost(postID)
+		if err != nil {
+			log.Error("post not found", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+		visible, err := posts.CanViewPost(post, userID)
+		if err != nil {
+			log.Error("failed to check post visibility", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to check post visibility")
+			return
+		}
+		if !visible {
+			log.Error("post not visible", slog.Int("post_id", postID), slog.String("status", post.Status), slog.String("visibility", post.Visibility))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		decision, err := filters.Check(r.Context(), contentfilter.Content{
+			Kind:   contentfilter.KindComment,
+			UserID: userID,
+			Body:   req.Content,
+		})
+		var rejected *contentfilter.RejectedError
+		if errors.As(err, &rejected) {
+			log.Info("comment rejected by content filters", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.Send(w, Response{
+				Status:     http.StatusText(http.StatusBadRequest),
+				Error:      contentfilter.ErrRejected.Error(),
+				Violations: rejected.Violations,
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to filter comment", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to create comment")
+			return
+		}
+
+		comment, err := service.CreateComment(database.CommentDTO{
+			PostId:   postID,
+			ParentId: req.ParentID,
+			UserId:   userID,
+			Content:  req.Content,
+		})
+		if err != nil {
+			log.Error("failed to create comment", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				utils.SendError(w, "post not found")
+			case errors.Is(err, database.ErrInvalidComment), errors.Is(err, database.ErrBlocked):
+				utils.SendError(w, err.Error())
+			default:
+				utils.SendError(w, "failed to create comment")
+			}
+			return
+		}
+
+		if decision.Verdict == contentfilter.Flag {
+			log.Warn("comment flagged by content filters", slog.Int("comment_id", comment.Id), slog.String("reason", decision.Reason()))
+		}
+
+		utils.Send(w, Response{
+			Status:  http.StatusText(http.StatusOK),
+			Comment: views.NewComment(comment),
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package createComment_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-rest-api-auth/internal/contentfilter"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/comment/createComment"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCreateCommentHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		postID         string
+		requestBody    createComment.Request
+		skipMock       bool
+		skipPost       bool
+		postError      error
+		hidden         bool
+		filterDecision contentfilter.Decision
+		filterError    error
+		mockResponse   database.CommentDTO
+		mockError      error
+		expectedBody   createComment.Response
+	}{
+		{
+			name:         "SuccessfulCreateReply",
+			postID:       "7",
+			requestBody:  createComment.Request{Content: "agreed", ParentID: 1},
+			mockResponse: database.CommentDTO{Id: 2, PostId: 7, ParentId: 1, UserId: 123, Content: "agreed"},
+			expectedBody: createComment.Response{
+				Status:  "OK",
+				Comment: views.Comment{Id: 2, PostId: 7, ParentId: 1, UserId: 123, Content: "agreed"},
+			},
+		},
+		{
+			name:        "InvalidPostID",
+			postID:      "abc",
+			requestBody: createComment.Request{Content: "agreed"},
+			skipMock:    true,
+			skipPost:    true,
+			expectedBody: createComment.Response{
+				Status: "Bad Request",
+				Error:  "Invalid post id",
+			},
+		},
+		{
+			name:        "EmptyContent",
+			postID:      "7",
+			requestBody: createComment.Request{},
+			skipMock:    true,
+			skipPost:    true,
+			expectedBody: createComment.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
+		{
+			name:        "TooLongContent",
+			postID:      "7",
+			requestBody: createComment.Request{Content: strings.Repeat("a", 10001)},
+			skipMock:    true,
+			skipPost:    true,
+			expectedBody: createComment.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
+		{
+			name:        "PostNotFound",
+			postID:      "7",
+			requestBody: createComment.Request{Content: "agreed"},
+			mockError:   pgx.ErrNoRows,
+			expectedBody: createComment.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:        "PostMissing",
+			postID:      "7",
+			requestBody: createComment.Request{Content: "agreed"},
+			skipMock:    true,
+			postError:   pgx.ErrNoRows,
+			expectedBody: createComment.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:        "PostNotVisible",
+			postID:      "7",
+			requestBody: createComment.Request{Content: "agreed"},
+			skipMock:    true,
+			hidden:      true,
+			expectedBody: createComment.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:        "ParentOnAnotherPost",
+			postID:      "7",
+			requestBody: createComment.Request{Content: "agreed", ParentID: 9},
+			mockError:   fmt.Errorf("%w: parent comment 9 is not on post 7", database.ErrInvalidComment),
+			expectedBody: createComment.Response{
+				Status: "Bad Request",
+				Error:  "invalid comment: parent comment 9 is not on post 7",
+			},
+		},
+		{
+			name:        "Blocked",
+			postID:      "7",
+			requestBody: createComment.Request{Content: "agreed"},
+			mockError:   database.ErrBlocked,
+			expectedBody: createComment.Response{
+				Status: "Bad Request",
+				Error:  "user is blocked",
+			},
+		},
+		{
+			name:        "ErrorCreateComment",
+			postID:      "7",
+			requestBody: createComment.Request{Content: "agreed"},
+			mockError:   errors.New("tx error"),
+			expectedBody: createComment.Response{
+				Status: "Bad Request",
+				Error:  "failed to create comment",
+			},
+		},
+		{
+			name:        "RejectedByFilters",
+			postID:      "7",
+			requestBody: createComment.Request{Content: "buy cheap pills"},
+			skipMock:    true,
+			filterError: &contentfilter.RejectedError{Violations: []contentfilter.Violation{
+				{Filter: "blocked_words", Reason: `contains "cheap pills"`},
+			}},
+			expectedBody: createComment.Response{
+				Status:     "Bad Request",
+				Error:      "content rejected",
+				Violations: []contentfilter.Violation{{Filter: "blocked_words", Reason: `contains "cheap pills"`}},
+			},
+		},
+		{
+			name:        "FilterError",
+			postID:      "7",
+			requestBody: createComment.Request{Content: "agreed"},
+			skipMock:    true,
+			filterError: errors.New("quota filter: db down"),
+			expectedBody: createComment.Response{
+				Status: "Bad Request",
+				Error:  "failed to create comment",
+			},
+		},
+		{
+			name:        "FlaggedByFilters",
+			postID:      "7",
+			requestBody: createComment.Request{Content: "crypto tips"},
+			filterDecision: contentfilter.Decision{
+				Verdict:    contentfilter.Flag,
+				Violations: []contentfilter.Violation{{Filter: "flagged_words", Reason: `contains "crypto"`}},
+			},
+			mockResponse: database.CommentDTO{Id: 3, PostId: 7, UserId: 123, Content: "crypto tips"},
+			expectedBody: createComment.Response{
+				Status:  "OK",
+				Comment: views.Comment{Id: 3, PostId: 7, UserId: 123, Content: "crypto tips"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFilters := new(mocks.Pipeline)
+			if !tt.skipMock || tt.filterError != nil {
+				content := contentfilter.Content{Kind: contentfilter.KindComment, UserID: 123, Body: tt.requestBody.Content}
+				mockFilters.On("Check", mock.Anything, content).Return(tt.filterDecision, tt.filterError)
+			}
+			defer mockFilters.AssertExpectations(t)
+
+			mockService := new(mocks.CommentService)
+			if !tt.skipMock {
+				comment := database.CommentDTO{PostId: 7, ParentId: tt.requestBody.ParentID, UserId: 123, Content: tt.requestBody.Content}
+				mockService.On("CreateComment", comment).Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			mockPosts := new(mocks.PostService)
+			if !tt.skipPost {
+				post := database.PostDTO{Id: 7, UserId: 9, Status: database.PostStatusPublished, Visibility: database.PostVisibilityFollowers}
+				mockPosts.On("GetPost", 7).Return(post, tt.postError)
+				if tt.postError == nil {
+					mockPosts.On("CanViewPost", post, 123).Return(!tt.hidden, nil)
+				}
+			}
+			defer mockPosts.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /posts/{postID}/comments", createComment.New(logger, mockService, mockPosts, mockFilters))
+
+			body, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/posts/"+tt.postID+"/comments", bytes.NewReader(body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody createComment.Response
+			err = json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
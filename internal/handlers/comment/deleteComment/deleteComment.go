@@ -0,0 +1,72 @@
+package deleteComment
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the deleting comment response payload.
+// swagger:model
+type Response struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	CommentID int    `json:"comment_id,omitempty"`
+}
+
+// New lets the author of a comment or an admin delete it.
+func New(log *slog.Logger, service database.CommentService, userService database.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Delete comment")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+		commentID, err := strconv.Atoi(r.PathValue("commentID"))
+		if err != nil {
+			log.Error("Invalid comment id", slog.String("comment_id", r.PathValue("commentID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid comment id")
+			return
+		}
+
+		comment, err := service.GetComment(commentID)
+		if err != nil || comment.PostId != postID || comment.Deleted {
+			log.Error("comment not found", slog.Int("post_id", postID), slog.Int("comment_id", commentID))
+			utils.SendError(w, "comment not found")
+			return
+		}
+
+		if comment.UserId != userID {
+			user, err := userService.GetUserById(userID)
+			if err != nil || user.Role != database.RoleAdmin {
+				log.Error("comment belongs to another user", slog.Int("comment_id", commentID), slog.Int("user_id", userID))
+				utils.SendError(w, "Forbidden")
+				return
+			}
+		}
+
+		err = service.DeleteComment(commentID)
+		if err != nil {
+			log.Error("Error deleting comment", slog.Int("comment_id", commentID), slog.String("error", err.Error()))
+			utils.SendError(w, "Error deleting comment")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:    http.StatusText(http.StatusOK),
+			CommentID: commentID,
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package deleteComment_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/comment/deleteComment"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestDeleteCommentHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		skipGet         bool
+		mockComment     database.CommentDTO
+		mockGetError    error
+		checkRole       bool
+		mockUser        database.UserDTO
+		skipDelete      bool
+		mockDeleteError error
+		expectedBody    deleteComment.Response
+	}{
+		{
+			name:        "AuthorDeletesComment",
+			path:        "/posts/7/comments/2",
+			mockComment: database.CommentDTO{Id: 2, PostId: 7, UserId: 123},
+			expectedBody: deleteComment.Response{
+				Status:    "OK",
+				CommentID: 2,
+			},
+		},
+		{
+			name:        "AdminDeletesComment",
+			path:        "/posts/7/comments/2",
+			mockComment: database.CommentDTO{Id: 2, PostId: 7, UserId: 456},
+			checkRole:   true,
+			mockUser:    database.UserDTO{Id: 123, Role: database.RoleAdmin},
+			expectedBody: deleteComment.Response{
+				Status:    "OK",
+				CommentID: 2,
+			},
+		},
+		{
+			name:        "OtherUserForbidden",
+			path:        "/posts/7/comments/2",
+			mockComment: database.CommentDTO{Id: 2, PostId: 7, UserId: 456},
+			checkRole:   true,
+			mockUser:    database.UserDTO{Id: 123, Role: database.RoleUser},
+			skipDelete:  true,
+			expectedBody: deleteComment.Response{
+				Status: "Bad Request",
+				Error:  "Forbidden",
+			},
+		},
+		{
+			name:       "InvalidPostID",
+			path:       "/posts/abc/comments/2",
+			skipGet:    true,
+			skipDelete: true,
+			expectedBody: deleteComment.Response{
+				Status: "Bad Request",
+				Error:  "Invalid post id",
+			},
+		},
+		{
+			name:         "CommentNotFound",
+			path:         "/posts/7/comments/2",
+			mockGetError: pgx.ErrNoRows,
+			skipDelete:   true,
+			expectedBody: deleteComment.Response{
+				Status: "Bad Request",
+				Error:  "comment not found",
+			},
+		},
+		{
+			name:        "AlreadyDeleted",
+			path:        "/posts/7/comments/2",
+			mockComment: database.CommentDTO{Id: 2, PostId: 7, Deleted: true},
+			skipDelete:  true,
+			expectedBody: deleteComment.Response{
+				Status: "Bad Request",
+				Error:  "comment not found",
+			},
+		},
+		{
+			name:            "ErrorDeleteComment",
+			path:            "/posts/7/comments/2",
+			mockComment:     database.CommentDTO{Id: 2, PostId: 7, UserId: 123},
+			mockDeleteError: errors.New("tx error"),
+			expectedBody: deleteComment.Response{
+				Status: "Bad Request",
+				Error:  "Error deleting comment",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.CommentService)
+			mockUserService := new(mocks.UserService)
+			if !tt.skipGet {
+				mockService.On("GetComment", 2).Return(tt.mockComment, tt.mockGetError)
+			}
+			if tt.checkRole {
+				mockUserService.On("GetUserById", 123).Return(tt.mockUser, nil)
+			}
+			if !tt.skipDelete {
+				mockService.On("DeleteComment", 2).Return(tt.mockDeleteError)
+			}
+			defer mockService.AssertExpectations(t)
+			defer mockUserService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /posts/{postID}/comments/{commentID}", deleteComment.New(logger, mockService, mockUserService))
+
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody deleteComment.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
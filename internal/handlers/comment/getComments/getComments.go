@@ -0,0 +1,122 @@
+package getComments
+
+import (
+	"errors"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const (
+	viewTree = "tree"
+	viewFlat = "flat"
+)
+
+// Response represents the get comments response payload.
+// swagger:model
+type Response struct {
+	Status     string          `json:"status"`
+	Error      string          `json:"error,omitempty"`
+	Comments   []views.Comment `json:"comments"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// New lists the comments of a post as a tree or flat, without the ones of users the viewer muted or blocked.
+// The comments of a post the viewer may not read are not found, like the post.
+func New(log *slog.Logger, service database.CommentService, posts database.PostService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get comments")
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+
+		query := r.URL.Query()
+
+		limit, err := utils.QueryInt(query, "limit")
+		if err != nil {
+			log.Error("invalid limit", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid limit")
+			return
+		}
+		depth, err := utils.QueryInt(query, "depth")
+		if err != nil {
+			log.Error("invalid depth", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid depth")
+			return
+		}
+		parentID, err := utils.QueryInt(query, "parent")
+		if err != nil {
+			log.Error("invalid parent", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid parent")
+			return
+		}
+
+		page := database.Page{
+			Limit:  limit,
+			Cursor: query.Get("cursor"),
+			Sort:   query.Get("sort"),
+			Order:  query.Get("order"),
+		}
+		viewerID, _ := utils.ContextUserID(r.Context())
+
+		post, err := posts.GetPost(postID)
+		if err != nil {
+			log.Error("post not found", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+		visible, err := posts.CanViewPost(post, viewerID)
+		if err != nil {
+			log.Error("failed to check post visibility", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to check post visibility")
+			return
+		}
+		if !visible {
+			log.Error("post not visible", slog.Int("post_id", postID), slog.String("status", post.Status), slog.String("visibility", post.Visibility))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		var comments []database.CommentDTO
+		var nextCursor string
+		view := query.Get("view")
+		switch view {
+		case "", viewTree:
+			comments, nextCursor, err = service.GetCommentTree(postID, viewerID, parentID, depth, page)
+		case viewFlat:
+			comments, nextCursor, err = service.GetComments(postID, viewerID, page)
+		default:
+			log.Error("invalid view", slog.String("view", view))
+			utils.SendError(w, "invalid view")
+			return
+		}
+		if err != nil {
+			log.Error("get comments failed", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			if errors.Is(err, database.ErrInvalidPage) {
+				utils.SendError(w, err.Error())
+				return
+			}
+			utils.SendError(w, "get comments failed")
+			return
+		}
+
+		response := Response{
+			Status:     http.StatusText(http.StatusOK),
+			NextCursor: nextCursor,
+		}
+		if view == viewFlat {
+			response.Comments = views.NewComments(comments)
+		} else {
+			response.Comments = views.NewCommentTree(comments)
+		}
+
+		utils.Send(w, response)
+	}
+}
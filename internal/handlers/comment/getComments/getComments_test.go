@@ -0,0 +1,188 @@
+package getComments_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/comment/getComments"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetCommentsHandler(t *testing.T) {
+	tree := []database.CommentDTO{
+		{Id: 1, PostId: 7, UserId: 3, Content: "root", ReplyCount: 1},
+		{Id: 2, PostId: 7, ParentId: 1, UserId: 4, Content: "reply", Depth: 1},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		skipMock       bool
+		skipPost       bool
+		postError      error
+		hidden         bool
+		mockMethod     string
+		expectedParent int
+		expectedDepth  int
+		expectedPage   database.Page
+		mockResponse   []database.CommentDTO
+		mockCursor     string
+		mockError      error
+		expectedBody   getComments.Response
+	}{
+		{
+			name:         "TreeByDefault",
+			mockMethod:   "GetCommentTree",
+			mockResponse: tree,
+			mockCursor:   "next",
+			expectedBody: getComments.Response{
+				Status: "OK",
+				Comments: []views.Comment{
+					{Id: 1, PostId: 7, UserId: 3, Content: "root", ReplyCount: 1, Replies: []views.Comment{
+						{Id: 2, PostId: 7, ParentId: 1, UserId: 4, Content: "reply"},
+					}},
+				},
+				NextCursor: "next",
+			},
+		},
+		{
+			name:           "TreeBelowParent",
+			query:          "?view=tree&parent=1&depth=2&limit=5&sort=id&order=desc",
+			mockMethod:     "GetCommentTree",
+			expectedParent: 1,
+			expectedDepth:  2,
+			expectedPage:   database.Page{Limit: 5, Sort: "id", Order: "desc"},
+			expectedBody: getComments.Response{
+				Status:   "OK",
+				Comments: []views.Comment{},
+			},
+		},
+		{
+			name:         "Flat",
+			query:        "?view=flat&cursor=abc",
+			mockMethod:   "GetComments",
+			expectedPage: database.Page{Cursor: "abc"},
+			mockResponse: tree,
+			expectedBody: getComments.Response{
+				Status: "OK",
+				Comments: []views.Comment{
+					{Id: 1, PostId: 7, UserId: 3, Content: "root", ReplyCount: 1},
+					{Id: 2, PostId: 7, ParentId: 1, UserId: 4, Content: "reply"},
+				},
+			},
+		},
+		{
+			name:     "InvalidView",
+			query:    "?view=nested",
+			skipMock: true,
+			expectedBody: getComments.Response{
+				Status: "Bad Request",
+				Error:  "invalid view",
+			},
+		},
+		{
+			name:     "InvalidDepth",
+			query:    "?depth=deep",
+			skipMock: true,
+			skipPost: true,
+			expectedBody: getComments.Response{
+				Status: "Bad Request",
+				Error:  "invalid depth",
+			},
+		},
+		{
+			name:      "PostMissing",
+			skipMock:  true,
+			postError: pgx.ErrNoRows,
+			expectedBody: getComments.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:     "PostNotVisible",
+			skipMock: true,
+			hidden:   true,
+			expectedBody: getComments.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:         "InvalidCursor",
+			query:        "?view=flat&cursor=garbage",
+			mockMethod:   "GetComments",
+			expectedPage: database.Page{Cursor: "garbage"},
+			mockError:    fmt.Errorf("%w: malformed cursor", database.ErrInvalidPage),
+			expectedBody: getComments.Response{
+				Status: "Bad Request",
+				Error:  "invalid page: malformed cursor",
+			},
+		},
+		{
+			name:       "ErrorGetComments",
+			mockMethod: "GetCommentTree",
+			mockError:  errors.New("query error"),
+			expectedBody: getComments.Response{
+				Status: "Bad Request",
+				Error:  "get comments failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.CommentService)
+			switch {
+			case tt.skipMock:
+			case tt.mockMethod == "GetComments":
+				mockService.On("GetComments", 7, 0, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			default:
+				mockService.On("GetCommentTree", 7, 0, tt.expectedParent, tt.expectedDepth, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			mockPosts := new(mocks.PostService)
+			if !tt.skipPost {
+				post := database.PostDTO{Id: 7, UserId: 9, Status: database.PostStatusPublished, Visibility: database.PostVisibilityPrivate}
+				mockPosts.On("GetPost", 7).Return(post, tt.postError)
+				if tt.postError == nil {
+					mockPosts.On("CanViewPost", post, 0).Return(!tt.hidden, nil)
+				}
+			}
+			defer mockPosts.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			handler := getComments.New(logger, mockService, mockPosts)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /posts/{postID}/comments", handler)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/posts/7/comments"+tt.query, nil)
+			assert.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var responseBody getComments.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package updateComment
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"go-rest-api-auth/internal/contentfilter"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request represents the updating comment request payload.
+// swagger:model
+type Request struct {
+	Content string `json:"content" validate:"required,max=10000"`
+}
+
+// Response represents the updating comment response payload. Violations lists why the content filters rejected the edit.
+// swagger:model
+type Response struct {
+	Status     string                    `json:"status"`
+	Error      string                    `json:"error,omitempty"`
+	Violations []contentfilter.Violation `json:"violations,omitempty"`
+	Comment    views.Comment             `json:"comment"`
+}
+
+// New lets the author of a comment edit it, once the edit passes the content filters.
+func New(log *slog.Logger, service database.CommentService, filters contentfilter.Pipeline) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Update comment")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+		commentID, err := strconv.Atoi(r.PathValue("commentID"))
+		if err != nil {
+			log.Error("Invalid comment id", slog.String("comment_id", r.PathValue("commentID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid comment id")
+			return
+		}
+
+		var req Request
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		err = validator.New().Struct(req)
+		if err != nil {
+			log.Error("failed to validate request", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate request")
+			return
+		}
+
+		comment, err := service.GetComment(commentID)
+		if err != nil || comment.PostId != postID || comment.Deleted {
+			log.Error("comment not found", slog.Int("post_id", postID), slog.Int("comment_id", commentID))
+			utils.SendError(w, "comment not found")
+			return
+		}
+		if comment.UserId != userID {
+			log.Error("comment belongs to another user", slog.Int("comment_id", commentID), slog.Int("user_id", userID))
+			utils.SendError(w, "Forbidden")
+			return
+		}
+
+		decision, err := filters.Check(r.Context(), contentfilter.Content{
+			Kind:   contentfilter.KindComment,
+			ID:     commentID,
+			UserID: userID,
+			Body:   req.Content,
+		})
+		var rejected *contentfilter.RejectedError
+		if errors.As(err, &rejected) {
+			log.Info("comment edit rejected by content filters", slog.Int("comment_id", commentID), slog.String("error", err.Error()))
+			utils.Send(w, Response{
+				Status:     http.StatusText(http.StatusBadRequest),
+				Error:      contentfilter.ErrRejected.Error(),
+				Violations: rejected.Violations,
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to filter comment", slog.Int("comment_id", commentID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to update comment")
+			return
+		}
+
+		updated, err := service.UpdateComment(database.CommentDTO{Id: commentID, Content: req.Content})
+		if err != nil {
+			log.Error("failed to update comment", slog.Int("comment_id", commentID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to update comment")
+			return
+		}
+
+		if decision.Verdict == contentfilter.Flag {
+			log.Warn("comment flagged by content filters", slog.Int("comment_id", commentID), slog.String("reason", decision.Reason()))
+		}
+
+		utils.Send(w, Response{
+			Status:  http.StatusText(http.StatusOK),
+			Comment: views.NewComment(updated),
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package updateComment_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-rest-api-auth/internal/contentfilter"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/comment/updateComment"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestUpdateCommentHandler(t *testing.T) {
+	own := database.CommentDTO{Id: 2, PostId: 7, UserId: 123, Content: "old"}
+
+	tests := []struct {
+		name            string
+		path            string
+		requestBody     updateComment.Request
+		skipGet         bool
+		mockComment     database.CommentDTO
+		mockGetError    error
+		filterError     error
+		skipUpdate      bool
+		mockUpdated     database.CommentDTO
+		mockUpdateError error
+		expectedBody    updateComment.Response
+	}{
+		{
+			name:        "SuccessfulUpdateComment",
+			path:        "/posts/7/comments/2",
+			requestBody: updateComment.Request{Content: "new"},
+			mockComment: own,
+			mockUpdated: database.CommentDTO{Id: 2, PostId: 7, UserId: 123, Content: "new"},
+			expectedBody: updateComment.Response{
+				Status:  "OK",
+				Comment: views.Comment{Id: 2, PostId: 7, UserId: 123, Content: "new"},
+			},
+		},
+		{
+			name:        "InvalidCommentID",
+			path:        "/posts/7/comments/abc",
+			requestBody: updateComment.Request{Content: "new"},
+			skipGet:     true,
+			skipUpdate:  true,
+			expectedBody: updateComment.Response{
+				Status: "Bad Request",
+				Error:  "Invalid comment id",
+			},
+		},
+		{
+			name:        "EmptyContent",
+			path:        "/posts/7/comments/2",
+			requestBody: updateComment.Request{},
+			skipGet:     true,
+			skipUpdate:  true,
+			expectedBody: updateComment.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
+		{
+			name:         "CommentNotFound",
+			path:         "/posts/7/comments/2",
+			requestBody:  updateComment.Request{Content: "new"},
+			mockGetError: pgx.ErrNoRows,
+			skipUpdate:   true,
+			expectedBody: updateComment.Response{
+				Status: "Bad Request",
+				Error:  "comment not found",
+			},
+		},
+		{
+			name:        "CommentOnAnotherPost",
+			path:        "/posts/8/comments/2",
+			requestBody: updateComment.Request{Content: "new"},
+			mockComment: own,
+			skipUpdate:  true,
+			expectedBody: updateComment.Response{
+				Status: "Bad Request",
+				Error:  "comment not found",
+			},
+		},
+		{
+			name:        "DeletedComment",
+			path:        "/posts/7/comments/2",
+			requestBody: updateComment.Request{Content: "new"},
+			mockComment: database.CommentDTO{Id: 2, PostId: 7, UserId: 123, Deleted: true},
+			skipUpdate:  true,
+			expectedBody: updateComment.Response{
+				Status: "Bad Request",
+				Error:  "comment not found",
+			},
+		},
+		{
+			name:        "NotTheAuthor",
+			path:        "/posts/7/comments/2",
+			requestBody: updateComment.Request{Content: "new"},
+			mockComment: database.CommentDTO{Id: 2, PostId: 7, UserId: 456, Content: "old"},
+			skipUpdate:  true,
+			expectedBody: updateComment.Response{
+				Status: "Bad Request",
+				Error:  "Forbidden",
+			},
+		},
+		{
+			name:            "ErrorUpdateComment",
+			path:            "/posts/7/comments/2",
+			requestBody:     updateComment.Request{Content: "new"},
+			mockComment:     own,
+			mockUpdateError: errors.New("query error"),
+			expectedBody: updateComment.Response{
+				Status: "Bad Request",
+				Error:  "failed to update comment",
+			},
+		},
+		{
+			name:        "RejectedByFilters",
+			path:        "/posts/7/comments/2",
+			requestBody: updateComment.Request{Content: "visit www.a.example www.b.example"},
+			mockComment: own,
+			filterError: &contentfilter.RejectedError{Violations: []contentfilter.Violation{
+				{Filter: "links", Reason: "contains 2 links, at most 1 are allowed"},
+			}},
+			skipUpdate: true,
+			expectedBody: updateComment.Response{
+				Status:     "Bad Request",
+				Error:      "content rejected",
+				Violations: []contentfilter.Violation{{Filter: "links", Reason: "contains 2 links, at most 1 are allowed"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.CommentService)
+			if !tt.skipGet {
+				mockService.On("GetComment", 2).Return(tt.mockComment, tt.mockGetError)
+			}
+			if !tt.skipUpdate {
+				mockService.On("UpdateComment", database.CommentDTO{Id: 2, Content: tt.requestBody.Content}).Return(tt.mockUpdated, tt.mockUpdateError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			mockFilters := new(mocks.Pipeline)
+			if !tt.skipUpdate || tt.filterError != nil {
+				content := contentfilter.Content{Kind: contentfilter.KindComment, ID: 2, UserID: 123, Body: tt.requestBody.Content}
+				mockFilters.On("Check", mock.Anything, content).Return(contentfilter.Decision{}, tt.filterError)
+			}
+			defer mockFilters.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PATCH /posts/{postID}/comments/{commentID}", updateComment.New(logger, mockService, mockFilters))
+
+			body, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPatch, tt.path, bytes.NewReader(body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody updateComment.Response
+			err = json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package followTag
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the follow tag response payload.
+// swagger:model
+type Response struct {
+	Status string    `json:"status"`
+	Error  string    `json:"error,omitempty"`
+	Tag    views.Tag `json:"tag"`
+}
+
+// New makes the current user follow a tag by its name or an alias, following twice is a no-op.
+func New(log *slog.Logger, service database.FollowService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Follow tag")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		name := r.PathValue("name")
+		tag, err := service.FollowTag(userID, name)
+		if err != nil {
+			log.Error("failed to follow tag", slog.String("tag", name), slog.String("error", err.Error()))
+			if errors.Is(err, pgx.ErrNoRows) {
+				utils.SendError(w, "tag not found")
+				return
+			}
+			utils.SendError(w, "failed to follow tag")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			Tag:    views.NewTag(tag),
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package followTag_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/follow/followTag"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestFollowTagHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		tag          string
+		mockResponse database.TagsDTO
+		mockError    error
+		expectedBody followTag.Response
+	}{
+		{
+			name:         "SuccessfulFollow",
+			tag:          "golang",
+			mockResponse: database.TagsDTO{Id: 1, Name: "Go"},
+			expectedBody: followTag.Response{
+				Status: "OK",
+				Tag:    views.Tag{Id: 1, Name: "Go"},
+			},
+		},
+		{
+			name:      "TagNotFound",
+			tag:       "missing",
+			mockError: pgx.ErrNoRows,
+			expectedBody: followTag.Response{
+				Status: "Bad Request",
+				Error:  "tag not found",
+			},
+		},
+		{
+			name:      "ErrorFollow",
+			tag:       "golang",
+			mockError: errors.New("query error"),
+			expectedBody: followTag.Response{
+				Status: "Bad Request",
+				Error:  "failed to follow tag",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.FollowService)
+			mockService.On("FollowTag", 123, tt.tag).Return(tt.mockResponse, tt.mockError)
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /me/followed-tags/{name}", followTag.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodPut, "/me/followed-tags/"+tt.tag, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody followTag.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
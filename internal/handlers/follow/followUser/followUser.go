@@ -0,0 +1,59 @@
+package followUser
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the follow user response payload.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	UserID int    `json:"user_id,omitempty"`
+}
+
+// New makes the current user follow another user, following twice is a no-op.
+func New(log *slog.Logger, service database.FollowService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Follow user")
+
+		followerID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		followeeID, err := strconv.Atoi(r.PathValue("userID"))
+		if err != nil {
+			log.Error("Invalid user id", slog.String("user_id", r.PathValue("userID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid user id")
+			return
+		}
+
+		err = service.FollowUser(followerID, followeeID)
+		if err != nil {
+			log.Error("failed to follow user", slog.Int("user_id", followeeID), slog.String("error", err.Error()))
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				utils.SendError(w, "user not found")
+			case errors.Is(err, database.ErrSelfFollow), errors.Is(err, database.ErrBlocked):
+				utils.SendError(w, err.Error())
+			default:
+				utils.SendError(w, "failed to follow user")
+			}
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			UserID: followeeID,
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package followUser_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/follow/followUser"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestFollowUserHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		skipMock     bool
+		mockError    error
+		expectedBody followUser.Response
+	}{
+		{
+			name:   "SuccessfulFollow",
+			userID: "7",
+			expectedBody: followUser.Response{
+				Status: "OK",
+				UserID: 7,
+			},
+		},
+		{
+			name:     "InvalidUserID",
+			userID:   "abc",
+			skipMock: true,
+			expectedBody: followUser.Response{
+				Status: "Bad Request",
+				Error:  "Invalid user id",
+			},
+		},
+		{
+			name:      "UserNotFound",
+			userID:    "7",
+			mockError: pgx.ErrNoRows,
+			expectedBody: followUser.Response{
+				Status: "Bad Request",
+				Error:  "user not found",
+			},
+		},
+		{
+			name:      "SelfFollow",
+			userID:    "7",
+			mockError: database.ErrSelfFollow,
+			expectedBody: followUser.Response{
+				Status: "Bad Request",
+				Error:  "users can not follow themselves",
+			},
+		},
+		{
+			name:      "Blocked",
+			userID:    "7",
+			mockError: database.ErrBlocked,
+			expectedBody: followUser.Response{
+				Status: "Bad Request",
+				Error:  "user is blocked",
+			},
+		},
+		{
+			name:      "ErrorFollow",
+			userID:    "7",
+			mockError: errors.New("query error"),
+			expectedBody: followUser.Response{
+				Status: "Bad Request",
+				Error:  "failed to follow user",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.FollowService)
+			if !tt.skipMock {
+				mockService.On("FollowUser", 123, 7).Return(tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /me/following/{userID}", followUser.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodPut, "/me/following/"+tt.userID, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody followUser.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
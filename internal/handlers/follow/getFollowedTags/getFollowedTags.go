@@ -0,0 +1,48 @@
+package getFollowedTags
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the get followed tags response payload.
+// swagger:model
+type Response struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error,omitempty"`
+	Tags   []views.Tag `json:"tags"`
+}
+
+// New lists the tags the current user follows, sorted by name.
+func New(log *slog.Logger, service database.FollowService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get followed tags")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		tags, err := service.GetFollowedTags(userID)
+		if err != nil {
+			log.Error("get followed tags failed", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			utils.SendError(w, "get followed tags failed")
+			return
+		}
+
+		result := make([]views.Tag, 0, len(tags))
+		for _, tag := range tags {
+			result = append(result, views.NewTag(tag))
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			Tags:   result,
+		})
+	}
+}
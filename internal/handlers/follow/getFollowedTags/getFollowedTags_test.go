@@ -0,0 +1,72 @@
+package getFollowedTags_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/follow/getFollowedTags"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetFollowedTagsHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockResponse []database.TagsDTO
+		mockError    error
+		expectedBody getFollowedTags.Response
+	}{
+		{
+			name:         "SuccessfulGetFollowedTags",
+			mockResponse: []database.TagsDTO{{Id: 1, Name: "Go"}, {Id: 2, Name: "Rust"}},
+			expectedBody: getFollowedTags.Response{
+				Status: "OK",
+				Tags:   []views.Tag{{Id: 1, Name: "Go"}, {Id: 2, Name: "Rust"}},
+			},
+		},
+		{
+			name: "NoFollowedTags",
+			expectedBody: getFollowedTags.Response{
+				Status: "OK",
+				Tags:   []views.Tag{},
+			},
+		},
+		{
+			name:      "ErrorGetFollowedTags",
+			mockError: errors.New("query error"),
+			expectedBody: getFollowedTags.Response{
+				Status: "Bad Request",
+				Error:  "get followed tags failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.FollowService)
+			mockService.On("GetFollowedTags", 123).Return(tt.mockResponse, tt.mockError)
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			handler := getFollowedTags.New(logger, mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/me/followed-tags", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			var responseBody getFollowedTags.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
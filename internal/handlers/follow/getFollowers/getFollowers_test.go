@@ -0,0 +1,115 @@
+package getFollowers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/follow/getFollowers"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetFollowersHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		skipMock     bool
+		expectedPage database.Page
+		mockResponse []database.UserDTO
+		mockCursor   string
+		mockError    error
+		expectedBody getFollowers.Response
+	}{
+		{
+			name:         "SuccessfulGetFollowers",
+			url:          "/users/7/followers?limit=1",
+			expectedPage: database.Page{Limit: 1},
+			mockResponse: []database.UserDTO{{Id: 2, Username: "follower", Password: "hash", FollowingCount: 1}},
+			mockCursor:   "next",
+			expectedBody: getFollowers.Response{
+				Status:     "OK",
+				Users:      []views.PublicUser{{Id: 2, Username: "follower", FollowingCount: 1}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name:         "NoFollowers",
+			url:          "/users/7/followers?cursor=abc",
+			expectedPage: database.Page{Cursor: "abc"},
+			expectedBody: getFollowers.Response{
+				Status: "OK",
+				Users:  []views.PublicUser{},
+			},
+		},
+		{
+			name:     "InvalidUserID",
+			url:      "/users/abc/followers",
+			skipMock: true,
+			expectedBody: getFollowers.Response{
+				Status: "Bad Request",
+				Error:  "Invalid user id",
+			},
+		},
+		{
+			name:     "InvalidLimit",
+			url:      "/users/7/followers?limit=abc",
+			skipMock: true,
+			expectedBody: getFollowers.Response{
+				Status: "Bad Request",
+				Error:  "invalid limit",
+			},
+		},
+		{
+			name:         "InvalidCursor",
+			url:          "/users/7/followers?cursor=abc",
+			expectedPage: database.Page{Cursor: "abc"},
+			mockError:    fmt.Errorf("%w: malformed cursor", database.ErrInvalidPage),
+			expectedBody: getFollowers.Response{
+				Status: "Bad Request",
+				Error:  "invalid page: malformed cursor",
+			},
+		},
+		{
+			name:      "ErrorGetFollowers",
+			url:       "/users/7/followers",
+			mockError: errors.New("query error"),
+			expectedBody: getFollowers.Response{
+				Status: "Bad Request",
+				Error:  "get followers failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.FollowService)
+			if !tt.skipMock {
+				mockService.On("GetFollowers", 7, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /users/{userID}/followers", getFollowers.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody getFollowers.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package getFollowing
+
+import (
+	"errors"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the get following response payload.
+// swagger:model
+type Response struct {
+	Status     string             `json:"status"`
+	Error      string             `json:"error,omitempty"`
+	Users      []views.PublicUser `json:"users"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// New lists the users a user follows, the latest followed first.
+func New(log *slog.Logger, service database.FollowService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get following")
+
+		userID, err := strconv.Atoi(r.PathValue("userID"))
+		if err != nil {
+			log.Error("Invalid user id", slog.String("user_id", r.PathValue("userID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid user id")
+			return
+		}
+
+		query := r.URL.Query()
+
+		limit, err := utils.QueryInt(query, "limit")
+		if err != nil {
+			log.Error("invalid limit", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid limit")
+			return
+		}
+
+		page := database.Page{
+			Limit:  limit,
+			Cursor: query.Get("cursor"),
+		}
+
+		users, nextCursor, err := service.GetFollowing(userID, page)
+		if err != nil {
+			log.Error("get following failed", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			if errors.Is(err, database.ErrInvalidPage) {
+				utils.SendError(w, err.Error())
+				return
+			}
+			utils.SendError(w, "get following failed")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:     http.StatusText(http.StatusOK),
+			Users:      views.NewPublicUsers(users),
+			NextCursor: nextCursor,
+		})
+	}
+}
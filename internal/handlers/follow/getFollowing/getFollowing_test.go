@@ -0,0 +1,115 @@
+package getFollowing_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/follow/getFollowing"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetFollowingHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		skipMock     bool
+		expectedPage database.Page
+		mockResponse []database.UserDTO
+		mockCursor   string
+		mockError    error
+		expectedBody getFollowing.Response
+	}{
+		{
+			name:         "SuccessfulGetFollowing",
+			url:          "/users/7/following?limit=1",
+			expectedPage: database.Page{Limit: 1},
+			mockResponse: []database.UserDTO{{Id: 2, Username: "followee", Password: "hash", FollowerCount: 1}},
+			mockCursor:   "next",
+			expectedBody: getFollowing.Response{
+				Status:     "OK",
+				Users:      []views.PublicUser{{Id: 2, Username: "followee", FollowerCount: 1}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name:         "NoFollowing",
+			url:          "/users/7/following?cursor=abc",
+			expectedPage: database.Page{Cursor: "abc"},
+			expectedBody: getFollowing.Response{
+				Status: "OK",
+				Users:  []views.PublicUser{},
+			},
+		},
+		{
+			name:     "InvalidUserID",
+			url:      "/users/abc/following",
+			skipMock: true,
+			expectedBody: getFollowing.Response{
+				Status: "Bad Request",
+				Error:  "Invalid user id",
+			},
+		},
+		{
+			name:     "InvalidLimit",
+			url:      "/users/7/following?limit=abc",
+			skipMock: true,
+			expectedBody: getFollowing.Response{
+				Status: "Bad Request",
+				Error:  "invalid limit",
+			},
+		},
+		{
+			name:         "InvalidCursor",
+			url:          "/users/7/following?cursor=abc",
+			expectedPage: database.Page{Cursor: "abc"},
+			mockError:    fmt.Errorf("%w: malformed cursor", database.ErrInvalidPage),
+			expectedBody: getFollowing.Response{
+				Status: "Bad Request",
+				Error:  "invalid page: malformed cursor",
+			},
+		},
+		{
+			name:      "ErrorGetFollowing",
+			url:       "/users/7/following",
+			mockError: errors.New("query error"),
+			expectedBody: getFollowing.Response{
+				Status: "Bad Request",
+				Error:  "get following failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.FollowService)
+			if !tt.skipMock {
+				mockService.On("GetFollowing", 7, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /users/{userID}/following", getFollowing.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody getFollowing.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
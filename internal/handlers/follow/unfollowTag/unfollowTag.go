@@ -0,0 +1,41 @@
+package unfollowTag
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the unfollow tag response payload.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// New makes the current user stop following a tag, unfollowing a tag that was not followed is a no-op.
+func New(log *slog.Logger, service database.FollowService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Unfollow tag")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		name := r.PathValue("name")
+		err := service.UnfollowTag(userID, name)
+		if err != nil {
+			log.Error("failed to unfollow tag", slog.String("tag", name), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to unfollow tag")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+		})
+	}
+}
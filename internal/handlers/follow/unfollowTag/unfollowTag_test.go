@@ -0,0 +1,63 @@
+package unfollowTag_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/handlers/follow/unfollowTag"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestUnfollowTagHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockError    error
+		expectedBody unfollowTag.Response
+	}{
+		{
+			name: "SuccessfulUnfollow",
+			expectedBody: unfollowTag.Response{
+				Status: "OK",
+			},
+		},
+		{
+			name:      "ErrorUnfollow",
+			mockError: errors.New("query error"),
+			expectedBody: unfollowTag.Response{
+				Status: "Bad Request",
+				Error:  "failed to unfollow tag",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.FollowService)
+			mockService.On("UnfollowTag", 123, "golang").Return(tt.mockError)
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /me/followed-tags/{name}", unfollowTag.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodDelete, "/me/followed-tags/golang", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody unfollowTag.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package unfollowUser
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the unfollow user response payload.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	UserID int    `json:"user_id,omitempty"`
+}
+
+// New makes the current user stop following another user, unfollowing a user that was not followed is a no-op.
+func New(log *slog.Logger, service database.FollowService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Unfollow user")
+
+		followerID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		followeeID, err := strconv.Atoi(r.PathValue("userID"))
+		if err != nil {
+			log.Error("Invalid user id", slog.String("user_id", r.PathValue("userID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid user id")
+			return
+		}
+
+		err = service.UnfollowUser(followerID, followeeID)
+		if err != nil {
+			log.Error("failed to unfollow user", slog.Int("user_id", followeeID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to unfollow user")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			UserID: followeeID,
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package unfollowUser_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/handlers/follow/unfollowUser"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestUnfollowUserHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		skipMock     bool
+		mockError    error
+		expectedBody unfollowUser.Response
+	}{
+		{
+			name:   "SuccessfulUnfollow",
+			userID: "7",
+			expectedBody: unfollowUser.Response{
+				Status: "OK",
+				UserID: 7,
+			},
+		},
+		{
+			name:     "InvalidUserID",
+			userID:   "abc",
+			skipMock: true,
+			expectedBody: unfollowUser.Response{
+				Status: "Bad Request",
+				Error:  "Invalid user id",
+			},
+		},
+		{
+			name:      "ErrorUnfollow",
+			userID:    "7",
+			mockError: errors.New("query error"),
+			expectedBody: unfollowUser.Response{
+				Status: "Bad Request",
+				Error:  "failed to unfollow user",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.FollowService)
+			if !tt.skipMock {
+				mockService.On("UnfollowUser", 123, 7).Return(tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /me/following/{userID}", unfollowUser.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodDelete, "/me/following/"+tt.userID, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody unfollowUser.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package deleteAvatar
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the delete avatar response payload.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// New removes the avatar of the current user, deleting a missing avatar is a no-op.
+func New(log *slog.Logger, service database.AvatarService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Delete avatar")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			utils.SendError(w, "Invalid user context")
+			return
+		}
+
+		if err := service.DeleteAvatar(userID); err != nil {
+			log.Error("failed to delete avatar", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to delete avatar")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package deleteAvatar_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/handlers/me/deleteAvatar"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestDeleteAvatarHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		mockError    error
+		expectedBody deleteAvatar.Response
+	}{
+		{
+			name:         "SuccessfulDeleteAvatar",
+			userID:       "123",
+			expectedBody: deleteAvatar.Response{Status: "OK"},
+		},
+		{
+			name:         "NoUserContext",
+			expectedBody: deleteAvatar.Response{Status: "Bad Request", Error: "Invalid user context"},
+		},
+		{
+			name:         "ErrorDeletingAvatar",
+			userID:       "123",
+			mockError:    errors.New("delete error"),
+			expectedBody: deleteAvatar.Response{Status: "Bad Request", Error: "failed to delete avatar"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.AvatarService)
+			if tt.userID != "" {
+				mockService.On("DeleteAvatar", 123).Return(tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			handler := deleteAvatar.New(logger, mockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/me/avatar", nil)
+			if tt.userID != "" {
+				req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			}
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			var responseBody deleteAvatar.Response
+			err := json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package deleteMe
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the deletion current user response payload.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	UserID int    `json:"user_id,omitempty"`
+}
+
+func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Delete current user")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			utils.SendError(w, "Invalid user context")
+			return
+		}
+
+		err := service.DeleteUser(userID)
+		if err != nil {
+			log.Error("Error deleting user", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			utils.SendError(w, "Error deleting user")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			UserID: userID,
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package deleteMe_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/handlers/me/deleteMe"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestDeleteMeHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		mockError    error
+		expectedBody deleteMe.Response
+	}{
+		{
+			name:   "SuccessfulDeleteMe",
+			userID: "123",
+			expectedBody: deleteMe.Response{
+				Status: "OK",
+				UserID: 123,
+			},
+		},
+		{
+			name:   "NoUserContext",
+			userID: "",
+			expectedBody: deleteMe.Response{
+				Status: "Bad Request",
+				Error:  "Invalid user context",
+			},
+		},
+		{
+			name:      "ErrorDeletingUser",
+			userID:    "123",
+			mockError: errors.New("delete error"),
+			expectedBody: deleteMe.Response{
+				Status: "Bad Request",
+				Error:  "Error deleting user",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.UserService)
+			if tt.userID != "" {
+				mockService.On("DeleteUser", 123).Return(tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			handler := deleteMe.New(logger, mockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/me", nil)
+			if tt.userID != "" {
+				req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			}
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			var responseBody deleteMe.Response
+			err := json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
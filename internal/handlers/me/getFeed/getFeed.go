@@ -0,0 +1,71 @@
+package getFeed
+
+import (
+	"errors"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the get feed response payload.
+// swagger:model
+type Response struct {
+	Status     string       `json:"status"`
+	Error      string       `json:"error,omitempty"`
+	Posts      []views.Post `json:"posts"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+// New returns the posts of the users and tags the current user follows, the latest published first.
+func New(log *slog.Logger, service database.FollowService, reactions database.ReactionService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get feed")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		query := r.URL.Query()
+
+		limit, err := utils.QueryInt(query, "limit")
+		if err != nil {
+			log.Error("invalid limit", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid limit")
+			return
+		}
+
+		page := database.Page{
+			Limit:  limit,
+			Cursor: query.Get("cursor"),
+		}
+
+		posts, nextCursor, err := service.GetFeed(userID, page)
+		if err != nil {
+			log.Error("get feed failed", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			if errors.Is(err, database.ErrInvalidPage) {
+				utils.SendError(w, err.Error())
+				return
+			}
+			utils.SendError(w, "get feed failed")
+			return
+		}
+
+		err = database.FillMyReactions(reactions, userID, posts)
+		if err != nil {
+			log.Error("failed to load reactions", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to load reactions")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:     http.StatusText(http.StatusOK),
+			Posts:      views.NewPosts(posts),
+			NextCursor: nextCursor,
+		})
+	}
+}
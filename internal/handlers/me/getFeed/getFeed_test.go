@@ -0,0 +1,150 @@
+package getFeed_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/me/getFeed"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetFeedHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		skipMock     bool
+		expectedPage database.Page
+		mockResponse []database.PostDTO
+		mockCursor   string
+		mockError    error
+		mockMine     map[int][]string
+		mineError    error
+		expectedBody getFeed.Response
+	}{
+		{
+			name: "SuccessfulGetFeed",
+			mockResponse: []database.PostDTO{
+				{Id: 2, Title: "Followed author", UserId: 7},
+				{Id: 1, Title: "Followed tag", UserId: 8, Tags: []string{"go"}},
+			},
+			expectedBody: getFeed.Response{
+				Status: "OK",
+				Posts: []views.Post{
+					{Id: 2, Title: "Followed author", UserId: 7},
+					{Id: 1, Title: "Followed tag", UserId: 8, Tags: []string{"go"}},
+				},
+			},
+		},
+		{
+			name:         "NextPage",
+			query:        "?limit=1&cursor=abc",
+			expectedPage: database.Page{Limit: 1, Cursor: "abc"},
+			mockResponse: []database.PostDTO{
+				{Id: 1, Title: "Followed tag", UserId: 8},
+			},
+			mockCursor: "next",
+			expectedBody: getFeed.Response{
+				Status:     "OK",
+				Posts:      []views.Post{{Id: 1, Title: "Followed tag", UserId: 8}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name: "WithReactions",
+			mockResponse: []database.PostDTO{
+				{Id: 2, Title: "Followed author", UserId: 7, Reactions: map[string]int{"like": 1}},
+			},
+			mockMine: map[int][]string{2: {"like"}},
+			expectedBody: getFeed.Response{
+				Status: "OK",
+				Posts: []views.Post{
+					{Id: 2, Title: "Followed author", UserId: 7, Reactions: map[string]int{"like": 1}, MyReactions: []string{"like"}},
+				},
+			},
+		},
+		{
+			name: "ErrorGetMyReactions",
+			mockResponse: []database.PostDTO{
+				{Id: 2, Title: "Followed author", UserId: 7},
+			},
+			mineError: errors.New("query error"),
+			expectedBody: getFeed.Response{
+				Status: "Bad Request",
+				Error:  "failed to load reactions",
+			},
+		},
+		{
+			name:     "InvalidLimit",
+			query:    "?limit=abc",
+			skipMock: true,
+			expectedBody: getFeed.Response{
+				Status: "Bad Request",
+				Error:  "invalid limit",
+			},
+		},
+		{
+			name:         "InvalidCursor",
+			query:        "?cursor=abc",
+			expectedPage: database.Page{Cursor: "abc"},
+			mockError:    fmt.Errorf("%w: malformed cursor", database.ErrInvalidPage),
+			expectedBody: getFeed.Response{
+				Status: "Bad Request",
+				Error:  "invalid page: malformed cursor",
+			},
+		},
+		{
+			name:      "ErrorGetFeed",
+			mockError: errors.New("query error"),
+			expectedBody: getFeed.Response{
+				Status: "Bad Request",
+				Error:  "get feed failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.FollowService)
+			if !tt.skipMock {
+				mockService.On("GetFeed", 123, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			mockReactions := new(mocks.ReactionService)
+			if !tt.skipMock && tt.mockError == nil {
+				postIDs := make([]int, 0, len(tt.mockResponse))
+				for _, post := range tt.mockResponse {
+					postIDs = append(postIDs, post.Id)
+				}
+				mockReactions.On("GetUserReactions", 123, postIDs).Return(tt.mockMine, tt.mineError)
+			}
+			defer mockReactions.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			handler := getFeed.New(logger, mockService, mockReactions)
+
+			req := httptest.NewRequest(http.MethodGet, "/me/feed"+tt.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			var responseBody getFeed.Response
+			err := json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package getMe
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the get current user response payload.
+// swagger:model
+type Response struct {
+	Status string         `json:"status"`
+	Error  string         `json:"error,omitempty"`
+	User   views.SelfUser `json:"user,omitempty"`
+}
+
+func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get current user")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			utils.SendError(w, "Invalid user context")
+			return
+		}
+
+		user, err := service.GetUserById(userID)
+		if err != nil {
+			log.Error("User not found", slog.Int("user_id", userID), slog.String("Error", err.Error()))
+			utils.SendError(w, "User not found")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			User:   views.NewSelfUser(user),
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package getMe_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/me/getMe"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetMeHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		mockResponse database.UserDTO
+		mockError    error
+		expectedBody getMe.Response
+	}{
+		{
+			name:   "SuccessfulGetMe",
+			userID: "1",
+			mockResponse: database.UserDTO{
+				Id:          1,
+				Username:    "testuser",
+				Password:    "hashed",
+				Description: "test description",
+			},
+			expectedBody: getMe.Response{
+				Status: "OK",
+				User: views.SelfUser{
+					PublicUser: views.PublicUser{
+						Id:          1,
+						Username:    "testuser",
+						Description: "test description",
+					},
+				},
+			},
+		},
+		{
+			name:   "NoUserContext",
+			userID: "",
+			expectedBody: getMe.Response{
+				Status: "Bad Request",
+				Error:  "Invalid user context",
+			},
+		},
+		{
+			name:      "UserNotFound",
+			userID:    "2",
+			mockError: errors.New("user not found"),
+			expectedBody: getMe.Response{
+				Status: "Bad Request",
+				Error:  "User not found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.UserService)
+			if tt.userID != "" {
+				mockService.On("GetUserById", mock.AnythingOfType("int")).Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			handler := getMe.New(logger, mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.userID != "" {
+				req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			}
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			var responseBody getMe.Response
+			err := json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package getMyPosts
+
+import (
+	"errors"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the get current user's posts response payload.
+// swagger:model
+type Response struct {
+	Status     string       `json:"status"`
+	Error      string       `json:"error,omitempty"`
+	Posts      []views.Post `json:"posts"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+func New(log *slog.Logger, service database.PostService, reactions database.ReactionService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get current user's posts")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			utils.SendError(w, "Invalid user context")
+			return
+		}
+
+		query := r.URL.Query()
+
+		limit, err := utils.QueryInt(query, "limit")
+		if err != nil {
+			log.Error("invalid limit", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid limit")
+			return
+		}
+
+		status := query.Get("status")
+		switch status {
+		case "", database.PostStatusDraft, database.PostStatusScheduled, database.PostStatusPublished, database.PostStatusArchived:
+		default:
+			log.Error("invalid status", slog.String("status", status))
+			utils.SendError(w, "invalid status")
+			return
+		}
+
+		filter := database.PostFilter{
+			ViewerID: userID,
+			AuthorID: userID,
+			Status:   status,
+		}
+		page := database.Page{
+			Limit:  limit,
+			Cursor: query.Get("cursor"),
+			Sort:   query.Get("sort"),
+			Order:  query.Get("order"),
+		}
+
+		posts, nextCursor, err := service.GetALlPosts(filter, page)
+		if err != nil {
+			log.Error("get user posts failed", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			if errors.Is(err, database.ErrInvalidPage) {
+				utils.SendError(w, err.Error())
+				return
+			}
+			utils.SendError(w, "get user posts failed")
+			return
+		}
+
+		err = database.FillMyReactions(reactions, userID, posts)
+		if err != nil {
+			log.Error("failed to load reactions", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to load reactions")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:     http.StatusText(http.StatusOK),
+			Posts:      views.NewPosts(posts),
+			NextCursor: nextCursor,
+		})
+	}
+}
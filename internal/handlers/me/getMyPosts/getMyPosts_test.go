@@ -0,0 +1,153 @@
+package getMyPosts_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/me/getMyPosts"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetMyPostsHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		skipMock       bool
+		expectedStatus string
+		expectedPage   database.Page
+		mockResponse   []database.PostDTO
+		mockCursor     string
+		mockError      error
+		mockMine       map[int][]string
+		mineError      error
+		expectedBody   getMyPosts.Response
+	}{
+		{
+			name: "SuccessfulGetMyPosts",
+			mockResponse: []database.PostDTO{
+				{Id: 1, Title: "Test Title 1", UserId: 123, Tags: []string{"tag1"}},
+			},
+			expectedBody: getMyPosts.Response{
+				Status: "OK",
+				Posts: []views.Post{
+					{Id: 1, Title: "Test Title 1", UserId: 123, Tags: []string{"tag1"}},
+				},
+			},
+		},
+		{
+			name:         "NextPage",
+			query:        "?limit=1&cursor=abc",
+			expectedPage: database.Page{Limit: 1, Cursor: "abc"},
+			mockResponse: []database.PostDTO{
+				{Id: 2, Title: "Test Title 2", UserId: 123},
+			},
+			mockCursor: "next",
+			expectedBody: getMyPosts.Response{
+				Status:     "OK",
+				Posts:      []views.Post{{Id: 2, Title: "Test Title 2", UserId: 123}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name: "WithReactions",
+			mockResponse: []database.PostDTO{
+				{Id: 1, Title: "Test Title 1", UserId: 123, Reactions: map[string]int{"like": 2}},
+				{Id: 2, Title: "Test Title 2", UserId: 123},
+			},
+			mockMine: map[int][]string{1: {"like"}},
+			expectedBody: getMyPosts.Response{
+				Status: "OK",
+				Posts: []views.Post{
+					{Id: 1, Title: "Test Title 1", UserId: 123, Reactions: map[string]int{"like": 2}, MyReactions: []string{"like"}},
+					{Id: 2, Title: "Test Title 2", UserId: 123},
+				},
+			},
+		},
+		{
+			name: "ErrorGetMyReactions",
+			mockResponse: []database.PostDTO{
+				{Id: 1, Title: "Test Title 1", UserId: 123},
+			},
+			mineError: errors.New("query error"),
+			expectedBody: getMyPosts.Response{
+				Status: "Bad Request",
+				Error:  "failed to load reactions",
+			},
+		},
+		{
+			name:           "Drafts",
+			query:          "?status=draft",
+			expectedStatus: "draft",
+			mockResponse: []database.PostDTO{
+				{Id: 3, Title: "Draft", UserId: 123, Status: "draft"},
+			},
+			expectedBody: getMyPosts.Response{
+				Status: "OK",
+				Posts:  []views.Post{{Id: 3, Title: "Draft", UserId: 123, Status: "draft"}},
+			},
+		},
+		{
+			name:     "InvalidStatus",
+			query:    "?status=deleted",
+			skipMock: true,
+			expectedBody: getMyPosts.Response{
+				Status: "Bad Request",
+				Error:  "invalid status",
+			},
+		},
+		{
+			name:      "ErrorGetMyPosts",
+			mockError: errors.New("query error"),
+			expectedBody: getMyPosts.Response{
+				Status: "Bad Request",
+				Error:  "get user posts failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.PostService)
+			if !tt.skipMock {
+				filter := database.PostFilter{ViewerID: 123, AuthorID: 123, Status: tt.expectedStatus}
+				mockService.On("GetALlPosts", filter, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			mockReactions := new(mocks.ReactionService)
+			if !tt.skipMock && tt.mockError == nil {
+				postIDs := make([]int, 0, len(tt.mockResponse))
+				for _, post := range tt.mockResponse {
+					postIDs = append(postIDs, post.Id)
+				}
+				mockReactions.On("GetUserReactions", 123, postIDs).Return(tt.mockMine, tt.mineError)
+			}
+			defer mockReactions.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			handler := getMyPosts.New(logger, mockService, mockReactions)
+
+			req := httptest.NewRequest(http.MethodGet, "/me/posts"+tt.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			var responseBody getMyPosts.Response
+			err := json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package setAvatar
+
+import (
+	"errors"
+	"go-rest-api-auth/internal/avatar"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+)
+
+const (
+	// AvatarField is the multipart form field holding the picture.
+	AvatarField = "avatar"
+
+	// formOverhead is allowed on top of the picture size for the multipart boundaries and headers.
+	formOverhead = 64 << 10
+)
+
+// Response represents the set avatar response payload.
+// swagger:model
+type Response struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+// New replaces the avatar of the current user with a PNG, JPEG or WebP picture sent as the
+// "avatar" field of a multipart form. Pictures larger than maxSize bytes or maxDimension
+// pixels per side are rejected, the others are cropped to a square and stored as thumbnails.
+func New(log *slog.Logger, service database.AvatarService, maxSize int64, maxDimension int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Set avatar")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize+formOverhead)
+		reader, err := r.MultipartReader()
+		if err != nil {
+			log.Error("invalid multipart form", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid multipart form")
+			return
+		}
+
+		part, err := nextAvatarPart(reader)
+		if err != nil {
+			log.Error("avatar not found in form", slog.String("error", err.Error()))
+			if isTooLarge(err) {
+				utils.SendError(w, "file too large")
+				return
+			}
+			utils.SendError(w, "avatar is required")
+			return
+		}
+		defer part.Close()
+
+		data, err := io.ReadAll(io.LimitReader(part, maxSize+1))
+		if err != nil {
+			log.Error("failed to read avatar", slog.String("error", err.Error()))
+			if isTooLarge(err) {
+				utils.SendError(w, "file too large")
+				return
+			}
+			utils.SendError(w, "failed to read avatar")
+			return
+		}
+		if int64(len(data)) > maxSize {
+			log.Error("file too large", slog.Int64("max_size", maxSize))
+			utils.SendError(w, "file too large")
+			return
+		}
+
+		variants, err := avatar.Process(data, maxDimension)
+		if err != nil {
+			log.Error("failed to process avatar", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			switch {
+			case errors.Is(err, avatar.ErrUnsupportedFormat):
+				utils.SendError(w, "unsupported image format")
+			case errors.Is(err, avatar.ErrInvalidImage):
+				utils.SendError(w, err.Error())
+			default:
+				utils.SendError(w, "failed to process avatar")
+			}
+			return
+		}
+
+		avatarID, err := service.SetAvatar(userID, variants)
+		if err != nil {
+			log.Error("failed to set avatar", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to set avatar")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:    http.StatusText(http.StatusOK),
+			AvatarURL: views.AvatarURL(avatarID),
+		})
+	}
+}
+
+// nextAvatarPart skips the other fields of the form up to the picture.
+func nextAvatarPart(reader *multipart.Reader) (*multipart.Part, error) {
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == AvatarField {
+			return part, nil
+		}
+		_ = part.Close()
+	}
+}
+
+func isTooLarge(err error) bool {
+	var maxBytesError *http.MaxBytesError
+	return errors.As(err, &maxBytesError)
+}
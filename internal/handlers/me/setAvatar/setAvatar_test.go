@@ -0,0 +1,142 @@
+package setAvatar_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go-rest-api-auth/internal/avatar"
+	"go-rest-api-auth/internal/handlers/me/setAvatar"
+	"go-rest-api-auth/testing/mocks"
+	"image"
+	"image/png"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+const (
+	testMaxSize      = 4096
+	testMaxDimension = 32
+)
+
+func picture(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func multipartBody(t *testing.T, field string, content []byte) (*bytes.Buffer, string) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(field, "me.png")
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func TestSetAvatarHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		field        string
+		content      []byte
+		contentType  string
+		expectSet    bool
+		mockAvatarID string
+		mockError    error
+		expectedBody setAvatar.Response
+	}{
+		{
+			name:         "SuccessfulSetAvatar",
+			field:        "avatar",
+			content:      picture(t, 20, 10),
+			expectSet:    true,
+			mockAvatarID: "ab12",
+			expectedBody: setAvatar.Response{Status: "OK", AvatarURL: "/avatars/ab12"},
+		},
+		{
+			name:         "ErrorSetAvatar",
+			field:        "avatar",
+			content:      picture(t, 20, 10),
+			expectSet:    true,
+			mockError:    errors.New("store error"),
+			expectedBody: setAvatar.Response{Status: "Bad Request", Error: "failed to set avatar"},
+		},
+		{
+			name:         "TooManyPixels",
+			field:        "avatar",
+			content:      picture(t, 40, 10),
+			expectedBody: setAvatar.Response{Status: "Bad Request", Error: "invalid image: 40x10 pixels, at most 32 per side"},
+		},
+		{
+			name:         "UnsupportedFormat",
+			field:        "avatar",
+			content:      []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;"),
+			expectedBody: setAvatar.Response{Status: "Bad Request", Error: "unsupported image format"},
+		},
+		{
+			name:         "FileTooLarge",
+			field:        "avatar",
+			content:      bytes.Repeat([]byte("a"), testMaxSize+1),
+			expectedBody: setAvatar.Response{Status: "Bad Request", Error: "file too large"},
+		},
+		{
+			name:         "MissingAvatar",
+			field:        "file",
+			content:      picture(t, 20, 10),
+			expectedBody: setAvatar.Response{Status: "Bad Request", Error: "avatar is required"},
+		},
+		{
+			name:         "NotMultipart",
+			contentType:  "image/png",
+			expectedBody: setAvatar.Response{Status: "Bad Request", Error: "invalid multipart form"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.AvatarService)
+			if tt.expectSet {
+				thumbnails := mock.MatchedBy(func(variants []avatar.Variant) bool {
+					return len(variants) == len(avatar.Sizes)
+				})
+				mockService.On("SetAvatar", 123, thumbnails).Return(tt.mockAvatarID, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /me/avatar", setAvatar.New(logger, mockService, testMaxSize, testMaxDimension))
+
+			var body io.Reader = strings.NewReader("raw")
+			contentType := tt.contentType
+			if tt.field != "" {
+				body, contentType = multipartBody(t, tt.field, tt.content)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/me/avatar", body)
+			req.Header.Set("Content-Type", contentType)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody setAvatar.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package updateMe
+
+import (
+	"encoding/json"
+	"github.com/go-playground/validator/v10"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+)
+
+// Request represents the updating current user request payload.
+// Passwords are changed through POST /me/password.
+// swagger:model
+type Request struct {
+	Username    string `json:"username"`
+	Description string `json:"description"`
+}
+
+// Response represents the updating current user response payload.
+// swagger:model
+type Response struct {
+	Status string         `json:"status"`
+	Error  string         `json:"error,omitempty"`
+	User   views.SelfUser `json:"user"`
+}
+
+func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Update current user")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			utils.SendError(w, "Invalid user context")
+			return
+		}
+
+		user, err := service.GetUserById(userID)
+		if err != nil {
+			log.Error("User not found", slog.Int("user_id", userID), slog.String("Error", err.Error()))
+			utils.SendError(w, "User not found")
+			return
+		}
+
+		//get request body info
+		var req Request
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		//validating request body info
+		if req.Username == "" && req.Description == "" {
+			log.Error("Empty request data")
+			utils.SendError(w, "Empty request data")
+			return
+		} else {
+			err = validator.New().Struct(req)
+			if err != nil {
+				log.Error("failed to validate request", slog.String("error", err.Error()))
+				utils.SendError(w, "failed to validate request")
+				return
+			}
+		}
+
+		userDto := database.UserDTO{
+			Id:          userID,
+			Username:    req.Username,
+			Description: req.Description,
+		}
+		err = service.UpdateUser(userDto)
+		if err != nil {
+			log.Error("failed to update user", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to update user")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			User: views.NewSelfUser(database.UserDTO{
+				Id:          userID,
+				Username:    utils.CoalesceString(req.Username, user.Username),
+				Description: utils.CoalesceString(req.Description, user.Description),
+				DateJoined:  user.DateJoined,
+				Role:        user.Role,
+				Avatar:      user.Avatar,
+			}),
+		})
+	}
+}
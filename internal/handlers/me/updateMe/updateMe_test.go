@@ -0,0 +1,115 @@
+package updateMe_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/me/updateMe"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestUpdateMeHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		requestBody     updateMe.Request
+		mockGetResponse database.UserDTO
+		mockGetError    error
+		mockUpdateError error
+		expectUpdate    bool
+		expectedBody    updateMe.Response
+	}{
+		{
+			name: "SuccessfulUpdateMe",
+			requestBody: updateMe.Request{
+				Description: "updated description",
+			},
+			mockGetResponse: database.UserDTO{
+				Id:          123,
+				Username:    "testuser",
+				Password:    "hashed",
+				Description: "test description",
+			},
+			expectUpdate: true,
+			expectedBody: updateMe.Response{
+				Status: "OK",
+				User: views.SelfUser{
+					PublicUser: views.PublicUser{
+						Id:          123,
+						Username:    "testuser",
+						Description: "updated description",
+					},
+				},
+			},
+		},
+		{
+			name:         "UserNotFound",
+			requestBody:  updateMe.Request{Username: "updateduser"},
+			mockGetError: errors.New("user not found"),
+			expectedBody: updateMe.Response{
+				Status: "Bad Request",
+				Error:  "User not found",
+			},
+		},
+		{
+			name:            "EmptyRequest",
+			requestBody:     updateMe.Request{},
+			mockGetResponse: database.UserDTO{Id: 123},
+			expectedBody: updateMe.Response{
+				Status: "Bad Request",
+				Error:  "Empty request data",
+			},
+		},
+		{
+			name:            "ErrorUpdatingUser",
+			requestBody:     updateMe.Request{Username: "updateduser"},
+			mockGetResponse: database.UserDTO{Id: 123},
+			mockUpdateError: errors.New("update error"),
+			expectUpdate:    true,
+			expectedBody: updateMe.Response{
+				Status: "Bad Request",
+				Error:  "failed to update user",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.UserService)
+			mockService.On("GetUserById", 123).Return(tt.mockGetResponse, tt.mockGetError)
+			if tt.expectUpdate {
+				mockService.On("UpdateUser", mock.AnythingOfType("database.UserDTO")).Return(tt.mockUpdateError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			handler := updateMe.New(logger, mockService)
+
+			requestBody, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPatch, "/me", bytes.NewBuffer(requestBody))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			var responseBody updateMe.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
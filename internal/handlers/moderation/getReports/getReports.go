@@ -0,0 +1,77 @@
+package getReports
+
+import (
+	"errors"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the get reports response payload.
+// swagger:model
+type Response struct {
+	Status     string         `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	Reports    []views.Report `json:"reports"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// New lists the moderation queue, the oldest reports first by default.
+func New(log *slog.Logger, service database.ModerationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get reports")
+
+		query := r.URL.Query()
+
+		limit, err := utils.QueryInt(query, "limit")
+		if err != nil {
+			log.Error("invalid limit", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid limit")
+			return
+		}
+		postID, err := utils.QueryInt(query, "post_id")
+		if err != nil {
+			log.Error("invalid post_id", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid post_id")
+			return
+		}
+		authorID, err := utils.QueryInt(query, "author_id")
+		if err != nil {
+			log.Error("invalid author_id", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid author_id")
+			return
+		}
+
+		filter := database.ReportFilter{
+			Status:   query.Get("status"),
+			Reason:   query.Get("reason"),
+			PostID:   postID,
+			AuthorID: authorID,
+		}
+		page := database.Page{
+			Limit:  limit,
+			Cursor: query.Get("cursor"),
+			Sort:   query.Get("sort"),
+			Order:  query.Get("order"),
+		}
+
+		reports, nextCursor, err := service.GetReports(filter, page)
+		if err != nil {
+			log.Error("get reports failed", slog.String("error", err.Error()))
+			if errors.Is(err, database.ErrInvalidPage) {
+				utils.SendError(w, err.Error())
+				return
+			}
+			utils.SendError(w, "get reports failed")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:     http.StatusText(http.StatusOK),
+			Reports:    views.NewReports(reports),
+			NextCursor: nextCursor,
+		})
+	}
+}
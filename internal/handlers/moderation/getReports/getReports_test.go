@@ -0,0 +1,116 @@
+package getReports_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/moderation/getReports"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetReportsHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		skipMock       bool
+		expectedFilter database.ReportFilter
+		expectedPage   database.Page
+		mockResponse   []database.ReportDTO
+		mockCursor     string
+		mockError      error
+		expectedBody   getReports.Response
+	}{
+		{
+			name:           "SuccessfulGetReports",
+			query:          "?status=open&reason=spam&post_id=7&author_id=2&limit=1",
+			expectedFilter: database.ReportFilter{Status: "open", Reason: "spam", PostID: 7, AuthorID: 2},
+			expectedPage:   database.Page{Limit: 1},
+			mockResponse:   []database.ReportDTO{{Id: 1, PostId: 7, AuthorId: 2, ReporterId: 3, Reason: "spam", Status: "open"}},
+			mockCursor:     "next",
+			expectedBody: getReports.Response{
+				Status:     "OK",
+				Reports:    []views.Report{{Id: 1, PostId: 7, AuthorId: 2, ReporterId: 3, Reason: "spam", Status: "open"}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name:         "NoReports",
+			query:        "?sort=id&order=desc",
+			expectedPage: database.Page{Sort: "id", Order: "desc"},
+			expectedBody: getReports.Response{
+				Status:  "OK",
+				Reports: []views.Report{},
+			},
+		},
+		{
+			name:     "InvalidPostID",
+			query:    "?post_id=abc",
+			skipMock: true,
+			expectedBody: getReports.Response{
+				Status: "Bad Request",
+				Error:  "invalid post_id",
+			},
+		},
+		{
+			name:     "InvalidAuthorID",
+			query:    "?author_id=abc",
+			skipMock: true,
+			expectedBody: getReports.Response{
+				Status: "Bad Request",
+				Error:  "invalid author_id",
+			},
+		},
+		{
+			name:         "InvalidSort",
+			query:        "?sort=reason",
+			expectedPage: database.Page{Sort: "reason"},
+			mockError:    fmt.Errorf("%w: unknown sort field %q", database.ErrInvalidPage, "reason"),
+			expectedBody: getReports.Response{
+				Status: "Bad Request",
+				Error:  `invalid page: unknown sort field "reason"`,
+			},
+		},
+		{
+			name:      "ErrorGetReports",
+			mockError: errors.New("query error"),
+			expectedBody: getReports.Response{
+				Status: "Bad Request",
+				Error:  "get reports failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ModerationService)
+			if !tt.skipMock {
+				mockService.On("GetReports", tt.expectedFilter, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /moderation/reports", getReports.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodGet, "/moderation/reports"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody getReports.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package reportPost
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request represents the report post request payload. Reason is one of database.ReportReasons.
+// swagger:model
+type Request struct {
+	Reason  string `json:"reason" validate:"required"`
+	Details string `json:"details,omitempty" validate:"max=2000"`
+}
+
+// Response represents the report post response payload.
+// swagger:model
+type Response struct {
+	Status string       `json:"status"`
+	Error  string       `json:"error,omitempty"`
+	Report views.Report `json:"report"`
+}
+
+// New reports a post the current user may read to the moderators, reporting it again while
+// the first report is open returns the first report.
+func New(log *slog.Logger, posts database.PostService, service database.ModerationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Report post")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+
+		var req Request
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		err = validator.New().Struct(req)
+		if err != nil {
+			log.Error("failed to validate request", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate request")
+			return
+		}
+
+		post, err := posts.GetPost(postID)
+		if err != nil {
+			log.Error("post not found", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		visible, err := posts.CanViewPost(post, userID)
+		if err != nil {
+			log.Error("failed to check post visibility", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to check post visibility")
+			return
+		}
+		if !visible {
+			log.Error("post not visible", slog.Int("post_id", postID), slog.String("status", post.Status), slog.String("visibility", post.Visibility))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		report, err := service.ReportPost(database.ReportDTO{
+			PostId:     postID,
+			ReporterId: userID,
+			Reason:     req.Reason,
+			Details:    req.Details,
+		})
+		if err != nil {
+			log.Error("failed to report post", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				utils.SendError(w, "post not found")
+			case errors.Is(err, database.ErrInvalidReport):
+				utils.SendError(w, err.Error())
+			default:
+				utils.SendError(w, "failed to report post")
+			}
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			Report: views.NewReport(report),
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package reportPost_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/moderation/reportPost"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestReportPostHandler(t *testing.T) {
+	post := database.PostDTO{Id: 7, UserId: 2, Status: database.PostStatusPublished}
+
+	tests := []struct {
+		name         string
+		postID       string
+		requestBody  reportPost.Request
+		skipPost     bool
+		postError    error
+		hidden       bool
+		skipMock     bool
+		mockResponse database.ReportDTO
+		mockError    error
+		expectedBody reportPost.Response
+	}{
+		{
+			name:         "SuccessfulReport",
+			postID:       "7",
+			requestBody:  reportPost.Request{Reason: "spam", Details: "ads"},
+			mockResponse: database.ReportDTO{Id: 1, PostId: 7, AuthorId: 2, ReporterId: 123, Reason: "spam", Details: "ads", Status: database.ReportStatusOpen},
+			expectedBody: reportPost.Response{
+				Status: "OK",
+				Report: views.Report{Id: 1, PostId: 7, AuthorId: 2, ReporterId: 123, Reason: "spam", Details: "ads", Status: "open"},
+			},
+		},
+		{
+			name:        "InvalidPostID",
+			postID:      "abc",
+			requestBody: reportPost.Request{Reason: "spam"},
+			skipPost:    true,
+			skipMock:    true,
+			expectedBody: reportPost.Response{
+				Status: "Bad Request",
+				Error:  "Invalid post id",
+			},
+		},
+		{
+			name:        "MissingReason",
+			postID:      "7",
+			requestBody: reportPost.Request{},
+			skipPost:    true,
+			skipMock:    true,
+			expectedBody: reportPost.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
+		{
+			name:        "PostNotFound",
+			postID:      "7",
+			requestBody: reportPost.Request{Reason: "spam"},
+			postError:   pgx.ErrNoRows,
+			skipMock:    true,
+			expectedBody: reportPost.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:        "PostNotVisible",
+			postID:      "7",
+			requestBody: reportPost.Request{Reason: "spam"},
+			hidden:      true,
+			skipMock:    true,
+			expectedBody: reportPost.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:        "UnknownReason",
+			postID:      "7",
+			requestBody: reportPost.Request{Reason: "boring"},
+			mockError:   fmt.Errorf("%w: %q is not one of %v", database.ErrInvalidReport, "boring", database.ReportReasons),
+			expectedBody: reportPost.Response{
+				Status: "Bad Request",
+				Error:  `invalid report: "boring" is not one of [spam harassment hate violence sexual misinformation other]`,
+			},
+		},
+		{
+			name:        "ErrorReport",
+			postID:      "7",
+			requestBody: reportPost.Request{Reason: "spam"},
+			mockError:   errors.New("tx error"),
+			expectedBody: reportPost.Response{
+				Status: "Bad Request",
+				Error:  "failed to report post",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPosts := new(mocks.PostService)
+			mockService := new(mocks.ModerationService)
+			if !tt.skipPost {
+				mockPosts.On("GetPost", 7).Return(post, tt.postError)
+				if tt.postError == nil {
+					mockPosts.On("CanViewPost", post, 123).Return(!tt.hidden, nil)
+				}
+			}
+			if !tt.skipMock {
+				report := database.ReportDTO{PostId: 7, ReporterId: 123, Reason: tt.requestBody.Reason, Details: tt.requestBody.Details}
+				mockService.On("ReportPost", report).Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockPosts.AssertExpectations(t)
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /posts/{postID}/report", reportPost.New(logger, mockPosts, mockService))
+
+			body, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/posts/"+tt.postID+"/report", bytes.NewReader(body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody reportPost.Response
+			err = json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package resolveReport
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request represents the resolve report request payload. Action is one of database.ModerationActions.
+// swagger:model
+type Request struct {
+	Action string `json:"action" validate:"required"`
+	Note   string `json:"note,omitempty" validate:"max=2000"`
+}
+
+// Response represents the resolve report response payload.
+// swagger:model
+type Response struct {
+	Status string       `json:"status"`
+	Error  string       `json:"error,omitempty"`
+	Report views.Report `json:"report"`
+}
+
+// New acts on a report as the current moderator, see database.ModerationService.ResolveReport.
+func New(log *slog.Logger, service database.ModerationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Resolve report")
+
+		moderatorID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		reportID, err := strconv.Atoi(r.PathValue("reportID"))
+		if err != nil {
+			log.Error("Invalid report id", slog.String("report_id", r.PathValue("reportID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid report id")
+			return
+		}
+
+		var req Request
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		err = validator.New().Struct(req)
+		if err != nil {
+			log.Error("failed to validate request", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate request")
+			return
+		}
+
+		report, err := service.ResolveReport(reportID, moderatorID, req.Action, req.Note)
+		if err != nil {
+			log.Error("failed to resolve report", slog.Int("report_id", reportID), slog.String("error", err.Error()))
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				utils.SendError(w, "report not found")
+			case errors.Is(err, database.ErrReportResolved), errors.Is(err, database.ErrInvalidModerationAction):
+				utils.SendError(w, err.Error())
+			default:
+				utils.SendError(w, "failed to resolve report")
+			}
+			return
+		}
+
+		log.Info("report resolved", slog.Int("report_id", reportID), slog.Int("moderator_id", moderatorID), slog.String("action", req.Action))
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			Report: views.NewReport(report),
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package resolveReport_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/moderation/resolveReport"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestResolveReportHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		reportID     string
+		requestBody  resolveReport.Request
+		skipMock     bool
+		mockResponse database.ReportDTO
+		mockError    error
+		expectedBody resolveReport.Response
+	}{
+		{
+			name:         "SuccessfulResolve",
+			reportID:     "5",
+			requestBody:  resolveReport.Request{Action: "hide", Note: "spam link"},
+			mockResponse: database.ReportDTO{Id: 5, PostId: 7, AuthorId: 2, Reason: "spam", Status: "resolved", Action: "hide", ModeratorId: 123, Note: "spam link"},
+			expectedBody: resolveReport.Response{
+				Status: "OK",
+				Report: views.Report{Id: 5, PostId: 7, AuthorId: 2, Reason: "spam", Status: "resolved", Action: "hide", ModeratorId: 123, Note: "spam link"},
+			},
+		},
+		{
+			name:        "InvalidReportID",
+			reportID:    "abc",
+			requestBody: resolveReport.Request{Action: "dismiss"},
+			skipMock:    true,
+			expectedBody: resolveReport.Response{
+				Status: "Bad Request",
+				Error:  "Invalid report id",
+			},
+		},
+		{
+			name:        "MissingAction",
+			reportID:    "5",
+			requestBody: resolveReport.Request{Note: "fine"},
+			skipMock:    true,
+			expectedBody: resolveReport.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
+		{
+			name:        "ReportNotFound",
+			reportID:    "5",
+			requestBody: resolveReport.Request{Action: "dismiss"},
+			mockError:   pgx.ErrNoRows,
+			expectedBody: resolveReport.Response{
+				Status: "Bad Request",
+				Error:  "report not found",
+			},
+		},
+		{
+			name:        "AlreadyResolved",
+			reportID:    "5",
+			requestBody: resolveReport.Request{Action: "dismiss"},
+			mockError:   database.ErrReportResolved,
+			expectedBody: resolveReport.Response{
+				Status: "Bad Request",
+				Error:  "report is already resolved",
+			},
+		},
+		{
+			name:        "UnknownAction",
+			reportID:    "5",
+			requestBody: resolveReport.Request{Action: "ban"},
+			mockError:   database.ErrInvalidModerationAction,
+			expectedBody: resolveReport.Response{
+				Status: "Bad Request",
+				Error:  "invalid moderation action",
+			},
+		},
+		{
+			name:        "ErrorResolve",
+			reportID:    "5",
+			requestBody: resolveReport.Request{Action: "delete"},
+			mockError:   errors.New("tx error"),
+			expectedBody: resolveReport.Response{
+				Status: "Bad Request",
+				Error:  "failed to resolve report",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ModerationService)
+			if !tt.skipMock {
+				mockService.On("ResolveReport", 5, 123, tt.requestBody.Action, tt.requestBody.Note).Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /moderation/reports/{reportID}/actions", resolveReport.New(logger, mockService))
+
+			body, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/moderation/reports/"+tt.reportID+"/actions", bytes.NewReader(body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody resolveReport.Response
+			err = json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package getMutes
+
+import (
+	"errors"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the get mutes response payload.
+// swagger:model
+type Response struct {
+	Status     string             `json:"status"`
+	Error      string             `json:"error,omitempty"`
+	Users      []views.PublicUser `json:"users"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// New lists the users the current user muted, the latest muted first.
+func New(log *slog.Logger, service database.BlockService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get mutes")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		query := r.URL.Query()
+
+		limit, err := utils.QueryInt(query, "limit")
+		if err != nil {
+			log.Error("invalid limit", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid limit")
+			return
+		}
+
+		page := database.Page{
+			Limit:  limit,
+			Cursor: query.Get("cursor"),
+		}
+
+		users, nextCursor, err := service.GetMutes(userID, page)
+		if err != nil {
+			log.Error("get mutes failed", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			if errors.Is(err, database.ErrInvalidPage) {
+				utils.SendError(w, err.Error())
+				return
+			}
+			utils.SendError(w, "get mutes failed")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:     http.StatusText(http.StatusOK),
+			Users:      views.NewPublicUsers(users),
+			NextCursor: nextCursor,
+		})
+	}
+}
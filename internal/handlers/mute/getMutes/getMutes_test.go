@@ -0,0 +1,108 @@
+package getMutes_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/mute/getMutes"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetMutesHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		skipMock     bool
+		expectedPage database.Page
+		mockResponse []database.UserDTO
+		mockCursor   string
+		mockError    error
+		expectedBody getMutes.Response
+	}{
+		{
+			name:         "SuccessfulGetMutes",
+			url:          "/me/mutes?limit=1",
+			expectedPage: database.Page{Limit: 1},
+			mockResponse: []database.UserDTO{{Id: 2, Username: "muted", Password: "hash"}},
+			mockCursor:   "next",
+			expectedBody: getMutes.Response{
+				Status:     "OK",
+				Users:      []views.PublicUser{{Id: 2, Username: "muted"}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name:         "NoMutes",
+			url:          "/me/mutes?cursor=abc",
+			expectedPage: database.Page{Cursor: "abc"},
+			expectedBody: getMutes.Response{
+				Status: "OK",
+				Users:  []views.PublicUser{},
+			},
+		},
+		{
+			name:     "InvalidLimit",
+			url:      "/me/mutes?limit=abc",
+			skipMock: true,
+			expectedBody: getMutes.Response{
+				Status: "Bad Request",
+				Error:  "invalid limit",
+			},
+		},
+		{
+			name:         "InvalidCursor",
+			url:          "/me/mutes?cursor=abc",
+			expectedPage: database.Page{Cursor: "abc"},
+			mockError:    fmt.Errorf("%w: malformed cursor", database.ErrInvalidPage),
+			expectedBody: getMutes.Response{
+				Status: "Bad Request",
+				Error:  "invalid page: malformed cursor",
+			},
+		},
+		{
+			name:      "ErrorGetMutes",
+			url:       "/me/mutes",
+			mockError: errors.New("query error"),
+			expectedBody: getMutes.Response{
+				Status: "Bad Request",
+				Error:  "get mutes failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.BlockService)
+			if !tt.skipMock {
+				mockService.On("GetMutes", 123, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /me/mutes", getMutes.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody getMutes.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package muteUser
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+)
+
+// Request represents the mute user request payload.
+// swagger:model
+type Request struct {
+	UserID int `json:"user_id" validate:"required,gt=0"`
+}
+
+// Response represents the mute user response payload.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	UserID int    `json:"user_id,omitempty"`
+}
+
+// New makes the current user mute another user, whose posts and comments are then left out of
+// their lists and feed. The muted user is not notified, muting twice is a no-op.
+func New(log *slog.Logger, service database.BlockService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Mute user")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		var req Request
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		err = validator.New().Struct(req)
+		if err != nil {
+			log.Error("failed to validate request", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate request")
+			return
+		}
+
+		err = service.MuteUser(userID, req.UserID)
+		if err != nil {
+			log.Error("failed to mute user", slog.Int("user_id", req.UserID), slog.String("error", err.Error()))
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				utils.SendError(w, "user not found")
+			case errors.Is(err, database.ErrSelfBlock):
+				utils.SendError(w, err.Error())
+			default:
+				utils.SendError(w, "failed to mute user")
+			}
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			UserID: req.UserID,
+		})
+	}
+}
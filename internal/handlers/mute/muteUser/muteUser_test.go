@@ -0,0 +1,102 @@
+package muteUser_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/mute/muteUser"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestMuteUserHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestBody  muteUser.Request
+		skipMock     bool
+		mockError    error
+		expectedBody muteUser.Response
+	}{
+		{
+			name:        "SuccessfulMute",
+			requestBody: muteUser.Request{UserID: 7},
+			expectedBody: muteUser.Response{
+				Status: "OK",
+				UserID: 7,
+			},
+		},
+		{
+			name:        "MissingUserID",
+			requestBody: muteUser.Request{},
+			skipMock:    true,
+			expectedBody: muteUser.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
+		{
+			name:        "UserNotFound",
+			requestBody: muteUser.Request{UserID: 7},
+			mockError:   pgx.ErrNoRows,
+			expectedBody: muteUser.Response{
+				Status: "Bad Request",
+				Error:  "user not found",
+			},
+		},
+		{
+			name:        "SelfMute",
+			requestBody: muteUser.Request{UserID: 7},
+			mockError:   database.ErrSelfBlock,
+			expectedBody: muteUser.Response{
+				Status: "Bad Request",
+				Error:  "users can not block or mute themselves",
+			},
+		},
+		{
+			name:        "ErrorMute",
+			requestBody: muteUser.Request{UserID: 7},
+			mockError:   errors.New("query error"),
+			expectedBody: muteUser.Response{
+				Status: "Bad Request",
+				Error:  "failed to mute user",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.BlockService)
+			if !tt.skipMock {
+				mockService.On("MuteUser", 123, tt.requestBody.UserID).Return(tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /me/mutes", muteUser.New(logger, mockService))
+
+			body, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/me/mutes", bytes.NewReader(body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody muteUser.Response
+			err = json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
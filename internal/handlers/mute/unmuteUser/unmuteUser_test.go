@@ -0,0 +1,79 @@
+package unmuteUser_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/handlers/mute/unmuteUser"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestUnmuteUserHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		skipMock     bool
+		mockError    error
+		expectedBody unmuteUser.Response
+	}{
+		{
+			name:   "SuccessfulUnmute",
+			userID: "7",
+			expectedBody: unmuteUser.Response{
+				Status: "OK",
+				UserID: 7,
+			},
+		},
+		{
+			name:     "InvalidUserID",
+			userID:   "abc",
+			skipMock: true,
+			expectedBody: unmuteUser.Response{
+				Status: "Bad Request",
+				Error:  "Invalid user id",
+			},
+		},
+		{
+			name:      "ErrorUnmute",
+			userID:    "7",
+			mockError: errors.New("query error"),
+			expectedBody: unmuteUser.Response{
+				Status: "Bad Request",
+				Error:  "failed to unmute user",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.BlockService)
+			if !tt.skipMock {
+				mockService.On("UnmuteUser", 123, 7).Return(tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /me/mutes/{userID}", unmuteUser.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodDelete, "/me/mutes/"+tt.userID, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody unmuteUser.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -2,36 +2,47 @@ package createPost
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/go-playground/validator/v10"
+	"go-rest-api-auth/internal/contentfilter"
 	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/markup"
+	"go-rest-api-auth/internal/slug"
 	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
 	"log/slog"
 	"net/http"
-	"strconv"
 )
 
 // Request represents the creation post request payload.
 // swagger:model
 type Request struct {
-	Title   string   `json:"title" validate:"required"`
-	Content string   `json:"content,omitempty"`
-	Tags    []string `json:"tags,omitempty"`
+	Title         string   `json:"title" validate:"required"`
+	Slug          string   `json:"slug,omitempty"`
+	Content       string   `json:"content,omitempty"`
+	ContentFormat string   `json:"content_format,omitempty" validate:"omitempty,oneof=plain markdown"`
+	Tags          []string `json:"tags,omitempty"`
+	Status        string   `json:"status,omitempty" validate:"omitempty,oneof=draft published"`
+	Visibility    string   `json:"visibility,omitempty" validate:"omitempty,oneof=public unlisted followers private"`
 }
 
-// Response represents the creation post response payload.
+// Response represents the creation post response payload. Violations lists why the content filters rejected the post.
 // swagger:model
 type Response struct {
-	Status string           `json:"status"`
-	Error  string           `json:"error,omitempty"`
-	Post   database.PostDTO `json:"post"`
+	Status     string                    `json:"status"`
+	Error      string                    `json:"error,omitempty"`
+	Violations []contentfilter.Violation `json:"violations,omitempty"`
+	Post       views.Post                `json:"post"`
 }
 
-func New(log *slog.Logger, service database.PostService) http.HandlerFunc {
+// New runs the post through the content filters before creating it,
+// posts they flag are created and reported to the moderation queue.
+func New(log *slog.Logger, service database.PostService, filters contentfilter.Pipeline, moderation database.ModerationService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Info("Create Post")
 
-		userID, err := strconv.Atoi(r.Context().Value("user_id").(string))
-		if err != nil {
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
 			log.Debug("User ID not found")
 			utils.SendError(w, "User ID not found")
 			return
@@ -39,7 +50,7 @@ func New(log *slog.Logger, service database.PostService) http.HandlerFunc {
 
 		//get request body info
 		var req Request
-		err = json.NewDecoder(r.Body).Decode(&req)
+		err := json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
 			log.Error("failed to decode request body", slog.String("error", err.Error()))
 			utils.SendError(w, "failed to decode request body")
@@ -54,31 +65,64 @@ func New(log *slog.Logger, service database.PostService) http.HandlerFunc {
 			return
 		}
 
+		//run the content filters
+		decision, err := filters.Check(r.Context(), contentfilter.Content{
+			Kind:   contentfilter.KindPost,
+			UserID: userID,
+			Title:  req.Title,
+			Body:   req.Content,
+		})
+		var rejected *contentfilter.RejectedError
+		if errors.As(err, &rejected) {
+			log.Info("post rejected by content filters", slog.String("error", err.Error()))
+			utils.Send(w, Response{
+				Status:     http.StatusText(http.StatusBadRequest),
+				Error:      contentfilter.ErrRejected.Error(),
+				Violations: rejected.Violations,
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to filter post", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to create post")
+			return
+		}
+
 		//create user in db
 		postDto := database.PostDTO{
-			Title:   req.Title,
-			Content: req.Content,
-			UserId:  userID,
-			Tags:    req.Tags,
+			Title:         req.Title,
+			Slug:          req.Slug,
+			Content:       req.Content,
+			ContentFormat: req.ContentFormat,
+			UserId:        userID,
+			Tags:          req.Tags,
+			Status:        req.Status,
+			Visibility:    req.Visibility,
 		}
 		createdPost, err := service.CreatePost(postDto)
+		if errors.Is(err, database.ErrInvalidTag) || errors.Is(err, database.ErrInvalidPostStatus) || errors.Is(err, database.ErrInvalidPostVisibility) ||
+			errors.Is(err, markup.ErrInvalidFormat) || errors.Is(err, slug.ErrInvalidSlug) || errors.Is(err, database.ErrSlugTaken) {
+			log.Error("invalid tags", slog.String("error", err.Error()))
+			utils.SendError(w, err.Error())
+			return
+		}
 		if err != nil {
 			log.Error("failed to create post", slog.String("error", err.Error()))
 			utils.SendError(w, "failed to create post")
 			return
 		}
 
+		if decision.Verdict == contentfilter.Flag {
+			_, err = moderation.FlagPost(createdPost.Id, decision.Reason())
+			if err != nil {
+				log.Error("failed to flag post", slog.Int("post_id", createdPost.Id), slog.String("error", err.Error()))
+			}
+		}
+
 		//send response
 		utils.Send(w, Response{
 			Status: http.StatusText(http.StatusOK),
-			Post: database.PostDTO{
-				Id:        createdPost.Id,
-				Title:     createdPost.Title,
-				Content:   createdPost.Content,
-				UserId:    userID,
-				CreatedAt: createdPost.CreatedAt,
-				Tags:      createdPost.Tags,
-			},
+			Post:   views.NewPost(createdPost),
 		})
 	}
 }
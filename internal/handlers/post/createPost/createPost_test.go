@@ -5,11 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go-rest-api-auth/internal/contentfilter"
 	"go-rest-api-auth/internal/database"
 	createPost "go-rest-api-auth/internal/handlers/post/createPost"
+	"go-rest-api-auth/internal/slug"
+	"go-rest-api-auth/internal/views"
 	"go-rest-api-auth/testing/mocks"
 	"log/slog"
 	"net/http"
@@ -24,6 +28,9 @@ func TestCreatePostHandler(t *testing.T) {
 		name           string
 		userID         string
 		requestBody    createPost.Request
+		skipMock       bool
+		filterDecision contentfilter.Decision
+		filterError    error
 		mockResponse   database.PostDTO
 		mockError      error
 		expectedStatus string
@@ -52,7 +59,7 @@ func TestCreatePostHandler(t *testing.T) {
 			expectedStatus: "OK",
 			expectedBody: createPost.Response{
 				Status: "OK",
-				Post: database.PostDTO{
+				Post: views.Post{
 					Id:      1, // JSON decodes numbers as float64
 					Title:   "Test Title",
 					Content: "Test Content",
@@ -65,10 +72,60 @@ func TestCreatePostHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "MarkdownPostCreation",
+			userID: "123",
+			requestBody: createPost.Request{
+				Title:         "Test Title",
+				Content:       "**Test** Content",
+				ContentFormat: "markdown",
+			},
+			mockResponse: database.PostDTO{
+				Id:            2,
+				Title:         "Test Title",
+				Content:       "**Test** Content",
+				ContentFormat: "markdown",
+				ContentHTML:   "<p><strong>Test</strong> Content</p>\n",
+				Excerpt:       "Test Content",
+				WordCount:     2,
+				ReadingTime:   1,
+				UserId:        123,
+			},
+			expectedStatus: "OK",
+			expectedBody: createPost.Response{
+				Status: "OK",
+				Post: views.Post{
+					Id:            2,
+					Title:         "Test Title",
+					Content:       "**Test** Content",
+					ContentFormat: "markdown",
+					ContentHTML:   "<p><strong>Test</strong> Content</p>\n",
+					Excerpt:       "Test Content",
+					WordCount:     2,
+					ReadingTime:   1,
+					UserId:        123,
+				},
+			},
+		},
+		{
+			name:   "InvalidContentFormat",
+			userID: "123",
+			requestBody: createPost.Request{
+				Title:         "Test Title",
+				ContentFormat: "html",
+			},
+			skipMock:       true,
+			expectedStatus: "Bad Request",
+			expectedBody: createPost.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
 		{
 			name:           "InvalidRequestBody",
 			userID:         "123",
 			requestBody:    createPost.Request{}, // Empty request
+			skipMock:       true,
 			mockResponse:   database.PostDTO{},
 			mockError:      nil,
 			expectedStatus: "Bad Request",
@@ -77,6 +134,104 @@ func TestCreatePostHandler(t *testing.T) {
 				Error:  "failed to validate request",
 			},
 		},
+		{
+			name:   "SuccessfulDraftCreation",
+			userID: "123",
+			requestBody: createPost.Request{
+				Title:  "Test Title",
+				Status: "draft",
+			},
+			mockResponse:   database.PostDTO{Id: 2, Title: "Test Title", UserId: 123, Status: "draft"},
+			expectedStatus: "OK",
+			expectedBody: createPost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 2, Title: "Test Title", UserId: 123, Status: "draft"},
+			},
+		},
+		{
+			name:   "UnlistedPostCreation",
+			userID: "123",
+			requestBody: createPost.Request{
+				Title:      "Test Title",
+				Visibility: "unlisted",
+			},
+			mockResponse:   database.PostDTO{Id: 4, Title: "Test Title", UserId: 123, Visibility: "unlisted"},
+			expectedStatus: "OK",
+			expectedBody: createPost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 4, Title: "Test Title", UserId: 123, Visibility: "unlisted"},
+			},
+		},
+		{
+			name:   "InvalidVisibility",
+			userID: "123",
+			requestBody: createPost.Request{
+				Title:      "Test Title",
+				Visibility: "secret",
+			},
+			skipMock:       true,
+			expectedStatus: "Bad Request",
+			expectedBody: createPost.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
+		{
+			name:   "CustomSlug",
+			userID: "123",
+			requestBody: createPost.Request{
+				Title: "Test Title",
+				Slug:  "my-first-post",
+			},
+			mockResponse:   database.PostDTO{Id: 3, Title: "Test Title", Slug: "my-first-post", UserId: 123},
+			expectedStatus: "OK",
+			expectedBody: createPost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 3, Title: "Test Title", Slug: "my-first-post", UserId: 123},
+			},
+		},
+		{
+			name:   "InvalidSlug",
+			userID: "123",
+			requestBody: createPost.Request{
+				Title: "Test Title",
+				Slug:  "My Post",
+			},
+			mockError:      fmt.Errorf("%w: only lowercase letters, digits and single hyphens between them are allowed", slug.ErrInvalidSlug),
+			expectedStatus: "Bad Request",
+			expectedBody: createPost.Response{
+				Status: "Bad Request",
+				Error:  "invalid slug: only lowercase letters, digits and single hyphens between them are allowed",
+			},
+		},
+		{
+			name:   "SlugTaken",
+			userID: "123",
+			requestBody: createPost.Request{
+				Title: "Test Title",
+				Slug:  "taken",
+			},
+			mockError:      database.ErrSlugTaken,
+			expectedStatus: "Bad Request",
+			expectedBody: createPost.Response{
+				Status: "Bad Request",
+				Error:  "slug already taken",
+			},
+		},
+		{
+			name:   "InvalidStatus",
+			userID: "123",
+			requestBody: createPost.Request{
+				Title:  "Test Title",
+				Status: "archived",
+			},
+			skipMock:       true,
+			expectedStatus: "Bad Request",
+			expectedBody: createPost.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
 		{
 			name:   "PostCreationFailure",
 			userID: "123",
@@ -93,12 +248,97 @@ func TestCreatePostHandler(t *testing.T) {
 				Error:  "failed to create post",
 			},
 		},
+		{
+			name:   "InvalidTags",
+			userID: "123",
+			requestBody: createPost.Request{
+				Title: "Test Title",
+				Tags:  []string{"tag1", " "},
+			},
+			mockResponse:   database.PostDTO{},
+			mockError:      fmt.Errorf("%w: tag must not be empty", database.ErrInvalidTag),
+			expectedStatus: "Bad Request",
+			expectedBody: createPost.Response{
+				Status: "Bad Request",
+				Error:  "invalid tag: tag must not be empty",
+			},
+		},
+		{
+			name:   "RejectedByFilters",
+			userID: "123",
+			requestBody: createPost.Request{
+				Title:   "Cheap pills",
+				Content: "buy now",
+			},
+			skipMock: true,
+			filterError: &contentfilter.RejectedError{Violations: []contentfilter.Violation{
+				{Filter: "blocked_words", Reason: `contains "cheap pills"`},
+				{Filter: "quota", Reason: "at most 10 posts may be saved within 1h0m0s"},
+			}},
+			expectedStatus: "Bad Request",
+			expectedBody: createPost.Response{
+				Status: "Bad Request",
+				Error:  "content rejected",
+				Violations: []contentfilter.Violation{
+					{Filter: "blocked_words", Reason: `contains "cheap pills"`},
+					{Filter: "quota", Reason: "at most 10 posts may be saved within 1h0m0s"},
+				},
+			},
+		},
+		{
+			name:   "FilterError",
+			userID: "123",
+			requestBody: createPost.Request{
+				Title: "Test Title",
+			},
+			skipMock:       true,
+			filterError:    errors.New("duplicate filter: db down"),
+			expectedStatus: "Bad Request",
+			expectedBody: createPost.Response{
+				Status: "Bad Request",
+				Error:  "failed to create post",
+			},
+		},
+		{
+			name:   "FlaggedByFilters",
+			userID: "123",
+			requestBody: createPost.Request{
+				Title: "Test Title",
+			},
+			filterDecision: contentfilter.Decision{
+				Verdict:    contentfilter.Flag,
+				Violations: []contentfilter.Violation{{Filter: "flagged_words", Reason: `contains "crypto"`}},
+			},
+			mockResponse:   database.PostDTO{Id: 4, Title: "Test Title", UserId: 123},
+			expectedStatus: "OK",
+			expectedBody: createPost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 4, Title: "Test Title", UserId: 123},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			mockFilters := new(mocks.Pipeline)
+			if !tt.skipMock || tt.filterError != nil {
+				mockFilters.On("Check", mock.Anything, contentfilter.Content{
+					Kind:   contentfilter.KindPost,
+					UserID: 123,
+					Title:  tt.requestBody.Title,
+					Body:   tt.requestBody.Content,
+				}).Return(tt.filterDecision, tt.filterError)
+			}
+			defer mockFilters.AssertExpectations(t)
+
+			mockModeration := new(mocks.ModerationService)
+			if tt.filterDecision.Verdict == contentfilter.Flag {
+				mockModeration.On("FlagPost", tt.mockResponse.Id, `flagged_words: contains "crypto"`).Return(database.ReportDTO{}, nil)
+			}
+			defer mockModeration.AssertExpectations(t)
+
 			mockService := new(mocks.PostService)
-			if tt.mockError == nil && tt.name != "InvalidRequestBody" {
+			if tt.mockError == nil && !tt.skipMock {
 				mockService.On("CreatePost", mock.Anything).Return(tt.mockResponse, nil)
 			} else if tt.mockError != nil {
 				mockService.On("CreatePost", mock.Anything).Return(database.PostDTO{}, tt.mockError)
@@ -115,7 +355,7 @@ func TestCreatePostHandler(t *testing.T) {
 			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 
 			// Хендлер
-			handler := createPost.New(logger, mockService)
+			handler := createPost.New(logger, mockService, mockFilters, mockModeration)
 			handler(w, req)
 
 			// Проверка ответа
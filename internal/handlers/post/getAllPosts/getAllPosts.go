@@ -1,8 +1,10 @@
 package getAllPosts
 
 import (
+	"errors"
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
 	"log/slog"
 	"net/http"
 )
@@ -10,25 +12,90 @@ import (
 // Response represents the get all posts response payload.
 // swagger:model
 type Response struct {
-	Status string             `json:"status"`
-	Error  string             `json:"error,omitempty"`
-	Posts  []database.PostDTO `json:"posts"`
+	Status     string       `json:"status"`
+	Error      string       `json:"error,omitempty"`
+	Posts      []views.Post `json:"posts"`
+	NextCursor string       `json:"next_cursor,omitempty"`
 }
 
-func New(log *slog.Logger, service database.PostService) http.HandlerFunc {
+func New(log *slog.Logger, service database.PostService, reactions database.ReactionService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Info("get all posts")
 
-		posts, err := service.GetALlPosts()
+		query := r.URL.Query()
+
+		limit, err := utils.QueryInt(query, "limit")
+		if err != nil {
+			log.Error("invalid limit", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid limit")
+			return
+		}
+		authorID, err := utils.QueryInt(query, "author")
+		if err != nil {
+			log.Error("invalid author", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid author")
+			return
+		}
+		descendants, err := utils.QueryBool(query, "descendants")
+		if err != nil {
+			log.Error("invalid descendants", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid descendants")
+			return
+		}
+		createdFrom, err := utils.QueryTime(query, "created_from")
+		if err != nil {
+			log.Error("invalid created_from", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid created_from")
+			return
+		}
+		createdTo, err := utils.QueryTime(query, "created_to")
+		if err != nil {
+			log.Error("invalid created_to", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid created_to")
+			return
+		}
+
+		viewerID, _ := utils.ContextUserID(r.Context())
+		filter := database.PostFilter{
+			ViewerID:           viewerID,
+			AuthorID:           authorID,
+			Tags:               utils.QueryList(query, "tag"),
+			IncludeDescendants: descendants,
+			CreatedFrom:        createdFrom,
+			CreatedTo:          createdTo,
+			TitlePrefix:        query.Get("title_prefix"),
+		}
+		page := database.Page{
+			Limit:  limit,
+			Cursor: query.Get("cursor"),
+			Sort:   query.Get("sort"),
+			Order:  query.Get("order"),
+		}
+
+		posts, nextCursor, err := service.GetALlPosts(filter, page)
 		if err != nil {
 			log.Error("get all posts failed", slog.String("error", err.Error()))
+			if errors.Is(err, database.ErrInvalidPage) {
+				utils.SendError(w, err.Error())
+				return
+			}
 			utils.SendError(w, "get all posts failed")
 			return
 		}
 
+		if viewerID != 0 {
+			err = database.FillMyReactions(reactions, viewerID, posts)
+			if err != nil {
+				log.Error("failed to load reactions", slog.String("error", err.Error()))
+				utils.SendError(w, "failed to load reactions")
+				return
+			}
+		}
+
 		utils.Send(w, Response{
-			Status: http.StatusText(http.StatusOK),
-			Posts:  posts,
+			Status:     http.StatusText(http.StatusOK),
+			Posts:      views.NewPosts(posts),
+			NextCursor: nextCursor,
 		})
 	}
 }
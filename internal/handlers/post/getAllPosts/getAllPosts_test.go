@@ -1,23 +1,32 @@
 package getAllPosts_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/handlers/post/getAllPosts"
+	"go-rest-api-auth/internal/views"
 	"go-rest-api-auth/testing/mocks"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestGetAllPostsHandler(t *testing.T) {
 	tests := []struct {
 		name           string
+		query          string
+		skipMock       bool
+		expectedFilter database.PostFilter
+		expectedPage   database.Page
 		mockResponse   []database.PostDTO
+		mockCursor     string
 		mockError      error
 		expectedStatus string
 		expectedBody   getAllPosts.Response
@@ -44,7 +53,7 @@ func TestGetAllPostsHandler(t *testing.T) {
 			expectedStatus: "OK",
 			expectedBody: getAllPosts.Response{
 				Status: "OK",
-				Posts: []database.PostDTO{
+				Posts: []views.Post{
 					{
 						Id:      1,
 						Title:   "Test Title 1",
@@ -62,6 +71,72 @@ func TestGetAllPostsHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "FilteredPageWithNextCursor",
+			query: "?limit=1&cursor=abc&sort=title&order=asc&author=123&tag=tag1,tag2&tag=tag3&descendants=true&created_from=2024-01-01&created_to=2024-02-01T00:00:00Z&title_prefix=Test",
+			expectedFilter: database.PostFilter{
+				AuthorID:           123,
+				Tags:               []string{"tag1", "tag2", "tag3"},
+				IncludeDescendants: true,
+				CreatedFrom:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				CreatedTo:          time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				TitlePrefix:        "Test",
+			},
+			expectedPage: database.Page{Limit: 1, Cursor: "abc", Sort: "title", Order: "asc"},
+			mockResponse: []database.PostDTO{
+				{Id: 1, Title: "Test Title 1", UserId: 123, Tags: []string{"tag1", "tag2", "tag3"}},
+			},
+			mockCursor:     "next",
+			expectedStatus: "OK",
+			expectedBody: getAllPosts.Response{
+				Status: "OK",
+				Posts: []views.Post{
+					{Id: 1, Title: "Test Title 1", UserId: 123, Tags: []string{"tag1", "tag2", "tag3"}},
+				},
+				NextCursor: "next",
+			},
+		},
+		{
+			name:           "InvalidLimit",
+			query:          "?limit=ten",
+			skipMock:       true,
+			expectedStatus: "Bad Request",
+			expectedBody: getAllPosts.Response{
+				Status: "Bad Request",
+				Error:  "invalid limit",
+			},
+		},
+		{
+			name:           "InvalidDescendants",
+			query:          "?descendants=maybe",
+			skipMock:       true,
+			expectedStatus: "Bad Request",
+			expectedBody: getAllPosts.Response{
+				Status: "Bad Request",
+				Error:  "invalid descendants",
+			},
+		},
+		{
+			name:           "InvalidCreatedFrom",
+			query:          "?created_from=yesterday",
+			skipMock:       true,
+			expectedStatus: "Bad Request",
+			expectedBody: getAllPosts.Response{
+				Status: "Bad Request",
+				Error:  "invalid created_from",
+			},
+		},
+		{
+			name:           "InvalidSort",
+			query:          "?sort=content",
+			expectedPage:   database.Page{Sort: "content"},
+			mockError:      fmt.Errorf("%w: unknown sort field \"content\"", database.ErrInvalidPage),
+			expectedStatus: "Bad Request",
+			expectedBody: getAllPosts.Response{
+				Status: "Bad Request",
+				Error:  "invalid page: unknown sort field \"content\"",
+			},
+		},
 		{
 			name:           "ErrorGetAllPosts",
 			mockResponse:   nil,
@@ -77,12 +152,14 @@ func TestGetAllPostsHandler(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := new(mocks.PostService)
-			mockService.On("GetALlPosts").Return(tt.mockResponse, tt.mockError)
+			if !tt.skipMock {
+				mockService.On("GetALlPosts", tt.expectedFilter, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
 			defer mockService.AssertExpectations(t)
 
 			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 
-			handler := getAllPosts.New(logger, mockService)
+			handler := getAllPosts.New(logger, mockService, new(mocks.ReactionService))
 
 			mux := http.NewServeMux()
 			mux.HandleFunc("GET /posts", handler)
@@ -90,7 +167,7 @@ func TestGetAllPostsHandler(t *testing.T) {
 			server := httptest.NewServer(mux)
 			defer server.Close()
 
-			url := server.URL + "/posts"
+			url := server.URL + "/posts" + tt.query
 
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			assert.NoError(t, err)
@@ -107,3 +184,66 @@ func TestGetAllPostsHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAllPostsMyReactions(t *testing.T) {
+	posts := []database.PostDTO{
+		{Id: 1, Title: "Test Title 1", Reactions: map[string]int{"like": 3, "wow": 1}},
+		{Id: 2, Title: "Test Title 2"},
+	}
+
+	tests := []struct {
+		name         string
+		mockMine     map[int][]string
+		mockError    error
+		expectedBody getAllPosts.Response
+	}{
+		{
+			name:     "SuccessfulMyReactions",
+			mockMine: map[int][]string{1: {"like", "wow"}},
+			expectedBody: getAllPosts.Response{
+				Status: "OK",
+				Posts: []views.Post{
+					{Id: 1, Title: "Test Title 1", Reactions: map[string]int{"like": 3, "wow": 1}, MyReactions: []string{"like", "wow"}},
+					{Id: 2, Title: "Test Title 2"},
+				},
+			},
+		},
+		{
+			name:      "ErrorMyReactions",
+			mockError: errors.New("query error"),
+			expectedBody: getAllPosts.Response{
+				Status: "Bad Request",
+				Error:  "failed to load reactions",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.PostService)
+			mockService.On("GetALlPosts", database.PostFilter{ViewerID: 123}, database.Page{}).Return(posts, "", nil)
+			defer mockService.AssertExpectations(t)
+
+			mockReactions := new(mocks.ReactionService)
+			mockReactions.On("GetUserReactions", 123, []int{1, 2}).Return(tt.mockMine, tt.mockError)
+			defer mockReactions.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /posts", getAllPosts.New(logger, mockService, mockReactions))
+
+			req := httptest.NewRequest(http.MethodGet, "/posts", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody getAllPosts.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
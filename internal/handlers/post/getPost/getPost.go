@@ -3,6 +3,7 @@ package getPost
 import (
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -11,12 +12,13 @@ import (
 // Response represents the get post response payload.
 // swagger:model
 type Response struct {
-	Status string           `json:"status"`
-	Error  string           `json:"error,omitempty"`
-	Post   database.PostDTO `json:"post"`
+	Status string     `json:"status"`
+	Error  string     `json:"error,omitempty"`
+	Post   views.Post `json:"post"`
 }
 
-func New(log *slog.Logger, service database.PostService) http.HandlerFunc {
+// New returns a post to the readers its status and visibility allow, see database.PostService.CanViewPost.
+func New(log *slog.Logger, service database.PostService, reactions database.ReactionService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Info("get one post")
 
@@ -34,9 +36,32 @@ func New(log *slog.Logger, service database.PostService) http.HandlerFunc {
 			return
 		}
 
+		viewerID, _ := utils.ContextUserID(r.Context())
+		visible, err := service.CanViewPost(post, viewerID)
+		if err != nil {
+			log.Error("failed to check post visibility", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to check post visibility")
+			return
+		}
+		if !visible {
+			log.Error("post not visible", slog.Int("post_id", postID), slog.String("status", post.Status), slog.String("visibility", post.Visibility))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		if viewerID != 0 {
+			mine, err := reactions.GetUserReactions(viewerID, []int{post.Id})
+			if err != nil {
+				log.Error("failed to load reactions", slog.String("error", err.Error()))
+				utils.SendError(w, "failed to load reactions")
+				return
+			}
+			post.MyReactions = mine[post.Id]
+		}
+
 		utils.Send(w, Response{
 			Status: http.StatusText(http.StatusOK),
-			Post:   post,
+			Post:   views.NewPost(post),
 		})
 	}
 }
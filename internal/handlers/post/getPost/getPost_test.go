@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/handlers/post/getPost"
+	"go-rest-api-auth/internal/views"
 	"go-rest-api-auth/testing/mocks"
 	"log/slog"
 	"net/http"
@@ -33,17 +34,19 @@ func TestGetPostHandler(t *testing.T) {
 				Title:   "Test Title",
 				Content: "Test Content",
 				UserId:  123,
+				Status:  "published",
 				Tags:    []string{"tag1", "tag2"},
 			},
 			mockError:      nil,
 			expectedStatus: "OK",
 			expectedBody: getPost.Response{
 				Status: "OK",
-				Post: database.PostDTO{
+				Post: views.Post{
 					Id:      1,
 					Title:   "Test Title",
 					Content: "Test Content",
 					UserId:  123,
+					Status:  "published",
 					Tags:    []string{"tag1", "tag2"},
 				},
 			},
@@ -77,12 +80,15 @@ func TestGetPostHandler(t *testing.T) {
 			mockService := new(mocks.PostService)
 			if tt.name != "InvalidPostID" {
 				mockService.On("GetPost", mock.AnythingOfType("int")).Return(tt.mockResponse, tt.mockError)
+				if tt.mockError == nil {
+					mockService.On("CanViewPost", tt.mockResponse, 0).Return(true, nil)
+				}
 			}
 			defer mockService.AssertExpectations(t)
 
 			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 
-			handler := getPost.New(logger, mockService)
+			handler := getPost.New(logger, mockService, new(mocks.ReactionService))
 
 			mux := http.NewServeMux()
 			mux.HandleFunc("GET /posts/{postID}", handler)
@@ -110,3 +116,165 @@ func TestGetPostHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPostMyReactions(t *testing.T) {
+	post := database.PostDTO{Id: 1, Title: "Test Title", UserId: 7, Status: "published", Reactions: map[string]int{"love": 2}}
+
+	tests := []struct {
+		name         string
+		mockMine     map[int][]string
+		mockError    error
+		expectedBody getPost.Response
+	}{
+		{
+			name:     "SuccessfulMyReactions",
+			mockMine: map[int][]string{1: {"love"}},
+			expectedBody: getPost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 1, Title: "Test Title", UserId: 7, Status: "published", Reactions: map[string]int{"love": 2}, MyReactions: []string{"love"}},
+			},
+		},
+		{
+			name:     "NoMyReactions",
+			mockMine: map[int][]string{},
+			expectedBody: getPost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 1, Title: "Test Title", UserId: 7, Status: "published", Reactions: map[string]int{"love": 2}},
+			},
+		},
+		{
+			name:      "ErrorMyReactions",
+			mockError: errors.New("query error"),
+			expectedBody: getPost.Response{
+				Status: "Bad Request",
+				Error:  "failed to load reactions",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.PostService)
+			mockService.On("GetPost", 1).Return(post, nil)
+			mockService.On("CanViewPost", post, 123).Return(true, nil)
+			defer mockService.AssertExpectations(t)
+
+			mockReactions := new(mocks.ReactionService)
+			mockReactions.On("GetUserReactions", 123, []int{1}).Return(tt.mockMine, tt.mockError)
+			defer mockReactions.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /posts/{postID}", getPost.New(logger, mockService, mockReactions))
+
+			req := httptest.NewRequest(http.MethodGet, "/posts/1", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody getPost.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
+
+func TestGetPostVisibility(t *testing.T) {
+	tests := []struct {
+		name             string
+		userID           string
+		post             database.PostDTO
+		expectedViewerID int
+		mockVisible      bool
+		mockError        error
+		expectedBody     getPost.Response
+	}{
+		{
+			name:             "OwnDraft",
+			userID:           "123",
+			post:             database.PostDTO{Id: 1, Title: "Draft", UserId: 123, Status: "draft", Visibility: "public"},
+			expectedViewerID: 123,
+			mockVisible:      true,
+			expectedBody: getPost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 1, Title: "Draft", UserId: 123, Status: "draft", Visibility: "public"},
+			},
+		},
+		{
+			name:             "FollowersOnly",
+			userID:           "123",
+			post:             database.PostDTO{Id: 1, Title: "Friends", UserId: 7, Status: "published", Visibility: "followers"},
+			expectedViewerID: 123,
+			expectedBody: getPost.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:        "AnonymousPublic",
+			post:        database.PostDTO{Id: 1, Title: "Hello", UserId: 7, Status: "published", Visibility: "public"},
+			mockVisible: true,
+			expectedBody: getPost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 1, Title: "Hello", UserId: 7, Status: "published", Visibility: "public"},
+			},
+		},
+		{
+			name: "AnonymousPrivate",
+			post: database.PostDTO{Id: 1, Title: "Diary", UserId: 7, Status: "published", Visibility: "private"},
+			expectedBody: getPost.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:             "ErrorCheckingVisibility",
+			userID:           "123",
+			post:             database.PostDTO{Id: 1, Title: "Friends", UserId: 7, Status: "published", Visibility: "followers"},
+			expectedViewerID: 123,
+			mockError:        errors.New("query error"),
+			expectedBody: getPost.Response{
+				Status: "Bad Request",
+				Error:  "failed to check post visibility",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.PostService)
+			mockService.On("GetPost", 1).Return(tt.post, nil)
+			mockService.On("CanViewPost", tt.post, tt.expectedViewerID).Return(tt.mockVisible, tt.mockError)
+			defer mockService.AssertExpectations(t)
+
+			mockReactions := new(mocks.ReactionService)
+			if tt.expectedBody.Error == "" && tt.expectedViewerID != 0 {
+				mockReactions.On("GetUserReactions", tt.expectedViewerID, []int{1}).Return(map[int][]string{}, nil)
+			}
+			defer mockReactions.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /posts/{postID}", getPost.New(logger, mockService, mockReactions))
+
+			req := httptest.NewRequest(http.MethodGet, "/posts/1", nil)
+			if tt.userID != "" {
+				req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			}
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody getPost.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
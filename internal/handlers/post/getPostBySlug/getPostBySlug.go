@@ -0,0 +1,59 @@
+package getPostBySlug
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"net/url"
+)
+
+// Response represents the get post by slug response payload.
+// swagger:model
+type Response struct {
+	Status string     `json:"status"`
+	Error  string     `json:"error,omitempty"`
+	Post   views.Post `json:"post"`
+}
+
+// New returns the post with the given slug to the readers its status and visibility allow.
+// A previous slug of a post is permanently redirected to its current one.
+func New(log *slog.Logger, service database.PostService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get post by slug")
+
+		postSlug := r.PathValue("slug")
+		post, err := service.GetPostBySlug(postSlug)
+		if err != nil {
+			log.Error("post not found", slog.String("slug", postSlug), slog.String("Error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		viewerID, _ := utils.ContextUserID(r.Context())
+		visible, err := service.CanViewPost(post, viewerID)
+		if err != nil {
+			log.Error("failed to check post visibility", slog.Int("post_id", post.Id), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to check post visibility")
+			return
+		}
+		if !visible {
+			log.Error("post not visible", slog.Int("post_id", post.Id), slog.String("status", post.Status), slog.String("visibility", post.Visibility))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		if post.Slug != postSlug {
+			// a relative reference, so the client keeps the /v1 or /v2 prefix the router stripped
+			w.Header().Set("Location", url.PathEscape(post.Slug))
+			w.WriteHeader(http.StatusMovedPermanently)
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			Post:   views.NewPost(post),
+		})
+	}
+}
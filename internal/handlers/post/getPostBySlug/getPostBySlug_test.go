@@ -0,0 +1,130 @@
+package getPostBySlug_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/post/getPostBySlug"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func TestGetPostBySlugHandler(t *testing.T) {
+	tests := []struct {
+		name             string
+		slug             string
+		userID           string
+		mockResponse     database.PostDTO
+		mockError        error
+		hidden           bool
+		expectedCode     int
+		expectedLocation string
+		expectedBody     getPostBySlug.Response
+	}{
+		{
+			name:         "SuccessfulGetPost",
+			slug:         "hello-world",
+			mockResponse: database.PostDTO{Id: 1, Title: "Hello World", Slug: "hello-world", UserId: 7, Status: "published"},
+			expectedCode: http.StatusOK,
+			expectedBody: getPostBySlug.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 1, Title: "Hello World", Slug: "hello-world", UserId: 7, Status: "published"},
+			},
+		},
+		{
+			name:             "PreviousSlug",
+			slug:             "hello-world",
+			mockResponse:     database.PostDTO{Id: 1, Title: "Hello Gophers", Slug: "hello-gophers", UserId: 7, Status: "published"},
+			expectedCode:     http.StatusMovedPermanently,
+			expectedLocation: "hello-gophers",
+		},
+		{
+			name:         "DraftOfAuthor",
+			slug:         "hello-world",
+			userID:       "7",
+			mockResponse: database.PostDTO{Id: 1, Title: "Hello World", Slug: "hello-world", UserId: 7, Status: "draft"},
+			expectedCode: http.StatusOK,
+			expectedBody: getPostBySlug.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 1, Title: "Hello World", Slug: "hello-world", UserId: 7, Status: "draft"},
+			},
+		},
+		{
+			name:         "DraftOfOtherUser",
+			slug:         "hello-world",
+			mockResponse: database.PostDTO{Id: 1, Title: "Hello World", Slug: "hello-world", UserId: 7, Status: "draft"},
+			hidden:       true,
+			expectedCode: http.StatusOK,
+			expectedBody: getPostBySlug.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:         "PreviousSlugOfDraft",
+			slug:         "hello-world",
+			mockResponse: database.PostDTO{Id: 1, Title: "Hello Gophers", Slug: "hello-gophers", UserId: 7, Status: "draft"},
+			hidden:       true,
+			expectedCode: http.StatusOK,
+			expectedBody: getPostBySlug.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:         "PostNotFound",
+			slug:         "missing",
+			mockError:    pgx.ErrNoRows,
+			expectedCode: http.StatusOK,
+			expectedBody: getPostBySlug.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.PostService)
+			mockService.On("GetPostBySlug", tt.slug).Return(tt.mockResponse, tt.mockError)
+			if tt.mockError == nil {
+				viewerID, _ := strconv.Atoi(tt.userID)
+				mockService.On("CanViewPost", tt.mockResponse, viewerID).Return(!tt.hidden, nil)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /posts/by-slug/{slug}", getPostBySlug.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodGet, "/posts/by-slug/"+tt.slug, nil)
+			if tt.userID != "" {
+				req = req.WithContext(context.WithValue(req.Context(), "user_id", tt.userID))
+			}
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+
+			var responseBody getPostBySlug.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
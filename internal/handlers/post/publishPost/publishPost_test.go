@@ -0,0 +1,142 @@
+package publishPost_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/post/publishPost"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPublishPostHandler(t *testing.T) {
+	publishAt := time.Date(2030, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		postID            string
+		body              string
+		mockPost          database.PostDTO
+		mockGetError      error
+		skipGet           bool
+		skipPublish       bool
+		expectedPublishAt time.Time
+		mockResponse      database.PostDTO
+		mockError         error
+		expectedBody      publishPost.Response
+	}{
+		{
+			name:         "PublishNow",
+			postID:       "7",
+			mockPost:     database.PostDTO{Id: 7, UserId: 123, Status: "draft"},
+			mockResponse: database.PostDTO{Id: 7, UserId: 123, Status: "published"},
+			expectedBody: publishPost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 7, UserId: 123, Status: "published"},
+			},
+		},
+		{
+			name:              "Schedule",
+			postID:            "7",
+			body:              `{"publish_at": "2030-01-02T09:00:00Z"}`,
+			mockPost:          database.PostDTO{Id: 7, UserId: 123, Status: "draft"},
+			expectedPublishAt: publishAt,
+			mockResponse:      database.PostDTO{Id: 7, UserId: 123, Status: "scheduled"},
+			expectedBody: publishPost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 7, UserId: 123, Status: "scheduled"},
+			},
+		},
+		{
+			name:        "InvalidPostID",
+			postID:      "abc",
+			skipGet:     true,
+			skipPublish: true,
+			expectedBody: publishPost.Response{
+				Status: "Bad Request",
+				Error:  "Invalid post id",
+			},
+		},
+		{
+			name:        "InvalidBody",
+			postID:      "7",
+			body:        `{"publish_at": "tomorrow"}`,
+			skipGet:     true,
+			skipPublish: true,
+			expectedBody: publishPost.Response{
+				Status: "Bad Request",
+				Error:  "failed to decode request body",
+			},
+		},
+		{
+			name:         "PostNotFound",
+			postID:       "7",
+			mockGetError: pgx.ErrNoRows,
+			skipPublish:  true,
+			expectedBody: publishPost.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:        "NotAuthor",
+			postID:      "7",
+			mockPost:    database.PostDTO{Id: 7, UserId: 4, Status: "draft"},
+			skipPublish: true,
+			expectedBody: publishPost.Response{
+				Status: "Bad Request",
+				Error:  "Forbidden",
+			},
+		},
+		{
+			name:      "ErrorPublishPost",
+			postID:    "7",
+			mockPost:  database.PostDTO{Id: 7, UserId: 123, Status: "draft"},
+			mockError: errors.New("query error"),
+			expectedBody: publishPost.Response{
+				Status: "Bad Request",
+				Error:  "failed to publish post",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.PostService)
+			if !tt.skipGet {
+				mockService.On("GetPost", 7).Return(tt.mockPost, tt.mockGetError)
+			}
+			if !tt.skipPublish {
+				mockService.On("PublishPost", 7, tt.expectedPublishAt).Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /posts/{postID}/publish", publishPost.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodPost, "/posts/"+tt.postID+"/publish", strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody publishPost.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package searchPosts
+
+import (
+	"errors"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the search posts response payload.
+// swagger:model
+type Response struct {
+	Status     string               `json:"status"`
+	Error      string               `json:"error,omitempty"`
+	Results    []views.SearchResult `json:"results"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
+
+func New(log *slog.Logger, service database.PostService, reactions database.ReactionService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("search posts")
+
+		query := r.URL.Query()
+
+		text := query.Get("q")
+		if text == "" {
+			log.Error("missing search query")
+			utils.SendError(w, "missing search query")
+			return
+		}
+
+		limit, err := utils.QueryInt(query, "limit")
+		if err != nil {
+			log.Error("invalid limit", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid limit")
+			return
+		}
+		authorID, err := utils.QueryInt(query, "author")
+		if err != nil {
+			log.Error("invalid author", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid author")
+			return
+		}
+		descendants, err := utils.QueryBool(query, "descendants")
+		if err != nil {
+			log.Error("invalid descendants", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid descendants")
+			return
+		}
+
+		viewerID, _ := utils.ContextUserID(r.Context())
+		filter := database.PostFilter{
+			ViewerID:           viewerID,
+			AuthorID:           authorID,
+			Tags:               utils.QueryList(query, "tag"),
+			IncludeDescendants: descendants,
+		}
+		page := database.Page{
+			Limit:  limit,
+			Cursor: query.Get("cursor"),
+			Sort:   query.Get("sort"),
+			Order:  query.Get("order"),
+		}
+
+		results, nextCursor, err := service.SearchPosts(text, filter, page)
+		if err != nil {
+			log.Error("search posts failed", slog.String("q", text), slog.String("error", err.Error()))
+			if errors.Is(err, database.ErrInvalidPage) || errors.Is(err, database.ErrEmptySearch) {
+				utils.SendError(w, err.Error())
+				return
+			}
+			utils.SendError(w, "search posts failed")
+			return
+		}
+
+		if viewerID != 0 {
+			postIDs := make([]int, len(results))
+			for i, result := range results {
+				postIDs[i] = result.Id
+			}
+			mine, err := reactions.GetUserReactions(viewerID, postIDs)
+			if err != nil {
+				log.Error("failed to load reactions", slog.String("error", err.Error()))
+				utils.SendError(w, "failed to load reactions")
+				return
+			}
+			for i := range results {
+				results[i].MyReactions = mine[results[i].Id]
+			}
+		}
+
+		utils.Send(w, Response{
+			Status:     http.StatusText(http.StatusOK),
+			Results:    views.NewSearchResults(results),
+			NextCursor: nextCursor,
+		})
+	}
+}
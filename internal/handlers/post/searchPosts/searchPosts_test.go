@@ -0,0 +1,162 @@
+package searchPosts_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/post/searchPosts"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+)
+
+func TestSearchPostsHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		skipMock       bool
+		expectedText   string
+		expectedFilter database.PostFilter
+		expectedPage   database.Page
+		mockResponse   []database.PostSearchResultDTO
+		mockCursor     string
+		mockError      error
+		expectedBody   searchPosts.Response
+	}{
+		{
+			name:         "SuccessfulSearchPosts",
+			query:        "?q=" + url.QueryEscape(`"connection pool" -mysql`),
+			expectedText: `"connection pool" -mysql`,
+			mockResponse: []database.PostSearchResultDTO{
+				{
+					PostDTO:        database.PostDTO{Id: 1, Title: "Connection pool tuning", Content: "Size the pool", UserId: 123},
+					Rank:           0.5,
+					TitleHighlight: "<b>Connection</b> <b>pool</b> tuning",
+					Snippet:        "Size the <b>pool</b>",
+				},
+			},
+			expectedBody: searchPosts.Response{
+				Status: "OK",
+				Results: []views.SearchResult{
+					{
+						Post:           views.Post{Id: 1, Title: "Connection pool tuning", Content: "Size the pool", UserId: 123},
+						Rank:           0.5,
+						TitleHighlight: "<b>Connection</b> <b>pool</b> tuning",
+						Snippet:        "Size the <b>pool</b>",
+					},
+				},
+			},
+		},
+		{
+			name:         "FilteredPageWithNextCursor",
+			query:        "?q=pgx&limit=1&cursor=abc&sort=rank&order=desc&author=123&tag=go,databases&descendants=true",
+			expectedText: "pgx",
+			expectedFilter: database.PostFilter{
+				AuthorID:           123,
+				Tags:               []string{"go", "databases"},
+				IncludeDescendants: true,
+			},
+			expectedPage: database.Page{Limit: 1, Cursor: "abc", Sort: "rank", Order: "desc"},
+			mockResponse: []database.PostSearchResultDTO{
+				{PostDTO: database.PostDTO{Id: 2, Title: "pgx", UserId: 123}, Rank: 0.1, TitleHighlight: "<b>pgx</b>"},
+			},
+			mockCursor: "next",
+			expectedBody: searchPosts.Response{
+				Status: "OK",
+				Results: []views.SearchResult{
+					{Post: views.Post{Id: 2, Title: "pgx", UserId: 123}, Rank: 0.1, TitleHighlight: "<b>pgx</b>"},
+				},
+				NextCursor: "next",
+			},
+		},
+		{
+			name:     "MissingQuery",
+			query:    "?tag=go",
+			skipMock: true,
+			expectedBody: searchPosts.Response{
+				Status: "Bad Request",
+				Error:  "missing search query",
+			},
+		},
+		{
+			name:     "InvalidLimit",
+			query:    "?q=go&limit=ten",
+			skipMock: true,
+			expectedBody: searchPosts.Response{
+				Status: "Bad Request",
+				Error:  "invalid limit",
+			},
+		},
+		{
+			name:         "BlankQuery",
+			query:        "?q=%20",
+			expectedText: " ",
+			mockError:    database.ErrEmptySearch,
+			expectedBody: searchPosts.Response{
+				Status: "Bad Request",
+				Error:  "empty search query",
+			},
+		},
+		{
+			name:         "InvalidSort",
+			query:        "?q=go&sort=title",
+			expectedText: "go",
+			expectedPage: database.Page{Sort: "title"},
+			mockError:    fmt.Errorf("%w: unknown sort field \"title\"", database.ErrInvalidPage),
+			expectedBody: searchPosts.Response{
+				Status: "Bad Request",
+				Error:  "invalid page: unknown sort field \"title\"",
+			},
+		},
+		{
+			name:         "ErrorSearchPosts",
+			query:        "?q=go",
+			expectedText: "go",
+			mockError:    errors.New("query error"),
+			expectedBody: searchPosts.Response{
+				Status: "Bad Request",
+				Error:  "search posts failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.PostService)
+			if !tt.skipMock {
+				mockService.On("SearchPosts", tt.expectedText, tt.expectedFilter, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			handler := searchPosts.New(logger, mockService, new(mocks.ReactionService))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /posts/search", handler)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/posts/search"+tt.query, nil)
+			assert.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var responseBody searchPosts.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package unpublishPost
+
+import (
+	"encoding/json"
+	"errors"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request represents the unpublishing post request payload, the body is optional.
+// swagger:model
+type Request struct {
+	Archive bool `json:"archive,omitempty"`
+}
+
+// Response represents the unpublishing post response payload.
+// swagger:model
+type Response struct {
+	Status string     `json:"status"`
+	Error  string     `json:"error,omitempty"`
+	Post   views.Post `json:"post"`
+}
+
+// New lets the author take a post back to a draft, or archive it.
+func New(log *slog.Logger, service database.PostService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Unpublish post")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+
+		var req Request
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil && !errors.Is(err, io.EOF) {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		post, err := service.GetPost(postID)
+		if err != nil {
+			log.Error("post not found", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+		if post.UserId != userID {
+			log.Error("post belongs to another user", slog.Int("post_id", postID), slog.Int("user_id", userID))
+			utils.SendError(w, "Forbidden")
+			return
+		}
+
+		unpublished, err := service.UnpublishPost(postID, req.Archive)
+		if err != nil {
+			log.Error("failed to unpublish post", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to unpublish post")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			Post:   views.NewPost(unpublished),
+		})
+	}
+}
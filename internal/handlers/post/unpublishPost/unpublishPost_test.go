@@ -0,0 +1,128 @@
+package unpublishPost_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/post/unpublishPost"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestUnpublishPostHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		postID          string
+		body            string
+		mockPost        database.PostDTO
+		mockGetError    error
+		skipGet         bool
+		skipUnpublish   bool
+		expectedArchive bool
+		mockResponse    database.PostDTO
+		mockError       error
+		expectedBody    unpublishPost.Response
+	}{
+		{
+			name:         "BackToDraft",
+			postID:       "7",
+			mockPost:     database.PostDTO{Id: 7, UserId: 123, Status: "published"},
+			mockResponse: database.PostDTO{Id: 7, UserId: 123, Status: "draft"},
+			expectedBody: unpublishPost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 7, UserId: 123, Status: "draft"},
+			},
+		},
+		{
+			name:            "Archive",
+			postID:          "7",
+			body:            `{"archive": true}`,
+			mockPost:        database.PostDTO{Id: 7, UserId: 123, Status: "published"},
+			expectedArchive: true,
+			mockResponse:    database.PostDTO{Id: 7, UserId: 123, Status: "archived"},
+			expectedBody: unpublishPost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 7, UserId: 123, Status: "archived"},
+			},
+		},
+		{
+			name:          "InvalidPostID",
+			postID:        "abc",
+			skipGet:       true,
+			skipUnpublish: true,
+			expectedBody: unpublishPost.Response{
+				Status: "Bad Request",
+				Error:  "Invalid post id",
+			},
+		},
+		{
+			name:          "PostNotFound",
+			postID:        "7",
+			mockGetError:  pgx.ErrNoRows,
+			skipUnpublish: true,
+			expectedBody: unpublishPost.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:          "NotAuthor",
+			postID:        "7",
+			mockPost:      database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			skipUnpublish: true,
+			expectedBody: unpublishPost.Response{
+				Status: "Bad Request",
+				Error:  "Forbidden",
+			},
+		},
+		{
+			name:      "ErrorUnpublishPost",
+			postID:    "7",
+			mockPost:  database.PostDTO{Id: 7, UserId: 123, Status: "published"},
+			mockError: errors.New("query error"),
+			expectedBody: unpublishPost.Response{
+				Status: "Bad Request",
+				Error:  "failed to unpublish post",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.PostService)
+			if !tt.skipGet {
+				mockService.On("GetPost", 7).Return(tt.mockPost, tt.mockGetError)
+			}
+			if !tt.skipUnpublish {
+				mockService.On("UnpublishPost", 7, tt.expectedArchive).Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /posts/{postID}/unpublish", unpublishPost.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodPost, "/posts/"+tt.postID+"/unpublish", strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody unpublishPost.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
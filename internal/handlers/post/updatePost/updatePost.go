@@ -2,9 +2,15 @@ package updatePost
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/go-playground/validator/v10"
+	"go-rest-api-auth/internal/contentfilter"
 	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/markup"
+	"go-rest-api-auth/internal/slug"
 	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -13,20 +19,33 @@ import (
 // Request represents the updating post request payload.
 // swagger:model
 type Request struct {
-	Title   string   `json:"title"`
-	Content string   `json:"content"`
-	Tags    []string `json:"tags"`
+	Title         string   `json:"title"`
+	Slug          string   `json:"slug"`
+	Content       string   `json:"content"`
+	ContentFormat string   `json:"content_format" validate:"omitempty,oneof=plain markdown"`
+	Tags          []string `json:"tags"`
+	Visibility    string   `json:"visibility" validate:"omitempty,oneof=public unlisted followers private"`
 }
 
-// Response represents the updating post response payload.
+// Response represents the updating post response payload. Violations lists why the content filters rejected the update.
 // swagger:model
 type Response struct {
-	Status string           `json:"status"`
-	Error  string           `json:"error,omitempty"`
-	Post   database.PostDTO `json:"post"`
+	Status     string                    `json:"status"`
+	Error      string                    `json:"error,omitempty"`
+	Violations []contentfilter.Violation `json:"violations,omitempty"`
+	Post       views.Post                `json:"post"`
 }
 
-func New(log *slog.Logger, service database.PostService) http.HandlerFunc {
+var (
+	errPostNotFound = errors.New("post not found")
+	errForbidden    = errors.New("forbidden")
+)
+
+// New updates the post and reads it back in one request-scoped transaction,
+// so the response carries the content rendered by the update. Only the author or an admin
+// may update a post. A new title or content runs the post through the content filters first,
+// as it reads after the update.
+func New(log *slog.Logger, uow database.UnitOfWork, filters contentfilter.Pipeline, moderation database.ModerationService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Info("Update post")
 
@@ -37,13 +56,6 @@ func New(log *slog.Logger, service database.PostService) http.HandlerFunc {
 			return
 		}
 
-		post, err := service.GetPost(postID)
-		if err != nil {
-			log.Error("post not found", slog.String("post_id", r.PathValue("postID")), slog.String("Error", err.Error()))
-			utils.SendError(w, "post not found")
-			return
-		}
-
 		//get request body info
 		var req Request
 		err = json.NewDecoder(r.Body).Decode(&req)
@@ -54,7 +66,7 @@ func New(log *slog.Logger, service database.PostService) http.HandlerFunc {
 		}
 
 		//validating request body info
-		if req.Title == "" && req.Content == "" && req.Tags == nil {
+		if req.Title == "" && req.Slug == "" && req.Content == "" && req.ContentFormat == "" && req.Tags == nil && req.Visibility == "" {
 			log.Error("Empty request data")
 			utils.SendError(w, "Empty request data")
 			return
@@ -67,29 +79,98 @@ func New(log *slog.Logger, service database.PostService) http.HandlerFunc {
 			}
 		}
 
-		postDto := database.PostDTO{
-			Id:      postID,
-			Title:   req.Title,
-			Content: req.Content,
-			Tags:    req.Tags,
+		editorID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		var post database.PostDTO
+		var decision contentfilter.Decision
+		err = uow.Do(r.Context(), func(repos database.Repositories) error {
+			existing, err := repos.Posts.GetPost(postID)
+			if err != nil {
+				return fmt.Errorf("%w: %w", errPostNotFound, err)
+			}
+			if existing.UserId != editorID {
+				editor, err := repos.Users.GetUserById(editorID)
+				if err != nil || editor.Role != database.RoleAdmin {
+					return errForbidden
+				}
+			}
+
+			if req.Title != "" || req.Content != "" {
+				decision, err = filters.Check(r.Context(), contentfilter.Content{
+					Kind:   contentfilter.KindPost,
+					ID:     postID,
+					UserID: existing.UserId,
+					Title:  utils.CoalesceString(req.Title, existing.Title),
+					Body:   utils.CoalesceString(req.Content, existing.Content),
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			err = repos.Posts.UpdatePost(database.PostDTO{
+				Id:            postID,
+				Title:         req.Title,
+				Slug:          req.Slug,
+				Content:       req.Content,
+				ContentFormat: req.ContentFormat,
+				Tags:          req.Tags,
+				Visibility:    req.Visibility,
+			}, editorID)
+			if err != nil {
+				return err
+			}
+
+			post, err = repos.Posts.GetPost(postID)
+			return err
+		})
+		var rejected *contentfilter.RejectedError
+		if errors.As(err, &rejected) {
+			log.Info("post update rejected by content filters", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.Send(w, Response{
+				Status:     http.StatusText(http.StatusBadRequest),
+				Error:      contentfilter.ErrRejected.Error(),
+				Violations: rejected.Violations,
+			})
+			return
+		}
+		if errors.Is(err, errPostNotFound) {
+			log.Error("post not found", slog.String("post_id", r.PathValue("postID")), slog.String("Error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+		if errors.Is(err, errForbidden) {
+			log.Error("post belongs to another user", slog.Int("post_id", postID), slog.Int("user_id", editorID))
+			utils.SendError(w, "Forbidden")
+			return
+		}
+		if errors.Is(err, database.ErrInvalidTag) || errors.Is(err, markup.ErrInvalidFormat) || errors.Is(err, database.ErrInvalidPostVisibility) ||
+			errors.Is(err, slug.ErrInvalidSlug) || errors.Is(err, database.ErrSlugTaken) {
+			log.Error("invalid tags", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, err.Error())
+			return
 		}
-		err = service.UpdatePost(postDto)
 		if err != nil {
 			log.Error("failed to update post", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
 			utils.SendError(w, "failed to update post")
 			return
 		}
 
+		if decision.Verdict == contentfilter.Flag {
+			_, err = moderation.FlagPost(postID, decision.Reason())
+			if err != nil {
+				log.Error("failed to flag post", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			}
+		}
+
 		utils.Send(w, Response{
 			Status: http.StatusText(http.StatusOK),
-			Post: database.PostDTO{
-				Id:        postID,
-				Title:     utils.CoalesceString(req.Title, post.Title),
-				Content:   utils.CoalesceString(req.Content, post.Content),
-				UserId:    post.UserId,
-				CreatedAt: post.CreatedAt,
-				Tags:      utils.CoalesceSliceStrings(req.Tags, post.Tags),
-			},
+			Post:   views.NewPost(post),
 		})
 	}
 }
@@ -5,10 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go-rest-api-auth/internal/contentfilter"
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/handlers/post/updatePost"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
 	"go-rest-api-auth/testing/mocks"
 	"log/slog"
 	"net/http"
@@ -24,7 +28,11 @@ func TestUpdatePostHandler(t *testing.T) {
 		requestBody     updatePost.Request
 		mockGetResponse database.PostDTO
 		mockGetError    error
+		editorRole      string
 		mockUpdateError error
+		mockUpdatedPost database.PostDTO
+		filterDecision  contentfilter.Decision
+		filterError     error
 		expectedStatus  string
 		expectedBody    updatePost.Response
 	}{
@@ -45,10 +53,17 @@ func TestUpdatePostHandler(t *testing.T) {
 			},
 			mockGetError:    nil,
 			mockUpdateError: nil,
-			expectedStatus:  "OK",
+			mockUpdatedPost: database.PostDTO{
+				Id:      1,
+				Title:   "Updated Title",
+				Content: "Updated Content",
+				UserId:  123,
+				Tags:    []string{"tag1", "tag2"},
+			},
+			expectedStatus: "OK",
 			expectedBody: updatePost.Response{
 				Status: "OK",
-				Post: database.PostDTO{
+				Post: views.Post{
 					Id:      1,
 					Title:   "Updated Title",
 					Content: "Updated Content",
@@ -57,6 +72,58 @@ func TestUpdatePostHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "SuccessfulUpdateContentFormat",
+			postID: "1",
+			requestBody: updatePost.Request{
+				ContentFormat: "markdown",
+			},
+			mockGetResponse: database.PostDTO{
+				Id:            1,
+				Title:         "Original Title",
+				Content:       "*Original*",
+				ContentFormat: "plain",
+				UserId:        123,
+			},
+			mockUpdatedPost: database.PostDTO{
+				Id:            1,
+				Title:         "Original Title",
+				Content:       "*Original*",
+				ContentFormat: "markdown",
+				ContentHTML:   "<p><em>Original</em></p>\n",
+				Excerpt:       "Original",
+				WordCount:     1,
+				ReadingTime:   1,
+				UserId:        123,
+			},
+			expectedStatus: "OK",
+			expectedBody: updatePost.Response{
+				Status: "OK",
+				Post: views.Post{
+					Id:            1,
+					Title:         "Original Title",
+					Content:       "*Original*",
+					ContentFormat: "markdown",
+					ContentHTML:   "<p><em>Original</em></p>\n",
+					Excerpt:       "Original",
+					WordCount:     1,
+					ReadingTime:   1,
+					UserId:        123,
+				},
+			},
+		},
+		{
+			name:   "InvalidContentFormat",
+			postID: "1",
+			requestBody: updatePost.Request{
+				ContentFormat: "html",
+			},
+			expectedStatus: "Bad Request",
+			expectedBody: updatePost.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
 		{
 			name:            "InvalidPostID",
 			postID:          "abc", // Невалидный ID
@@ -73,7 +140,7 @@ func TestUpdatePostHandler(t *testing.T) {
 		{
 			name:            "PostNotFound",
 			postID:          "2",
-			requestBody:     updatePost.Request{},
+			requestBody:     updatePost.Request{Title: "Updated Title"},
 			mockGetResponse: database.PostDTO{},
 			mockGetError:    errors.New("post not found"),
 			mockUpdateError: nil,
@@ -106,46 +173,188 @@ func TestUpdatePostHandler(t *testing.T) {
 				Error:  "failed to update post",
 			},
 		},
+		{
+			name:   "SuccessfulUpdateVisibility",
+			postID: "1",
+			requestBody: updatePost.Request{
+				Visibility: "followers",
+			},
+			mockGetResponse: database.PostDTO{Id: 1, Title: "Original Title", UserId: 123, Visibility: "public"},
+			mockUpdatedPost: database.PostDTO{Id: 1, Title: "Original Title", UserId: 123, Visibility: "followers"},
+			expectedStatus:  "OK",
+			expectedBody: updatePost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 1, Title: "Original Title", UserId: 123, Visibility: "followers"},
+			},
+		},
+		{
+			name:   "CustomSlug",
+			postID: "1",
+			requestBody: updatePost.Request{
+				Slug: "better-slug",
+			},
+			mockGetResponse: database.PostDTO{Id: 1, Title: "Original Title", Slug: "original-title", UserId: 123},
+			mockUpdatedPost: database.PostDTO{Id: 1, Title: "Original Title", Slug: "better-slug", UserId: 123},
+			expectedStatus:  "OK",
+			expectedBody: updatePost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 1, Title: "Original Title", Slug: "better-slug", UserId: 123},
+			},
+		},
+		{
+			name:   "SlugTaken",
+			postID: "1",
+			requestBody: updatePost.Request{
+				Slug: "taken",
+			},
+			mockGetResponse: database.PostDTO{Id: 1, Title: "Original Title", Slug: "original-title", UserId: 123},
+			mockUpdateError: database.ErrSlugTaken,
+			expectedStatus:  "Bad Request",
+			expectedBody: updatePost.Response{
+				Status: "Bad Request",
+				Error:  "slug already taken",
+			},
+		},
+		{
+			name:   "TooManyTags",
+			postID: "1",
+			requestBody: updatePost.Request{
+				Tags: []string{"tag1", "tag2", "tag3"},
+			},
+			mockGetResponse: database.PostDTO{
+				Id:     1,
+				Title:  "Original Title",
+				UserId: 123,
+			},
+			mockUpdateError: fmt.Errorf("%w: a post can have at most 2 tags", database.ErrInvalidTag),
+			expectedStatus:  "Bad Request",
+			expectedBody: updatePost.Response{
+				Status: "Bad Request",
+				Error:  "invalid tag: a post can have at most 2 tags",
+			},
+		},
+		{
+			name:   "RejectedByFilters",
+			postID: "1",
+			requestBody: updatePost.Request{
+				Content: "buy cheap pills",
+			},
+			mockGetResponse: database.PostDTO{Id: 1, Title: "Original Title", Content: "Original Content", UserId: 456},
+			editorRole:      database.RoleAdmin,
+			filterError: &contentfilter.RejectedError{Violations: []contentfilter.Violation{
+				{Filter: "blocked_words", Reason: `contains "cheap pills"`},
+			}},
+			expectedStatus: "Bad Request",
+			expectedBody: updatePost.Response{
+				Status:     "Bad Request",
+				Error:      "content rejected",
+				Violations: []contentfilter.Violation{{Filter: "blocked_words", Reason: `contains "cheap pills"`}},
+			},
+		},
+		{
+			name:   "FlaggedByFilters",
+			postID: "1",
+			requestBody: updatePost.Request{
+				Title: "Crypto tips",
+			},
+			mockGetResponse: database.PostDTO{Id: 1, Title: "Original Title", Content: "Original Content", UserId: 456},
+			editorRole:      database.RoleAdmin,
+			mockUpdatedPost: database.PostDTO{Id: 1, Title: "Crypto tips", Content: "Original Content", UserId: 456},
+			filterDecision: contentfilter.Decision{
+				Verdict:    contentfilter.Flag,
+				Violations: []contentfilter.Violation{{Filter: "flagged_words", Reason: `contains "crypto"`}},
+			},
+			expectedStatus: "OK",
+			expectedBody: updatePost.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 1, Title: "Crypto tips", Content: "Original Content", UserId: 456},
+			},
+		},
+		{
+			name:   "NotAuthor",
+			postID: "1",
+			requestBody: updatePost.Request{
+				Title: "Taken over",
+			},
+			mockGetResponse: database.PostDTO{Id: 1, Title: "Original Title", Content: "Original Content", UserId: 456},
+			editorRole:      database.RoleUser,
+			expectedStatus:  "Bad Request",
+			expectedBody: updatePost.Response{
+				Status: "Bad Request",
+				Error:  "Forbidden",
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			forbidden := tt.expectedBody.Error == "Forbidden"
+
 			mockService := new(mocks.PostService)
 			if tt.name == "PostNotFound" {
 				mockService.On("GetPost", mock.AnythingOfType("int")).Return(tt.mockGetResponse, tt.mockGetError)
-			} else if tt.name != "InvalidPostID" {
-				mockService.On("GetPost", mock.AnythingOfType("int")).Return(tt.mockGetResponse, tt.mockGetError)
-				mockService.On("UpdatePost", mock.AnythingOfType("database.PostDTO")).Return(tt.mockUpdateError)
+			} else if tt.name != "InvalidPostID" && tt.name != "InvalidContentFormat" {
+				mockService.On("GetPost", mock.AnythingOfType("int")).Return(tt.mockGetResponse, tt.mockGetError).Once()
+			}
+			if tt.name != "InvalidPostID" && tt.name != "InvalidContentFormat" && tt.name != "PostNotFound" && tt.filterError == nil && !forbidden {
+				mockService.On("UpdatePost", mock.AnythingOfType("database.PostDTO"), mock.AnythingOfType("int")).Return(tt.mockUpdateError)
+				if tt.mockUpdateError == nil {
+					mockService.On("GetPost", mock.AnythingOfType("int")).Return(tt.mockUpdatedPost, nil).Once()
+				}
 			}
 			defer mockService.AssertExpectations(t)
 
-			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			// posts of other users are only open to admins
+			mockUsers := new(mocks.UserService)
+			if tt.mockGetError == nil && tt.mockGetResponse.UserId != 0 && tt.mockGetResponse.UserId != 123 {
+				mockUsers.On("GetUserById", 123).Return(database.UserDTO{Id: 123, Role: tt.editorRole}, nil)
+			}
+			defer mockUsers.AssertExpectations(t)
 
-			handler := updatePost.New(logger, mockService)
+			// the filters see the post as it reads after the update
+			mockFilters := new(mocks.Pipeline)
+			if tt.mockGetError == nil && !forbidden && (tt.requestBody.Title != "" || tt.requestBody.Content != "") {
+				mockFilters.On("Check", mock.Anything, contentfilter.Content{
+					Kind:   contentfilter.KindPost,
+					ID:     tt.mockGetResponse.Id,
+					UserID: tt.mockGetResponse.UserId,
+					Title:  utils.CoalesceString(tt.requestBody.Title, tt.mockGetResponse.Title),
+					Body:   utils.CoalesceString(tt.requestBody.Content, tt.mockGetResponse.Content),
+				}).Return(tt.filterDecision, tt.filterError)
+			}
+			defer mockFilters.AssertExpectations(t)
 
-			mux := http.NewServeMux()
-			mux.HandleFunc("PUT /posts/{postID}", handler)
+			mockModeration := new(mocks.ModerationService)
+			if tt.filterDecision.Verdict == contentfilter.Flag {
+				mockModeration.On("FlagPost", 1, `flagged_words: contains "crypto"`).Return(database.ReportDTO{}, nil)
+			}
+			defer mockModeration.AssertExpectations(t)
 
-			server := httptest.NewServer(mux)
-			defer server.Close()
+			mockUnitOfWork := new(mocks.UnitOfWork)
+			if tt.name != "InvalidPostID" && tt.name != "InvalidContentFormat" {
+				mockUnitOfWork.On("Do", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(database.Repositories) error) error {
+					return fn(database.Repositories{Users: mockUsers, Posts: mockService})
+				})
+			}
+			defer mockUnitOfWork.AssertExpectations(t)
 
-			url := server.URL + "/posts/" + tt.postID
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /posts/{postID}", updatePost.New(logger, mockUnitOfWork, mockFilters, mockModeration))
 
 			requestBody, err := json.Marshal(tt.requestBody)
 			assert.NoError(t, err)
 
-			req, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(requestBody))
-			assert.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPut, "/posts/"+tt.postID, bytes.NewBuffer(requestBody))
 			req.Header.Set("Content-Type", "application/json")
-
 			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
 
-			resp, err := http.DefaultClient.Do(req)
-			assert.NoError(t, err)
-			defer resp.Body.Close()
+			mux.ServeHTTP(w, req)
 
 			var responseBody updatePost.Response
-			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			err = json.NewDecoder(w.Result().Body).Decode(&responseBody)
 			assert.NoError(t, err)
 
 			assert.Equal(t, tt.expectedStatus, responseBody.Status)
@@ -0,0 +1,81 @@
+package addReaction
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the adding reaction response payload.
+// swagger:model
+type Response struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	PostID   int    `json:"post_id,omitempty"`
+	Reaction string `json:"reaction,omitempty"`
+}
+
+// New reacts to a post on behalf of the current user, reacting twice with the same type is a no-op.
+// Posts the user may not read are not found.
+func New(log *slog.Logger, service database.ReactionService, posts database.PostService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Add reaction")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+
+		post, err := posts.GetPost(postID)
+		if err != nil {
+			log.Error("post not found", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+		visible, err := posts.CanViewPost(post, userID)
+		if err != nil {
+			log.Error("failed to check post visibility", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to check post visibility")
+			return
+		}
+		if !visible {
+			log.Error("post not visible", slog.Int("post_id", postID), slog.String("status", post.Status), slog.String("visibility", post.Visibility))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		reaction := r.PathValue("type")
+		err = service.AddReaction(postID, userID, reaction)
+		if err != nil {
+			log.Error("failed to add reaction", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				utils.SendError(w, "post not found")
+			case errors.Is(err, database.ErrInvalidReaction), errors.Is(err, database.ErrBlocked):
+				utils.SendError(w, err.Error())
+			default:
+				utils.SendError(w, "failed to add reaction")
+			}
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:   http.StatusText(http.StatusOK),
+			PostID:   postID,
+			Reaction: reaction,
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package addReaction_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/reaction/addReaction"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestAddReactionHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		postID       string
+		reaction     string
+		skipMock     bool
+		postError    error
+		hidden       bool
+		mockError    error
+		expectedBody addReaction.Response
+	}{
+		{
+			name:     "SuccessfulAddReaction",
+			postID:   "7",
+			reaction: "like",
+			expectedBody: addReaction.Response{
+				Status:   "OK",
+				PostID:   7,
+				Reaction: "like",
+			},
+		},
+		{
+			name:     "InvalidPostID",
+			postID:   "abc",
+			reaction: "like",
+			skipMock: true,
+			expectedBody: addReaction.Response{
+				Status: "Bad Request",
+				Error:  "Invalid post id",
+			},
+		},
+		{
+			name:      "UnknownReaction",
+			postID:    "7",
+			reaction:  "angry",
+			mockError: fmt.Errorf("%w: unknown reaction type %q", database.ErrInvalidReaction, "angry"),
+			expectedBody: addReaction.Response{
+				Status: "Bad Request",
+				Error:  `invalid reaction: unknown reaction type "angry"`,
+			},
+		},
+		{
+			name:      "Blocked",
+			postID:    "7",
+			reaction:  "like",
+			mockError: database.ErrBlocked,
+			expectedBody: addReaction.Response{
+				Status: "Bad Request",
+				Error:  "user is blocked",
+			},
+		},
+		{
+			name:      "PostNotFound",
+			postID:    "7",
+			reaction:  "like",
+			mockError: pgx.ErrNoRows,
+			expectedBody: addReaction.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:      "PostMissing",
+			postID:    "7",
+			reaction:  "like",
+			skipMock:  true,
+			postError: pgx.ErrNoRows,
+			expectedBody: addReaction.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:     "PostNotVisible",
+			postID:   "7",
+			reaction: "like",
+			skipMock: true,
+			hidden:   true,
+			expectedBody: addReaction.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:      "ErrorAddReaction",
+			postID:    "7",
+			reaction:  "like",
+			mockError: errors.New("query error"),
+			expectedBody: addReaction.Response{
+				Status: "Bad Request",
+				Error:  "failed to add reaction",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ReactionService)
+			if !tt.skipMock {
+				mockService.On("AddReaction", 7, 123, tt.reaction).Return(tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			mockPosts := new(mocks.PostService)
+			if tt.postID == "7" {
+				post := database.PostDTO{Id: 7, UserId: 9, Status: database.PostStatusDraft}
+				mockPosts.On("GetPost", 7).Return(post, tt.postError)
+				if tt.postError == nil {
+					mockPosts.On("CanViewPost", post, 123).Return(!tt.hidden, nil)
+				}
+			}
+			defer mockPosts.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /posts/{postID}/reactions/{type}", addReaction.New(logger, mockService, mockPosts))
+
+			req := httptest.NewRequest(http.MethodPut, "/posts/"+tt.postID+"/reactions/"+tt.reaction, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody addReaction.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package getReactions
+
+import (
+	"errors"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the get reactions response payload.
+// swagger:model
+type Response struct {
+	Status     string           `json:"status"`
+	Error      string           `json:"error,omitempty"`
+	Reactions  []views.Reaction `json:"reactions"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// New lists who reacted to a post, optionally only with the reaction given by the type parameter.
+// The reactions to a post the viewer may not read are not found, like the post.
+func New(log *slog.Logger, service database.ReactionService, posts database.PostService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get reactions")
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+
+		query := r.URL.Query()
+
+		limit, err := utils.QueryInt(query, "limit")
+		if err != nil {
+			log.Error("invalid limit", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid limit")
+			return
+		}
+
+		page := database.Page{
+			Limit:  limit,
+			Cursor: query.Get("cursor"),
+			Sort:   query.Get("sort"),
+			Order:  query.Get("order"),
+		}
+
+		viewerID, _ := utils.ContextUserID(r.Context())
+		post, err := posts.GetPost(postID)
+		if err != nil {
+			log.Error("post not found", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+		visible, err := posts.CanViewPost(post, viewerID)
+		if err != nil {
+			log.Error("failed to check post visibility", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to check post visibility")
+			return
+		}
+		if !visible {
+			log.Error("post not visible", slog.Int("post_id", postID), slog.String("status", post.Status), slog.String("visibility", post.Visibility))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		reactions, nextCursor, err := service.GetReactions(postID, query.Get("type"), page)
+		if err != nil {
+			log.Error("get reactions failed", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			if errors.Is(err, database.ErrInvalidPage) || errors.Is(err, database.ErrInvalidReaction) {
+				utils.SendError(w, err.Error())
+				return
+			}
+			utils.SendError(w, "get reactions failed")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:     http.StatusText(http.StatusOK),
+			Reactions:  views.NewReactions(reactions),
+			NextCursor: nextCursor,
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package getReactions_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/reaction/getReactions"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetReactionsHandler(t *testing.T) {
+	tests := []struct {
+		name             string
+		postID           string
+		query            string
+		skipMock         bool
+		skipPost         bool
+		postError        error
+		hidden           bool
+		expectedReaction string
+		expectedPage     database.Page
+		mockResponse     []database.ReactionDTO
+		mockCursor       string
+		mockError        error
+		expectedBody     getReactions.Response
+	}{
+		{
+			name:   "SuccessfulGetReactions",
+			postID: "7",
+			mockResponse: []database.ReactionDTO{
+				{Id: 2, UserId: 4, Username: "bob", Type: "love"},
+				{Id: 1, UserId: 3, Username: "alice", Type: "like"},
+			},
+			expectedBody: getReactions.Response{
+				Status: "OK",
+				Reactions: []views.Reaction{
+					{Id: 2, UserId: 4, Username: "bob", Type: "love"},
+					{Id: 1, UserId: 3, Username: "alice", Type: "like"},
+				},
+			},
+		},
+		{
+			name:             "FilteredNextPage",
+			postID:           "7",
+			query:            "?type=like&limit=1&cursor=abc",
+			expectedReaction: "like",
+			expectedPage:     database.Page{Limit: 1, Cursor: "abc"},
+			mockResponse:     []database.ReactionDTO{{Id: 1, UserId: 3, Username: "alice", Type: "like"}},
+			mockCursor:       "next",
+			expectedBody: getReactions.Response{
+				Status:     "OK",
+				Reactions:  []views.Reaction{{Id: 1, UserId: 3, Username: "alice", Type: "like"}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name:     "InvalidPostID",
+			postID:   "abc",
+			skipMock: true,
+			skipPost: true,
+			expectedBody: getReactions.Response{
+				Status: "Bad Request",
+				Error:  "Invalid post id",
+			},
+		},
+		{
+			name:     "InvalidLimit",
+			postID:   "7",
+			query:    "?limit=many",
+			skipMock: true,
+			skipPost: true,
+			expectedBody: getReactions.Response{
+				Status: "Bad Request",
+				Error:  "invalid limit",
+			},
+		},
+		{
+			name:             "UnknownReaction",
+			postID:           "7",
+			query:            "?type=angry",
+			expectedReaction: "angry",
+			mockError:        fmt.Errorf("%w: unknown reaction type %q", database.ErrInvalidReaction, "angry"),
+			expectedBody: getReactions.Response{
+				Status: "Bad Request",
+				Error:  `invalid reaction: unknown reaction type "angry"`,
+			},
+		},
+		{
+			name:         "InvalidSort",
+			postID:       "7",
+			query:        "?sort=type",
+			expectedPage: database.Page{Sort: "type"},
+			mockError:    fmt.Errorf("%w: unknown sort field %q", database.ErrInvalidPage, "type"),
+			expectedBody: getReactions.Response{
+				Status: "Bad Request",
+				Error:  `invalid page: unknown sort field "type"`,
+			},
+		},
+		{
+			name:      "PostMissing",
+			postID:    "7",
+			skipMock:  true,
+			postError: pgx.ErrNoRows,
+			expectedBody: getReactions.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:     "PostNotVisible",
+			postID:   "7",
+			skipMock: true,
+			hidden:   true,
+			expectedBody: getReactions.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:      "ErrorGetReactions",
+			postID:    "7",
+			mockError: errors.New("query error"),
+			expectedBody: getReactions.Response{
+				Status: "Bad Request",
+				Error:  "get reactions failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ReactionService)
+			if !tt.skipMock {
+				mockService.On("GetReactions", 7, tt.expectedReaction, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			mockPosts := new(mocks.PostService)
+			if !tt.skipPost {
+				post := database.PostDTO{Id: 7, UserId: 9, Status: database.PostStatusPublished, Visibility: database.PostVisibilityPrivate}
+				mockPosts.On("GetPost", 7).Return(post, tt.postError)
+				if tt.postError == nil {
+					mockPosts.On("CanViewPost", post, 0).Return(!tt.hidden, nil)
+				}
+			}
+			defer mockPosts.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /posts/{postID}/reactions", getReactions.New(logger, mockService, mockPosts))
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			resp, err := http.Get(server.URL + "/posts/" + tt.postID + "/reactions" + tt.query)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var responseBody getReactions.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
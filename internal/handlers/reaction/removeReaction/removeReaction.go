@@ -0,0 +1,58 @@
+package removeReaction
+
+import (
+	"errors"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the removing reaction response payload.
+// swagger:model
+type Response struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	PostID   int    `json:"post_id,omitempty"`
+	Reaction string `json:"reaction,omitempty"`
+}
+
+// New takes back a reaction of the current user, removing a missing reaction is a no-op.
+func New(log *slog.Logger, service database.ReactionService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Remove reaction")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+
+		reaction := r.PathValue("type")
+		err = service.RemoveReaction(postID, userID, reaction)
+		if err != nil {
+			log.Error("failed to remove reaction", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			if errors.Is(err, database.ErrInvalidReaction) {
+				utils.SendError(w, err.Error())
+				return
+			}
+			utils.SendError(w, "failed to remove reaction")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:   http.StatusText(http.StatusOK),
+			PostID:   postID,
+			Reaction: reaction,
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package removeReaction_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/reaction/removeReaction"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestRemoveReactionHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		postID       string
+		reaction     string
+		skipMock     bool
+		mockError    error
+		expectedBody removeReaction.Response
+	}{
+		{
+			name:     "SuccessfulRemoveReaction",
+			postID:   "7",
+			reaction: "like",
+			expectedBody: removeReaction.Response{
+				Status:   "OK",
+				PostID:   7,
+				Reaction: "like",
+			},
+		},
+		{
+			name:     "InvalidPostID",
+			postID:   "abc",
+			reaction: "like",
+			skipMock: true,
+			expectedBody: removeReaction.Response{
+				Status: "Bad Request",
+				Error:  "Invalid post id",
+			},
+		},
+		{
+			name:      "UnknownReaction",
+			postID:    "7",
+			reaction:  "angry",
+			mockError: fmt.Errorf("%w: unknown reaction type %q", database.ErrInvalidReaction, "angry"),
+			expectedBody: removeReaction.Response{
+				Status: "Bad Request",
+				Error:  `invalid reaction: unknown reaction type "angry"`,
+			},
+		},
+		{
+			name:      "ErrorRemoveReaction",
+			postID:    "7",
+			reaction:  "like",
+			mockError: errors.New("query error"),
+			expectedBody: removeReaction.Response{
+				Status: "Bad Request",
+				Error:  "failed to remove reaction",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ReactionService)
+			if !tt.skipMock {
+				mockService.On("RemoveReaction", 7, 123, tt.reaction).Return(tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /posts/{postID}/reactions/{type}", removeReaction.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodDelete, "/posts/"+tt.postID+"/reactions/"+tt.reaction, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody removeReaction.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
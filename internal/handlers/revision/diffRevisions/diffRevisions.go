@@ -0,0 +1,112 @@
+package diffRevisions
+
+import (
+	"fmt"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/diff"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Response represents the diff revisions response payload. Diff is a unified diff
+// of the title, content format, tags and content, empty when both revisions are the same.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Diff   string `json:"diff"`
+}
+
+// New compares a revision of a post with the revision given by the from parameter,
+// by default the one right before it. The revisions of a post are only shown to the readers
+// of the post.
+func New(log *slog.Logger, posts database.PostService, service database.RevisionService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("diff revisions")
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+
+		to, err := strconv.Atoi(r.PathValue("revision"))
+		if err != nil {
+			log.Error("Invalid revision", slog.String("revision", r.PathValue("revision")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid revision")
+			return
+		}
+
+		from, err := utils.QueryInt(r.URL.Query(), "from")
+		if err != nil || from < 0 {
+			log.Error("invalid from", slog.String("from", r.URL.Query().Get("from")))
+			utils.SendError(w, "invalid from")
+			return
+		}
+		if r.URL.Query().Get("from") == "" {
+			from = to - 1
+		}
+
+		post, err := posts.GetPost(postID)
+		if err != nil {
+			log.Error("post not found", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		viewerID, _ := utils.ContextUserID(r.Context())
+		visible, err := posts.CanViewPost(post, viewerID)
+		if err != nil {
+			log.Error("failed to check post visibility", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to check post visibility")
+			return
+		}
+		if !visible {
+			log.Error("post not visible", slog.Int("post_id", postID), slog.String("status", post.Status), slog.String("visibility", post.Visibility))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		toRev, err := service.GetRevision(postID, to)
+		if err != nil {
+			log.Error("revision not found", slog.Int("post_id", postID), slog.Int("revision", to), slog.String("error", err.Error()))
+			utils.SendError(w, "revision not found")
+			return
+		}
+
+		// revision 0 is the empty post before the first one
+		fromText := ""
+		if from > 0 {
+			fromRev, err := service.GetRevision(postID, from)
+			if err != nil {
+				log.Error("revision not found", slog.Int("post_id", postID), slog.Int("revision", from), slog.String("error", err.Error()))
+				utils.SendError(w, "revision not found")
+				return
+			}
+			fromText = revisionText(fromRev)
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			From:   from,
+			To:     to,
+			Diff:   diff.Unified(revisionName(from), revisionName(to), fromText, revisionText(toRev)),
+		})
+	}
+}
+
+func revisionName(revision int) string {
+	return fmt.Sprintf("revision %d", revision)
+}
+
+// revisionText renders a revision as the text the diff is computed on.
+func revisionText(revision database.RevisionDTO) string {
+	return fmt.Sprintf("Title: %s\nFormat: %s\nTags: %s\n\n%s",
+		revision.Title, revision.ContentFormat, strings.Join(revision.Tags, ", "), revision.Content)
+}
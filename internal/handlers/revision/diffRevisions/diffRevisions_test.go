@@ -0,0 +1,170 @@
+package diffRevisions_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/revision/diffRevisions"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestDiffRevisionsHandler(t *testing.T) {
+	first := database.RevisionDTO{PostId: 7, Revision: 1, Title: "first", Content: "one\ntwo\n", ContentFormat: "plain", Tags: []string{"go"}}
+	second := database.RevisionDTO{PostId: 7, Revision: 2, Title: "first", Content: "one\n2\n", ContentFormat: "markdown", Tags: []string{"go", "sql"}}
+	third := database.RevisionDTO{PostId: 7, Revision: 3, Title: "third", Content: "one\n2\n", ContentFormat: "markdown", Tags: []string{"go", "sql"}}
+
+	tests := []struct {
+		name          string
+		postID        string
+		revision      string
+		query         string
+		mockPost      database.PostDTO
+		skipGet       bool
+		hidden        bool
+		mockRevisions map[int]database.RevisionDTO
+		missing       []int
+		expectedBody  diffRevisions.Response
+	}{
+		{
+			name:          "PreviousRevision",
+			postID:        "7",
+			revision:      "2",
+			mockPost:      database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			mockRevisions: map[int]database.RevisionDTO{1: first, 2: second},
+			expectedBody: diffRevisions.Response{
+				Status: "OK",
+				From:   1,
+				To:     2,
+				Diff: "--- revision 1\n+++ revision 2\n@@ -1,6 +1,6 @@\n Title: first\n-Format: plain\n-Tags: go\n" +
+					"+Format: markdown\n+Tags: go, sql\n \n one\n-two\n+2\n",
+			},
+		},
+		{
+			name:          "FromQuery",
+			postID:        "7",
+			revision:      "3",
+			query:         "?from=2",
+			mockPost:      database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			mockRevisions: map[int]database.RevisionDTO{2: second, 3: third},
+			expectedBody: diffRevisions.Response{
+				Status: "OK",
+				From:   2,
+				To:     3,
+				Diff:   "--- revision 2\n+++ revision 3\n@@ -1,4 +1,4 @@\n-Title: first\n+Title: third\n Format: markdown\n Tags: go, sql\n \n",
+			},
+		},
+		{
+			name:          "FirstRevision",
+			postID:        "7",
+			revision:      "1",
+			mockPost:      database.PostDTO{Id: 7, UserId: 123, Status: "draft"},
+			mockRevisions: map[int]database.RevisionDTO{1: first},
+			expectedBody: diffRevisions.Response{
+				Status: "OK",
+				From:   0,
+				To:     1,
+				Diff:   "--- revision 0\n+++ revision 1\n@@ -0,0 +1,6 @@\n+Title: first\n+Format: plain\n+Tags: go\n+\n+one\n+two\n",
+			},
+		},
+		{
+			name:          "SameRevision",
+			postID:        "7",
+			revision:      "2",
+			query:         "?from=2",
+			mockPost:      database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			mockRevisions: map[int]database.RevisionDTO{2: second},
+			expectedBody:  diffRevisions.Response{Status: "OK", From: 2, To: 2},
+		},
+		{
+			name:          "FromNotFound",
+			postID:        "7",
+			revision:      "3",
+			mockPost:      database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			mockRevisions: map[int]database.RevisionDTO{3: third},
+			missing:       []int{2},
+			expectedBody:  diffRevisions.Response{Status: "Bad Request", Error: "revision not found"},
+		},
+		{
+			name:         "RevisionNotFound",
+			postID:       "7",
+			revision:     "9",
+			mockPost:     database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			missing:      []int{9},
+			expectedBody: diffRevisions.Response{Status: "Bad Request", Error: "revision not found"},
+		},
+		{
+			name:         "DraftOfAnotherUser",
+			postID:       "7",
+			revision:     "2",
+			mockPost:     database.PostDTO{Id: 7, UserId: 4, Status: "draft"},
+			hidden:       true,
+			expectedBody: diffRevisions.Response{Status: "Bad Request", Error: "post not found"},
+		},
+		{
+			name:         "InvalidFrom",
+			postID:       "7",
+			revision:     "2",
+			query:        "?from=-1",
+			skipGet:      true,
+			expectedBody: diffRevisions.Response{Status: "Bad Request", Error: "invalid from"},
+		},
+		{
+			name:         "InvalidRevision",
+			postID:       "7",
+			revision:     "latest",
+			skipGet:      true,
+			expectedBody: diffRevisions.Response{Status: "Bad Request", Error: "Invalid revision"},
+		},
+		{
+			name:         "InvalidPostID",
+			postID:       "abc",
+			revision:     "2",
+			skipGet:      true,
+			expectedBody: diffRevisions.Response{Status: "Bad Request", Error: "Invalid post id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPosts := new(mocks.PostService)
+			if !tt.skipGet {
+				mockPosts.On("GetPost", 7).Return(tt.mockPost, nil)
+				mockPosts.On("CanViewPost", tt.mockPost, 123).Return(!tt.hidden, nil)
+			}
+			defer mockPosts.AssertExpectations(t)
+
+			mockService := new(mocks.RevisionService)
+			for revision, rev := range tt.mockRevisions {
+				mockService.On("GetRevision", 7, revision).Return(rev, nil)
+			}
+			for _, revision := range tt.missing {
+				mockService.On("GetRevision", 7, revision).Return(database.RevisionDTO{}, pgx.ErrNoRows)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /posts/{postID}/revisions/{revision}/diff", diffRevisions.New(logger, mockPosts, mockService))
+
+			req := httptest.NewRequest(http.MethodGet, "/posts/"+tt.postID+"/revisions/"+tt.revision+"/diff"+tt.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody diffRevisions.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package getRevision
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the get revision response payload.
+// swagger:model
+type Response struct {
+	Status   string         `json:"status"`
+	Error    string         `json:"error,omitempty"`
+	Revision views.Revision `json:"revision"`
+}
+
+// New returns a revision of a post, the revisions of a post are only shown
+// to the readers of the post.
+func New(log *slog.Logger, posts database.PostService, service database.RevisionService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get revision")
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+
+		revision, err := strconv.Atoi(r.PathValue("revision"))
+		if err != nil {
+			log.Error("Invalid revision", slog.String("revision", r.PathValue("revision")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid revision")
+			return
+		}
+
+		post, err := posts.GetPost(postID)
+		if err != nil {
+			log.Error("post not found", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		viewerID, _ := utils.ContextUserID(r.Context())
+		visible, err := posts.CanViewPost(post, viewerID)
+		if err != nil {
+			log.Error("failed to check post visibility", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to check post visibility")
+			return
+		}
+		if !visible {
+			log.Error("post not visible", slog.Int("post_id", postID), slog.String("status", post.Status), slog.String("visibility", post.Visibility))
+			utils.SendError(w, "post not found")
+			return
+		}
+
+		rev, err := service.GetRevision(postID, revision)
+		if err != nil {
+			log.Error("revision not found", slog.Int("post_id", postID), slog.Int("revision", revision), slog.String("error", err.Error()))
+			utils.SendError(w, "revision not found")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:   http.StatusText(http.StatusOK),
+			Revision: views.NewRevision(rev),
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package getRevision_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/revision/getRevision"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetRevisionHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		postID       string
+		revision     string
+		mockPost     database.PostDTO
+		mockGetError error
+		skipGet      bool
+		hidden       bool
+		skipMock     bool
+		expectedRev  int
+		mockResponse database.RevisionDTO
+		mockError    error
+		expectedBody getRevision.Response
+	}{
+		{
+			name:         "SuccessfulGetRevision",
+			postID:       "7",
+			revision:     "2",
+			mockPost:     database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			expectedRev:  2,
+			mockResponse: database.RevisionDTO{Id: 12, PostId: 7, Revision: 2, EditorId: 4, Title: "second", Content: "text", Tags: []string{"go"}},
+			expectedBody: getRevision.Response{
+				Status:   "OK",
+				Revision: views.Revision{Revision: 2, PostId: 7, EditorId: 4, Title: "second", Content: "text", Tags: []string{"go"}},
+			},
+		},
+		{
+			name:         "RevisionNotFound",
+			postID:       "7",
+			revision:     "9",
+			mockPost:     database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			expectedRev:  9,
+			mockError:    pgx.ErrNoRows,
+			expectedBody: getRevision.Response{Status: "Bad Request", Error: "revision not found"},
+		},
+		{
+			name:         "DraftOfAnotherUser",
+			postID:       "7",
+			revision:     "2",
+			mockPost:     database.PostDTO{Id: 7, UserId: 4, Status: "draft"},
+			hidden:       true,
+			skipMock:     true,
+			expectedBody: getRevision.Response{Status: "Bad Request", Error: "post not found"},
+		},
+		{
+			name:         "PostNotFound",
+			postID:       "7",
+			revision:     "2",
+			mockGetError: pgx.ErrNoRows,
+			skipMock:     true,
+			expectedBody: getRevision.Response{Status: "Bad Request", Error: "post not found"},
+		},
+		{
+			name:         "InvalidPostID",
+			postID:       "abc",
+			revision:     "2",
+			skipGet:      true,
+			skipMock:     true,
+			expectedBody: getRevision.Response{Status: "Bad Request", Error: "Invalid post id"},
+		},
+		{
+			name:         "InvalidRevision",
+			postID:       "7",
+			revision:     "latest",
+			skipGet:      true,
+			skipMock:     true,
+			expectedBody: getRevision.Response{Status: "Bad Request", Error: "Invalid revision"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPosts := new(mocks.PostService)
+			if !tt.skipGet {
+				mockPosts.On("GetPost", 7).Return(tt.mockPost, tt.mockGetError)
+				if tt.mockGetError == nil {
+					mockPosts.On("CanViewPost", tt.mockPost, 123).Return(!tt.hidden, nil)
+				}
+			}
+			defer mockPosts.AssertExpectations(t)
+
+			mockService := new(mocks.RevisionService)
+			if !tt.skipMock {
+				mockService.On("GetRevision", 7, tt.expectedRev).Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /posts/{postID}/revisions/{revision}", getRevision.New(logger, mockPosts, mockService))
+
+			req := httptest.NewRequest(http.MethodGet, "/posts/"+tt.postID+"/revisions/"+tt.revision, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody getRevision.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package getRevisions_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/revision/getRevisions"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetRevisionsHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		postID       string
+		query        string
+		mockPost     database.PostDTO
+		mockGetError error
+		skipGet      bool
+		hidden       bool
+		skipMock     bool
+		expectedPage database.Page
+		mockResponse []database.RevisionDTO
+		mockCursor   string
+		mockError    error
+		expectedBody getRevisions.Response
+	}{
+		{
+			name:     "SuccessfulGetRevisions",
+			postID:   "7",
+			mockPost: database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			mockResponse: []database.RevisionDTO{
+				{Id: 12, PostId: 7, Revision: 2, EditorId: 4, Title: "second", Tags: []string{"go"}},
+				{Id: 11, PostId: 7, Revision: 1, EditorId: 4, Title: "first", Tags: []string{}},
+			},
+			expectedBody: getRevisions.Response{
+				Status: "OK",
+				Revisions: []views.Revision{
+					{Revision: 2, PostId: 7, EditorId: 4, Title: "second", Tags: []string{"go"}},
+					{Revision: 1, PostId: 7, EditorId: 4, Title: "first", Tags: []string{}},
+				},
+			},
+		},
+		{
+			name:         "NextPage",
+			postID:       "7",
+			query:        "?limit=1&cursor=abc&order=asc",
+			mockPost:     database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			expectedPage: database.Page{Limit: 1, Cursor: "abc", Order: "asc"},
+			mockResponse: []database.RevisionDTO{{Id: 11, PostId: 7, Revision: 1, EditorId: 4, Title: "first"}},
+			mockCursor:   "next",
+			expectedBody: getRevisions.Response{
+				Status:     "OK",
+				Revisions:  []views.Revision{{Revision: 1, PostId: 7, EditorId: 4, Title: "first"}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name:         "OwnDraft",
+			postID:       "7",
+			mockPost:     database.PostDTO{Id: 7, UserId: 123, Status: "draft"},
+			mockResponse: []database.RevisionDTO{},
+			expectedBody: getRevisions.Response{
+				Status:    "OK",
+				Revisions: []views.Revision{},
+			},
+		},
+		{
+			name:     "DraftOfAnotherUser",
+			postID:   "7",
+			mockPost: database.PostDTO{Id: 7, UserId: 4, Status: "draft"},
+			hidden:   true,
+			skipMock: true,
+			expectedBody: getRevisions.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:         "PostNotFound",
+			postID:       "7",
+			mockGetError: pgx.ErrNoRows,
+			skipMock:     true,
+			expectedBody: getRevisions.Response{
+				Status: "Bad Request",
+				Error:  "post not found",
+			},
+		},
+		{
+			name:     "InvalidPostID",
+			postID:   "abc",
+			skipGet:  true,
+			skipMock: true,
+			expectedBody: getRevisions.Response{
+				Status: "Bad Request",
+				Error:  "Invalid post id",
+			},
+		},
+		{
+			name:     "InvalidLimit",
+			postID:   "7",
+			query:    "?limit=ten",
+			skipGet:  true,
+			skipMock: true,
+			expectedBody: getRevisions.Response{
+				Status: "Bad Request",
+				Error:  "invalid limit",
+			},
+		},
+		{
+			name:         "InvalidPage",
+			postID:       "7",
+			query:        "?order=up",
+			mockPost:     database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			expectedPage: database.Page{Order: "up"},
+			mockError:    fmt.Errorf("%w: unknown sort order %q", database.ErrInvalidPage, "up"),
+			expectedBody: getRevisions.Response{
+				Status: "Bad Request",
+				Error:  `invalid page: unknown sort order "up"`,
+			},
+		},
+		{
+			name:      "ErrorGetRevisions",
+			postID:    "7",
+			mockPost:  database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			mockError: errors.New("query error"),
+			expectedBody: getRevisions.Response{
+				Status: "Bad Request",
+				Error:  "get revisions failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPosts := new(mocks.PostService)
+			if !tt.skipGet {
+				mockPosts.On("GetPost", 7).Return(tt.mockPost, tt.mockGetError)
+				if tt.mockGetError == nil {
+					mockPosts.On("CanViewPost", tt.mockPost, 123).Return(!tt.hidden, nil)
+				}
+			}
+			defer mockPosts.AssertExpectations(t)
+
+			mockService := new(mocks.RevisionService)
+			if !tt.skipMock {
+				mockService.On("GetRevisions", 7, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /posts/{postID}/revisions", getRevisions.New(logger, mockPosts, mockService))
+
+			req := httptest.NewRequest(http.MethodGet, "/posts/"+tt.postID+"/revisions"+tt.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody getRevisions.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package restoreRevision
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/contentfilter"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the restore revision response payload. Violations lists why the content filters rejected the restore.
+// swagger:model
+type Response struct {
+	Status     string                    `json:"status"`
+	Error      string                    `json:"error,omitempty"`
+	Violations []contentfilter.Violation `json:"violations,omitempty"`
+	Post       views.Post                `json:"post"`
+}
+
+// New lets the author bring a post back to one of its revisions, which is recorded as a new revision.
+// The revision runs through the content filters first, as the filters may have changed since it was saved.
+func New(log *slog.Logger, service database.PostService, revisions database.RevisionService, filters contentfilter.Pipeline, moderation database.ModerationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Restore revision")
+
+		userID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Debug("User ID not found")
+			utils.SendError(w, "User ID not found")
+			return
+		}
+
+		postID, err := strconv.Atoi(r.PathValue("postID"))
+		if err != nil {
+			log.Error("Invalid post id", slog.String("post_id", r.PathValue("postID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid post id")
+			return
+		}
+
+		revision, err := strconv.Atoi(r.PathValue("revision"))
+		if err != nil {
+			log.Error("Invalid revision", slog.String("revision", r.PathValue("revision")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid revision")
+			return
+		}
+
+		post, err := service.GetPost(postID)
+		if err != nil {
+			log.Error("post not found", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "post not found")
+			return
+		}
+		if post.UserId != userID {
+			log.Error("post belongs to another user", slog.Int("post_id", postID), slog.Int("user_id", userID))
+			utils.SendError(w, "Forbidden")
+			return
+		}
+
+		rev, err := revisions.GetRevision(postID, revision)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error("revision not found", slog.Int("post_id", postID), slog.Int("revision", revision))
+			utils.SendError(w, "revision not found")
+			return
+		}
+		if err != nil {
+			log.Error("failed to get revision", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to restore revision")
+			return
+		}
+
+		decision, err := filters.Check(r.Context(), contentfilter.Content{
+			Kind:   contentfilter.KindPost,
+			ID:     postID,
+			UserID: post.UserId,
+			Title:  rev.Title,
+			Body:   rev.Content,
+		})
+		var rejected *contentfilter.RejectedError
+		if errors.As(err, &rejected) {
+			log.Info("revision restore rejected by content filters", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.Send(w, Response{
+				Status:     http.StatusText(http.StatusBadRequest),
+				Error:      contentfilter.ErrRejected.Error(),
+				Violations: rejected.Violations,
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to check revision", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to restore revision")
+			return
+		}
+
+		restored, err := service.RestoreRevision(postID, revision, userID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error("revision not found", slog.Int("post_id", postID), slog.Int("revision", revision))
+			utils.SendError(w, "revision not found")
+			return
+		}
+		if errors.Is(err, database.ErrInvalidTag) {
+			log.Error("invalid tags", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, err.Error())
+			return
+		}
+		if err != nil {
+			log.Error("failed to restore revision", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			utils.SendError(w, "failed to restore revision")
+			return
+		}
+
+		if decision.Verdict == contentfilter.Flag {
+			_, err = moderation.FlagPost(postID, decision.Reason())
+			if err != nil {
+				log.Error("failed to flag post", slog.Int("post_id", postID), slog.String("error", err.Error()))
+			}
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			Post:   views.NewPost(restored),
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package restoreRevision_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-rest-api-auth/internal/contentfilter"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/revision/restoreRevision"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestRestoreRevisionHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		postID       string
+		revision     string
+		mockPost     database.PostDTO
+		mockGetError error
+		skipGet      bool
+		skipRevision bool
+		mockRevision database.RevisionDTO
+		revisionErr  error
+		skipFilters  bool
+		decision     contentfilter.Decision
+		filterError  error
+		skipRestore  bool
+		mockResponse database.PostDTO
+		mockError    error
+		expectedBody restoreRevision.Response
+	}{
+		{
+			name:         "SuccessfulRestore",
+			postID:       "7",
+			revision:     "2",
+			mockPost:     database.PostDTO{Id: 7, UserId: 123, Title: "third", Status: "published"},
+			mockResponse: database.PostDTO{Id: 7, UserId: 123, Title: "second", Status: "published", Tags: []string{"go"}},
+			expectedBody: restoreRevision.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 7, UserId: 123, Title: "second", Status: "published", Tags: []string{"go"}},
+			},
+		},
+		{
+			name:         "RevisionNotFound",
+			postID:       "7",
+			revision:     "2",
+			mockPost:     database.PostDTO{Id: 7, UserId: 123, Status: "published"},
+			revisionErr:  pgx.ErrNoRows,
+			skipFilters:  true,
+			skipRestore:  true,
+			expectedBody: restoreRevision.Response{Status: "Bad Request", Error: "revision not found"},
+		},
+		{
+			name:         "RevisionRemovedWhileRestoring",
+			postID:       "7",
+			revision:     "2",
+			mockPost:     database.PostDTO{Id: 7, UserId: 123, Status: "published"},
+			mockError:    pgx.ErrNoRows,
+			expectedBody: restoreRevision.Response{Status: "Bad Request", Error: "revision not found"},
+		},
+		{
+			name:     "RejectedByFilters",
+			postID:   "7",
+			revision: "2",
+			mockPost: database.PostDTO{Id: 7, UserId: 123, Status: "published"},
+			filterError: &contentfilter.RejectedError{Violations: []contentfilter.Violation{
+				{Filter: "blocked_words", Reason: `contains "cheap pills"`},
+			}},
+			skipRestore: true,
+			expectedBody: restoreRevision.Response{
+				Status:     "Bad Request",
+				Error:      "content rejected",
+				Violations: []contentfilter.Violation{{Filter: "blocked_words", Reason: `contains "cheap pills"`}},
+			},
+		},
+		{
+			name:         "FilterError",
+			postID:       "7",
+			revision:     "2",
+			mockPost:     database.PostDTO{Id: 7, UserId: 123, Status: "published"},
+			filterError:  errors.New("duplicate filter: db down"),
+			skipRestore:  true,
+			expectedBody: restoreRevision.Response{Status: "Bad Request", Error: "failed to restore revision"},
+		},
+		{
+			name:     "FlaggedByFilters",
+			postID:   "7",
+			revision: "2",
+			mockPost: database.PostDTO{Id: 7, UserId: 123, Status: "published"},
+			decision: contentfilter.Decision{
+				Verdict:    contentfilter.Flag,
+				Violations: []contentfilter.Violation{{Filter: "flagged_words", Reason: `contains "crypto"`}},
+			},
+			mockResponse: database.PostDTO{Id: 7, UserId: 123, Title: "second", Status: "published"},
+			expectedBody: restoreRevision.Response{
+				Status: "OK",
+				Post:   views.Post{Id: 7, UserId: 123, Title: "second", Status: "published"},
+			},
+		},
+		{
+			name:         "InvalidTags",
+			postID:       "7",
+			revision:     "2",
+			mockPost:     database.PostDTO{Id: 7, UserId: 123, Status: "published"},
+			mockError:    fmt.Errorf("%w: a post can have at most 2 tags", database.ErrInvalidTag),
+			expectedBody: restoreRevision.Response{Status: "Bad Request", Error: "invalid tag: a post can have at most 2 tags"},
+		},
+		{
+			name:         "ErrorRestore",
+			postID:       "7",
+			revision:     "2",
+			mockPost:     database.PostDTO{Id: 7, UserId: 123, Status: "published"},
+			mockError:    errors.New("query error"),
+			expectedBody: restoreRevision.Response{Status: "Bad Request", Error: "failed to restore revision"},
+		},
+		{
+			name:         "NotAuthor",
+			postID:       "7",
+			revision:     "2",
+			mockPost:     database.PostDTO{Id: 7, UserId: 4, Status: "published"},
+			skipRevision: true,
+			skipFilters:  true,
+			skipRestore:  true,
+			expectedBody: restoreRevision.Response{Status: "Bad Request", Error: "Forbidden"},
+		},
+		{
+			name:         "PostNotFound",
+			postID:       "7",
+			revision:     "2",
+			mockGetError: pgx.ErrNoRows,
+			skipRevision: true,
+			skipFilters:  true,
+			skipRestore:  true,
+			expectedBody: restoreRevision.Response{Status: "Bad Request", Error: "post not found"},
+		},
+		{
+			name:         "InvalidRevision",
+			postID:       "7",
+			revision:     "latest",
+			skipGet:      true,
+			skipRevision: true,
+			skipFilters:  true,
+			skipRestore:  true,
+			expectedBody: restoreRevision.Response{Status: "Bad Request", Error: "Invalid revision"},
+		},
+		{
+			name:         "InvalidPostID",
+			postID:       "abc",
+			revision:     "2",
+			skipGet:      true,
+			skipRevision: true,
+			skipFilters:  true,
+			skipRestore:  true,
+			expectedBody: restoreRevision.Response{Status: "Bad Request", Error: "Invalid post id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.PostService)
+			if !tt.skipGet {
+				mockService.On("GetPost", 7).Return(tt.mockPost, tt.mockGetError)
+			}
+			if !tt.skipRestore {
+				mockService.On("RestoreRevision", 7, 2, 123).Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			mockRevisions := new(mocks.RevisionService)
+			rev := database.RevisionDTO{PostId: 7, Revision: 2, Title: "second", Content: "old content"}
+			if !tt.skipRevision {
+				mockRevisions.On("GetRevision", 7, 2).Return(rev, tt.revisionErr)
+			}
+			defer mockRevisions.AssertExpectations(t)
+
+			mockFilters := new(mocks.Pipeline)
+			if !tt.skipFilters {
+				mockFilters.On("Check", mock.Anything, contentfilter.Content{
+					Kind:   contentfilter.KindPost,
+					ID:     7,
+					UserID: 123,
+					Title:  "second",
+					Body:   "old content",
+				}).Return(tt.decision, tt.filterError)
+			}
+			defer mockFilters.AssertExpectations(t)
+
+			mockModeration := new(mocks.ModerationService)
+			if tt.decision.Verdict == contentfilter.Flag {
+				mockModeration.On("FlagPost", 7, `flagged_words: contains "crypto"`).Return(database.ReportDTO{}, nil)
+			}
+			defer mockModeration.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /posts/{postID}/revisions/{revision}/restore", restoreRevision.New(logger, mockService, mockRevisions, mockFilters, mockModeration))
+
+			req := httptest.NewRequest(http.MethodPost, "/posts/"+tt.postID+"/revisions/"+tt.revision+"/restore", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody restoreRevision.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package addTagAlias
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request represents the adding tag alias request payload.
+// swagger:model
+type Request struct {
+	Alias string `json:"alias" validate:"required"`
+}
+
+// Response represents the adding tag alias response payload.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	TagID  int    `json:"tag_id,omitempty"`
+	Alias  string `json:"alias,omitempty"`
+}
+
+func New(log *slog.Logger, service database.TagsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Add tag alias")
+
+		tagID, err := strconv.Atoi(r.PathValue("tagID"))
+		if err != nil {
+			log.Error("Invalid tag id", slog.String("tag_id", r.PathValue("tagID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid tag id")
+			return
+		}
+
+		var req Request
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		err = validator.New().Struct(req)
+		if err != nil {
+			log.Error("failed to validate request", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate request")
+			return
+		}
+
+		_, err = service.GetTagByID(tagID)
+		if err != nil {
+			log.Error("tag not found", slog.Int("tag_id", tagID), slog.String("error", err.Error()))
+			utils.SendError(w, "tag not found")
+			return
+		}
+
+		err = service.AddTagAlias(tagID, req.Alias)
+		if err != nil {
+			log.Error("failed to add tag alias", slog.Int("tag_id", tagID), slog.String("alias", req.Alias), slog.String("error", err.Error()))
+			if errors.Is(err, database.ErrInvalidTag) || errors.Is(err, database.ErrTagExists) {
+				utils.SendError(w, err.Error())
+				return
+			}
+			utils.SendError(w, "failed to add tag alias")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			TagID:  tagID,
+			Alias:  database.NormalizeTag(req.Alias),
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package addTagAlias_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/tag/addTagAlias"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestAddTagAliasHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		tagID        string
+		requestBody  addTagAlias.Request
+		skipGet      bool
+		mockGetError error
+		skipAdd      bool
+		mockAddError error
+		expectedBody addTagAlias.Response
+	}{
+		{
+			name:        "SuccessfulAddTagAlias",
+			tagID:       "1",
+			requestBody: addTagAlias.Request{Alias: " GoLang "},
+			expectedBody: addTagAlias.Response{
+				Status: "OK",
+				TagID:  1,
+				Alias:  "golang",
+			},
+		},
+		{
+			name:        "InvalidTagID",
+			tagID:       "abc",
+			requestBody: addTagAlias.Request{Alias: "golang"},
+			skipGet:     true,
+			skipAdd:     true,
+			expectedBody: addTagAlias.Response{
+				Status: "Bad Request",
+				Error:  "Invalid tag id",
+			},
+		},
+		{
+			name:        "MissingAlias",
+			tagID:       "1",
+			requestBody: addTagAlias.Request{},
+			skipGet:     true,
+			skipAdd:     true,
+			expectedBody: addTagAlias.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
+		{
+			name:         "TagNotFound",
+			tagID:        "1",
+			requestBody:  addTagAlias.Request{Alias: "golang"},
+			mockGetError: pgx.ErrNoRows,
+			skipAdd:      true,
+			expectedBody: addTagAlias.Response{
+				Status: "Bad Request",
+				Error:  "tag not found",
+			},
+		},
+		{
+			name:         "AliasTaken",
+			tagID:        "1",
+			requestBody:  addTagAlias.Request{Alias: "rust"},
+			mockAddError: fmt.Errorf("%w: %q", database.ErrTagExists, "rust"),
+			expectedBody: addTagAlias.Response{
+				Status: "Bad Request",
+				Error:  "tag already exists: \"rust\"",
+			},
+		},
+		{
+			name:         "ErrorAddTagAlias",
+			tagID:        "1",
+			requestBody:  addTagAlias.Request{Alias: "golang"},
+			mockAddError: errors.New("tx error"),
+			expectedBody: addTagAlias.Response{
+				Status: "Bad Request",
+				Error:  "failed to add tag alias",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.TagsService)
+			if !tt.skipGet {
+				mockService.On("GetTagByID", 1).Return(database.TagsDTO{Id: 1, Name: "go"}, tt.mockGetError)
+			}
+			if !tt.skipAdd {
+				mockService.On("AddTagAlias", 1, tt.requestBody.Alias).Return(tt.mockAddError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			handler := addTagAlias.New(logger, mockService)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /tags/{tagID}/aliases", handler)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			requestBody, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, server.URL+"/tags/"+tt.tagID+"/aliases", bytes.NewBuffer(requestBody))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var responseBody addTagAlias.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package deleteTag
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the deleting tag response payload.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	TagID  int    `json:"tag_id,omitempty"`
+}
+
+func New(log *slog.Logger, service database.TagsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Delete tag")
+
+		tagID, err := strconv.Atoi(r.PathValue("tagID"))
+		if err != nil {
+			log.Error("Invalid tag id", slog.String("tag_id", r.PathValue("tagID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid tag id")
+			return
+		}
+
+		_, err = service.GetTagByID(tagID)
+		if err != nil {
+			log.Error("tag not found", slog.String("tag_id", r.PathValue("tagID")), slog.String("Error", err.Error()))
+			utils.SendError(w, "tag not found")
+			return
+		}
+
+		err = service.DeleteTag(tagID)
+		if err != nil {
+			log.Error("Error deleting tag", slog.String("tag_id", r.PathValue("tagID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Error deleting tag")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			TagID:  tagID,
+		})
+	}
+}
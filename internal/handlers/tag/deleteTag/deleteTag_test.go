@@ -0,0 +1,97 @@
+package deleteTag_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/tag/deleteTag"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestDeleteTagHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		tagID           string
+		mockGetError    error
+		mockDeleteError error
+		expectedBody    deleteTag.Response
+	}{
+		{
+			name:  "SuccessfulDeleteTag",
+			tagID: "1",
+			expectedBody: deleteTag.Response{
+				Status: "OK",
+				TagID:  1,
+			},
+		},
+		{
+			name:  "InvalidTagID",
+			tagID: "abc",
+			expectedBody: deleteTag.Response{
+				Status: "Bad Request",
+				Error:  "Invalid tag id",
+			},
+		},
+		{
+			name:         "TagNotFound",
+			tagID:        "1",
+			mockGetError: pgx.ErrNoRows,
+			expectedBody: deleteTag.Response{
+				Status: "Bad Request",
+				Error:  "tag not found",
+			},
+		},
+		{
+			name:            "ErrorDeleteTag",
+			tagID:           "1",
+			mockDeleteError: errors.New("delete error"),
+			expectedBody: deleteTag.Response{
+				Status: "Bad Request",
+				Error:  "Error deleting tag",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.TagsService)
+			if tt.name != "InvalidTagID" {
+				mockService.On("GetTagByID", 1).Return(database.TagsDTO{Id: 1, Name: "go"}, tt.mockGetError)
+			}
+			if tt.name != "InvalidTagID" && tt.mockGetError == nil {
+				mockService.On("DeleteTag", 1).Return(tt.mockDeleteError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			handler := deleteTag.New(logger, mockService)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /tags/{tagID}", handler)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodDelete, server.URL+"/tags/"+tt.tagID, nil)
+			assert.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var responseBody deleteTag.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package deleteTagAlias
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the deleting tag alias response payload.
+// swagger:model
+type Response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	TagID  int    `json:"tag_id,omitempty"`
+	Alias  string `json:"alias,omitempty"`
+}
+
+func New(log *slog.Logger, service database.TagsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Delete tag alias")
+
+		tagID, err := strconv.Atoi(r.PathValue("tagID"))
+		if err != nil {
+			log.Error("Invalid tag id", slog.String("tag_id", r.PathValue("tagID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid tag id")
+			return
+		}
+
+		alias := database.NormalizeTag(r.PathValue("alias"))
+		if alias == "" {
+			log.Error("Invalid alias", slog.String("alias", r.PathValue("alias")))
+			utils.SendError(w, "Invalid alias")
+			return
+		}
+
+		err = service.DeleteTagAlias(tagID, alias)
+		if err != nil {
+			log.Error("failed to delete tag alias", slog.Int("tag_id", tagID), slog.String("alias", alias), slog.String("error", err.Error()))
+			if errors.Is(err, pgx.ErrNoRows) {
+				utils.SendError(w, "alias not found")
+				return
+			}
+			utils.SendError(w, "failed to delete tag alias")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			TagID:  tagID,
+			Alias:  alias,
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package deleteTagAlias_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/handlers/tag/deleteTagAlias"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestDeleteTagAliasHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		skipMock      bool
+		expectedAlias string
+		mockError     error
+		expectedBody  deleteTagAlias.Response
+	}{
+		{
+			name:          "SuccessfulDeleteTagAlias",
+			path:          "/tags/1/aliases/GoLang",
+			expectedAlias: "golang",
+			expectedBody: deleteTagAlias.Response{
+				Status: "OK",
+				TagID:  1,
+				Alias:  "golang",
+			},
+		},
+		{
+			name:     "InvalidTagID",
+			path:     "/tags/abc/aliases/golang",
+			skipMock: true,
+			expectedBody: deleteTagAlias.Response{
+				Status: "Bad Request",
+				Error:  "Invalid tag id",
+			},
+		},
+		{
+			name:     "BlankAlias",
+			path:     "/tags/1/aliases/%20",
+			skipMock: true,
+			expectedBody: deleteTagAlias.Response{
+				Status: "Bad Request",
+				Error:  "Invalid alias",
+			},
+		},
+		{
+			name:          "AliasNotFound",
+			path:          "/tags/1/aliases/rust",
+			expectedAlias: "rust",
+			mockError:     pgx.ErrNoRows,
+			expectedBody: deleteTagAlias.Response{
+				Status: "Bad Request",
+				Error:  "alias not found",
+			},
+		},
+		{
+			name:          "ErrorDeleteTagAlias",
+			path:          "/tags/1/aliases/golang",
+			expectedAlias: "golang",
+			mockError:     errors.New("query error"),
+			expectedBody: deleteTagAlias.Response{
+				Status: "Bad Request",
+				Error:  "failed to delete tag alias",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.TagsService)
+			if !tt.skipMock {
+				mockService.On("DeleteTagAlias", 1, tt.expectedAlias).Return(tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			handler := deleteTagAlias.New(logger, mockService)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /tags/{tagID}/aliases/{alias}", handler)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodDelete, server.URL+tt.path, nil)
+			assert.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var responseBody deleteTagAlias.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package getAllTags
+
+import (
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the get all tags response payload.
+// swagger:model
+type Response struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error,omitempty"`
+	Tags   []views.Tag `json:"tags"`
+}
+
+func New(log *slog.Logger, service database.TagsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get all tags")
+
+		query := r.URL.Query()
+
+		limit, err := utils.QueryInt(query, "limit")
+		if err != nil {
+			log.Error("invalid limit", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid limit")
+			return
+		}
+
+		tags, err := service.SearchTags(query.Get("prefix"), limit)
+		if err != nil {
+			log.Error("get all tags failed", slog.String("error", err.Error()))
+			utils.SendError(w, "get all tags failed")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			Tags:   views.NewTagCounts(tags),
+		})
+	}
+}
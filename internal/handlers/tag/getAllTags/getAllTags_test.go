@@ -0,0 +1,105 @@
+package getAllTags_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/tag/getAllTags"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetAllTagsHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		skipMock       bool
+		expectedPrefix string
+		expectedLimit  int
+		mockResponse   []database.TagCountDTO
+		mockError      error
+		expectedBody   getAllTags.Response
+	}{
+		{
+			name: "SuccessfulGetAllTags",
+			mockResponse: []database.TagCountDTO{
+				{Id: 1, Name: "Go", PostCount: 3},
+				{Id: 2, Name: "unused"},
+			},
+			expectedBody: getAllTags.Response{
+				Status: "OK",
+				Tags: []views.Tag{
+					{Id: 1, Name: "Go", PostCount: 3},
+					{Id: 2, Name: "unused"},
+				},
+			},
+		},
+		{
+			name:           "Autocomplete",
+			query:          "?prefix=g&limit=5",
+			expectedPrefix: "g",
+			expectedLimit:  5,
+			mockResponse:   []database.TagCountDTO{{Id: 1, Name: "Go", PostCount: 3}},
+			expectedBody: getAllTags.Response{
+				Status: "OK",
+				Tags:   []views.Tag{{Id: 1, Name: "Go", PostCount: 3}},
+			},
+		},
+		{
+			name:     "InvalidLimit",
+			query:    "?limit=many",
+			skipMock: true,
+			expectedBody: getAllTags.Response{
+				Status: "Bad Request",
+				Error:  "invalid limit",
+			},
+		},
+		{
+			name:      "ErrorGetAllTags",
+			mockError: errors.New("query error"),
+			expectedBody: getAllTags.Response{
+				Status: "Bad Request",
+				Error:  "get all tags failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.TagsService)
+			if !tt.skipMock {
+				mockService.On("SearchTags", tt.expectedPrefix, tt.expectedLimit).Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			handler := getAllTags.New(logger, mockService)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /tags", handler)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/tags"+tt.query, nil)
+			assert.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var responseBody getAllTags.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package getTag
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Response represents the get tag response payload.
+// swagger:model
+type Response struct {
+	Status string           `json:"status"`
+	Error  string           `json:"error,omitempty"`
+	Tag    views.TagDetails `json:"tag"`
+}
+
+func New(log *slog.Logger, service database.TagsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Get tag")
+
+		tagID, err := strconv.Atoi(r.PathValue("tagID"))
+		if err != nil {
+			log.Error("Invalid tag id", slog.String("tag_id", r.PathValue("tagID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid tag id")
+			return
+		}
+
+		tag, err := service.GetTagDetails(tagID)
+		if err != nil {
+			log.Error("failed to get tag", slog.Int("tag_id", tagID), slog.String("error", err.Error()))
+			if errors.Is(err, pgx.ErrNoRows) {
+				utils.SendError(w, "tag not found")
+				return
+			}
+			utils.SendError(w, "failed to get tag")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			Tag:    views.NewTagDetails(tag),
+		})
+	}
+}
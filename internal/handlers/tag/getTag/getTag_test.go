@@ -0,0 +1,128 @@
+package getTag_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/tag/getTag"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetTagHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		tagID        string
+		expectedID   int
+		skipMock     bool
+		mockResponse database.TagDetailsDTO
+		mockError    error
+		expectedBody getTag.Response
+	}{
+		{
+			name:       "SuccessfulGetTag",
+			tagID:      "1",
+			expectedID: 1,
+			mockResponse: database.TagDetailsDTO{
+				Id:       1,
+				Name:     "databases",
+				Aliases:  []string{"db"},
+				Children: []database.TagsDTO{{Id: 2, Name: "postgres"}},
+			},
+			expectedBody: getTag.Response{
+				Status: "OK",
+				Tag: views.TagDetails{
+					Id:       1,
+					Name:     "databases",
+					Aliases:  []string{"db"},
+					Children: []views.Tag{{Id: 2, Name: "postgres"}},
+				},
+			},
+		},
+		{
+			name:         "LeafTagWithParent",
+			tagID:        "2",
+			expectedID:   2,
+			mockResponse: database.TagDetailsDTO{Id: 2, Name: "postgres", ParentID: 1},
+			expectedBody: getTag.Response{
+				Status: "OK",
+				Tag: views.TagDetails{
+					Id:       2,
+					Name:     "postgres",
+					ParentID: 1,
+					Aliases:  []string{},
+					Children: []views.Tag{},
+				},
+			},
+		},
+		{
+			name:     "InvalidTagID",
+			tagID:    "abc",
+			skipMock: true,
+			expectedBody: getTag.Response{
+				Status: "Bad Request",
+				Error:  "Invalid tag id",
+			},
+		},
+		{
+			name:       "TagNotFound",
+			tagID:      "3",
+			expectedID: 3,
+			mockError:  pgx.ErrNoRows,
+			expectedBody: getTag.Response{
+				Status: "Bad Request",
+				Error:  "tag not found",
+			},
+		},
+		{
+			name:       "ErrorGetTag",
+			tagID:      "1",
+			expectedID: 1,
+			mockError:  errors.New("query error"),
+			expectedBody: getTag.Response{
+				Status: "Bad Request",
+				Error:  "failed to get tag",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.TagsService)
+			if !tt.skipMock {
+				mockService.On("GetTagDetails", tt.expectedID).Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			handler := getTag.New(logger, mockService)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /tags/{tagID}", handler)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/tags/"+tt.tagID, nil)
+			assert.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var responseBody getTag.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package getTagPosts
+
+import (
+	"errors"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+)
+
+// Response represents the get tag posts response payload.
+// swagger:model
+type Response struct {
+	Status     string       `json:"status"`
+	Error      string       `json:"error,omitempty"`
+	Posts      []views.Post `json:"posts"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+func New(log *slog.Logger, service database.PostService, reactions database.ReactionService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("get tag posts")
+
+		tagName := r.PathValue("name")
+		if database.NormalizeTag(tagName) == "" {
+			log.Error("Invalid tag name", slog.String("tag", tagName))
+			utils.SendError(w, "Invalid tag name")
+			return
+		}
+
+		query := r.URL.Query()
+
+		limit, err := utils.QueryInt(query, "limit")
+		if err != nil {
+			log.Error("invalid limit", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid limit")
+			return
+		}
+
+		descendants, err := utils.QueryBool(query, "descendants")
+		if err != nil {
+			log.Error("invalid descendants", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid descendants")
+			return
+		}
+
+		viewerID, _ := utils.ContextUserID(r.Context())
+		filter := database.PostFilter{
+			ViewerID:           viewerID,
+			Tags:               []string{tagName},
+			IncludeDescendants: descendants,
+		}
+		page := database.Page{
+			Limit:  limit,
+			Cursor: query.Get("cursor"),
+			Sort:   query.Get("sort"),
+			Order:  query.Get("order"),
+		}
+
+		posts, nextCursor, err := service.GetALlPosts(filter, page)
+		if err != nil {
+			log.Error("get tag posts failed", slog.String("tag", tagName), slog.String("error", err.Error()))
+			if errors.Is(err, database.ErrInvalidPage) {
+				utils.SendError(w, err.Error())
+				return
+			}
+			utils.SendError(w, "get tag posts failed")
+			return
+		}
+
+		if viewerID != 0 {
+			err = database.FillMyReactions(reactions, viewerID, posts)
+			if err != nil {
+				log.Error("failed to load reactions", slog.String("error", err.Error()))
+				utils.SendError(w, "failed to load reactions")
+				return
+			}
+		}
+
+		utils.Send(w, Response{
+			Status:     http.StatusText(http.StatusOK),
+			Posts:      views.NewPosts(posts),
+			NextCursor: nextCursor,
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package getTagPosts_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/tag/getTagPosts"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetTagPostsHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		skipMock     bool
+		expectedTag  string
+		descendants  bool
+		expectedPage database.Page
+		mockResponse []database.PostDTO
+		mockCursor   string
+		mockError    error
+		expectedBody getTagPosts.Response
+	}{
+		{
+			name:         "SuccessfulGetTagPosts",
+			path:         "/tags/go/posts?limit=1",
+			expectedTag:  "go",
+			expectedPage: database.Page{Limit: 1},
+			mockResponse: []database.PostDTO{
+				{Id: 1, Title: "Test Title", UserId: 123, Tags: []string{"Go"}},
+			},
+			mockCursor: "next",
+			expectedBody: getTagPosts.Response{
+				Status:     "OK",
+				Posts:      []views.Post{{Id: 1, Title: "Test Title", UserId: 123, Tags: []string{"Go"}}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name:        "EscapedTagName",
+			path:        "/tags/web%20dev/posts",
+			expectedTag: "web dev",
+			expectedBody: getTagPosts.Response{
+				Status: "OK",
+				Posts:  []views.Post{},
+			},
+		},
+		{
+			name:        "IncludeDescendants",
+			path:        "/tags/databases/posts?descendants=1",
+			expectedTag: "databases",
+			descendants: true,
+			mockResponse: []database.PostDTO{
+				{Id: 2, Title: "Indexes", UserId: 123, Tags: []string{"postgres"}},
+			},
+			expectedBody: getTagPosts.Response{
+				Status: "OK",
+				Posts:  []views.Post{{Id: 2, Title: "Indexes", UserId: 123, Tags: []string{"postgres"}}},
+			},
+		},
+		{
+			name:     "InvalidDescendants",
+			path:     "/tags/go/posts?descendants=maybe",
+			skipMock: true,
+			expectedBody: getTagPosts.Response{
+				Status: "Bad Request",
+				Error:  "invalid descendants",
+			},
+		},
+		{
+			name:     "BlankTagName",
+			path:     "/tags/%20/posts",
+			skipMock: true,
+			expectedBody: getTagPosts.Response{
+				Status: "Bad Request",
+				Error:  "Invalid tag name",
+			},
+		},
+		{
+			name:         "InvalidCursor",
+			path:         "/tags/go/posts?cursor=garbage",
+			expectedTag:  "go",
+			expectedPage: database.Page{Cursor: "garbage"},
+			mockError:    fmt.Errorf("%w: malformed cursor", database.ErrInvalidPage),
+			expectedBody: getTagPosts.Response{
+				Status: "Bad Request",
+				Error:  "invalid page: malformed cursor",
+			},
+		},
+		{
+			name:        "ErrorGetTagPosts",
+			path:        "/tags/go/posts",
+			expectedTag: "go",
+			mockError:   errors.New("query error"),
+			expectedBody: getTagPosts.Response{
+				Status: "Bad Request",
+				Error:  "get tag posts failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.PostService)
+			if !tt.skipMock {
+				filter := database.PostFilter{Tags: []string{tt.expectedTag}, IncludeDescendants: tt.descendants}
+				mockService.On("GetALlPosts", filter, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			handler := getTagPosts.New(logger, mockService, new(mocks.ReactionService))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /tags/{name}/posts", handler)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+tt.path, nil)
+			assert.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var responseBody getTagPosts.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
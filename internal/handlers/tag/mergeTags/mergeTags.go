@@ -0,0 +1,74 @@
+package mergeTags
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request represents the merging tags request payload.
+// swagger:model
+type Request struct {
+	TargetID int `json:"target_id" validate:"required"`
+}
+
+// Response represents the merging tags response payload.
+// swagger:model
+type Response struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	TargetID int    `json:"target_id,omitempty"`
+}
+
+func New(log *slog.Logger, service database.TagsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Merge tags")
+
+		tagID, err := strconv.Atoi(r.PathValue("tagID"))
+		if err != nil {
+			log.Error("Invalid tag id", slog.String("tag_id", r.PathValue("tagID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid tag id")
+			return
+		}
+
+		var req Request
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		err = validator.New().Struct(req)
+		if err != nil {
+			log.Error("failed to validate request", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate request")
+			return
+		}
+
+		err = service.MergeTags(tagID, req.TargetID)
+		if err != nil {
+			log.Error("failed to merge tags", slog.Int("tag_id", tagID), slog.Int("target_id", req.TargetID), slog.String("error", err.Error()))
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				utils.SendError(w, "tag not found")
+			case errors.Is(err, database.ErrInvalidTag):
+				utils.SendError(w, err.Error())
+			default:
+				utils.SendError(w, "failed to merge tags")
+			}
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:   http.StatusText(http.StatusOK),
+			TargetID: req.TargetID,
+		})
+	}
+}
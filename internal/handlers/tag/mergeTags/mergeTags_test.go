@@ -0,0 +1,126 @@
+package mergeTags_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/tag/mergeTags"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestMergeTagsHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		tagID        string
+		requestBody  mergeTags.Request
+		skipMock     bool
+		mockError    error
+		expectedBody mergeTags.Response
+	}{
+		{
+			name:        "SuccessfulMergeTags",
+			tagID:       "1",
+			requestBody: mergeTags.Request{TargetID: 2},
+			expectedBody: mergeTags.Response{
+				Status:   "OK",
+				TargetID: 2,
+			},
+		},
+		{
+			name:        "InvalidTagID",
+			tagID:       "abc",
+			requestBody: mergeTags.Request{TargetID: 2},
+			skipMock:    true,
+			expectedBody: mergeTags.Response{
+				Status: "Bad Request",
+				Error:  "Invalid tag id",
+			},
+		},
+		{
+			name:        "MissingTarget",
+			tagID:       "1",
+			requestBody: mergeTags.Request{},
+			skipMock:    true,
+			expectedBody: mergeTags.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
+		{
+			name:        "MergeIntoItself",
+			tagID:       "1",
+			requestBody: mergeTags.Request{TargetID: 1},
+			mockError:   fmt.Errorf("%w: can not merge a tag into itself", database.ErrInvalidTag),
+			expectedBody: mergeTags.Response{
+				Status: "Bad Request",
+				Error:  "invalid tag: can not merge a tag into itself",
+			},
+		},
+		{
+			name:        "TagNotFound",
+			tagID:       "1",
+			requestBody: mergeTags.Request{TargetID: 3},
+			mockError:   pgx.ErrNoRows,
+			expectedBody: mergeTags.Response{
+				Status: "Bad Request",
+				Error:  "tag not found",
+			},
+		},
+		{
+			name:        "ErrorMergeTags",
+			tagID:       "1",
+			requestBody: mergeTags.Request{TargetID: 2},
+			mockError:   errors.New("tx error"),
+			expectedBody: mergeTags.Response{
+				Status: "Bad Request",
+				Error:  "failed to merge tags",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.TagsService)
+			if !tt.skipMock {
+				mockService.On("MergeTags", 1, tt.requestBody.TargetID).Return(tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			handler := mergeTags.New(logger, mockService)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /tags/{tagID}/merge", handler)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			requestBody, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, server.URL+"/tags/"+tt.tagID+"/merge", bytes.NewBuffer(requestBody))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var responseBody mergeTags.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
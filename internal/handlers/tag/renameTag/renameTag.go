@@ -0,0 +1,75 @@
+package renameTag
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request represents the renaming tag request payload.
+// swagger:model
+type Request struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// Response represents the renaming tag response payload.
+// swagger:model
+type Response struct {
+	Status string    `json:"status"`
+	Error  string    `json:"error,omitempty"`
+	Tag    views.Tag `json:"tag"`
+}
+
+func New(log *slog.Logger, service database.TagsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Rename tag")
+
+		tagID, err := strconv.Atoi(r.PathValue("tagID"))
+		if err != nil {
+			log.Error("Invalid tag id", slog.String("tag_id", r.PathValue("tagID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid tag id")
+			return
+		}
+
+		var req Request
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		err = validator.New().Struct(req)
+		if err != nil {
+			log.Error("failed to validate request", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate request")
+			return
+		}
+
+		tag, err := service.RenameTag(tagID, req.Name)
+		if err != nil {
+			log.Error("failed to rename tag", slog.Int("tag_id", tagID), slog.String("error", err.Error()))
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				utils.SendError(w, "tag not found")
+			case errors.Is(err, database.ErrInvalidTag), errors.Is(err, database.ErrTagExists):
+				utils.SendError(w, err.Error())
+			default:
+				utils.SendError(w, "failed to rename tag")
+			}
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			Tag:    views.NewTag(tag),
+		})
+	}
+}
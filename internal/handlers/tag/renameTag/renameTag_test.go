@@ -0,0 +1,130 @@
+package renameTag_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/tag/renameTag"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestRenameTagHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		tagID        string
+		requestBody  renameTag.Request
+		skipMock     bool
+		mockResponse database.TagsDTO
+		mockError    error
+		expectedBody renameTag.Response
+	}{
+		{
+			name:         "SuccessfulRenameTag",
+			tagID:        "1",
+			requestBody:  renameTag.Request{Name: "Golang"},
+			mockResponse: database.TagsDTO{Id: 1, Name: "Golang"},
+			expectedBody: renameTag.Response{
+				Status: "OK",
+				Tag:    views.Tag{Id: 1, Name: "Golang"},
+			},
+		},
+		{
+			name:        "InvalidTagID",
+			tagID:       "abc",
+			requestBody: renameTag.Request{Name: "Golang"},
+			skipMock:    true,
+			expectedBody: renameTag.Response{
+				Status: "Bad Request",
+				Error:  "Invalid tag id",
+			},
+		},
+		{
+			name:        "EmptyName",
+			tagID:       "1",
+			requestBody: renameTag.Request{},
+			skipMock:    true,
+			expectedBody: renameTag.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
+		{
+			name:        "TagNotFound",
+			tagID:       "2",
+			requestBody: renameTag.Request{Name: "Golang"},
+			mockError:   pgx.ErrNoRows,
+			expectedBody: renameTag.Response{
+				Status: "Bad Request",
+				Error:  "tag not found",
+			},
+		},
+		{
+			name:        "NameTaken",
+			tagID:       "1",
+			requestBody: renameTag.Request{Name: "Rust"},
+			mockError:   fmt.Errorf("%w: %q", database.ErrTagExists, "Rust"),
+			expectedBody: renameTag.Response{
+				Status: "Bad Request",
+				Error:  `tag already exists: "Rust"`,
+			},
+		},
+		{
+			name:        "ErrorRenameTag",
+			tagID:       "1",
+			requestBody: renameTag.Request{Name: "Golang"},
+			mockError:   errors.New("update error"),
+			expectedBody: renameTag.Response{
+				Status: "Bad Request",
+				Error:  "failed to rename tag",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.TagsService)
+			if !tt.skipMock {
+				mockService.On("RenameTag", mock.AnythingOfType("int"), tt.requestBody.Name).Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			handler := renameTag.New(logger, mockService)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PATCH /tags/{tagID}", handler)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			requestBody, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPatch, server.URL+"/tags/"+tt.tagID, bytes.NewBuffer(requestBody))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var responseBody renameTag.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
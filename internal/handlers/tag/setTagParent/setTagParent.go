@@ -0,0 +1,77 @@
+package setTagParent
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request represents the setting tag parent request payload.
+// A parent_id of 0 makes the tag a root tag.
+// swagger:model
+type Request struct {
+	ParentID int `json:"parent_id" validate:"gte=0"`
+}
+
+// Response represents the setting tag parent response payload.
+// swagger:model
+type Response struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	TagID    int    `json:"tag_id,omitempty"`
+	ParentID int    `json:"parent_id,omitempty"`
+}
+
+func New(log *slog.Logger, service database.TagsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Set tag parent")
+
+		tagID, err := strconv.Atoi(r.PathValue("tagID"))
+		if err != nil {
+			log.Error("Invalid tag id", slog.String("tag_id", r.PathValue("tagID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid tag id")
+			return
+		}
+
+		var req Request
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		err = validator.New().Struct(req)
+		if err != nil {
+			log.Error("failed to validate request", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate request")
+			return
+		}
+
+		err = service.SetTagParent(tagID, req.ParentID)
+		if err != nil {
+			log.Error("failed to set tag parent", slog.Int("tag_id", tagID), slog.Int("parent_id", req.ParentID), slog.String("error", err.Error()))
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				utils.SendError(w, "tag not found")
+			case errors.Is(err, database.ErrTagCycle):
+				utils.SendError(w, err.Error())
+			default:
+				utils.SendError(w, "failed to set tag parent")
+			}
+			return
+		}
+
+		utils.Send(w, Response{
+			Status:   http.StatusText(http.StatusOK),
+			TagID:    tagID,
+			ParentID: req.ParentID,
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package setTagParent_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/tag/setTagParent"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestSetTagParentHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		tagID        string
+		requestBody  setTagParent.Request
+		skipMock     bool
+		mockError    error
+		expectedBody setTagParent.Response
+	}{
+		{
+			name:        "SuccessfulSetTagParent",
+			tagID:       "2",
+			requestBody: setTagParent.Request{ParentID: 1},
+			expectedBody: setTagParent.Response{
+				Status:   "OK",
+				TagID:    2,
+				ParentID: 1,
+			},
+		},
+		{
+			name:        "ClearTagParent",
+			tagID:       "2",
+			requestBody: setTagParent.Request{ParentID: 0},
+			expectedBody: setTagParent.Response{
+				Status: "OK",
+				TagID:  2,
+			},
+		},
+		{
+			name:        "InvalidTagID",
+			tagID:       "abc",
+			requestBody: setTagParent.Request{ParentID: 1},
+			skipMock:    true,
+			expectedBody: setTagParent.Response{
+				Status: "Bad Request",
+				Error:  "Invalid tag id",
+			},
+		},
+		{
+			name:        "NegativeParentID",
+			tagID:       "2",
+			requestBody: setTagParent.Request{ParentID: -1},
+			skipMock:    true,
+			expectedBody: setTagParent.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
+		{
+			name:        "Cycle",
+			tagID:       "2",
+			requestBody: setTagParent.Request{ParentID: 3},
+			mockError:   fmt.Errorf("%w: tag 3 is a descendant of tag 2", database.ErrTagCycle),
+			expectedBody: setTagParent.Response{
+				Status: "Bad Request",
+				Error:  "tag hierarchy cycle: tag 3 is a descendant of tag 2",
+			},
+		},
+		{
+			name:        "TagNotFound",
+			tagID:       "2",
+			requestBody: setTagParent.Request{ParentID: 9},
+			mockError:   pgx.ErrNoRows,
+			expectedBody: setTagParent.Response{
+				Status: "Bad Request",
+				Error:  "tag not found",
+			},
+		},
+		{
+			name:        "ErrorSetTagParent",
+			tagID:       "2",
+			requestBody: setTagParent.Request{ParentID: 1},
+			mockError:   errors.New("tx error"),
+			expectedBody: setTagParent.Response{
+				Status: "Bad Request",
+				Error:  "failed to set tag parent",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.TagsService)
+			if !tt.skipMock {
+				mockService.On("SetTagParent", 2, tt.requestBody.ParentID).Return(tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			handler := setTagParent.New(logger, mockService)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /tags/{tagID}/parent", handler)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			requestBody, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPut, server.URL+"/tags/"+tt.tagID+"/parent", bytes.NewBuffer(requestBody))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var responseBody setTagParent.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
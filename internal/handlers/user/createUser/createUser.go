@@ -5,6 +5,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
 	"log/slog"
 	"net/http"
 )
@@ -20,9 +21,9 @@ type Request struct {
 // Response represents the creation user response payload.
 // swagger:model
 type Response struct {
-	Status string           `json:"status"`
-	Error  string           `json:"error,omitempty"`
-	User   database.UserDTO `json:"user"`
+	Status string         `json:"status"`
+	Error  string         `json:"error,omitempty"`
+	User   views.SelfUser `json:"user"`
 }
 
 func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
@@ -62,13 +63,7 @@ func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
 		//send response
 		utils.Send(w, Response{
 			Status: http.StatusText(http.StatusOK),
-			User: database.UserDTO{
-				Id:          createdUser.Id,
-				Username:    createdUser.Username,
-				Password:    createdUser.Password,
-				Description: createdUser.Description,
-				DateJoined:  createdUser.DateJoined,
-			},
+			User:   views.NewSelfUser(createdUser),
 		})
 	}
 }
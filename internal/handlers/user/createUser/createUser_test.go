@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/handlers/user/createUser"
+	"go-rest-api-auth/internal/views"
 	"go-rest-api-auth/testing/mocks"
 	"log/slog"
 	"net/http"
@@ -45,12 +46,13 @@ func TestCreateUserHandler(t *testing.T) {
 			expectedStatus: "OK",
 			expectedBody: createUser.Response{
 				Status: "OK",
-				User: database.UserDTO{
-					Id:          1,
-					Username:    "testuser",
-					Password:    "testpass",
-					Description: "test description",
-					DateJoined:  pgtype.Date{},
+				User: views.SelfUser{
+					PublicUser: views.PublicUser{
+						Id:          1,
+						Username:    "testuser",
+						Description: "test description",
+						DateJoined:  pgtype.Date{},
+					},
 				},
 			},
 		},
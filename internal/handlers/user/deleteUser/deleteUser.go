@@ -27,6 +27,21 @@ func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
 			return
 		}
 
+		callerID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			utils.SendError(w, "Invalid user context")
+			return
+		}
+		if callerID != userID {
+			caller, err := service.GetUserById(callerID)
+			if err != nil || caller.Role != database.RoleAdmin {
+				log.Error("only the user or an admin may change the account", slog.Int("user_id", userID), slog.Int("caller_id", callerID))
+				utils.SendError(w, "Forbidden")
+				return
+			}
+		}
+
 		_, err = service.GetUserById(userID)
 		if err != nil {
 			log.Error("User not found", slog.String("user_id", r.PathValue("userID")), slog.String("Error", err.Error()))
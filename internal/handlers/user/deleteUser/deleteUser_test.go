@@ -6,7 +6,6 @@ import (
 	"errors"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/handlers/user/deleteUser"
 	"go-rest-api-auth/testing/mocks"
@@ -14,6 +13,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"testing"
 )
 
@@ -21,6 +21,9 @@ func TestDeleteUserHandler(t *testing.T) {
 	tests := []struct {
 		name            string
 		userID          string
+		callerID        string
+		mockCaller      database.UserDTO
+		mockCallerError error
 		mockGetResponse database.UserDTO
 		mockGetError    error
 		mockDeleteError error
@@ -87,42 +90,76 @@ func TestDeleteUserHandler(t *testing.T) {
 				Error:  "Error deleting user",
 			},
 		},
+		{
+			name:            "AdminDeletesOtherUser",
+			userID:          "1",
+			callerID:        "5",
+			mockCaller:      database.UserDTO{Id: 5, Role: database.RoleAdmin},
+			mockGetResponse: database.UserDTO{Id: 1, Username: "testuser"},
+			expectedStatus:  "OK",
+			expectedBody: deleteUser.Response{
+				Status: "OK",
+				UserID: 1,
+			},
+		},
+		{
+			name:           "ForbiddenForOtherUser",
+			userID:         "1",
+			callerID:       "5",
+			mockCaller:     database.UserDTO{Id: 5, Role: database.RoleUser},
+			expectedStatus: "Bad Request",
+			expectedBody: deleteUser.Response{
+				Status: "Bad Request",
+				Error:  "Forbidden",
+			},
+		},
+		{
+			name:            "CallerLookupError",
+			userID:          "1",
+			callerID:        "5",
+			mockCallerError: errors.New("query error"),
+			expectedStatus:  "Bad Request",
+			expectedBody: deleteUser.Response{
+				Status: "Bad Request",
+				Error:  "Forbidden",
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			callerID := tt.callerID
+			if callerID == "" {
+				callerID = tt.userID
+			}
+
 			mockService := new(mocks.UserService)
+			if callerID != tt.userID {
+				id, _ := strconv.Atoi(callerID)
+				mockService.On("GetUserById", id).Return(tt.mockCaller, tt.mockCallerError)
+			}
+			forbidden := tt.expectedBody.Error == "Forbidden"
 			if tt.name == "UserNotFound" {
-				mockService.On("GetUserById", mock.AnythingOfType("int")).Return(tt.mockGetResponse, tt.mockGetError)
-			} else if tt.name != "InvalidUserID" {
-				mockService.On("GetUserById", mock.AnythingOfType("int")).Return(tt.mockGetResponse, tt.mockGetError)
-				mockService.On("DeleteUser", mock.AnythingOfType("int")).Return(tt.mockDeleteError)
+				mockService.On("GetUserById", 2).Return(tt.mockGetResponse, tt.mockGetError)
+			} else if tt.name != "InvalidUserID" && !forbidden {
+				mockService.On("GetUserById", 1).Return(tt.mockGetResponse, tt.mockGetError)
+				mockService.On("DeleteUser", 1).Return(tt.mockDeleteError)
 			}
 			defer mockService.AssertExpectations(t)
 
 			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 
-			handler := deleteUser.New(logger, mockService)
-
 			mux := http.NewServeMux()
-			mux.HandleFunc("DELETE /users/{userID}", handler)
-
-			server := httptest.NewServer(mux)
-			defer server.Close()
-
-			url := server.URL + "/users/" + tt.userID
+			mux.HandleFunc("DELETE /users/{userID}", deleteUser.New(logger, mockService))
 
-			req, err := http.NewRequest(http.MethodDelete, url, nil)
-			assert.NoError(t, err)
-
-			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+			req := httptest.NewRequest(http.MethodDelete, "/users/"+tt.userID, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", callerID))
+			w := httptest.NewRecorder()
 
-			resp, err := http.DefaultClient.Do(req)
-			assert.NoError(t, err)
-			defer resp.Body.Close()
+			mux.ServeHTTP(w, req)
 
 			var responseBody deleteUser.Response
-			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
 			assert.NoError(t, err)
 
 			assert.Equal(t, tt.expectedStatus, responseBody.Status)
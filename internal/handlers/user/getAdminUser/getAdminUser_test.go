@@ -0,0 +1,127 @@
+package getAdminUser_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/user/getAdminUser"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetAdminUserHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		mockResponse   database.UserDTO
+		mockError      error
+		expectedStatus string
+		expectedBody   getAdminUser.Response
+	}{
+		{
+			name:   "SuccessfulGetAdminUser",
+			userID: "1",
+			mockResponse: database.UserDTO{
+				Id:             1,
+				Username:       "testuser",
+				Password:       "testpass",
+				Description:    "test description",
+				DateJoined:     pgtype.Date{},
+				FollowerCount:  3,
+				FollowingCount: 5,
+				TokenVersion:   2,
+				Role:           database.RoleModerator,
+				Suspended:      true,
+			},
+			mockError:      nil,
+			expectedStatus: "OK",
+			expectedBody: getAdminUser.Response{
+				Status: "OK",
+				User: views.AdminUser{
+					SelfUser: views.SelfUser{
+						PublicUser: views.PublicUser{
+							Id:             1,
+							Username:       "testuser",
+							Description:    "test description",
+							DateJoined:     pgtype.Date{},
+							FollowerCount:  3,
+							FollowingCount: 5,
+						},
+						Role: database.RoleModerator,
+					},
+					TokenVersion: 2,
+					Suspended:    true,
+				},
+			},
+		},
+		{
+			name:           "InvalidUserID",
+			userID:         "abc", // Невалидный ID
+			mockResponse:   database.UserDTO{},
+			mockError:      nil,
+			expectedStatus: "Bad Request",
+			expectedBody: getAdminUser.Response{
+				Status: "Bad Request",
+				Error:  "Invalid user id",
+			},
+		},
+		{
+			name:           "UserNotFound",
+			userID:         "2",
+			mockResponse:   database.UserDTO{},
+			mockError:      errors.New("user not found"),
+			expectedStatus: "Bad Request",
+			expectedBody: getAdminUser.Response{
+				Status: "Bad Request",
+				Error:  "User not found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.UserService)
+			if tt.name != "InvalidUserID" {
+				mockService.On("GetUserById", mock.AnythingOfType("int")).Return(tt.mockResponse, tt.mockError)
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			handler := getAdminUser.New(logger, mockService)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /admin/users/{userID}", handler)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			url := server.URL + "/admin/users/" + tt.userID
+
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			assert.NoError(t, err)
+
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var responseBody getAdminUser.Response
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedStatus, responseBody.Status)
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
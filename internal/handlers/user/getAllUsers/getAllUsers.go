@@ -1,8 +1,10 @@
 package getAllUsers
 
 import (
+	"errors"
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
 	"log/slog"
 	"net/http"
 )
@@ -10,25 +12,64 @@ import (
 // Response represents the get all users response payload.
 // swagger:model
 type Response struct {
-	Status string             `json:"status"`
-	Error  string             `json:"error,omitempty"`
-	Users  []database.UserDTO `json:"usernames,omitempty"`
+	Status     string             `json:"status"`
+	Error      string             `json:"error,omitempty"`
+	Users      []views.PublicUser `json:"usernames,omitempty"`
+	NextCursor string             `json:"next_cursor,omitempty"`
 }
 
 func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Info("get all users")
 
-		users, err := service.GetALlUsers()
+		query := r.URL.Query()
+
+		limit, err := utils.QueryInt(query, "limit")
+		if err != nil {
+			log.Error("invalid limit", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid limit")
+			return
+		}
+		joinedFrom, err := utils.QueryTime(query, "joined_from")
+		if err != nil {
+			log.Error("invalid joined_from", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid joined_from")
+			return
+		}
+		joinedTo, err := utils.QueryTime(query, "joined_to")
+		if err != nil {
+			log.Error("invalid joined_to", slog.String("error", err.Error()))
+			utils.SendError(w, "invalid joined_to")
+			return
+		}
+
+		filter := database.UserFilter{
+			JoinedFrom:     joinedFrom,
+			JoinedTo:       joinedTo,
+			UsernamePrefix: query.Get("username_prefix"),
+		}
+		page := database.Page{
+			Limit:  limit,
+			Cursor: query.Get("cursor"),
+			Sort:   query.Get("sort"),
+			Order:  query.Get("order"),
+		}
+
+		users, nextCursor, err := service.GetALlUsers(filter, page)
 		if err != nil {
 			log.Error("get all users failed", slog.String("error", err.Error()))
+			if errors.Is(err, database.ErrInvalidPage) {
+				utils.SendError(w, err.Error())
+				return
+			}
 			utils.SendError(w, "get all users failed")
 			return
 		}
 
 		utils.Send(w, Response{
-			Status: http.StatusText(http.StatusOK),
-			Users:  users,
+			Status:     http.StatusText(http.StatusOK),
+			Users:      views.NewPublicUsers(users),
+			NextCursor: nextCursor,
 		})
 	}
 }
@@ -4,22 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/handlers/user/getAllUsers"
+	"go-rest-api-auth/internal/views"
 	"go-rest-api-auth/testing/mocks"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestGetAllUsersHandler(t *testing.T) {
 	tests := []struct {
 		name           string
+		query          string
+		skipMock       bool
+		expectedFilter database.UserFilter
+		expectedPage   database.Page
 		mockResponse   []database.UserDTO
+		mockCursor     string
 		mockError      error
 		expectedStatus string
 		expectedBody   getAllUsers.Response
@@ -46,24 +54,63 @@ func TestGetAllUsersHandler(t *testing.T) {
 			expectedStatus: "OK",
 			expectedBody: getAllUsers.Response{
 				Status: "OK",
-				Users: []database.UserDTO{
+				Users: []views.PublicUser{
 					{
 						Id:          1,
 						Username:    "testuser1",
-						Password:    "testpass1",
 						Description: "test description 1",
 						DateJoined:  pgtype.Date{},
 					},
 					{
 						Id:          2,
 						Username:    "testuser2",
-						Password:    "testpass2",
 						Description: "test description 2",
 						DateJoined:  pgtype.Date{},
 					},
 				},
 			},
 		},
+		{
+			name:  "FilteredPageWithNextCursor",
+			query: "?limit=1&cursor=abc&sort=username&order=desc&joined_from=2024-01-01&joined_to=2024-02-01&username_prefix=test",
+			expectedFilter: database.UserFilter{
+				JoinedFrom:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				JoinedTo:       time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				UsernamePrefix: "test",
+			},
+			expectedPage: database.Page{Limit: 1, Cursor: "abc", Sort: "username", Order: "desc"},
+			mockResponse: []database.UserDTO{
+				{Id: 2, Username: "testuser2", Password: "testpass2"},
+			},
+			mockCursor:     "next",
+			expectedStatus: "OK",
+			expectedBody: getAllUsers.Response{
+				Status:     "OK",
+				Users:      []views.PublicUser{{Id: 2, Username: "testuser2"}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name:           "InvalidJoinedTo",
+			query:          "?joined_to=tomorrow",
+			skipMock:       true,
+			expectedStatus: "Bad Request",
+			expectedBody: getAllUsers.Response{
+				Status: "Bad Request",
+				Error:  "invalid joined_to",
+			},
+		},
+		{
+			name:           "InvalidCursor",
+			query:          "?cursor=garbage",
+			expectedPage:   database.Page{Cursor: "garbage"},
+			mockError:      fmt.Errorf("%w: malformed cursor", database.ErrInvalidPage),
+			expectedStatus: "Bad Request",
+			expectedBody: getAllUsers.Response{
+				Status: "Bad Request",
+				Error:  "invalid page: malformed cursor",
+			},
+		},
 		{
 			name:           "GetAllUsersError",
 			mockResponse:   nil,
@@ -79,7 +126,9 @@ func TestGetAllUsersHandler(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := new(mocks.UserService)
-			mockService.On("GetALlUsers").Return(tt.mockResponse, tt.mockError)
+			if !tt.skipMock {
+				mockService.On("GetALlUsers", tt.expectedFilter, tt.expectedPage).Return(tt.mockResponse, tt.mockCursor, tt.mockError)
+			}
 			defer mockService.AssertExpectations(t)
 
 			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
@@ -92,7 +141,7 @@ func TestGetAllUsersHandler(t *testing.T) {
 			server := httptest.NewServer(mux)
 			defer server.Close()
 
-			url := server.URL + "/users"
+			url := server.URL + "/users" + tt.query
 
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			assert.NoError(t, err)
@@ -3,6 +3,7 @@ package getUser
 import (
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -13,7 +14,7 @@ import (
 type Response struct {
 	Status string           `json:"status"`
 	Error  string           `json:"error,omitempty"`
-	User   database.UserDTO `json:"user,omitempty"`
+	User   views.PublicUser `json:"user,omitempty"`
 }
 
 func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
@@ -36,7 +37,7 @@ func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
 
 		utils.Send(w, Response{
 			Status: http.StatusText(http.StatusOK),
-			User:   user,
+			User:   views.NewPublicUser(user),
 		})
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/handlers/user/getUser"
+	"go-rest-api-auth/internal/views"
 	"go-rest-api-auth/testing/mocks"
 	"log/slog"
 	"net/http"
@@ -30,22 +31,25 @@ func TestGetUserHandler(t *testing.T) {
 			name:   "SuccessfulGetUser",
 			userID: "1",
 			mockResponse: database.UserDTO{
-				Id:          1,
-				Username:    "testuser",
-				Password:    "testpass",
-				Description: "test description",
-				DateJoined:  pgtype.Date{},
+				Id:             1,
+				Username:       "testuser",
+				Password:       "testpass",
+				Description:    "test description",
+				DateJoined:     pgtype.Date{},
+				FollowerCount:  3,
+				FollowingCount: 5,
 			},
 			mockError:      nil,
 			expectedStatus: "OK",
 			expectedBody: getUser.Response{
 				Status: "OK",
-				User: database.UserDTO{
-					Id:          1,
-					Username:    "testuser",
-					Password:    "testpass",
-					Description: "test description",
-					DateJoined:  pgtype.Date{},
+				User: views.PublicUser{
+					Id:             1,
+					Username:       "testuser",
+					Description:    "test description",
+					DateJoined:     pgtype.Date{},
+					FollowerCount:  3,
+					FollowingCount: 5,
 				},
 			},
 		},
@@ -0,0 +1,84 @@
+package setUserRole
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request represents the set role request payload.
+// swagger:model
+type Request struct {
+	Role string `json:"role" validate:"required"`
+}
+
+// Response represents the set role response payload.
+// swagger:model
+type Response struct {
+	Status string          `json:"status"`
+	Error  string          `json:"error,omitempty"`
+	User   views.AdminUser `json:"user,omitempty"`
+}
+
+// New changes the role of a user. The tokens and sessions of the user are revoked with it,
+// so the previous role can not be used anymore.
+func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Info("Set user role")
+
+		userID, err := strconv.Atoi(r.PathValue("userID"))
+		if err != nil {
+			log.Error("Invalid user id", slog.String("user_id", r.PathValue("userID")), slog.String("error", err.Error()))
+			utils.SendError(w, "Invalid user id")
+			return
+		}
+
+		var req Request
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to decode request body")
+			return
+		}
+
+		err = validator.New().Struct(req)
+		if err != nil {
+			log.Error("failed to validate request", slog.String("error", err.Error()))
+			utils.SendError(w, "failed to validate request")
+			return
+		}
+
+		_, err = service.SetRole(userID, req.Role)
+		if err != nil {
+			log.Error("failed to set role", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			switch {
+			case errors.Is(err, database.ErrInvalidRole):
+				utils.SendError(w, err.Error())
+			case errors.Is(err, pgx.ErrNoRows):
+				utils.SendError(w, "User not found")
+			default:
+				utils.SendError(w, "failed to set role")
+			}
+			return
+		}
+
+		user, err := service.GetUserById(userID)
+		if err != nil {
+			log.Error("User not found", slog.Int("user_id", userID), slog.String("error", err.Error()))
+			utils.SendError(w, "User not found")
+			return
+		}
+
+		utils.Send(w, Response{
+			Status: http.StatusText(http.StatusOK),
+			User:   views.NewAdminUser(user),
+		})
+	}
+}
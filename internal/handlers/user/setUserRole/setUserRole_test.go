@@ -0,0 +1,124 @@
+package setUserRole_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/handlers/user/setUserRole"
+	"go-rest-api-auth/internal/views"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSetUserRoleHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		body         string
+		skipMock     bool
+		setRoleError error
+		expectedBody setUserRole.Response
+	}{
+		{
+			name:   "SuccessfulSetRole",
+			userID: "1",
+			body:   `{"role":"moderator"}`,
+			expectedBody: setUserRole.Response{
+				Status: "OK",
+				User: views.AdminUser{
+					SelfUser:     views.SelfUser{PublicUser: views.PublicUser{Id: 1, Username: "testuser"}, Role: database.RoleModerator},
+					TokenVersion: 3,
+				},
+			},
+		},
+		{
+			name:     "InvalidUserID",
+			userID:   "abc",
+			body:     `{"role":"moderator"}`,
+			skipMock: true,
+			expectedBody: setUserRole.Response{
+				Status: "Bad Request",
+				Error:  "Invalid user id",
+			},
+		},
+		{
+			name:     "EmptyRole",
+			userID:   "1",
+			body:     `{}`,
+			skipMock: true,
+			expectedBody: setUserRole.Response{
+				Status: "Bad Request",
+				Error:  "failed to validate request",
+			},
+		},
+		{
+			name:         "InvalidRole",
+			userID:       "1",
+			body:         `{"role":"owner"}`,
+			setRoleError: fmt.Errorf("%w: %q is not one of %v", database.ErrInvalidRole, "owner", database.Roles),
+			expectedBody: setUserRole.Response{
+				Status: "Bad Request",
+				Error:  `invalid role: "owner" is not one of [user moderator admin]`,
+			},
+		},
+		{
+			name:         "UserNotFound",
+			userID:       "1",
+			body:         `{"role":"moderator"}`,
+			setRoleError: pgx.ErrNoRows,
+			expectedBody: setUserRole.Response{
+				Status: "Bad Request",
+				Error:  "User not found",
+			},
+		},
+		{
+			name:         "ErrorSetRole",
+			userID:       "1",
+			body:         `{"role":"moderator"}`,
+			setRoleError: errors.New("query error"),
+			expectedBody: setUserRole.Response{
+				Status: "Bad Request",
+				Error:  "failed to set role",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.UserService)
+			if !tt.skipMock {
+				var role struct{ Role string }
+				assert.NoError(t, json.Unmarshal([]byte(tt.body), &role))
+				mockService.On("SetRole", 1, role.Role).Return(3, tt.setRoleError)
+				if tt.setRoleError == nil {
+					mockService.On("GetUserById", 1).Return(database.UserDTO{Id: 1, Username: "testuser", Role: role.Role, TokenVersion: 3}, nil)
+				}
+			}
+			defer mockService.AssertExpectations(t)
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /admin/users/{userID}/role", setUserRole.New(logger, mockService))
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/users/"+tt.userID+"/role", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			var responseBody setUserRole.Response
+			err := json.NewDecoder(w.Result().Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/utils"
+	"go-rest-api-auth/internal/views"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -14,7 +15,6 @@ import (
 // swagger:model
 type Request struct {
 	Username    string `json:"username"`
-	Password    string `json:"password"`
 	Description string `json:"description"`
 }
 
@@ -23,7 +23,7 @@ type Request struct {
 type Response struct {
 	Status string           `json:"status"`
 	Error  string           `json:"error,omitempty"`
-	User   database.UserDTO `json:"user"`
+	User   views.PublicUser `json:"user"`
 }
 
 func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
@@ -37,6 +37,21 @@ func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
 			return
 		}
 
+		callerID, ok := utils.ContextUserID(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			utils.SendError(w, "Invalid user context")
+			return
+		}
+		if callerID != userID {
+			caller, err := service.GetUserById(callerID)
+			if err != nil || caller.Role != database.RoleAdmin {
+				log.Error("only the user or an admin may change the account", slog.Int("user_id", userID), slog.Int("caller_id", callerID))
+				utils.SendError(w, "Forbidden")
+				return
+			}
+		}
+
 		user, err := service.GetUserById(userID)
 		if err != nil {
 			log.Error("User not found", slog.String("user_id", r.PathValue("userID")), slog.String("Error", err.Error()))
@@ -54,7 +69,7 @@ func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
 		}
 
 		//validating request body info
-		if req.Username == "" && req.Password == "" && req.Description == "" {
+		if req.Username == "" && req.Description == "" {
 			log.Error("Empty request data")
 			utils.SendError(w, "Empty request data")
 			return
@@ -70,7 +85,6 @@ func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
 		userDto := database.UserDTO{
 			Id:          userID,
 			Username:    req.Username,
-			Password:    req.Password,
 			Description: req.Description,
 		}
 		err = service.UpdateUser(userDto)
@@ -80,15 +94,11 @@ func New(log *slog.Logger, service database.UserService) http.HandlerFunc {
 			return
 		}
 
+		user.Username = utils.CoalesceString(req.Username, user.Username)
+		user.Description = utils.CoalesceString(req.Description, user.Description)
 		utils.Send(w, Response{
 			Status: http.StatusText(http.StatusOK),
-			User: database.UserDTO{
-				Id:          userID,
-				Username:    utils.CoalesceString(req.Username, user.Username),
-				Password:    utils.CoalesceString(req.Password, user.Password),
-				Description: utils.CoalesceString(req.Description, user.Description),
-				DateJoined:  user.DateJoined,
-			},
+			User:   views.NewPublicUser(user),
 		})
 	}
 }
@@ -10,11 +10,13 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/handlers/user/updateUser"
+	"go-rest-api-auth/internal/views"
 	"go-rest-api-auth/testing/mocks"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"testing"
 )
 
@@ -22,6 +24,9 @@ func TestUpdateUserHandler(t *testing.T) {
 	tests := []struct {
 		name            string
 		userID          string
+		callerID        string
+		mockCaller      database.UserDTO
+		mockCallerError error
 		requestBody     updateUser.Request
 		mockGetResponse database.UserDTO
 		mockGetError    error
@@ -34,27 +39,31 @@ func TestUpdateUserHandler(t *testing.T) {
 			userID: "1",
 			requestBody: updateUser.Request{
 				Username:    "updateduser",
-				Password:    "updatedpass",
 				Description: "updated description",
 			},
 			mockGetResponse: database.UserDTO{
-				Id:          1,
-				Username:    "testuser",
-				Password:    "testpass",
-				Description: "test description",
-				DateJoined:  pgtype.Date{},
+				Id:             1,
+				Username:       "testuser",
+				Password:       "testpass",
+				Description:    "test description",
+				DateJoined:     pgtype.Date{},
+				Avatar:         "avatar-id",
+				FollowerCount:  3,
+				FollowingCount: 5,
 			},
 			mockGetError:    nil,
 			mockUpdateError: nil,
 			expectedStatus:  "OK",
 			expectedBody: updateUser.Response{
 				Status: "OK",
-				User: database.UserDTO{
-					Id:          1,
-					Username:    "updateduser",
-					Password:    "updatedpass",
-					Description: "updated description",
-					DateJoined:  pgtype.Date{},
+				User: views.PublicUser{
+					Id:             1,
+					Username:       "updateduser",
+					Description:    "updated description",
+					DateJoined:     pgtype.Date{},
+					AvatarURL:      views.AvatarPath + "avatar-id",
+					FollowerCount:  3,
+					FollowingCount: 5,
 				},
 			},
 		},
@@ -76,7 +85,6 @@ func TestUpdateUserHandler(t *testing.T) {
 			userID: "2",
 			requestBody: updateUser.Request{
 				Username:    "updateduser",
-				Password:    "updatedpass",
 				Description: "updated description",
 			},
 			mockGetResponse: database.UserDTO{},
@@ -93,7 +101,6 @@ func TestUpdateUserHandler(t *testing.T) {
 			userID: "1",
 			requestBody: updateUser.Request{
 				Username:    "updateduser",
-				Password:    "updatedpass",
 				Description: "updated description",
 			},
 			mockGetResponse: database.UserDTO{
@@ -111,46 +118,80 @@ func TestUpdateUserHandler(t *testing.T) {
 				Error:  "failed to update user",
 			},
 		},
+		{
+			name:     "AdminUpdatesOtherUser",
+			userID:   "1",
+			callerID: "5",
+			requestBody: updateUser.Request{
+				Username:    "updateduser",
+				Description: "updated description",
+			},
+			mockCaller:      database.UserDTO{Id: 5, Role: database.RoleAdmin},
+			mockGetResponse: database.UserDTO{Id: 1, Username: "testuser"},
+			expectedStatus:  "OK",
+			expectedBody: updateUser.Response{
+				Status: "OK",
+				User: views.PublicUser{
+					Id:          1,
+					Username:    "updateduser",
+					Description: "updated description",
+				},
+			},
+		},
+		{
+			name:     "ForbiddenForOtherUser",
+			userID:   "1",
+			callerID: "5",
+			requestBody: updateUser.Request{
+				Username: "updateduser",
+			},
+			mockCaller:     database.UserDTO{Id: 5, Role: database.RoleUser},
+			expectedStatus: "Bad Request",
+			expectedBody: updateUser.Response{
+				Status: "Bad Request",
+				Error:  "Forbidden",
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			callerID := tt.callerID
+			if callerID == "" {
+				callerID = tt.userID
+			}
+
 			mockService := new(mocks.UserService)
+			if callerID != tt.userID {
+				id, _ := strconv.Atoi(callerID)
+				mockService.On("GetUserById", id).Return(tt.mockCaller, tt.mockCallerError)
+			}
+			forbidden := tt.expectedBody.Error == "Forbidden"
 			if tt.name == "UserNotFound" {
-				mockService.On("GetUserById", mock.AnythingOfType("int")).Return(tt.mockGetResponse, tt.mockGetError)
-			} else if tt.name != "InvalidUserID" {
-				mockService.On("GetUserById", mock.AnythingOfType("int")).Return(tt.mockGetResponse, tt.mockGetError)
+				mockService.On("GetUserById", 2).Return(tt.mockGetResponse, tt.mockGetError)
+			} else if tt.name != "InvalidUserID" && !forbidden {
+				mockService.On("GetUserById", 1).Return(tt.mockGetResponse, tt.mockGetError)
 				mockService.On("UpdateUser", mock.AnythingOfType("database.UserDTO")).Return(tt.mockUpdateError)
 			}
 			defer mockService.AssertExpectations(t)
 
 			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 
-			handler := updateUser.New(logger, mockService)
-
 			mux := http.NewServeMux()
-			mux.HandleFunc("PUT /users/{userID}", handler)
-
-			server := httptest.NewServer(mux)
-			defer server.Close()
-
-			url := server.URL + "/users/" + tt.userID
+			mux.HandleFunc("PUT /users/{userID}", updateUser.New(logger, mockService))
 
 			requestBody, err := json.Marshal(tt.requestBody)
 			assert.NoError(t, err)
 
-			req, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(requestBody))
-			assert.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPut, "/users/"+tt.userID, bytes.NewBuffer(requestBody))
 			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", callerID))
+			w := httptest.NewRecorder()
 
-			req = req.WithContext(context.WithValue(req.Context(), "user_id", "123"))
-
-			resp, err := http.DefaultClient.Do(req)
-			assert.NoError(t, err)
-			defer resp.Body.Close()
+			mux.ServeHTTP(w, req)
 
 			var responseBody updateUser.Response
-			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			err = json.NewDecoder(w.Result().Body).Decode(&responseBody)
 			assert.NoError(t, err)
 
 			assert.Equal(t, tt.expectedStatus, responseBody.Status)
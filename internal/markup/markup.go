@@ -0,0 +1,113 @@
+// Package markup renders post contents to HTML that is safe to embed in a page,
+// together with the plain text figures derived from it.
+package markup
+
+import (
+	"errors"
+	"fmt"
+	"github.com/russross/blackfriday/v2"
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	FormatPlain    = "plain"
+	FormatMarkdown = "markdown"
+
+	// ExcerptLength is the maximum length of an excerpt in characters, without the ellipsis.
+	ExcerptLength = 200
+	// WordsPerMinute is the reading speed reading times are estimated with.
+	WordsPerMinute = 200
+)
+
+// ErrInvalidFormat is returned for content formats other than FormatPlain and FormatMarkdown.
+var ErrInvalidFormat = errors.New("invalid content format")
+
+// Rendered is a content rendered to sanitized HTML. Excerpt is the beginning of its text,
+// ReadingTime is in minutes and at least 1 for any content with words.
+type Rendered struct {
+	HTML        string
+	Excerpt     string
+	WordCount   int
+	ReadingTime int
+}
+
+const markdownExtensions = blackfriday.NoIntraEmphasis | blackfriday.Tables | blackfriday.FencedCode |
+	blackfriday.Autolink | blackfriday.Strikethrough | blackfriday.SpaceHeadings |
+	blackfriday.BackslashLineBreak | blackfriday.DefinitionLists
+
+// Render renders content of the given format, an empty format is FormatPlain.
+// Plain text is escaped and split into paragraphs on blank lines, Markdown is rendered
+// and then sanitized, so raw HTML in it only keeps the allowed elements.
+func Render(format, content string) (Rendered, error) {
+	if err := ValidateFormat(format); err != nil {
+		return Rendered{}, err
+	}
+
+	var rendered string
+	var text string
+	if format == FormatMarkdown {
+		output := blackfriday.Run([]byte(content), blackfriday.WithExtensions(markdownExtensions))
+		rendered, text = sanitize(string(output))
+	} else {
+		rendered = renderPlain(content)
+		text = content
+	}
+
+	words := strings.Fields(text)
+	return Rendered{
+		HTML:        rendered,
+		Excerpt:     excerpt(words),
+		WordCount:   len(words),
+		ReadingTime: (len(words) + WordsPerMinute - 1) / WordsPerMinute,
+	}, nil
+}
+
+// ValidateFormat returns ErrInvalidFormat for unknown formats, an empty format is FormatPlain.
+func ValidateFormat(format string) error {
+	if format != "" && format != FormatPlain && format != FormatMarkdown {
+		return fmt.Errorf("%w: %q is neither %s nor %s", ErrInvalidFormat, format, FormatPlain, FormatMarkdown)
+	}
+	return nil
+}
+
+func renderPlain(content string) string {
+	var b strings.Builder
+	for _, paragraph := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n\n") {
+		paragraph = strings.Trim(paragraph, "\n")
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>\n"))
+		b.WriteString("</p>\n")
+	}
+	return b.String()
+}
+
+// excerpt joins words up to ExcerptLength characters, cutting between words.
+// A single word longer than that is cut inside.
+func excerpt(words []string) string {
+	var b strings.Builder
+	length := 0
+	for i, word := range words {
+		wordLength := utf8.RuneCountInString(word)
+		if i > 0 {
+			wordLength++
+		}
+		if length+wordLength > ExcerptLength {
+			if i == 0 {
+				b.WriteString(string([]rune(word)[:ExcerptLength]))
+			}
+			b.WriteString("…")
+			break
+		}
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(word)
+		length += wordLength
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package markup
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		content  string
+		expected Rendered
+	}{
+		{
+			name:    "Plain",
+			format:  FormatPlain,
+			content: "Hello <world> & co\nsecond line\n\n\nnext paragraph",
+			expected: Rendered{
+				HTML:        "<p>Hello &lt;world&gt; &amp; co<br>\nsecond line</p>\n<p>next paragraph</p>\n",
+				Excerpt:     "Hello <world> & co second line next paragraph",
+				WordCount:   8,
+				ReadingTime: 1,
+			},
+		},
+		{
+			name:     "EmptyFormatIsPlain",
+			content:  "text",
+			expected: Rendered{HTML: "<p>text</p>\n", Excerpt: "text", WordCount: 1, ReadingTime: 1},
+		},
+		{
+			name:     "Empty",
+			format:   FormatMarkdown,
+			expected: Rendered{},
+		},
+		{
+			name:    "Markdown",
+			format:  FormatMarkdown,
+			content: "# Title\n\nSome *emphasis* and [a link](https://example.com \"site\").\n\n```\ncode <b>\n```\n",
+			expected: Rendered{
+				HTML: "<h1>Title</h1>\n\n<p>Some <em>emphasis</em> and " +
+					`<a href="https://example.com" title="site" rel="nofollow noopener noreferrer">a link</a>.</p>` +
+					"\n\n<pre><code>code &lt;b&gt;\n</code></pre>\n",
+				Excerpt:     "Title Some emphasis and a link. code <b>",
+				WordCount:   8,
+				ReadingTime: 1,
+			},
+		},
+		{
+			name:    "MarkdownRawHTML",
+			format:  FormatMarkdown,
+			content: "<div onclick=\"steal()\">hi<script>alert(1)</script></div>\n\n[x](javascript:void) ![y](data:image/png;base64,AAAA)",
+			expected: Rendered{
+				HTML:        "hi\n\n<p><a rel=\"nofollow noopener noreferrer\">x</a> <img alt=\"y\"></p>\n",
+				Excerpt:     "hi x",
+				WordCount:   2,
+				ReadingTime: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := Render(tt.format, tt.content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rendered)
+		})
+	}
+}
+
+func TestRenderInvalidFormat(t *testing.T) {
+	_, err := Render("html", "<p>hi</p>")
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+}
+
+func TestRenderExcerptAndReadingTime(t *testing.T) {
+	content := strings.Repeat("word ", 401)
+	rendered, err := Render(FormatPlain, content)
+	require.NoError(t, err)
+
+	assert.Equal(t, 401, rendered.WordCount)
+	assert.Equal(t, 3, rendered.ReadingTime)
+	assert.True(t, strings.HasSuffix(rendered.Excerpt, "…"))
+	assert.Equal(t, strings.Repeat("word ", 39)+"word…", rendered.Excerpt)
+
+	long := strings.Repeat("x", ExcerptLength+10)
+	rendered, err = Render(FormatPlain, long)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("x", ExcerptLength)+"…", rendered.Excerpt)
+}
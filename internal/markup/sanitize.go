@@ -0,0 +1,158 @@
+package markup
+
+import (
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// allowedElements maps every element kept by sanitize to its allowed attributes.
+var allowedElements = map[atom.Atom][]string{
+	atom.P: nil, atom.Br: nil, atom.Hr: nil,
+	atom.H1: nil, atom.H2: nil, atom.H3: nil, atom.H4: nil, atom.H5: nil, atom.H6: nil,
+	atom.Blockquote: nil, atom.Pre: nil, atom.Code: nil,
+	atom.Em: nil, atom.Strong: nil, atom.Del: nil, atom.Sup: nil, atom.Sub: nil,
+	atom.Ul: nil, atom.Ol: {"start"}, atom.Li: nil,
+	atom.Dl: nil, atom.Dt: nil, atom.Dd: nil,
+	atom.Table: nil, atom.Thead: nil, atom.Tbody: nil, atom.Tr: nil,
+	atom.Th: {"align"}, atom.Td: {"align"},
+	atom.A:   {"href", "title"},
+	atom.Img: {"src", "alt", "title"},
+}
+
+// droppedElements are removed together with everything inside them.
+var droppedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true,
+	atom.Noscript: true, atom.Template: true, atom.Textarea: true, atom.Title: true,
+}
+
+// inlineElements do not separate words in the text content.
+var inlineElements = map[atom.Atom]bool{
+	atom.A: true, atom.Em: true, atom.Strong: true, atom.Del: true, atom.Code: true, atom.Sup: true, atom.Sub: true,
+}
+
+// voidElements have no end tag.
+var voidElements = map[atom.Atom]bool{
+	atom.Br: true, atom.Hr: true, atom.Img: true,
+}
+
+// sanitize keeps the allowed elements and attributes of fragment and escapes everything
+// else, the text of elements that are not allowed is kept. Links only keep http, https,
+// mailto and relative URLs and images only http, https and relative ones. Every opened
+// element is closed, so the result can not break the page it is embedded in.
+// The text content is returned as well, with blocks separated by a space.
+func sanitize(fragment string) (string, string) {
+	var out, text strings.Builder
+	var open []atom.Atom
+	dropping := atom.Atom(0)
+	depth := 0
+
+	tokenizer := html.NewTokenizer(strings.NewReader(fragment))
+	for {
+		kind := tokenizer.Next()
+		if kind == html.ErrorToken {
+			break
+		}
+		token := tokenizer.Token()
+
+		if dropping != 0 {
+			switch {
+			case kind == html.StartTagToken && token.DataAtom == dropping:
+				depth++
+			case kind == html.EndTagToken && token.DataAtom == dropping:
+				depth--
+				if depth == 0 {
+					dropping = 0
+				}
+			}
+			continue
+		}
+
+		switch kind {
+		case html.TextToken:
+			out.WriteString(html.EscapeString(token.Data))
+			text.WriteString(token.Data)
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedElements[token.DataAtom] {
+				if kind == html.StartTagToken {
+					dropping, depth = token.DataAtom, 1
+				}
+				continue
+			}
+			allowed, ok := allowedElements[token.DataAtom]
+			if !ok {
+				continue
+			}
+			writeStartTag(&out, token, allowed)
+			if voidElements[token.DataAtom] {
+				text.WriteByte(' ')
+				continue
+			}
+			if kind == html.SelfClosingTagToken {
+				out.WriteString("</" + token.DataAtom.String() + ">")
+				continue
+			}
+			open = append(open, token.DataAtom)
+		case html.EndTagToken:
+			// close up to the matching element, end tags of elements that are not open are dropped
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != token.DataAtom {
+					continue
+				}
+				for j := len(open) - 1; j >= i; j-- {
+					out.WriteString("</" + open[j].String() + ">")
+				}
+				open = open[:i]
+				if !inlineElements[token.DataAtom] {
+					text.WriteByte(' ')
+				}
+				break
+			}
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i].String() + ">")
+	}
+	return out.String(), text.String()
+}
+
+func writeStartTag(out *strings.Builder, token html.Token, allowed []string) {
+	out.WriteString("<" + token.DataAtom.String())
+	for _, attr := range token.Attr {
+		if attr.Namespace != "" || !slices.Contains(allowed, attr.Key) {
+			continue
+		}
+		value := attr.Val
+		if attr.Key == "href" || attr.Key == "src" {
+			var ok bool
+			if value, ok = safeURL(value, attr.Key == "href"); !ok {
+				continue
+			}
+		}
+		out.WriteString(" " + attr.Key + `="` + html.EscapeString(value) + `"`)
+	}
+	if token.DataAtom == atom.A {
+		out.WriteString(` rel="nofollow noopener noreferrer"`)
+	}
+	out.WriteString(">")
+}
+
+// safeURL returns the URL when its scheme is allowed, mailto only for links. The tokenizer
+// already decoded character references, and URLs with control characters fail to parse.
+func safeURL(value string, link bool) (string, bool) {
+	value = strings.TrimSpace(value)
+	u, err := url.Parse(value)
+	if err != nil {
+		return "", false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https":
+		return value, true
+	case "mailto":
+		return value, link
+	}
+	return "", false
+}
@@ -0,0 +1,71 @@
+package markup
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name         string
+		fragment     string
+		expectedHTML string
+		expectedText string
+	}{
+		{
+			name:         "AllowedElements",
+			fragment:     `<p>a <strong>b</strong> <code>c</code></p><ol start="3" class="x"><li>d</li></ol>`,
+			expectedHTML: `<p>a <strong>b</strong> <code>c</code></p><ol start="3"><li>d</li></ol>`,
+			expectedText: "a b c d  ",
+		},
+		{
+			name:         "UnknownElementsKeepText",
+			fragment:     `<div><span style="color:red">red</span></div><form><input value="x">send</form>`,
+			expectedHTML: `redsend`,
+			expectedText: "redsend",
+		},
+		{
+			name:         "DroppedElements",
+			fragment:     `a<script>alert("<p>")</script>b<style>p{}</style>c<iframe src="x"><p>d</p></iframe>e`,
+			expectedHTML: `abce`,
+			expectedText: "abce",
+		},
+		{
+			name:         "EventHandlersAndStyles",
+			fragment:     `<p onclick="x()" style="display:none">t</p><img src="/a.png" onerror="x()">`,
+			expectedHTML: `<p>t</p><img src="/a.png">`,
+			expectedText: "t  ",
+		},
+		{
+			name: "URLs",
+			fragment: `<a href="https://a.b/c?d=1&amp;e=2">1</a><a href="mailto:x@y.z">2</a>` +
+				`<a href="javascript:alert(1)">3</a><a href="jav&#x09;ascript:alert(1)">4</a><a href=" JAVASCRIPT:x">5</a>` +
+				`<img src="mailto:x@y.z"><img src="data:image/png;base64,AA"><img src="//cdn.example/i.png">`,
+			expectedHTML: `<a href="https://a.b/c?d=1&amp;e=2" rel="nofollow noopener noreferrer">1</a>` +
+				`<a href="mailto:x@y.z" rel="nofollow noopener noreferrer">2</a>` +
+				`<a rel="nofollow noopener noreferrer">3</a><a rel="nofollow noopener noreferrer">4</a>` +
+				`<a rel="nofollow noopener noreferrer">5</a><img><img><img src="//cdn.example/i.png">`,
+			expectedText: "12345   ",
+		},
+		{
+			name:         "UnbalancedTags",
+			fragment:     `<p><em>open</p></strong><blockquote>quote`,
+			expectedHTML: `<p><em>open</em></p><blockquote>quote</blockquote>`,
+			expectedText: "open quote",
+		},
+		{
+			name:         "EscapesText",
+			fragment:     `1 &lt; 2 &amp;&amp; "q" <!-- comment -->`,
+			expectedHTML: `1 &lt; 2 &amp;&amp; &#34;q&#34; `,
+			expectedText: `1 < 2 && "q" `,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, text := sanitize(tt.fragment)
+			assert.Equal(t, tt.expectedHTML, html)
+			assert.Equal(t, tt.expectedText, text)
+		})
+	}
+}
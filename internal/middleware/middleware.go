@@ -3,10 +3,12 @@ package middleware
 import (
 	"context"
 	"errors"
+	"go-rest-api-auth/internal/database"
 	"go-rest-api-auth/internal/database/auth"
 	"go-rest-api-auth/internal/utils"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -87,7 +89,7 @@ func TestAuthMiddleware(log *slog.Logger) func(next http.Handler) http.Handler {
 	}
 }
 
-func JWTAuthMiddleware(log *slog.Logger, tokenManager auth.JwtManager) func(next http.Handler) http.Handler {
+func JWTAuthMiddleware(log *slog.Logger, tokenManager auth.JwtManager, userService database.UserService) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		log = log.With(slog.String("component", "middleware/JWTAuthMiddleware"))
 		log.Info("JWT Auth middleware enabled")
@@ -107,6 +109,30 @@ func JWTAuthMiddleware(log *slog.Logger, tokenManager auth.JwtManager) func(next
 				return
 			}
 
+			userID, err := strconv.Atoi(accessTokenClaims["sub"].(string))
+			if err != nil {
+				utils.SendError(w, "Invalid user id in token")
+				return
+			}
+
+			tokenVersion, err := auth.ClaimsTokenVersion(accessTokenClaims)
+			if err != nil {
+				utils.SendError(w, err.Error())
+				return
+			}
+
+			currentTokenVersion, err := userService.GetTokenVersion(userID)
+			if err != nil {
+				log.Error("failed to get user token version", slog.Int("user_id", userID), slog.String("error", err.Error()))
+				utils.SendError(w, "Unauthorized user")
+				return
+			}
+
+			if tokenVersion != currentTokenVersion {
+				utils.SendError(w, "Token has been revoked")
+				return
+			}
+
 			ctx := context.WithValue(r.Context(), "user_id", accessTokenClaims["sub"].(string))
 			next.ServeHTTP(w, r.WithContext(ctx))
 		}
@@ -115,20 +141,20 @@ func JWTAuthMiddleware(log *slog.Logger, tokenManager auth.JwtManager) func(next
 	}
 }
 
-func SessionAuthMiddleware(log *slog.Logger, sessionManager auth.SessionManager) func(next http.Handler) http.Handler {
+func SessionAuthMiddleware(log *slog.Logger, sessionManager auth.SessionManager, sessionCookie *auth.SessionCookie, userService database.UserService) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		log = log.With(slog.String("component", "middleware/SessionAuthMiddleware"))
 		log.Info("Session Auth middleware enabled")
 
 		fn := func(w http.ResponseWriter, r *http.Request) {
-			cookie, err := r.Cookie("session_id")
+			cookie, err := r.Cookie(sessionCookie.Name())
 			if err != nil {
 				utils.SendError(w, "Unauthorized cookies")
 				return
 			}
 
 			sessionID := cookie.Value
-			userID, err := sessionManager.GetUserIdBySession(sessionID)
+			session, err := sessionManager.GetSession(sessionID)
 			if err != nil {
 				if errors.Is(err, sessionManager.GetterErrSessionNotFound()) {
 					utils.SendError(w, "Session not found")
@@ -139,10 +165,70 @@ func SessionAuthMiddleware(log *slog.Logger, sessionManager auth.SessionManager)
 				}
 			}
 
-			ctx := context.WithValue(r.Context(), "user_id", userID)
+			userID, err := strconv.Atoi(session.UserID)
+			if err != nil {
+				utils.SendError(w, "Invalid user id in session")
+				return
+			}
+
+			currentTokenVersion, err := userService.GetTokenVersion(userID)
+			if err != nil {
+				log.Error("failed to get user token version", slog.Int("user_id", userID), slog.String("error", err.Error()))
+				utils.SendError(w, "Unauthorized user")
+				return
+			}
+
+			if session.TokenVersion != currentTokenVersion {
+				if err = sessionManager.DeleteSession(sessionID); err != nil {
+					log.Error("failed to delete revoked session", slog.String("error", err.Error()))
+				}
+				utils.SendError(w, "Session has been revoked")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), "user_id", session.UserID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		}
 
 		return http.HandlerFunc(fn)
 	}
 }
+
+// RequireRoleMiddleware only lets through users with one of the given roles. It reads the user set by
+// JWTAuthMiddleware or SessionAuthMiddleware, so it has to be stacked after one of them.
+func RequireRoleMiddleware(log *slog.Logger, userService database.UserService, roles ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		roleLog := log.With(slog.String("component", "middleware/RequireRoleMiddleware"), slog.String("role", strings.Join(roles, ",")))
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			userIDValue, ok := r.Context().Value("user_id").(string)
+			if !ok {
+				utils.SendError(w, "Unauthorized user")
+				return
+			}
+
+			userID, err := strconv.Atoi(userIDValue)
+			if err != nil {
+				utils.SendError(w, "Invalid user id")
+				return
+			}
+
+			user, err := userService.GetUserById(userID)
+			if err != nil {
+				roleLog.Error("failed to get user", slog.Int("user_id", userID), slog.String("error", err.Error()))
+				utils.SendError(w, "Unauthorized user")
+				return
+			}
+
+			if !slices.Contains(roles, user.Role) {
+				roleLog.Info("forbidden", slog.Int("user_id", userID), slog.String("user_role", user.Role))
+				utils.SendError(w, "Forbidden")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,113 @@
+// Package slug turns post titles into the readable identifiers used in permalinks.
+package slug
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+// MaxLength is the maximum length of a slug in bytes, generated slugs are cut at a
+// word boundary to leave room for the suffix telling apart posts with the same title.
+const (
+	MaxLength       = 100
+	generatedLength = 80
+)
+
+// Fallback is the slug of titles without any letter or digit that can be transliterated.
+const Fallback = "post"
+
+// ErrInvalidSlug is returned for custom slugs not in the form Make produces.
+var ErrInvalidSlug = errors.New("invalid slug")
+
+// transliterations spell letters without a decomposition into a latin letter and diacritics.
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'þ': "th", 'ł': "l", 'ı': "i", 'ħ': "h",
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+}
+
+// Make returns the slug of a title: its words transliterated to lowercase latin letters
+// and digits, joined by hyphens. Letters of other scripts are dropped.
+func Make(title string) string {
+	var b strings.Builder
+	hyphen := false
+	write := func(part string) {
+		if hyphen {
+			b.WriteByte('-')
+			hyphen = false
+		}
+		b.WriteString(part)
+	}
+
+	for _, r := range strings.ToLower(title) {
+		if part, ok := transliterations[r]; ok {
+			if part != "" {
+				write(part)
+			}
+			continue
+		}
+		if r == '\'' || r == '’' {
+			// keeps "don't" a single word
+			continue
+		}
+
+		// splits letters from their diacritics and spells out ligatures and the like
+		for _, d := range norm.NFKD.String(string(r)) {
+			switch {
+			case d >= 'a' && d <= 'z' || d >= '0' && d <= '9':
+				write(string(d))
+			case unicode.Is(unicode.Mn, d):
+			default:
+				hyphen = b.Len() > 0
+			}
+		}
+	}
+
+	slug := truncate(b.String(), generatedLength)
+	if slug == "" {
+		return Fallback
+	}
+	return slug
+}
+
+// WithSuffix returns the n-th candidate for a slug already taken, n starting at 2.
+func WithSuffix(slug string, n int) string {
+	suffix := fmt.Sprintf("-%d", n)
+	return truncate(slug, MaxLength-len(suffix)) + suffix
+}
+
+// Validate checks that a custom slug is made of lowercase latin letters and digits
+// in words separated by single hyphens, like the ones Make returns.
+func Validate(slug string) error {
+	if slug == "" || len(slug) > MaxLength {
+		return fmt.Errorf("%w: must be 1 to %d characters long", ErrInvalidSlug, MaxLength)
+	}
+
+	for i, r := range slug {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+		case r == '-' && i > 0 && i < len(slug)-1 && slug[i-1] != '-':
+		default:
+			return fmt.Errorf("%w: only lowercase letters, digits and single hyphens between them are allowed", ErrInvalidSlug)
+		}
+	}
+
+	return nil
+}
+
+// truncate cuts a slug to at most length bytes, at the last hyphen when there is one.
+func truncate(slug string, length int) string {
+	if len(slug) <= length {
+		return slug
+	}
+	slug = slug[:length]
+	if i := strings.LastIndexByte(slug, '-'); i > 0 {
+		return slug[:i]
+	}
+	return slug
+}
@@ -0,0 +1,82 @@
+package slug
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		expected string
+	}{
+		{name: "Plain", title: "Hello World", expected: "hello-world"},
+		{name: "Punctuation", title: "  Go: the -- good, the bad & the ugly!  ", expected: "go-the-good-the-bad-the-ugly"},
+		{name: "Apostrophe", title: "Don't panic, it’s fine", expected: "dont-panic-its-fine"},
+		{name: "Diacritics", title: "Crème brûlée à Zürich", expected: "creme-brulee-a-zurich"},
+		{name: "SpecialLetters", title: "Straße Łódź Ærø", expected: "strasse-lodz-aero"},
+		{name: "Ligature", title: "ﬁnal ½ time", expected: "final-1-2-time"},
+		{name: "Cyrillic", title: "Привет, мир! Щука и йогурт", expected: "privet-mir-shchuka-i-yogurt"},
+		{name: "Digits", title: "Top 10 of 2024", expected: "top-10-of-2024"},
+		{name: "OtherScript", title: "東京 trip", expected: "trip"},
+		{name: "Empty", title: "", expected: Fallback},
+		{name: "NothingToKeep", title: "!!! 日本 ???", expected: Fallback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Make(tt.title))
+			assert.NoError(t, Validate(Make(tt.title)))
+		})
+	}
+}
+
+func TestMakeLongTitle(t *testing.T) {
+	slug := Make(strings.Repeat("word ", 40))
+
+	assert.LessOrEqual(t, len(slug), generatedLength)
+	assert.True(t, strings.HasSuffix(slug, "word"))
+	assert.NoError(t, Validate(slug))
+}
+
+func TestWithSuffix(t *testing.T) {
+	assert.Equal(t, "hello-world-2", WithSuffix("hello-world", 2))
+
+	long := WithSuffix(strings.Repeat("a", MaxLength), 12)
+	assert.Len(t, long, MaxLength)
+	assert.True(t, strings.HasSuffix(long, "-12"))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		slug  string
+		valid bool
+	}{
+		{name: "Words", slug: "my-first-post", valid: true},
+		{name: "WithDigits", slug: "top-10", valid: true},
+		{name: "DigitsOnly", slug: "2024", valid: true},
+		{name: "Empty", slug: ""},
+		{name: "TooLong", slug: strings.Repeat("a", MaxLength+1)},
+		{name: "Uppercase", slug: "My-Post"},
+		{name: "Spaces", slug: "my post"},
+		{name: "LeadingHyphen", slug: "-post"},
+		{name: "TrailingHyphen", slug: "post-"},
+		{name: "DoubleHyphen", slug: "my--post"},
+		{name: "NonLatin", slug: "café"},
+		{name: "Slash", slug: "a/b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.slug)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidSlug)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+// Package storage keeps uploaded files, addressed by key, on the local disk or in an S3-compatible bucket.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore persists blobs by key. Keys are content checksums, so putting a key
+// twice stores the same content and is harmless. Implementations must return
+// ErrBlobNotFound for unknown keys and ignore deletes of unknown keys.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.46.3 --name BlobStore --output ../../testing/mocks
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
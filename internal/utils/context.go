@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"context"
+	"strconv"
+)
+
+// ContextUserID returns the id of the user set by the auth middlewares, ok is false
+// when the request is not authenticated.
+func ContextUserID(ctx context.Context) (int, bool) {
+	value, ok := ctx.Value("user_id").(string)
+	if !ok {
+		return 0, false
+	}
+
+	userID, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return userID, true
+}
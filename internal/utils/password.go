@@ -1,7 +1,15 @@
 package utils
 
 import (
+	"errors"
 	"golang.org/x/crypto/bcrypt"
+	"unicode"
+)
+
+const (
+	MinPasswordLength = 8
+	// bcrypt ignores everything after the first 72 bytes
+	MaxPasswordLength = 72
 )
 
 func HashPassword(password string) (string, error) {
@@ -13,3 +21,28 @@ func CheckPasswordHash(password, hashedPassword string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	return err == nil
 }
+
+// ValidatePassword checks a new password against the password policy.
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return errors.New("password must be at least 8 characters long")
+	}
+	if len(password) > MaxPasswordLength {
+		return errors.New("password must be at most 72 bytes long")
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return errors.New("password must contain at least one letter and one digit")
+	}
+
+	return nil
+}
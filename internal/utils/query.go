@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryInt parses an integer query parameter, an absent parameter is 0.
+func QueryInt(query url.Values, key string) (int, error) {
+	value := query.Get(key)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// QueryBool parses a boolean query parameter, an absent parameter is false.
+func QueryBool(query url.Values, key string) (bool, error) {
+	value := query.Get(key)
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// QueryTime parses an RFC 3339 timestamp or a plain 2006-01-02 date, an absent parameter is the zero time.
+func QueryTime(query url.Values, key string) (time.Time, error) {
+	value := query.Get(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// QueryList collects a parameter given either repeatedly (?tag=a&tag=b) or comma separated (?tag=a,b).
+func QueryList(query url.Values, key string) []string {
+	var result []string
+	for _, value := range query[key] {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
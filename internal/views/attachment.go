@@ -0,0 +1,41 @@
+package views
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"go-rest-api-auth/internal/database"
+)
+
+// Attachment is the API representation of a file attached to a post, its content is
+// served by GET /attachments/{id}. user_id is 0 once the uploader deleted their account.
+// swagger:model
+type Attachment struct {
+	Id          int              `json:"id"`
+	PostId      int              `json:"post_id"`
+	UserId      int              `json:"user_id"`
+	Filename    string           `json:"filename"`
+	ContentType string           `json:"content_type"`
+	Size        int64            `json:"size"`
+	Checksum    string           `json:"checksum"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+}
+
+func NewAttachment(attachment database.AttachmentDTO) Attachment {
+	return Attachment{
+		Id:          attachment.Id,
+		PostId:      attachment.PostId,
+		UserId:      attachment.UserId,
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Checksum:    attachment.Checksum,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+func NewAttachments(attachments []database.AttachmentDTO) []Attachment {
+	result := make([]Attachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		result = append(result, NewAttachment(attachment))
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+package views
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"go-rest-api-auth/internal/database"
+)
+
+// Comment is the API representation of a comment. A deleted comment is kept as a
+// placeholder without author and content, so its replies stay in place.
+// Replies is only filled in tree responses.
+// swagger:model
+type Comment struct {
+	Id         int              `json:"id"`
+	PostId     int              `json:"post_id"`
+	ParentId   int              `json:"parent_id,omitempty"`
+	UserId     int              `json:"user_id,omitempty"`
+	Content    string           `json:"content"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
+	Deleted    bool             `json:"deleted,omitempty"`
+	ReplyCount int              `json:"reply_count"`
+	Replies    []Comment        `json:"replies,omitempty"`
+}
+
+func NewComment(comment database.CommentDTO) Comment {
+	result := Comment{
+		Id:         comment.Id,
+		PostId:     comment.PostId,
+		ParentId:   comment.ParentId,
+		UserId:     comment.UserId,
+		Content:    comment.Content,
+		CreatedAt:  comment.CreatedAt,
+		UpdatedAt:  comment.UpdatedAt,
+		Deleted:    comment.Deleted,
+		ReplyCount: comment.ReplyCount,
+	}
+	if comment.Deleted {
+		result.UserId = 0
+		result.Content = ""
+	}
+	return result
+}
+
+func NewComments(comments []database.CommentDTO) []Comment {
+	result := make([]Comment, 0, len(comments))
+	for _, comment := range comments {
+		result = append(result, NewComment(comment))
+	}
+	return result
+}
+
+// NewCommentTree nests comments listed depth first, as returned by GetCommentTree.
+func NewCommentTree(comments []database.CommentDTO) []Comment {
+	var build func(i int) (Comment, int)
+	// build returns the comment at i with its replies and the index right after its subtree
+	build = func(i int) (Comment, int) {
+		node := NewComment(comments[i])
+		depth := comments[i].Depth
+		i++
+		for i < len(comments) && comments[i].Depth > depth {
+			var reply Comment
+			reply, i = build(i)
+			node.Replies = append(node.Replies, reply)
+		}
+		return node, i
+	}
+
+	result := make([]Comment, 0)
+	for i := 0; i < len(comments); {
+		var root Comment
+		root, i = build(i)
+		result = append(result, root)
+	}
+	return result
+}
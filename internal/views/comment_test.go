@@ -0,0 +1,31 @@
+package views
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"testing"
+)
+
+func TestNewCommentTree(t *testing.T) {
+	comments := []database.CommentDTO{
+		{Id: 1, Depth: 0, ReplyCount: 2},
+		{Id: 2, ParentId: 1, Depth: 1, ReplyCount: 1},
+		{Id: 4, ParentId: 2, Depth: 2},
+		{Id: 3, ParentId: 1, Depth: 1, Deleted: true, UserId: 7, Content: "gone"},
+		{Id: 5, Depth: 0},
+	}
+
+	tree := NewCommentTree(comments)
+
+	assert.Equal(t, []Comment{
+		{Id: 1, ReplyCount: 2, Replies: []Comment{
+			{Id: 2, ParentId: 1, ReplyCount: 1, Replies: []Comment{{Id: 4, ParentId: 2}}},
+			{Id: 3, ParentId: 1, Deleted: true},
+		}},
+		{Id: 5},
+	}, tree)
+}
+
+func TestNewCommentTreeEmpty(t *testing.T) {
+	assert.Equal(t, []Comment{}, NewCommentTree(nil))
+}
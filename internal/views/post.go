@@ -0,0 +1,95 @@
+package views
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"go-rest-api-auth/internal/database"
+)
+
+// HiddenPostNotice tells the author of a post hidden by a moderator why nobody else sees it.
+const HiddenPostNotice = "This post was hidden by a moderator and is no longer shown to other readers."
+
+// Post is the API representation of a post. ContentHTML is the sanitized rendering of Content,
+// safe to embed in a page as is, and ReadingTime is in minutes. Hidden posts only reach their
+// author and admins, Notice then says why.
+// swagger:model
+type Post struct {
+	Id            int              `json:"id,omitempty"`
+	Title         string           `json:"title,omitempty"`
+	Slug          string           `json:"slug,omitempty"`
+	Content       string           `json:"content,omitempty"`
+	ContentFormat string           `json:"content_format,omitempty"`
+	ContentHTML   string           `json:"content_html,omitempty"`
+	Excerpt       string           `json:"excerpt,omitempty"`
+	WordCount     int              `json:"word_count"`
+	ReadingTime   int              `json:"reading_time"`
+	UserId        int              `json:"user_id,omitempty"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+	Status        string           `json:"status,omitempty"`
+	PublishedAt   pgtype.Timestamp `json:"published_at"`
+	Visibility    string           `json:"visibility,omitempty"`
+	Hidden        bool             `json:"hidden,omitempty"`
+	Notice        string           `json:"notice,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	CommentCount  int              `json:"comment_count"`
+	Reactions     map[string]int   `json:"reactions,omitempty"`
+	MyReactions   []string         `json:"my_reactions,omitempty"`
+}
+
+func NewPost(post database.PostDTO) Post {
+	result := Post{
+		Id:            post.Id,
+		Title:         post.Title,
+		Slug:          post.Slug,
+		Content:       post.Content,
+		ContentFormat: post.ContentFormat,
+		ContentHTML:   post.ContentHTML,
+		Excerpt:       post.Excerpt,
+		WordCount:     post.WordCount,
+		ReadingTime:   post.ReadingTime,
+		UserId:        post.UserId,
+		CreatedAt:     post.CreatedAt,
+		Status:        post.Status,
+		PublishedAt:   post.PublishedAt,
+		Visibility:    post.Visibility,
+		Tags:          post.Tags,
+		CommentCount:  post.CommentCount,
+		Reactions:     post.Reactions,
+		MyReactions:   post.MyReactions,
+	}
+	if post.HiddenAt.Valid {
+		result.Hidden = true
+		result.Notice = HiddenPostNotice
+	}
+	return result
+}
+
+func NewPosts(posts []database.PostDTO) []Post {
+	result := make([]Post, 0, len(posts))
+	for _, post := range posts {
+		result = append(result, NewPost(post))
+	}
+	return result
+}
+
+// SearchResult is a post matching a search. TitleHighlight and Snippet
+// wrap the matched words in <b></b>.
+// swagger:model
+type SearchResult struct {
+	Post
+	Rank           float32 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
+}
+
+func NewSearchResults(results []database.PostSearchResultDTO) []SearchResult {
+	result := make([]SearchResult, 0, len(results))
+	for _, r := range results {
+		result = append(result, SearchResult{
+			Post:           NewPost(r.PostDTO),
+			Rank:           r.Rank,
+			TitleHighlight: r.TitleHighlight,
+			Snippet:        r.Snippet,
+		})
+	}
+	return result
+}
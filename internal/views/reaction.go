@@ -0,0 +1,30 @@
+package views
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"go-rest-api-auth/internal/database"
+)
+
+// Reaction is the API representation of a user's reaction to a post.
+// swagger:model
+type Reaction struct {
+	Id        int              `json:"id"`
+	UserId    int              `json:"user_id"`
+	Username  string           `json:"username"`
+	Type      string           `json:"type"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+func NewReactions(reactions []database.ReactionDTO) []Reaction {
+	result := make([]Reaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		result = append(result, Reaction{
+			Id:        reaction.Id,
+			UserId:    reaction.UserId,
+			Username:  reaction.Username,
+			Type:      reaction.Type,
+			CreatedAt: reaction.CreatedAt,
+		})
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package views
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"go-rest-api-auth/internal/database"
+)
+
+// Report is a report of a post in the moderation queue. PostId is missing once the post is deleted.
+// Action, ModeratorId, Note and ResolvedAt describe the moderation action resolving the report.
+// swagger:model
+type Report struct {
+	Id          int              `json:"id"`
+	PostId      int              `json:"post_id,omitempty"`
+	PostTitle   string           `json:"post_title,omitempty"`
+	AuthorId    int              `json:"author_id"`
+	ReporterId  int              `json:"reporter_id,omitempty"`
+	Reason      string           `json:"reason"`
+	Details     string           `json:"details,omitempty"`
+	Status      string           `json:"status"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	Action      string           `json:"action,omitempty"`
+	ModeratorId int              `json:"moderator_id,omitempty"`
+	Note        string           `json:"note,omitempty"`
+	ResolvedAt  pgtype.Timestamp `json:"resolved_at"`
+}
+
+func NewReport(report database.ReportDTO) Report {
+	return Report{
+		Id:          report.Id,
+		PostId:      report.PostId,
+		PostTitle:   report.PostTitle,
+		AuthorId:    report.AuthorId,
+		ReporterId:  report.ReporterId,
+		Reason:      report.Reason,
+		Details:     report.Details,
+		Status:      report.Status,
+		CreatedAt:   report.CreatedAt,
+		Action:      report.Action,
+		ModeratorId: report.ModeratorId,
+		Note:        report.Note,
+		ResolvedAt:  report.ResolvedAt,
+	}
+}
+
+func NewReports(reports []database.ReportDTO) []Report {
+	result := make([]Report, 0, len(reports))
+	for _, report := range reports {
+		result = append(result, NewReport(report))
+	}
+	return result
+}
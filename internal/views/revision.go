@@ -0,0 +1,41 @@
+package views
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"go-rest-api-auth/internal/database"
+)
+
+// Revision is the API representation of a snapshot of a post, editor_id is 0
+// once the editor deleted their account.
+// swagger:model
+type Revision struct {
+	Revision      int              `json:"revision"`
+	PostId        int              `json:"post_id"`
+	EditorId      int              `json:"editor_id"`
+	Title         string           `json:"title"`
+	Content       string           `json:"content"`
+	ContentFormat string           `json:"content_format"`
+	Tags          []string         `json:"tags"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+}
+
+func NewRevision(revision database.RevisionDTO) Revision {
+	return Revision{
+		Revision:      revision.Revision,
+		PostId:        revision.PostId,
+		EditorId:      revision.EditorId,
+		Title:         revision.Title,
+		Content:       revision.Content,
+		ContentFormat: revision.ContentFormat,
+		Tags:          revision.Tags,
+		CreatedAt:     revision.CreatedAt,
+	}
+}
+
+func NewRevisions(revisions []database.RevisionDTO) []Revision {
+	result := make([]Revision, 0, len(revisions))
+	for _, revision := range revisions {
+		result = append(result, NewRevision(revision))
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package views
+
+import (
+	"go-rest-api-auth/internal/database"
+)
+
+// Tag is the API representation of a tag.
+// swagger:model
+type Tag struct {
+	Id        int    `json:"id"`
+	Name      string `json:"name"`
+	PostCount int    `json:"post_count,omitempty"`
+}
+
+func NewTag(tag database.TagsDTO) Tag {
+	return Tag{
+		Id:   tag.Id,
+		Name: tag.Name,
+	}
+}
+
+func NewTagCounts(tags []database.TagCountDTO) []Tag {
+	result := make([]Tag, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, Tag{
+			Id:        tag.Id,
+			Name:      tag.Name,
+			PostCount: tag.PostCount,
+		})
+	}
+	return result
+}
+
+// TagDetails is a tag with its parent, aliases and direct children.
+// swagger:model
+type TagDetails struct {
+	Id       int      `json:"id"`
+	Name     string   `json:"name"`
+	ParentID int      `json:"parent_id,omitempty"`
+	Aliases  []string `json:"aliases"`
+	Children []Tag    `json:"children"`
+}
+
+func NewTagDetails(tag database.TagDetailsDTO) TagDetails {
+	children := make([]Tag, 0, len(tag.Children))
+	for _, child := range tag.Children {
+		children = append(children, NewTag(child))
+	}
+
+	aliases := tag.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return TagDetails{
+		Id:       tag.Id,
+		Name:     tag.Name,
+		ParentID: tag.ParentID,
+		Aliases:  aliases,
+		Children: children,
+	}
+}
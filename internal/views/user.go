@@ -0,0 +1,80 @@
+package views
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"go-rest-api-auth/internal/database"
+)
+
+// AvatarPath is where avatars are served, followed by their id.
+const AvatarPath = "/avatars/"
+
+// PublicUser is what anyone may see about a user. avatar_url is missing for users without an avatar,
+// a size query parameter picks one of the smaller thumbnails.
+// swagger:model
+type PublicUser struct {
+	Id             int         `json:"id"`
+	Username       string      `json:"username"`
+	Description    string      `json:"description"`
+	DateJoined     pgtype.Date `json:"date_joined"`
+	AvatarURL      string      `json:"avatar_url,omitempty"`
+	FollowerCount  int         `json:"follower_count"`
+	FollowingCount int         `json:"following_count"`
+}
+
+// SelfUser is what a user sees about their own account.
+// swagger:model
+type SelfUser struct {
+	PublicUser
+	Role string `json:"role"`
+}
+
+// AdminUser is what administrators see about any account.
+// swagger:model
+type AdminUser struct {
+	SelfUser
+	TokenVersion int  `json:"token_version"`
+	Suspended    bool `json:"suspended"`
+}
+
+func NewPublicUser(user database.UserDTO) PublicUser {
+	return PublicUser{
+		Id:             user.Id,
+		Username:       user.Username,
+		Description:    user.Description,
+		DateJoined:     user.DateJoined,
+		AvatarURL:      AvatarURL(user.Avatar),
+		FollowerCount:  user.FollowerCount,
+		FollowingCount: user.FollowingCount,
+	}
+}
+
+// AvatarURL returns the URL of an avatar, or "" when avatarID is empty.
+func AvatarURL(avatarID string) string {
+	if avatarID == "" {
+		return ""
+	}
+	return AvatarPath + avatarID
+}
+
+func NewSelfUser(user database.UserDTO) SelfUser {
+	return SelfUser{
+		PublicUser: NewPublicUser(user),
+		Role:       user.Role,
+	}
+}
+
+func NewAdminUser(user database.UserDTO) AdminUser {
+	return AdminUser{
+		SelfUser:     NewSelfUser(user),
+		TokenVersion: user.TokenVersion,
+		Suspended:    user.Suspended,
+	}
+}
+
+func NewPublicUsers(users []database.UserDTO) []PublicUser {
+	result := make([]PublicUser, 0, len(users))
+	for _, user := range users {
+		result = append(result, NewPublicUser(user))
+	}
+	return result
+}
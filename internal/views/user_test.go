@@ -0,0 +1,69 @@
+package views_test
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"go-rest-api-auth/internal/database"
+	"go-rest-api-auth/internal/views"
+	"testing"
+)
+
+func TestUserViews(t *testing.T) {
+	user := database.UserDTO{
+		Id:           1,
+		Username:     "testuser",
+		Password:     "$2a$10$hash",
+		Description:  "test description",
+		TokenVersion: 3,
+		Role:         database.RoleAdmin,
+	}
+
+	tests := []struct {
+		name         string
+		view         interface{}
+		expectedKeys []string
+	}{
+		{
+			name:         "PublicUser",
+			view:         views.NewPublicUser(user),
+			expectedKeys: []string{"id", "username", "description", "date_joined", "follower_count", "following_count"},
+		},
+		{
+			name:         "SelfUser",
+			view:         views.NewSelfUser(user),
+			expectedKeys: []string{"id", "username", "description", "date_joined", "follower_count", "following_count", "role"},
+		},
+		{
+			name:         "AdminUser",
+			view:         views.NewAdminUser(user),
+			expectedKeys: []string{"id", "username", "description", "date_joined", "follower_count", "following_count", "role", "token_version", "suspended"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(tt.view)
+			assert.NoError(t, err)
+			assert.NotContains(t, string(body), user.Password)
+
+			var fields map[string]interface{}
+			err = json.Unmarshal(body, &fields)
+			assert.NoError(t, err)
+
+			var keys []string
+			for key := range fields {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
+func TestPublicUserAvatarURL(t *testing.T) {
+	withAvatar := views.NewPublicUser(database.UserDTO{Id: 1, Avatar: "ab12"})
+	assert.Equal(t, "/avatars/ab12", withAvatar.AvatarURL)
+
+	body, err := json.Marshal(views.NewPublicUser(database.UserDTO{Id: 1}))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(body), "avatar_url")
+}
@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"go-rest-api-auth/internal/database"
+	"log/slog"
+	"time"
+)
+
+// BlobCleaner removes the blobs no attachment refers to anymore, left behind by deleted posts
+// and attachments. Every app instance may run one, AttachmentService.DeleteOrphanedBlobs skips
+// the blobs another instance is removing.
+type BlobCleaner struct {
+	*drainer
+	log     *slog.Logger
+	service database.AttachmentService
+}
+
+// NewBlobCleaner starts removing orphaned blobs every interval, at most batch blobs at a time.
+// interval and batch must be positive.
+func NewBlobCleaner(log *slog.Logger, service database.AttachmentService, interval time.Duration, batch int) *BlobCleaner {
+	cleaner := &BlobCleaner{
+		log:     log.With(slog.String("component", "worker/BlobCleaner")),
+		service: service,
+	}
+	cleaner.drainer = startDrainer(interval, batch, cleaner.clean)
+
+	return cleaner
+}
+
+func (c *BlobCleaner) clean(batch int) int {
+	deleted, err := c.service.DeleteOrphanedBlobs(batch)
+	if err != nil {
+		c.log.Error("Error deleting orphaned blobs", slog.String("error", err.Error()))
+		return 0
+	}
+	if deleted > 0 {
+		c.log.Info("Deleted orphaned blobs", slog.Int("count", deleted))
+	}
+	return deleted
+}
@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// drainer calls drain every interval, and right away again while drain handles a full batch,
+// so a backlog is not spread over many ticks. drain returns how many items it handled, it logs
+// its errors itself and reports 0 for them.
+type drainer struct {
+	batch     int
+	drain     func(batch int) int
+	stop      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func startDrainer(interval time.Duration, batch int, drain func(batch int) int) *drainer {
+	d := &drainer{
+		batch: batch,
+		drain: drain,
+		stop:  make(chan struct{}),
+	}
+
+	d.wg.Add(1)
+	go d.run(interval)
+
+	return d
+}
+
+func (d *drainer) run(interval time.Duration) {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.round()
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+func (d *drainer) round() {
+	for d.drain(d.batch) >= d.batch {
+		select {
+		case <-d.stop:
+			return
+		default:
+		}
+	}
+}
+
+// Close stops the drainer and waits for a running batch to finish.
+func (d *drainer) Close() error {
+	d.closeOnce.Do(func() {
+		close(d.stop)
+	})
+	d.wg.Wait()
+	return nil
+}
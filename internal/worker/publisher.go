@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"go-rest-api-auth/internal/database"
+	"log/slog"
+	"time"
+)
+
+// Publisher publishes scheduled posts once they are due. Every app instance may run one,
+// PostService.PublishScheduled skips the posts another instance is publishing.
+type Publisher struct {
+	*drainer
+	log     *slog.Logger
+	service database.PostService
+}
+
+// NewPublisher starts publishing due posts every interval, at most batch posts at a time.
+// interval and batch must be positive.
+func NewPublisher(log *slog.Logger, service database.PostService, interval time.Duration, batch int) *Publisher {
+	publisher := &Publisher{
+		log:     log.With(slog.String("component", "worker/Publisher")),
+		service: service,
+	}
+	publisher.drainer = startDrainer(interval, batch, publisher.publish)
+
+	return publisher
+}
+
+func (p *Publisher) publish(batch int) int {
+	published, err := p.service.PublishScheduled(batch)
+	if err != nil {
+		p.log.Error("Error publishing scheduled posts", slog.String("error", err.Error()))
+		return 0
+	}
+	if published > 0 {
+		p.log.Info("Published scheduled posts", slog.Int("count", published))
+	}
+	return published
+}
@@ -0,0 +1,64 @@
+package worker_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-rest-api-auth/internal/worker"
+	"go-rest-api-auth/testing/mocks"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPublisher(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []int
+		err     error
+	}{
+		{
+			name:    "DrainsFullBatches",
+			results: []int{2, 2, 1},
+		},
+		{
+			name:    "NothingDue",
+			results: []int{0},
+		},
+		{
+			name:    "ErrorStopsTheRound",
+			results: []int{0},
+			err:     errors.New("query error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := make(chan struct{}, len(tt.results))
+			mockService := new(mocks.PostService)
+			for _, published := range tt.results {
+				mockService.On("PublishScheduled", 2).Return(published, tt.err).Once().Run(func(_ mock.Arguments) {
+					calls <- struct{}{}
+				})
+			}
+			// later rounds find nothing due
+			mockService.On("PublishScheduled", 2).Return(0, nil).Maybe()
+
+			logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			publisher := worker.NewPublisher(logger, mockService, 10*time.Millisecond, 2)
+
+			for range tt.results {
+				select {
+				case <-calls:
+				case <-time.After(time.Second):
+					t.Fatal("publisher did not run")
+				}
+			}
+
+			assert.NoError(t, publisher.Close())
+			assert.NoError(t, publisher.Close())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -35,9 +35,9 @@ func (_m *JwtManager) DeleteRefreshToken(userID int) error {
 	return r0
 }
 
-// GenerateJWT provides a mock function with given fields: userId, tokenType, ttl
-func (_m *JwtManager) GenerateJWT(userId string, tokenType string, ttl time.Duration) (string, error) {
-	ret := _m.Called(userId, tokenType, ttl)
+// GenerateJWT provides a mock function with given fields: userId, tokenVersion, tokenType, ttl
+func (_m *JwtManager) GenerateJWT(userId string, tokenVersion int, tokenType string, ttl time.Duration) (string, error) {
+	ret := _m.Called(userId, tokenVersion, tokenType, ttl)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateJWT")
@@ -45,17 +45,17 @@ func (_m *JwtManager) GenerateJWT(userId string, tokenType string, ttl time.Dura
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, time.Duration) (string, error)); ok {
-		return rf(userId, tokenType, ttl)
+	if rf, ok := ret.Get(0).(func(string, int, string, time.Duration) (string, error)); ok {
+		return rf(userId, tokenVersion, tokenType, ttl)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, time.Duration) string); ok {
-		r0 = rf(userId, tokenType, ttl)
+	if rf, ok := ret.Get(0).(func(string, int, string, time.Duration) string); ok {
+		r0 = rf(userId, tokenVersion, tokenType, ttl)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, time.Duration) error); ok {
-		r1 = rf(userId, tokenType, ttl)
+	if rf, ok := ret.Get(1).(func(string, int, string, time.Duration) error); ok {
+		r1 = rf(userId, tokenVersion, tokenType, ttl)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -6,6 +6,8 @@ import (
 	database "go-rest-api-auth/internal/database"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // PostService is an autogenerated mock type for the PostService type
@@ -13,6 +15,34 @@ type PostService struct {
 	mock.Mock
 }
 
+// CanViewPost provides a mock function with given fields: post, viewerID
+func (_m *PostService) CanViewPost(post database.PostDTO, viewerID int) (bool, error) {
+	ret := _m.Called(post, viewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CanViewPost")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(database.PostDTO, int) (bool, error)); ok {
+		return rf(post, viewerID)
+	}
+	if rf, ok := ret.Get(0).(func(database.PostDTO, int) bool); ok {
+		r0 = rf(post, viewerID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(database.PostDTO, int) error); ok {
+		r1 = rf(post, viewerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePost provides a mock function with given fields: post
 func (_m *PostService) CreatePost(post database.PostDTO) (database.PostDTO, error) {
 	ret := _m.Called(post)
@@ -59,34 +89,41 @@ func (_m *PostService) DeletePost(postID int) error {
 	return r0
 }
 
-// GetALlPosts provides a mock function with given fields:
-func (_m *PostService) GetALlPosts() ([]database.PostDTO, error) {
-	ret := _m.Called()
+// GetALlPosts provides a mock function with given fields: filter, page
+func (_m *PostService) GetALlPosts(filter database.PostFilter, page database.Page) ([]database.PostDTO, string, error) {
+	ret := _m.Called(filter, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetALlPosts")
 	}
 
 	var r0 []database.PostDTO
-	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]database.PostDTO, error)); ok {
-		return rf()
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(database.PostFilter, database.Page) ([]database.PostDTO, string, error)); ok {
+		return rf(filter, page)
 	}
-	if rf, ok := ret.Get(0).(func() []database.PostDTO); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(database.PostFilter, database.Page) []database.PostDTO); ok {
+		r0 = rf(filter, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]database.PostDTO)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(database.PostFilter, database.Page) string); ok {
+		r1 = rf(filter, page)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(database.PostFilter, database.Page) error); ok {
+		r2 = rf(filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // GetPost provides a mock function with given fields: postID
@@ -117,17 +154,194 @@ func (_m *PostService) GetPost(postID int) (database.PostDTO, error) {
 	return r0, r1
 }
 
-// UpdatePost provides a mock function with given fields: post
-func (_m *PostService) UpdatePost(post database.PostDTO) error {
-	ret := _m.Called(post)
+// GetPostBySlug provides a mock function with given fields: slug
+func (_m *PostService) GetPostBySlug(slug string) (database.PostDTO, error) {
+	ret := _m.Called(slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostBySlug")
+	}
+
+	var r0 database.PostDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (database.PostDTO, error)); ok {
+		return rf(slug)
+	}
+	if rf, ok := ret.Get(0).(func(string) database.PostDTO); ok {
+		r0 = rf(slug)
+	} else {
+		r0 = ret.Get(0).(database.PostDTO)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublishPost provides a mock function with given fields: postID, publishAt
+func (_m *PostService) PublishPost(postID int, publishAt time.Time) (database.PostDTO, error) {
+	ret := _m.Called(postID, publishAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishPost")
+	}
+
+	var r0 database.PostDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, time.Time) (database.PostDTO, error)); ok {
+		return rf(postID, publishAt)
+	}
+	if rf, ok := ret.Get(0).(func(int, time.Time) database.PostDTO); ok {
+		r0 = rf(postID, publishAt)
+	} else {
+		r0 = ret.Get(0).(database.PostDTO)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, time.Time) error); ok {
+		r1 = rf(postID, publishAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublishScheduled provides a mock function with given fields: batch
+func (_m *PostService) PublishScheduled(batch int) (int, error) {
+	ret := _m.Called(batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishScheduled")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (int, error)); ok {
+		return rf(batch)
+	}
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(batch)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(batch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreRevision provides a mock function with given fields: postID, revision, editorID
+func (_m *PostService) RestoreRevision(postID int, revision int, editorID int) (database.PostDTO, error) {
+	ret := _m.Called(postID, revision, editorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreRevision")
+	}
+
+	var r0 database.PostDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, int) (database.PostDTO, error)); ok {
+		return rf(postID, revision, editorID)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, int) database.PostDTO); ok {
+		r0 = rf(postID, revision, editorID)
+	} else {
+		r0 = ret.Get(0).(database.PostDTO)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, int) error); ok {
+		r1 = rf(postID, revision, editorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchPosts provides a mock function with given fields: text, filter, page
+func (_m *PostService) SearchPosts(text string, filter database.PostFilter, page database.Page) ([]database.PostSearchResultDTO, string, error) {
+	ret := _m.Called(text, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchPosts")
+	}
+
+	var r0 []database.PostSearchResultDTO
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, database.PostFilter, database.Page) ([]database.PostSearchResultDTO, string, error)); ok {
+		return rf(text, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(string, database.PostFilter, database.Page) []database.PostSearchResultDTO); ok {
+		r0 = rf(text, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.PostSearchResultDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, database.PostFilter, database.Page) string); ok {
+		r1 = rf(text, filter, page)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, database.PostFilter, database.Page) error); ok {
+		r2 = rf(text, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UnpublishPost provides a mock function with given fields: postID, archive
+func (_m *PostService) UnpublishPost(postID int, archive bool) (database.PostDTO, error) {
+	ret := _m.Called(postID, archive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnpublishPost")
+	}
+
+	var r0 database.PostDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, bool) (database.PostDTO, error)); ok {
+		return rf(postID, archive)
+	}
+	if rf, ok := ret.Get(0).(func(int, bool) database.PostDTO); ok {
+		r0 = rf(postID, archive)
+	} else {
+		r0 = ret.Get(0).(database.PostDTO)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, bool) error); ok {
+		r1 = rf(postID, archive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePost provides a mock function with given fields: post, editorID
+func (_m *PostService) UpdatePost(post database.PostDTO, editorID int) error {
+	ret := _m.Called(post, editorID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdatePost")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(database.PostDTO) error); ok {
-		r0 = rf(post)
+	if rf, ok := ret.Get(0).(func(database.PostDTO, int) error); ok {
+		r0 = rf(post, editorID)
 	} else {
 		r0 = ret.Error(0)
 	}
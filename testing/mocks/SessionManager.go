@@ -3,9 +3,11 @@
 package mocks
 
 import (
-	time "time"
+	auth "go-rest-api-auth/internal/database/auth"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // SessionManager is an autogenerated mock type for the SessionManager type
@@ -13,9 +15,9 @@ type SessionManager struct {
 	mock.Mock
 }
 
-// CreateSession provides a mock function with given fields: userID
-func (_m *SessionManager) CreateSession(userID string) (string, error) {
-	ret := _m.Called(userID)
+// CreateSession provides a mock function with given fields: userID, tokenVersion
+func (_m *SessionManager) CreateSession(userID string, tokenVersion int) (string, error) {
+	ret := _m.Called(userID, tokenVersion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateSession")
@@ -23,17 +25,17 @@ func (_m *SessionManager) CreateSession(userID string) (string, error) {
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(userID)
+	if rf, ok := ret.Get(0).(func(string, int) (string, error)); ok {
+		return rf(userID, tokenVersion)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(string, int) string); ok {
+		r0 = rf(userID, tokenVersion)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(userID, tokenVersion)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,27 +61,27 @@ func (_m *SessionManager) DeleteSession(sessionID string) error {
 	return r0
 }
 
-// GetSessionByUserID provides a mock function with given fields: userID
-func (_m *SessionManager) GetSessionByUserID(userID string) (string, error) {
-	ret := _m.Called(userID)
+// GetSession provides a mock function with given fields: sessionID
+func (_m *SessionManager) GetSession(sessionID string) (auth.Session, error) {
+	ret := _m.Called(sessionID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSessionByUserID")
+		panic("no return value specified for GetSession")
 	}
 
-	var r0 string
+	var r0 auth.Session
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(userID)
+	if rf, ok := ret.Get(0).(func(string) (auth.Session, error)); ok {
+		return rf(sessionID)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(string) auth.Session); ok {
+		r0 = rf(sessionID)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(auth.Session)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+		r1 = rf(sessionID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -87,27 +89,27 @@ func (_m *SessionManager) GetSessionByUserID(userID string) (string, error) {
 	return r0, r1
 }
 
-// GetUserIdBySession provides a mock function with given fields: sessionID
-func (_m *SessionManager) GetUserIdBySession(sessionID string) (string, error) {
-	ret := _m.Called(sessionID)
+// GetSessionByUserID provides a mock function with given fields: userID
+func (_m *SessionManager) GetSessionByUserID(userID string) (string, error) {
+	ret := _m.Called(userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserIdBySession")
+		panic("no return value specified for GetSessionByUserID")
 	}
 
 	var r0 string
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(sessionID)
+		return rf(userID)
 	}
 	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(sessionID)
+		r0 = rf(userID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(sessionID)
+		r1 = rf(userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -151,6 +153,34 @@ func (_m *SessionManager) GetterTtl() time.Duration {
 	return r0
 }
 
+// RegenerateSession provides a mock function with given fields: sessionID, tokenVersion
+func (_m *SessionManager) RegenerateSession(sessionID string, tokenVersion int) (string, error) {
+	ret := _m.Called(sessionID, tokenVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegenerateSession")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) (string, error)); ok {
+		return rf(sessionID, tokenVersion)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) string); ok {
+		r0 = rf(sessionID, tokenVersion)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(sessionID, tokenVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewSessionManager creates a new instance of SessionManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSessionManager(t interface {
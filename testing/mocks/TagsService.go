@@ -13,6 +13,24 @@ type TagsService struct {
 	mock.Mock
 }
 
+// AddTagAlias provides a mock function with given fields: tagID, alias
+func (_m *TagsService) AddTagAlias(tagID int, alias string) error {
+	ret := _m.Called(tagID, alias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTagAlias")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(tagID, alias)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreatePostTagsRelation provides a mock function with given fields: tag, post
 func (_m *TagsService) CreatePostTagsRelation(tag database.TagsDTO, post database.PostDTO) error {
 	ret := _m.Called(tag, post)
@@ -95,6 +113,24 @@ func (_m *TagsService) DeleteTag(tagID int) error {
 	return r0
 }
 
+// DeleteTagAlias provides a mock function with given fields: tagID, alias
+func (_m *TagsService) DeleteTagAlias(tagID int, alias string) error {
+	ret := _m.Called(tagID, alias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTagAlias")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(tagID, alias)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetALlTags provides a mock function with given fields:
 func (_m *TagsService) GetALlTags() ([]database.TagsDTO, error) {
 	ret := _m.Called()
@@ -211,6 +247,156 @@ func (_m *TagsService) GetTagByName(tagName string) (database.TagsDTO, error) {
 	return r0, r1
 }
 
+// GetTagDetails provides a mock function with given fields: tagID
+func (_m *TagsService) GetTagDetails(tagID int) (database.TagDetailsDTO, error) {
+	ret := _m.Called(tagID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTagDetails")
+	}
+
+	var r0 database.TagDetailsDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (database.TagDetailsDTO, error)); ok {
+		return rf(tagID)
+	}
+	if rf, ok := ret.Get(0).(func(int) database.TagDetailsDTO); ok {
+		r0 = rf(tagID)
+	} else {
+		r0 = ret.Get(0).(database.TagDetailsDTO)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(tagID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MergeTags provides a mock function with given fields: sourceID, targetID
+func (_m *TagsService) MergeTags(sourceID int, targetID int) error {
+	ret := _m.Called(sourceID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(sourceID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenameTag provides a mock function with given fields: tagID, name
+func (_m *TagsService) RenameTag(tagID int, name string) (database.TagsDTO, error) {
+	ret := _m.Called(tagID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameTag")
+	}
+
+	var r0 database.TagsDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (database.TagsDTO, error)); ok {
+		return rf(tagID, name)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) database.TagsDTO); ok {
+		r0 = rf(tagID, name)
+	} else {
+		r0 = ret.Get(0).(database.TagsDTO)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(tagID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchTags provides a mock function with given fields: prefix, limit
+func (_m *TagsService) SearchTags(prefix string, limit int) ([]database.TagCountDTO, error) {
+	ret := _m.Called(prefix, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchTags")
+	}
+
+	var r0 []database.TagCountDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]database.TagCountDTO, error)); ok {
+		return rf(prefix, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []database.TagCountDTO); ok {
+		r0 = rf(prefix, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.TagCountDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(prefix, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTagParent provides a mock function with given fields: tagID, parentID
+func (_m *TagsService) SetTagParent(tagID int, parentID int) error {
+	ret := _m.Called(tagID, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTagParent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(tagID, parentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertTag provides a mock function with given fields: tagName
+func (_m *TagsService) UpsertTag(tagName string) (database.TagsDTO, error) {
+	ret := _m.Called(tagName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertTag")
+	}
+
+	var r0 database.TagsDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (database.TagsDTO, error)); ok {
+		return rf(tagName)
+	}
+	if rf, ok := ret.Get(0).(func(string) database.TagsDTO); ok {
+		r0 = rf(tagName)
+	} else {
+		r0 = ret.Get(0).(database.TagsDTO)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tagName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTagsService creates a new instance of TagsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTagsService(t interface {
@@ -13,6 +13,62 @@ type UserService struct {
 	mock.Mock
 }
 
+// BumpTokenVersion provides a mock function with given fields: userID
+func (_m *UserService) BumpTokenVersion(userID int) (int, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BumpTokenVersion")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (int, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangePassword provides a mock function with given fields: userID, password
+func (_m *UserService) ChangePassword(userID int, password string) (int, error) {
+	ret := _m.Called(userID, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (int, error)); ok {
+		return rf(userID, password)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) int); ok {
+		r0 = rf(userID, password)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(userID, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateUser provides a mock function with given fields: user
 func (_m *UserService) CreateUser(user database.UserDTO) (database.UserDTO, error) {
 	ret := _m.Called(user)
@@ -59,29 +115,64 @@ func (_m *UserService) DeleteUser(userID int) error {
 	return r0
 }
 
-// GetALlUsers provides a mock function with given fields:
-func (_m *UserService) GetALlUsers() ([]database.UserDTO, error) {
-	ret := _m.Called()
+// GetALlUsers provides a mock function with given fields: filter, page
+func (_m *UserService) GetALlUsers(filter database.UserFilter, page database.Page) ([]database.UserDTO, string, error) {
+	ret := _m.Called(filter, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetALlUsers")
 	}
 
 	var r0 []database.UserDTO
-	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]database.UserDTO, error)); ok {
-		return rf()
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(database.UserFilter, database.Page) ([]database.UserDTO, string, error)); ok {
+		return rf(filter, page)
 	}
-	if rf, ok := ret.Get(0).(func() []database.UserDTO); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(database.UserFilter, database.Page) []database.UserDTO); ok {
+		r0 = rf(filter, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]database.UserDTO)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(database.UserFilter, database.Page) string); ok {
+		r1 = rf(filter, page)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(database.UserFilter, database.Page) error); ok {
+		r2 = rf(filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTokenVersion provides a mock function with given fields: userID
+func (_m *UserService) GetTokenVersion(userID int) (int, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenVersion")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (int, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -145,6 +236,34 @@ func (_m *UserService) GetUserByName(username string) (database.UserDTO, error)
 	return r0, r1
 }
 
+// SetRole provides a mock function with given fields: userID, role
+func (_m *UserService) SetRole(userID int, role string) (int, error) {
+	ret := _m.Called(userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRole")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (int, error)); ok {
+		return rf(userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) int); ok {
+		r0 = rf(userID, role)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateUser provides a mock function with given fields: user
 func (_m *UserService) UpdateUser(user database.UserDTO) error {
 	ret := _m.Called(user)